@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/seed"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Exit codes shared by the subcommands
+const (
+	exitOK          = 0
+	exitFailure     = 1 // The command could not run, e.g. the server failed to start
+	exitUsage       = 2 // Unknown subcommand or invalid flags
+	exitInvalidData = 3 // Fixtures failed to load or validate, or records failed to migrate
+)
+
+// command is one CLI subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// serveOptions are the serve flags, applied over the environment
+type serveOptions struct {
+	port     string
+	seedFile string
+	noSeed   bool
+}
+
+// migration converts the records of one model to its successor and
+// returns how many were converted and the errors of those that failed
+type migration struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, users *services.UserService, dryRun bool) (int, []error)
+}
+
+// commands lists the subcommands; serve runs when none is given
+func commands() []command {
+	return []command{
+		{name: "serve", summary: "Run the HTTP server (default)", run: runServe},
+		{name: "seed", summary: "Load, validate and apply a fixture file", run: runSeed},
+		{name: "migrate", summary: "Run data migrations over seeded fixtures", run: runMigrate},
+	}
+}
+
+// migrations lists the data migrations migrate can run, in the order they run
+func migrations() []migration {
+	return []migration{
+		{name: "users", summary: "User -> UserRefactored", run: migrateUsers},
+	}
+}
+
+// runCLI dispatches to a subcommand and returns the process exit code.
+// Arguments that start with a flag go to serve, so existing invocations
+// without a subcommand keep working.
+func runCLI(args []string) int {
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "-help" && args[0] != "--help") {
+		return runServe(args)
+	}
+
+	name := args[0]
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd.run(args[1:])
+		}
+	}
+
+	if name == "help" || strings.HasPrefix(name, "-") {
+		printUsage(os.Stdout)
+		return exitOK
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	printUsage(os.Stderr)
+	return exitUsage
+}
+
+// printUsage lists the subcommands
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun '%s <command> -h' for the flags of a command.\n", os.Args[0])
+}
+
+// parseFlags parses a subcommand's flags; when it returns false the
+// command should exit with the returned code
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	err := fs.Parse(args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK, false
+	case err != nil:
+		return exitUsage, false
+	case fs.NArg() > 0:
+		fmt.Fprintf(fs.Output(), "Unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// =====================================
+// Subcommands
+// =====================================
+
+// runServe runs the HTTP server until it receives SIGINT or SIGTERM
+func runServe(args []string) int {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = defaultPort
+	}
+
+	var opts serveOptions
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.StringVar(&opts.port, "port", port, "port to listen on (PORT)")
+	fs.StringVar(&opts.seedFile, "seed-file", "", "fixture file to seed at startup, overriding SEED_FILE")
+	fs.BoolVar(&opts.noSeed, "no-seed", false, "start without seeding, overriding SEED_DISABLED")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	return serve(opts)
+}
+
+// runSeed loads and validates a fixture file and applies it to empty
+// stores. Stores are in memory, so outside serve this checks that a file
+// seeds cleanly; serve seeds its own stores at startup.
+func runSeed(args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := fs.String("file", os.Getenv("SEED_FILE"), "fixture file, .json or .yaml (SEED_FILE); empty uses the built-in fixtures")
+	check := fs.Bool("check", false, "validate the fixtures without applying them")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	fixtures := seed.Default()
+	if *file != "" {
+		loaded, err := seed.Load(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitInvalidData
+		}
+		fixtures = loaded
+	}
+	if err := fixtures.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitInvalidData
+	}
+	if *check {
+		fmt.Printf("Fixtures are valid: %d users, %d organizations, %d memberships\n", len(fixtures.Users), len(fixtures.Organizations), len(fixtures.Memberships))
+		return exitOK
+	}
+
+	users := services.NewUserService()
+	orgs := services.NewOrganizationService()
+	seeded, err := fixtures.Apply(context.Background(), users, orgs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitInvalidData
+	}
+	fmt.Printf("Seeded %d users, %d organizations, %d memberships\n", seeded.Users, seeded.Organizations, seeded.Memberships)
+	return exitOK
+}
+
+// runMigrate seeds fixtures into empty stores and runs data migrations
+// over them, reporting every record that fails to convert
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	file := fs.String("seed-file", os.Getenv("SEED_FILE"), "fixture file to migrate (SEED_FILE); empty uses the built-in fixtures")
+	only := fs.String("only", "", "comma-separated migrations to run; empty runs all")
+	dryRun := fs.Bool("dry-run", false, "check that records convert without storing them")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of migrate:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(fs.Output(), "\nMigrations:\n")
+		for _, m := range migrations() {
+			fmt.Fprintf(fs.Output(), "  %-8s %s\n", m.name, m.summary)
+		}
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	selected, err := selectMigrations(*only)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	ctx := context.Background()
+	users := services.NewUserService()
+	orgs := services.NewOrganizationService()
+	if _, err := seed.Run(ctx, seed.Config{File: *file}, users, orgs); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitInvalidData
+	}
+
+	code := exitOK
+	for _, m := range selected {
+		migrated, errs := m.run(ctx, users, *dryRun)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", m.name, err)
+		}
+		fmt.Printf("%s: %d migrated, %d failed (dry run: %t)\n", m.name, migrated, len(errs), *dryRun)
+		if len(errs) > 0 {
+			code = exitInvalidData
+		}
+	}
+	return code
+}
+
+// selectMigrations resolves the -only flag against the known migrations
+func selectMigrations(only string) ([]migration, error) {
+	all := migrations()
+	if only == "" {
+		return all, nil
+	}
+
+	var selected []migration
+	for _, name := range strings.Split(only, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, m := range all {
+			if m.name == name {
+				selected = append(selected, m)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown migration %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// migrateUsers converts every stored User, deleted ones included, to UserRefactored
+func migrateUsers(ctx context.Context, users *services.UserService, dryRun bool) (int, []error) {
+	all, err := users.ReadAllIncludingDeleted(ctx)
+	if err != nil {
+		return 0, []error{err}
+	}
+
+	batch := make([]*models.User, len(all))
+	for i := range all {
+		batch[i] = &all[i]
+	}
+
+	migration := services.NewUserMigrationService(users)
+	results := migration.MigrateUsers
+	if dryRun {
+		results = migration.CheckUsers
+	}
+
+	var errs []error
+	for i, err := range results(ctx, batch) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", batch[i].ID, err))
+		}
+	}
+	return len(batch) - len(errs), errs
+}
+
+// seedConfigFor applies the serve flags over the environment's seed configuration
+func seedConfigFor(logger *log.Logger, opts serveOptions) seed.Config {
+	config := seedConfigFromEnv(logger)
+	if opts.seedFile != "" {
+		config.File = opts.seedFile
+	}
+	if opts.noSeed {
+		config.Disabled = true
+	}
+	return config
+}
+
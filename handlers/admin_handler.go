@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/logging"
+	"github.com/test-repo-golang-support/services"
+)
+
+// ImpersonationHeader carries an impersonation token on API requests
+const ImpersonationHeader = "X-Impersonation-Token"
+
+// adminPathPrefix is where the admin API is served. It sits outside
+// /api/v1 so it is never part of the public API surface.
+const adminPathPrefix = "/admin/v1"
+
+// Audit log page sizes
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AdminConfig configures the admin API
+type AdminConfig struct {
+	Token string // Bearer token the admin API requires; empty disables the API
+}
+
+// AdminHandler serves the admin API: cross-organization user management,
+// impersonation for support, and the audit log. Every request needs the
+// admin token and an acting user with the admin role.
+type AdminHandler struct {
+	users          *services.UserService
+	orgs           *services.OrganizationService
+	impersonations *services.ImpersonationService
+	deletions      *services.UserDeletionService
+	control        *logging.Controller
+	logger         *log.Logger
+	config         AdminConfig
+}
+
+// AdminUser is a user with every organization they belong to
+type AdminUser struct {
+	models.User
+	Organizations []AdminUserOrg `json:"organizations"`
+}
+
+// AdminUserOrg is one organization membership of an AdminUser
+type AdminUserOrg struct {
+	OrgID string            `json:"org_id"`
+	Name  string            `json:"name"`
+	Role  models.MemberRole `json:"role"`
+}
+
+// NewAdminHandler creates a new AdminHandler instance
+func NewAdminHandler(users *services.UserService, orgs *services.OrganizationService, impersonations *services.ImpersonationService, deletions *services.UserDeletionService, control *logging.Controller, logger *log.Logger, config AdminConfig) *AdminHandler {
+	return &AdminHandler{
+		users:          users,
+		orgs:           orgs,
+		impersonations: impersonations,
+		deletions:      deletions,
+		control:        control,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// =====================================
+// Admin HTTP Handlers
+// =====================================
+
+// GetUsers handles GET /admin/v1/users?include_deleted=true - lists every
+// user with the organizations they belong to
+func (h *AdminHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	read := h.users.ReadAll
+	if r.URL.Query().Get("include_deleted") == "true" {
+		read = h.users.ReadAllIncludingDeleted
+	}
+	users, err := read(ctx)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve users")
+		return
+	}
+
+	result := make([]AdminUser, 0, len(users))
+	for _, user := range users {
+		orgs, err := h.orgs.GetUserOrganizations(ctx, user.ID)
+		if err != nil {
+			h.respondError(w, http.StatusInternalServerError, "Failed to retrieve organizations")
+			return
+		}
+
+		memberships := make([]AdminUserOrg, 0, len(orgs))
+		for _, org := range orgs {
+			membership, err := h.orgs.GetMembership(ctx, user.ID, org.ID)
+			if err != nil {
+				continue
+			}
+			memberships = append(memberships, AdminUserOrg{OrgID: org.ID, Name: org.Name, Role: membership.Role})
+		}
+		sort.Slice(memberships, func(i, j int) bool {
+			return memberships[i].OrgID < memberships[j].OrgID
+		})
+		result = append(result, AdminUser{User: user, Organizations: memberships})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Users retrieved successfully",
+		Data:    result,
+	})
+}
+
+// ForceDeleteUser handles DELETE /admin/v1/users/{id}?reason= - removes a
+// user outright, deleted or not, along with everything tied to them; see
+// services.UserDeletionService. Owners of organizations must hand them
+// over first.
+func (h *AdminHandler) ForceDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	admin := r.Header.Get(ActingUserHeader)
+
+	reason := strings.TrimSpace(r.URL.Query().Get("reason"))
+	if reason == "" {
+		h.respondError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+	if id == admin {
+		h.respondError(w, http.StatusConflict, "Administrators cannot delete themselves")
+		return
+	}
+
+	if _, err := h.users.ReadIncludingDeleted(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	owned, err := h.orgs.ReadOrgsByOwner(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve organizations")
+		return
+	}
+	if len(owned) > 0 {
+		ids := make([]string, 0, len(owned))
+		for _, org := range owned {
+			ids = append(ids, org.ID)
+		}
+		sort.Strings(ids)
+		h.respondError(w, http.StatusConflict, fmt.Sprintf("User owns organizations %s; transfer ownership first", strings.Join(ids, ", ")))
+		return
+	}
+
+	deletion, err := h.deletions.Delete(ctx, id)
+	if err != nil {
+		h.logger.Printf("audit action=admin.user.force_delete_failed admin=%s user=%s error=%q", admin, id, err)
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete user")
+		return
+	}
+
+	h.logger.Printf("audit action=admin.user.force_delete admin=%s user=%s removed=%v reason=%q", admin, id, deletion.Removed, reason)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "User deleted successfully",
+		Data:    deletion,
+	})
+}
+
+// Impersonate handles POST /admin/v1/users/{id}/impersonate - issues a
+// token for acting as the user, e.g. {"reason":"ticket 123","ttl":"15m"}.
+// API requests that send it in X-Impersonation-Token are made as the user.
+func (h *AdminHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	admin := r.Header.Get(ActingUserHeader)
+
+	var input struct {
+		Reason string `json:"reason" validate:"required,max=500"`
+		TTL    string `json:"ttl"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	var ttl time.Duration
+	if input.TTL != "" {
+		parsed, err := time.ParseDuration(input.TTL)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid ttl")
+			return
+		}
+		ttl = parsed
+	}
+
+	impersonation, err := h.impersonations.Start(ctx, admin, id, input.Reason, ttl)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("audit action=admin.impersonation.start impersonation=%s admin=%s user=%s expires=%s reason=%q", impersonation.ID, admin, id, impersonation.ExpiresAt.Format(time.RFC3339), impersonation.Reason)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Impersonation started successfully",
+		Data:    impersonation,
+	})
+}
+
+// GetImpersonations handles GET /admin/v1/impersonations - lists the
+// impersonations that are still usable
+func (h *AdminHandler) GetImpersonations(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Impersonations retrieved successfully",
+		Data:    h.impersonations.ListActive(r.Context()),
+	})
+}
+
+// EndImpersonation handles DELETE /admin/v1/impersonations/{impersonationId} -
+// revokes an impersonation token before it expires
+func (h *AdminHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["impersonationId"]
+
+	impersonation, err := h.impersonations.End(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("audit action=admin.impersonation.end impersonation=%s admin=%s user=%s", id, r.Header.Get(ActingUserHeader), impersonation.UserID)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Impersonation ended successfully",
+		Data:    impersonation,
+	})
+}
+
+// GetAuditLog handles GET /admin/v1/audit?action=org.&limit=100 - lists
+// recent audit lines, newest first, optionally filtered by action prefix
+func (h *AdminHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := defaultAuditLimit
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 || n > maxAuditLimit {
+			h.respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+			return
+		}
+		limit = n
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Audit log retrieved successfully",
+		Data:    h.control.Audit(query.Get("action"), limit),
+	})
+}
+
+// =====================================
+// Admin Authentication
+// =====================================
+
+// AdminAuthMiddleware guards the admin API. Requests need the admin token
+// as a bearer token and an X-Acting-User naming an active user with the
+// admin role; impersonated requests are refused. Every admitted request
+// is audited.
+func AdminAuthMiddleware(h *AdminHandler) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h.config.Token == "" {
+				h.respondError(w, http.StatusNotFound, "Admin API is disabled")
+				return
+			}
+
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(token), []byte(h.config.Token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				h.respondError(w, http.StatusUnauthorized, "Admin token is required")
+				return
+			}
+			if r.Header.Get(ImpersonationHeader) != "" {
+				h.respondError(w, http.StatusForbidden, "Impersonated requests cannot use the admin API")
+				return
+			}
+
+			admin := r.Header.Get(ActingUserHeader)
+			user, err := h.users.Read(r.Context(), admin)
+			if err != nil || !user.IsActive() || !user.IsAdmin() {
+				h.logger.Printf("audit action=admin.denied actor=%q method=%s path=%s", admin, r.Method, r.URL.Path)
+				h.respondError(w, http.StatusForbidden, ActingUserHeader+" must name an active administrator")
+				return
+			}
+
+			h.logger.Printf("audit action=admin.request admin=%s method=%s path=%s", admin, r.Method, r.URL.Path)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ImpersonationMiddleware serves API requests that carry an
+// X-Impersonation-Token as the impersonated user by setting X-Acting-User.
+// Every impersonated request is audited.
+func ImpersonationMiddleware(h *AdminHandler) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(ImpersonationHeader)
+			if token == "" || strings.HasPrefix(r.URL.Path, adminPathPrefix+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			impersonation, err := h.impersonations.Resolve(r.Context(), token)
+			if err != nil {
+				h.respondServiceError(w, err)
+				return
+			}
+
+			h.logger.Printf("audit action=admin.impersonation.request impersonation=%s admin=%s user=%s method=%s path=%s", impersonation.ID, impersonation.AdminID, impersonation.UserID, r.Method, r.URL.Path)
+
+			impersonated := r.Clone(r.Context())
+			impersonated.Header.Set(ActingUserHeader, impersonation.UserID)
+			next.ServeHTTP(w, impersonated)
+		})
+	}
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondServiceError maps impersonation errors to HTTP statuses (pointer receiver)
+func (h *AdminHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrImpersonationNotFound):
+		h.respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrImpersonationInvalid):
+		h.respondError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrImpersonationNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *AdminHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *AdminHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Admin
+// =====================================
+
+// SetupAdminRoutes configures the admin API on the top-level router and
+// returns its guarded subrouter, where every other admin route is mounted
+func SetupAdminRoutes(router *mux.Router, h *AdminHandler) *mux.Router {
+	admin := router.PathPrefix(adminPathPrefix).Subrouter()
+	admin.Use(AdminAuthMiddleware(h))
+	admin.HandleFunc("/users", h.GetUsers).Methods("GET")
+	admin.HandleFunc("/users/{id}", h.ForceDeleteUser).Methods("DELETE")
+	admin.HandleFunc("/users/{id}/impersonate", h.Impersonate).Methods("POST")
+	admin.HandleFunc("/impersonations", h.GetImpersonations).Methods("GET")
+	admin.HandleFunc("/impersonations/{impersonationId}", h.EndImpersonation).Methods("DELETE")
+	admin.HandleFunc("/audit", h.GetAuditLog).Methods("GET")
+	return admin
+}
+
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// BackfillHandler exposes the backfill orchestrator over HTTP
+type BackfillHandler struct {
+	orchestrator *services.BackfillOrchestrator
+	logger       *log.Logger
+}
+
+// NewBackfillHandler creates a new BackfillHandler instance
+func NewBackfillHandler(orchestrator *services.BackfillOrchestrator, logger *log.Logger) *BackfillHandler {
+	return &BackfillHandler{
+		orchestrator: orchestrator,
+		logger:       logger,
+	}
+}
+
+// =====================================
+// Backfill HTTP Handlers
+// =====================================
+
+// GetBackfills handles GET /admin/v1/backfills - lists every backfill with its progress
+func (h *BackfillHandler) GetBackfills(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Backfills retrieved successfully",
+		Data:    h.orchestrator.List(),
+	})
+}
+
+// GetBackfill handles GET /admin/v1/backfills/{name} - returns one backfill's progress
+func (h *BackfillHandler) GetBackfill(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	progress, err := h.orchestrator.Progress(vars["name"])
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Backfill not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Backfill retrieved successfully",
+		Data:    progress,
+	})
+}
+
+// StartBackfill handles POST /admin/v1/backfills/{name}/start - runs a backfill
+// from the beginning; ?restart=true discards the checkpoint of a paused run
+func (h *BackfillHandler) StartBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	progress, err := h.orchestrator.Start(ctx, name, r.URL.Query().Get("restart") == "true")
+	if err != nil {
+		h.respondBackfillError(w, name, err)
+		return
+	}
+
+	h.logger.Printf("audit action=backfill.start name=%s total=%d", name, progress.Total)
+	h.respondJSON(w, http.StatusAccepted, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Backfill started",
+		Data:    progress,
+	})
+}
+
+// PauseBackfill handles POST /admin/v1/backfills/{name}/pause - stops after the current batch
+func (h *BackfillHandler) PauseBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	progress, err := h.orchestrator.Pause(ctx, name)
+	if err != nil {
+		h.respondBackfillError(w, name, err)
+		return
+	}
+
+	h.logger.Printf("audit action=backfill.pause name=%s checkpoint=%q", name, progress.Checkpoint)
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Backfill paused",
+		Data:    progress,
+	})
+}
+
+// ResumeBackfill handles POST /admin/v1/backfills/{name}/resume - continues from the checkpoint
+func (h *BackfillHandler) ResumeBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	progress, err := h.orchestrator.Resume(ctx, name)
+	if err != nil {
+		h.respondBackfillError(w, name, err)
+		return
+	}
+
+	h.logger.Printf("audit action=backfill.resume name=%s checkpoint=%q", name, progress.Checkpoint)
+	h.respondJSON(w, http.StatusAccepted, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Backfill resumed",
+		Data:    progress,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondBackfillError maps orchestrator errors to HTTP statuses (pointer receiver)
+func (h *BackfillHandler) respondBackfillError(w http.ResponseWriter, name string, err error) {
+	if errors.Is(err, services.ErrBackfillState) {
+		h.respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if _, lookupErr := h.orchestrator.Progress(name); lookupErr != nil {
+		h.respondError(w, http.StatusNotFound, "Backfill not found")
+		return
+	}
+	h.respondError(w, http.StatusInternalServerError, err.Error())
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *BackfillHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *BackfillHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Backfills
+// =====================================
+
+// SetupBackfillRoutes configures backfill routes on the admin router
+func SetupBackfillRoutes(router *mux.Router, h *BackfillHandler) {
+	router.HandleFunc("/backfills", h.GetBackfills).Methods("GET")
+	router.HandleFunc("/backfills/{name}", h.GetBackfill).Methods("GET")
+	router.HandleFunc("/backfills/{name}/start", h.StartBackfill).Methods("POST")
+	router.HandleFunc("/backfills/{name}/pause", h.PauseBackfill).Methods("POST")
+	router.HandleFunc("/backfills/{name}/resume", h.ResumeBackfill).Methods("POST")
+}
+
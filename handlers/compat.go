@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// PayloadShape identifies which user payload version a route expects
+type PayloadShape int
+
+// Payload shape constants
+const (
+	PayloadShapeV1 PayloadShape = iota + 1 // models.User ("email")
+	PayloadShapeV2                         // models.UserRefactored ("email_address")
+)
+
+// String returns the shape name (value receiver)
+func (p PayloadShape) String() string {
+	switch p {
+	case PayloadShapeV1:
+		return "v1"
+	case PayloadShapeV2:
+		return "v2"
+	}
+	return "unknown"
+}
+
+// renamedUserFields maps v1 JSON field names to their v2 replacements
+var renamedUserFields = map[string]string{
+	"email": "email_address",
+}
+
+// UserPayloadCompat wraps a handler so that user payloads written for the
+// other model version are auto-mapped to the shape the route expects.
+// Every remapping is logged and reported back in a Warning header so
+// clients can migrate instead of silently losing fields.
+func UserPayloadCompat(shape PayloadShape, logger *log.Logger, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.ContentLength == 0 {
+			next(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			writeDecodeError(w, logger, err)
+			return
+		}
+
+		mapped, warnings := remapUserPayload(body, shape)
+		for _, warning := range warnings {
+			logger.Printf("payload_compat method=%s path=%s shape=%s warning=%q", r.Method, r.URL.Path, shape, warning)
+			w.Header().Add("Warning", fmt.Sprintf("299 - %q", warning))
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(mapped))
+		r.ContentLength = int64(len(mapped))
+		next(w, r)
+	}
+}
+
+// remapUserPayload renames known user fields to match the target shape.
+// Bodies that are not JSON objects are returned unchanged so the handler
+// can report the decoding error itself.
+func remapUserPayload(body []byte, shape PayloadShape) ([]byte, []string) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body, nil
+	}
+
+	warnings := make([]string, 0)
+	for v1Name, v2Name := range renamedUserFields {
+		from, to := v1Name, v2Name
+		if shape == PayloadShapeV1 {
+			from, to = v2Name, v1Name
+		}
+
+		value, present := fields[from]
+		if !present {
+			continue
+		}
+		delete(fields, from)
+
+		if existing, conflict := fields[to]; conflict {
+			if !bytes.Equal(existing, value) {
+				warnings = append(warnings, fmt.Sprintf("both %s and %s supplied; ignoring %s", from, to, from))
+			}
+			continue
+		}
+
+		fields[to] = value
+		warnings = append(warnings, fmt.Sprintf("field %s is not part of the %s user payload; mapped to %s", from, shape, to))
+	}
+
+	if len(warnings) == 0 {
+		return body, nil
+	}
+
+	mapped, err := json.Marshal(fields)
+	if err != nil {
+		return body, nil
+	}
+	return mapped, warnings
+}
+
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// DedupReplayedHeader marks a response replayed for a duplicate submission
+const DedupReplayedHeader = "Idempotent-Replayed"
+
+// maxDedupEntries bounds how many submissions are remembered at once
+const maxDedupEntries = 10000
+
+// dedupRoutes are the create endpoints browsers are known to double-submit
+var dedupRoutes = map[string]bool{
+	"/api/v1/users":         true,
+	"/api/v1/organizations": true,
+}
+
+// DedupConfig configures DedupMiddleware
+type DedupConfig struct {
+	Window time.Duration // How long a successful result is replayed; 0 disables
+}
+
+// dedupEntry is a submission in flight or its remembered result
+type dedupEntry struct {
+	done    chan struct{} // Closed once the result is known
+	ok      bool          // Whether the result may be replayed
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// deduplicator remembers recent submissions by content hash
+type deduplicator struct {
+	window  time.Duration
+	entries map[string]*dedupEntry
+	mu      sync.Mutex
+}
+
+// DedupMiddleware collapses identical POSTs to the create-user and
+// create-organization routes that arrive within the window, as happens
+// when a browser submits a form twice. Submissions are identical when
+// they come from the same client address and user agent with the same
+// body. A duplicate waits for the original to finish and receives its
+// response, marked with an Idempotent-Replayed header, instead of
+// creating a second record. Only successful results are replayed.
+func DedupMiddleware(logger *log.Logger, config DedupConfig) mux.MiddlewareFunc {
+	d := &deduplicator{
+		window:  config.Window,
+		entries: make(map[string]*dedupEntry),
+	}
+
+	return func(next http.Handler) http.Handler {
+		if config.Window <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || !dedupRoutes[routeTemplate(r)] || r.Body == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				writeDecodeError(w, logger, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key := submissionKey(r, body)
+			entry, original := d.claim(key, time.Now())
+			if !original {
+				select {
+				case <-entry.done:
+				case <-r.Context().Done():
+					return
+				}
+				if entry.ok {
+					logger.Printf("dedup method=%s route=%s replayed status=%d", r.Method, routeTemplate(r), entry.status)
+					replay(w, entry)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				d.settle(key, entry, recorder, time.Now())
+			}()
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on deduplicator
+// =====================================
+
+// claim returns the entry for a submission key and whether this request
+// is the original that must produce the result (pointer receiver)
+func (d *deduplicator) claim(key string, now time.Time) (*dedupEntry, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry, exists := d.entries[key]; exists {
+		select {
+		case <-entry.done:
+			if now.Before(entry.expires) {
+				return entry, false
+			}
+		default:
+			return entry, false
+		}
+	}
+
+	if len(d.entries) >= maxDedupEntries {
+		d.sweep(now)
+	}
+	entry := &dedupEntry{done: make(chan struct{})}
+	d.entries[key] = entry
+	return entry, true
+}
+
+// settle records the original's result and wakes any duplicates waiting
+// on it. Unsuccessful results are forgotten so a retry runs again
+// (pointer receiver).
+func (d *deduplicator) settle(key string, entry *dedupEntry, recorder *capturingWriter, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry.status = recorder.status
+	entry.header = recorder.Header().Clone()
+	entry.body = recorder.body.Bytes()
+	entry.ok = recorder.status >= 200 && recorder.status < 300
+	entry.expires = now.Add(d.window)
+	if !entry.ok && d.entries[key] == entry {
+		delete(d.entries, key)
+	}
+	close(entry.done)
+}
+
+// sweep drops expired results; callers hold d.mu (pointer receiver)
+func (d *deduplicator) sweep(now time.Time) {
+	for key, entry := range d.entries {
+		select {
+		case <-entry.done:
+			if !now.Before(entry.expires) {
+				delete(d.entries, key)
+			}
+		default:
+		}
+	}
+}
+
+// =====================================
+// Helper Functions
+// =====================================
+
+// capturingWriter passes a response through while keeping a copy
+type capturingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status before passing it on (pointer receiver)
+func (w *capturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write copies the body before passing it on (pointer receiver)
+func (w *capturingWriter) Write(p []byte) (int, error) {
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// replay writes a remembered response (standalone function)
+func replay(w http.ResponseWriter, entry *dedupEntry) {
+	for name, values := range entry.header {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+	w.Header().Set(DedupReplayedHeader, "true")
+	w.WriteHeader(entry.status)
+	_, _ = w.Write(entry.body)
+}
+
+// submissionKey hashes what makes two submissions the same (standalone function)
+func submissionKey(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	for _, part := range []string{r.Method, routeTemplate(r), clientAddress(r), r.UserAgent()} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// dedupSubmission is one POST sent through the dedup test router
+type dedupSubmission struct {
+	path      string
+	body      string
+	addr      string
+	userAgent string
+}
+
+// newTestDedupRouter serves POST /api/v1/users, /api/v1/organizations and
+// /api/v1/projects behind DedupMiddleware. Each request the handlers run
+// is counted; status decides the response to the nth run (from 1).
+func newTestDedupRouter(config DedupConfig, runs *int64, status func(n int64) int) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(DedupMiddleware(log.New(io.Discard, "", 0), config))
+	api := router.PathPrefix("/api/v1").Subrouter()
+	create := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := atomic.AddInt64(runs, 1)
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/records/%d", n))
+		w.WriteHeader(status(n))
+		fmt.Fprintf(w, `{"run":%d,"body":%s}`, n, body)
+	}
+	for _, path := range []string{"/users", "/organizations", "/projects"} {
+		api.HandleFunc(path, create).Methods(http.MethodPost)
+	}
+	return router
+}
+
+// serveSubmission sends a submission through a router
+func serveSubmission(router *mux.Router, s dedupSubmission) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, s.path, strings.NewReader(s.body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = s.addr
+	req.Header.Set("User-Agent", s.userAgent)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDedupMiddlewareReplays(t *testing.T) {
+	first := dedupSubmission{"/api/v1/users", `{"email":"ada@example.com"}`, "10.0.0.1:1000", "browser"}
+	with := func(change func(s *dedupSubmission)) dedupSubmission {
+		s := first
+		change(&s)
+		return s
+	}
+	org := with(func(s *dedupSubmission) { s.path = "/api/v1/organizations" })
+	created := func(int64) int { return http.StatusCreated }
+
+	tests := []struct {
+		name     string
+		window   time.Duration
+		status   func(n int64) int
+		wait     time.Duration // Pause between the two submissions
+		first    dedupSubmission
+		second   dedupSubmission
+		wantRuns int64
+	}{
+		{"double submit", time.Minute, created, 0, first, first, 1},
+		{"double submit from another port", time.Minute, created, 0, first, with(func(s *dedupSubmission) { s.addr = "10.0.0.1:2000" }), 1},
+		{"organization double submit", time.Minute, created, 0, org, org, 1},
+		{"different body", time.Minute, created, 0, first, with(func(s *dedupSubmission) { s.body = `{"email":"augusta@example.com"}` }), 2},
+		{"different client", time.Minute, created, 0, first, with(func(s *dedupSubmission) { s.addr = "10.0.0.2:1000" }), 2},
+		{"different user agent", time.Minute, created, 0, first, with(func(s *dedupSubmission) { s.userAgent = "script" }), 2},
+		{"different route", time.Minute, created, 0, first, org, 2},
+		{"route not deduplicated", time.Minute, created, 0, with(func(s *dedupSubmission) { s.path = "/api/v1/projects" }),
+			with(func(s *dedupSubmission) { s.path = "/api/v1/projects" }), 2},
+		{"after the window", 10 * time.Millisecond, created, 30 * time.Millisecond, first, first, 2},
+		{"dedup disabled", 0, created, 0, first, first, 2},
+		{"original failed", time.Minute, func(n int64) int {
+			if n == 1 {
+				return http.StatusBadRequest
+			}
+			return http.StatusCreated
+		}, 0, first, first, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int64
+			router := newTestDedupRouter(DedupConfig{Window: tt.window}, &runs, tt.status)
+
+			rec1 := serveSubmission(router, tt.first)
+			time.Sleep(tt.wait)
+			rec2 := serveSubmission(router, tt.second)
+
+			if runs != tt.wantRuns {
+				t.Fatalf("handler ran %d times, want %d", runs, tt.wantRuns)
+			}
+			if rec1.Header().Get(DedupReplayedHeader) != "" {
+				t.Errorf("original marked as replayed")
+			}
+			replayed := rec2.Header().Get(DedupReplayedHeader) == "true"
+			if replayed != (tt.wantRuns == 1) {
+				t.Fatalf("second submission replayed = %v, want %v", replayed, tt.wantRuns == 1)
+			}
+			if !replayed {
+				return
+			}
+
+			// A replay repeats the original response exactly
+			if rec2.Code != rec1.Code || rec2.Body.String() != rec1.Body.String() {
+				t.Errorf("replay = %d %s, want %d %s", rec2.Code, rec2.Body, rec1.Code, rec1.Body)
+			}
+			if got, want := rec2.Header().Get("Location"), rec1.Header().Get("Location"); got != want {
+				t.Errorf("replayed Location = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDedupMiddlewareConcurrentDuplicates(t *testing.T) {
+	var runs int64
+	release := make(chan struct{})
+	router := newTestDedupRouter(DedupConfig{Window: time.Minute}, &runs, func(int64) int {
+		<-release
+		return http.StatusCreated
+	})
+	submission := dedupSubmission{"/api/v1/users", `{"email":"ada@example.com"}`, "10.0.0.1:1000", "browser"}
+
+	// The original holds its response until the duplicates have arrived
+	const submissions = 5
+	recs := make([]*httptest.ResponseRecorder, submissions)
+	var wg sync.WaitGroup
+	for i := range recs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recs[i] = serveSubmission(router, submission)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs != 1 {
+		t.Fatalf("handler ran %d times, want 1", runs)
+	}
+	replays := 0
+	for _, rec := range recs {
+		if rec.Code != http.StatusCreated || rec.Body.String() != recs[0].Body.String() {
+			t.Errorf("response = %d %s, want %d %s", rec.Code, rec.Body, http.StatusCreated, recs[0].Body)
+		}
+		if rec.Header().Get(DedupReplayedHeader) == "true" {
+			replays++
+		}
+	}
+	if replays != submissions-1 {
+		t.Errorf("%d responses replayed, want %d", replays, submissions-1)
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/asynclog"
+	"github.com/test-repo-golang-support/pkg/runtimeconfig"
+)
+
+// DiagnosticsHandler reports the effective Go runtime settings and live runtime statistics
+type DiagnosticsHandler struct {
+	settings runtimeconfig.Settings
+	logs     *asynclog.Writer // Optional; reports log buffer usage and losses
+	logger   *log.Logger
+}
+
+// NewDiagnosticsHandler creates a new DiagnosticsHandler instance
+func NewDiagnosticsHandler(settings runtimeconfig.Settings, logger *log.Logger) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// SetLogWriter includes the async log writer's buffer statistics in the report (pointer receiver)
+func (h *DiagnosticsHandler) SetLogWriter(logs *asynclog.Writer) {
+	h.logs = logs
+}
+
+// runtimeStats is a point-in-time view of scheduler and memory statistics
+type runtimeStats struct {
+	Goroutines    int           `json:"goroutines"`
+	HeapAlloc     uint64        `json:"heap_alloc_bytes"`
+	HeapSys       uint64        `json:"heap_sys_bytes"`
+	Sys           uint64        `json:"sys_bytes"`
+	NumGC         uint32        `json:"num_gc"`
+	PauseTotal    time.Duration `json:"gc_pause_total_ns"`
+	GCCPUFraction float64       `json:"gc_cpu_fraction"`
+	UptimeSeconds float64       `json:"uptime_seconds"`
+	LastGC        *time.Time    `json:"last_gc,omitempty"`
+}
+
+// =====================================
+// Diagnostics HTTP Handlers
+// =====================================
+
+// GetDiagnostics handles GET /admin/v1/diagnostics - returns runtime settings
+// (GOMAXPROCS, GOGC, GOMEMLIMIT and their sources), live statistics, and
+// log buffer usage including entries dropped on overflow
+func (h *DiagnosticsHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	settings := h.settings
+	settings.Refresh()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := runtimeStats{
+		Goroutines:    runtime.NumGoroutine(),
+		HeapAlloc:     mem.HeapAlloc,
+		HeapSys:       mem.HeapSys,
+		Sys:           mem.Sys,
+		NumGC:         mem.NumGC,
+		PauseTotal:    time.Duration(mem.PauseTotalNs),
+		GCCPUFraction: mem.GCCPUFraction,
+		UptimeSeconds: time.Since(h.settings.AppliedAt).Seconds(),
+	}
+	if mem.LastGC > 0 {
+		lastGC := time.Unix(0, int64(mem.LastGC)).UTC()
+		stats.LastGC = &lastGC
+	}
+
+	data := map[string]interface{}{
+		"runtime": settings,
+		"stats":   stats,
+	}
+	if h.logs != nil {
+		data["log_buffer"] = h.logs.Stats()
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Diagnostics retrieved successfully",
+		Data:    data,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *DiagnosticsHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// =====================================
+// Route Setup for Diagnostics
+// =====================================
+
+// SetupDiagnosticsRoutes configures diagnostics routes on the admin router
+func SetupDiagnosticsRoutes(router *mux.Router, h *DiagnosticsHandler) {
+	router.HandleFunc("/diagnostics", h.GetDiagnostics).Methods("GET")
+}
+
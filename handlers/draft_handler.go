@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// DraftHandler handles draft HTTP requests
+type DraftHandler struct {
+	service *services.DraftService
+	logger  *log.Logger
+}
+
+// NewDraftHandler creates a new DraftHandler instance
+func NewDraftHandler(service *services.DraftService, logger *log.Logger) *DraftHandler {
+	return &DraftHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Draft HTTP Handlers
+// =====================================
+
+// CreateDraft handles POST /drafts - starts a draft of a user, organization,
+// or project, e.g. {"kind":"organization","created_by":"u1","fields":{"name":"Acme"}}.
+// Fields take the body of the resource's create endpoint and may be partial.
+func (h *DraftHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var input struct {
+		Kind      models.DraftKind `json:"kind" validate:"required,oneof=user organization project"`
+		CreatedBy string           `json:"created_by"`
+		Fields    json.RawMessage  `json:"fields"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	draft, err := h.service.CreateDraft(ctx, input.Kind, input.CreatedBy, input.Fields)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Created %s draft: %s", draft.Kind, draft.ID)
+
+	w.Header().Set("ETag", versionETag(draft.Version))
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Draft created successfully",
+		Data:    draft,
+	})
+}
+
+// GetDrafts handles GET /drafts?kind=&created_by= - lists drafts
+func (h *DraftHandler) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	kind := models.DraftKind(query.Get("kind"))
+	if kind != "" && !kind.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "kind must be user, organization, or project")
+		return
+	}
+
+	drafts, err := h.service.ListDrafts(ctx, kind, query.Get("created_by"))
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve drafts")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Drafts retrieved successfully",
+		Data:    drafts,
+	})
+}
+
+// GetDraft handles GET /drafts/{id} - retrieves a draft
+func (h *DraftHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	draft, err := h.service.ReadDraft(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(draft.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Draft retrieved successfully",
+		Data:    draft,
+	})
+}
+
+// ReplaceDraft handles PUT /drafts/{id} - replaces a draft's fields,
+// e.g. {"fields":{"name":"Acme","owner_id":"u1"}}
+func (h *DraftHandler) ReplaceDraft(w http.ResponseWriter, r *http.Request) {
+	h.updateDraft(w, r, false)
+}
+
+// PatchDraft handles PATCH /drafts/{id} - sets the given fields and keeps
+// the rest; a null field is removed, e.g. {"fields":{"slug":"acme","size":null}}
+func (h *DraftHandler) PatchDraft(w http.ResponseWriter, r *http.Request) {
+	h.updateDraft(w, r, true)
+}
+
+// DeleteDraft handles DELETE /drafts/{id} - discards a draft
+func (h *DraftHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.service.DeleteDraft(ctx, id); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Discarded draft: %s", id)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Draft deleted successfully",
+	})
+}
+
+// PublishDraft handles POST /drafts/{id}/publish - validates the draft and
+// creates the user, organization, or project it describes. With If-Match
+// only the version the client last saw is published.
+func (h *DraftHandler) PublishDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadDraft(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if !ifMatchSatisfied(r, existing.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Draft has been modified")
+		return
+	}
+
+	publication, err := h.service.PublishDraft(ctx, id, existing.Version)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Published %s draft %s as %s", publication.Kind, publication.DraftID, publication.ResourceID)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Draft published successfully",
+		Data:    publication,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// updateDraft replaces or merges a draft's fields (pointer receiver)
+func (h *DraftHandler) updateDraft(w http.ResponseWriter, r *http.Request, merge bool) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadDraft(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if !ifMatchSatisfied(r, existing.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Draft has been modified")
+		return
+	}
+
+	var input struct {
+		Fields json.RawMessage `json:"fields" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	draft, err := h.service.UpdateDraft(ctx, id, input.Fields, merge, existing.Version)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(draft.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Draft updated successfully",
+		Data:    draft,
+	})
+}
+
+// respondServiceError maps draft service errors to HTTP statuses (pointer receiver)
+func (h *DraftHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrDraftNotFound):
+		h.respondError(w, http.StatusNotFound, "Draft not found")
+	case errors.Is(err, services.ErrDraftInvalid):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrDraftConflict):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrVersionConflict):
+		h.respondError(w, http.StatusConflict, "Draft has been modified")
+	case errors.Is(err, services.ErrStoreFull):
+		h.respondError(w, http.StatusInsufficientStorage, err.Error())
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *DraftHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *DraftHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Drafts
+// =====================================
+
+// SetupDraftRoutes configures draft routes
+func SetupDraftRoutes(router *mux.Router, h *DraftHandler) {
+	router.HandleFunc("/drafts", h.GetDrafts).Methods("GET")
+	router.HandleFunc("/drafts", h.CreateDraft).Methods("POST")
+	router.HandleFunc("/drafts/{id}", h.GetDraft).Methods("GET")
+	router.HandleFunc("/drafts/{id}", h.ReplaceDraft).Methods("PUT")
+	router.HandleFunc("/drafts/{id}", h.PatchDraft).Methods("PATCH")
+	router.HandleFunc("/drafts/{id}", h.DeleteDraft).Methods("DELETE")
+	router.HandleFunc("/drafts/{id}/publish", h.PublishDraft).Methods("POST")
+}
+
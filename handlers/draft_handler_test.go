@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestDraftRouter serves the draft routes over fresh stores
+func newTestDraftRouter() *mux.Router {
+	users := services.NewUserService()
+	orgs := services.NewOrganizationService()
+	router := mux.NewRouter()
+	SetupDraftRoutes(router, NewDraftHandler(services.NewDraftService(users, orgs, services.NewProjectService(orgs)), log.New(io.Discard, "", 0)))
+	return router
+}
+
+func TestDraftIfMatch(t *testing.T) {
+	const fields = `{"fields":{"first_name":"Ada","last_name":"Lovelace","email":"ada@example.com"}}`
+	serve := func(router *mux.Router, method, path, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string // Relative to the draft
+		ifMatch string // "first" sends the ETag the draft was created with
+		body    string
+		want    int
+	}{
+		{"edit without If-Match", http.MethodPatch, "", "", fields, http.StatusOK},
+		{"edit at the current version", http.MethodPatch, "", "current", fields, http.StatusOK},
+		{"edit at a stale version", http.MethodPatch, "", "first", fields, http.StatusPreconditionFailed},
+		{"replace at a stale version", http.MethodPut, "", "first", fields, http.StatusPreconditionFailed},
+		{"publish with any version", http.MethodPost, "/publish", "*", "", http.StatusCreated},
+		{"publish at the current version", http.MethodPost, "/publish", "current", "", http.StatusCreated},
+		{"publish at a stale version", http.MethodPost, "/publish", "first", "", http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestDraftRouter()
+			created := serve(router, http.MethodPost, "/drafts", "", `{"kind":"user","created_by":"user_admin",`+fields[1:])
+			if created.Code != http.StatusCreated {
+				t.Fatalf("create: status = %d; body %s", created.Code, created.Body)
+			}
+			id := draftID(t, created)
+			first := created.Header().Get("ETag")
+
+			// An edit moves the draft past the version it was created with
+			edited := serve(router, http.MethodPatch, "/drafts/"+id, "", `{"fields":{"first_name":"Augusta"}}`)
+			if edited.Code != http.StatusOK || edited.Header().Get("ETag") == first {
+				t.Fatalf("edit: status = %d, ETag %q; body %s", edited.Code, edited.Header().Get("ETag"), edited.Body)
+			}
+
+			ifMatch := tt.ifMatch
+			switch ifMatch {
+			case "first":
+				ifMatch = first
+			case "current":
+				ifMatch = edited.Header().Get("ETag")
+			}
+			rec := serve(router, tt.method, "/drafts/"+id+tt.path, ifMatch, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+
+			// A refused write leaves the edited draft in place
+			if tt.want == http.StatusPreconditionFailed {
+				current := serve(router, http.MethodGet, "/drafts/"+id, "", "")
+				if current.Code != http.StatusOK || current.Header().Get("ETag") != edited.Header().Get("ETag") {
+					t.Errorf("draft after refused write: status %d, ETag %q, want 200 %q", current.Code, current.Header().Get("ETag"), edited.Header().Get("ETag"))
+				}
+			}
+		})
+	}
+}
+
+// draftID reads the ID of the draft in a response
+func draftID(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var response struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil || response.Data.ID == "" {
+		t.Fatalf("no draft ID in %s (err %v)", rec.Body, err)
+	}
+	return response.Data.ID
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// embedMaxAge is how long browsers and CDNs may cache an embedded badge
+const embedMaxAge = "300"
+
+// jsonpCallbackPattern restricts JSONP callbacks to dotted JavaScript identifiers
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// EmbedHandler serves badges that organizations embed on their own sites.
+// Only organizations with a public profile can be embedded.
+type EmbedHandler struct {
+	orgService *services.OrganizationService
+	logger     *log.Logger
+}
+
+// NewEmbedHandler creates a new EmbedHandler instance
+func NewEmbedHandler(orgService *services.OrganizationService, logger *log.Logger) *EmbedHandler {
+	return &EmbedHandler{
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+// OrgBadge is the data shown on an embedded organization badge
+type OrgBadge struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	MemberCount int    `json:"member_count"`
+	Verified    bool   `json:"verified"`
+}
+
+// =====================================
+// Embed HTTP Handlers
+// =====================================
+
+// GetOrgBadge handles GET /embed/organizations/{id}/badge - returns the badge as
+// SVG by default, as JSON with ?format=json, or as JSONP with ?callback=fn
+func (h *EmbedHandler) GetOrgBadge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	org, err := h.orgService.ReadOrg(ctx, id)
+	if err != nil || !org.HasPublicProfile() {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	members, err := h.orgService.GetMembers(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	badge := OrgBadge{
+		ID:          org.ID,
+		Name:        org.Name,
+		Slug:        org.Slug,
+		MemberCount: len(members),
+		Verified:    org.Verified,
+	}
+
+	query := r.URL.Query()
+	callback := query.Get("callback")
+	if callback != "" && !jsonpCallbackPattern.MatchString(callback) {
+		h.respondError(w, http.StatusBadRequest, "Invalid callback name")
+		return
+	}
+
+	// Membership changes don't bump the organization version, so the
+	// member count is part of the validator
+	etag := fmt.Sprintf("W/\"%d-%d\"", org.Version, badge.MemberCount)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age="+embedMaxAge)
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cross-Origin-Resource-Policy", "cross-origin")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if noneMatchSatisfied(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	switch {
+	case callback != "":
+		h.respondJSONP(w, callback, badge)
+	case query.Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json"):
+		h.respondJSON(w, http.StatusOK, models.APIResponse{
+			Code:    models.ResponseOK,
+			Message: "Badge retrieved successfully",
+			Data:    badge,
+		})
+	default:
+		h.respondSVG(w, badge)
+	}
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// renderBadgeSVG draws a two-part badge: the organization name on the left
+// and its member count, with a check mark when verified, on the right
+func renderBadgeSVG(badge OrgBadge) string {
+	label := html.EscapeString(badge.Name)
+	value := fmt.Sprintf("%d members", badge.MemberCount)
+	if badge.MemberCount == 1 {
+		value = "1 member"
+	}
+	if badge.Verified {
+		value = "✓ " + value
+	}
+
+	// Approximate text widths; the badge only needs to be legible, not exact
+	labelWidth := 10 + 7*len([]rune(badge.Name))
+	valueWidth := 10 + 7*len([]rune(value))
+	width := labelWidth + valueWidth
+
+	color := "#555"
+	if badge.Verified {
+		color = "#2e7d32"
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+
+		`<title>%s: %s</title>`+
+		`<rect width="%d" height="20" fill="#333"/>`+
+		`<rect x="%d" width="%d" height="20" fill="%s"/>`+
+		`<g fill="#fff" font-family="Verdana,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="5" y="14">%s</text>`+
+		`<text x="%d" y="14">%s</text>`+
+		`</g></svg>`,
+		width, label, value,
+		label, value,
+		labelWidth,
+		labelWidth, valueWidth, color,
+		label,
+		labelWidth+5, value)
+}
+
+// respondSVG sends the badge as an SVG image that cannot run script (pointer receiver)
+func (h *EmbedHandler) respondSVG(w http.ResponseWriter, badge OrgBadge) {
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(renderBadgeSVG(badge))); err != nil {
+		h.logger.Printf("Error writing badge: %v", err)
+	}
+}
+
+// respondJSONP sends the badge wrapped in a call to the given callback (pointer receiver)
+func (h *EmbedHandler) respondJSONP(w http.ResponseWriter, callback string, badge OrgBadge) {
+	data, err := json.Marshal(badge)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to encode badge")
+		return
+	}
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "/**/%s(%s);", callback, data); err != nil {
+		h.logger.Printf("Error writing badge: %v", err)
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *EmbedHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *EmbedHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Embeds
+// =====================================
+
+// SetupEmbedRoutes configures embeddable widget routes on the root router,
+// outside the /api/v1 prefix
+func SetupEmbedRoutes(router *mux.Router, h *EmbedHandler) {
+	router.HandleFunc("/embed/organizations/{id}/badge", h.GetOrgBadge).Methods("GET")
+}
+
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/services"
+)
+
+// EnumHandler exposes the enum registry so UIs don't hard-code allowed values
+type EnumHandler struct {
+	service *services.EnumService
+	locales *services.LocaleService
+	logger  *log.Logger
+}
+
+// NewEnumHandler creates a new EnumHandler instance
+func NewEnumHandler(service *services.EnumService, locales *services.LocaleService, logger *log.Logger) *EnumHandler {
+	return &EnumHandler{
+		service: service,
+		locales: locales,
+		logger:  logger,
+	}
+}
+
+// enumValueView is an allowed value with its label resolved for one locale
+type enumValueView struct {
+	Value   string `json:"value"`
+	Label   string `json:"label"`
+	Builtin bool   `json:"builtin"`
+}
+
+// enumView is an enumeration as returned to clients
+type enumView struct {
+	Kind       models.EnumKind `json:"kind"`
+	Extensible bool            `json:"extensible"`
+	Locale     string          `json:"locale"`
+	Values     []enumValueView `json:"values"`
+}
+
+// =====================================
+// Enum HTTP Handlers
+// =====================================
+
+// GetEnums handles GET /enums?locale=de - lists every enumeration.
+// With ?user_id= and/or ?org_id= labels follow the user's or organization's locale.
+func (h *EnumHandler) GetEnums(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	locale := h.requestLocale(r)
+
+	defs := h.service.ListEnums(ctx)
+	views := make([]enumView, 0, len(defs))
+	for _, def := range defs {
+		views = append(views, newEnumView(def, locale))
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Enums retrieved successfully",
+		Data:    views,
+	})
+}
+
+// GetEnum handles GET /enums/{kind}?locale=de - lists the values of one enumeration
+func (h *EnumHandler) GetEnum(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	kind := models.EnumKind(vars["kind"])
+
+	def, err := h.service.GetEnum(ctx, kind)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Enum not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Enum retrieved successfully",
+		Data:    newEnumView(*def, h.requestLocale(r)),
+	})
+}
+
+// AddEnumValue handles POST /admin/v1/enums/{kind} - adds a value to an extensible enumeration
+func (h *EnumHandler) AddEnumValue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	kind := models.EnumKind(vars["kind"])
+
+	if _, err := h.service.GetEnum(ctx, kind); err != nil {
+		h.respondError(w, http.StatusNotFound, "Enum not found")
+		return
+	}
+
+	var input struct {
+		Value  string            `json:"value" validate:"required"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	value := models.EnumValue{Value: input.Value, Labels: input.Labels}
+	if err := h.service.AddValue(ctx, kind, value); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("Added %s enum value: %s", kind, input.Value)
+
+	def, _ := h.service.GetEnum(ctx, kind)
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Enum value added successfully",
+		Data:    newEnumView(*def, h.requestLocale(r)),
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// newEnumView resolves every label of an enumeration for a locale
+func newEnumView(def models.EnumDefinition, locale string) enumView {
+	values := make([]enumValueView, 0, len(def.Values))
+	for _, v := range def.Values {
+		values = append(values, enumValueView{
+			Value:   v.Value,
+			Label:   v.Label(locale),
+			Builtin: v.Builtin,
+		})
+	}
+	return enumView{
+		Kind:       def.Kind,
+		Extensible: def.Extensible,
+		Locale:     locale,
+		Values:     values,
+	}
+}
+
+// requestLocale picks the locale from ?locale=, then the preference of
+// ?user_id= or the default of ?org_id=, then the first Accept-Language
+// tag, defaulting to English (pointer receiver)
+func (h *EnumHandler) requestLocale(r *http.Request) string {
+	query := r.URL.Query()
+
+	var preferred string
+	if h.locales != nil {
+		preferred = h.locales.Preferred(r.Context(), query.Get("user_id"), query.Get("org_id"))
+	}
+
+	accept := r.Header.Get("Accept-Language")
+	tag, _, _ := strings.Cut(accept, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	if tag = strings.TrimSpace(tag); tag == "*" {
+		tag = ""
+	}
+
+	return i18n.Negotiate(query.Get("locale"), preferred, tag)
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *EnumHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *EnumHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Enums
+// =====================================
+
+// SetupEnumRoutes configures enum routes
+func SetupEnumRoutes(router *mux.Router, h *EnumHandler) {
+	router.HandleFunc("/enums", h.GetEnums).Methods("GET")
+	router.HandleFunc("/enums/{kind}", h.GetEnum).Methods("GET")
+}
+
+// SetupEnumAdminRoutes configures enum routes on the admin router
+func SetupEnumAdminRoutes(admin *mux.Router, h *EnumHandler) {
+	admin.HandleFunc("/enums/{kind}", h.AddEnumValue).Methods("POST")
+}
+
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Supported export formats
+const (
+	exportFormatJSON = "json"
+	exportFormatCSV  = "csv"
+)
+
+// exportFlushInterval is how many records are written between flushes
+const exportFlushInterval = 100
+
+// exportEmitFunc receives each exported record, both as a value for JSON
+// encoding and as a flat row for CSV encoding
+type exportEmitFunc func(record interface{}, row []string) error
+
+// exportResource describes a dataset that can be streamed by ExportHandler
+type exportResource struct {
+	columns []string
+	each    func(ctx context.Context, emit exportEmitFunc) error
+}
+
+// ExportHandler streams full datasets as CSV or JSON downloads
+type ExportHandler struct {
+	userService *services.UserService
+	orgService  *services.OrganizationService
+	logger      *log.Logger
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(userService *services.UserService, orgService *services.OrganizationService, logger *log.Logger) *ExportHandler {
+	return &ExportHandler{
+		userService: userService,
+		orgService:  orgService,
+		logger:      logger,
+	}
+}
+
+// =====================================
+// Export HTTP Handlers
+// =====================================
+
+// Export handles GET /export/{resource}?format=csv|json - streams a dataset
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["resource"]
+
+	resource, ok := h.resource(name)
+	if !ok {
+		h.respondError(w, http.StatusNotFound, "Unknown export resource")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatJSON
+	}
+
+	var encoder exportEncoder
+	switch format {
+	case exportFormatJSON:
+		encoder = &jsonExportEncoder{w: w}
+		w.Header().Set("Content-Type", "application/json")
+	case exportFormatCSV:
+		encoder = &csvExportEncoder{w: csv.NewWriter(w)}
+		w.Header().Set("Content-Type", "text/csv")
+	default:
+		h.respondError(w, http.StatusBadRequest, "Unsupported export format")
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", name, models.Now().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	count := 0
+
+	err := encoder.Begin(resource.columns)
+	if err == nil {
+		err = resource.each(ctx, func(record interface{}, row []string) error {
+			if err := encoder.Encode(record, row); err != nil {
+				return err
+			}
+			count++
+			if count%exportFlushInterval == 0 {
+				return encoder.Flush(flusher)
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = encoder.End()
+	}
+
+	// Headers are already sent, so failures can only be logged
+	if err != nil {
+		h.logger.Printf("Export of %s aborted after %d records: %v", name, count, err)
+		return
+	}
+	h.logger.Printf("Exported %d %s as %s", count, name, format)
+}
+
+// resource returns the export definition for a resource name (pointer receiver)
+func (h *ExportHandler) resource(name string) (exportResource, bool) {
+	switch name {
+	case "users":
+		return exportResource{
+			columns: []string{"id", "first_name", "last_name", "email", "role", "active", "created_at", "updated_at"},
+			each: func(ctx context.Context, emit exportEmitFunc) error {
+				return h.userService.Snapshot(ctx).Each(ctx, func(u models.User) error {
+					return emit(u, []string{
+						u.ID, u.FirstName, u.LastName, u.Email, u.Role,
+						strconv.FormatBool(u.Active),
+						u.CreatedAt.Format(time.RFC3339),
+						u.UpdatedAt.Format(time.RFC3339),
+					})
+				})
+			},
+		}, true
+	case "organizations":
+		return exportResource{
+			columns: []string{"id", "name", "description", "industry", "size", "owner_id", "active", "website", "city", "country", "created_at", "updated_at"},
+			each: func(ctx context.Context, emit exportEmitFunc) error {
+				return h.orgService.Snapshot(ctx).Each(ctx, func(o models.Organization) error {
+					return emit(o, []string{
+						o.ID, o.Name, o.Description, o.Industry, string(o.Size), o.OwnerID,
+						strconv.FormatBool(o.Active),
+						o.ContactInfo.Website, o.Address.City, o.Address.Country,
+						o.CreatedAt.Format(time.RFC3339),
+						o.UpdatedAt.Format(time.RFC3339),
+					})
+				})
+			},
+		}, true
+	}
+	return exportResource{}, false
+}
+
+// =====================================
+// Streaming Encoders
+// =====================================
+
+// exportEncoder writes records incrementally in a specific format
+type exportEncoder interface {
+	Begin(columns []string) error
+	Encode(record interface{}, row []string) error
+	Flush(flusher http.Flusher) error
+	End() error
+}
+
+// jsonExportEncoder streams records as a JSON array
+type jsonExportEncoder struct {
+	w       io.Writer
+	written bool
+}
+
+// Begin opens the JSON array (pointer receiver)
+func (e *jsonExportEncoder) Begin(columns []string) error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+// Encode writes a single record as an array element (pointer receiver)
+func (e *jsonExportEncoder) Encode(record interface{}, row []string) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if e.written {
+		if _, err := io.WriteString(e.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	e.written = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+// Flush pushes written records to the client (pointer receiver)
+func (e *jsonExportEncoder) Flush(flusher http.Flusher) error {
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// End closes the JSON array (pointer receiver)
+func (e *jsonExportEncoder) End() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// csvExportEncoder streams records as CSV rows
+type csvExportEncoder struct {
+	w *csv.Writer
+}
+
+// Begin writes the CSV header (pointer receiver)
+func (e *csvExportEncoder) Begin(columns []string) error {
+	return e.w.Write(columns)
+}
+
+// Encode writes a single CSV row (pointer receiver)
+func (e *csvExportEncoder) Encode(record interface{}, row []string) error {
+	return e.w.Write(row)
+}
+
+// Flush pushes buffered rows to the client (pointer receiver)
+func (e *csvExportEncoder) Flush(flusher http.Flusher) error {
+	e.w.Flush()
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return e.w.Error()
+}
+
+// End flushes buffered CSV output (pointer receiver)
+func (e *csvExportEncoder) End() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ExportHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ExportHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Exports
+// =====================================
+
+// SetupExportRoutes configures export routes
+func SetupExportRoutes(router *mux.Router, h *ExportHandler) {
+	router.HandleFunc("/export/{resource}", h.Export).Methods("GET")
+}
+
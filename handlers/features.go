@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Capability negotiation headers
+const (
+	FeaturesHeader        = "X-API-Features"
+	FeaturesGrantedHeader = "X-API-Features-Granted"
+	NextCursorHeader      = "X-Next-Cursor"
+)
+
+// Feature is a response behavior a client can declare support for
+type Feature string
+
+// Supported features
+const (
+	FeatureEnvelope         Feature = "envelope"          // {"code","message","data"} around successful responses
+	FeatureProblemJSON      Feature = "problem+json"      // RFC 9457 application/problem+json errors
+	FeatureCursorPagination Feature = "cursor-pagination" // ?cursor=&limit= pages over list responses
+	FeatureLegacyErrors     Feature = "legacy-errors"     // errors in the {"code","message"} envelope instead of problem+json
+)
+
+// FeatureConfig configures FeatureMiddleware
+type FeatureConfig struct {
+	LegacyErrors bool // Keep enveloped errors unless a client asks for problem+json
+}
+
+// Cursor pagination page sizes
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// Cursor pagination errors
+var (
+	errInvalidLimit  = errors.New("limit must be between 1 and 200")
+	errInvalidCursor = errors.New("invalid cursor")
+)
+
+// supportedFeatures lists the features in the order they are reported
+var supportedFeatures = []Feature{FeatureEnvelope, FeatureProblemJSON, FeatureCursorPagination, FeatureLegacyErrors}
+
+// FeatureSet is the set of features granted to a request
+type FeatureSet map[Feature]bool
+
+// =====================================
+// Feature Negotiation
+// =====================================
+
+// ParseFeatures grants the supported features named in an X-API-Features
+// header value, e.g. "envelope, problem+json". Unknown names are ignored
+// (standalone function).
+func ParseFeatures(header string) FeatureSet {
+	features := make(FeatureSet)
+	for _, name := range strings.Split(header, ",") {
+		feature := Feature(strings.ToLower(strings.TrimSpace(name)))
+		for _, supported := range supportedFeatures {
+			if feature == supported {
+				features[feature] = true
+			}
+		}
+	}
+	return features
+}
+
+// String lists the granted features in a stable order (value receiver)
+func (f FeatureSet) String() string {
+	names := make([]string, 0, len(f))
+	for _, feature := range supportedFeatures {
+		if f[feature] {
+			names = append(names, string(feature))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// FeatureMiddleware adapts JSON responses to the behaviors a client
+// declares in X-API-Features and reports what was granted in
+// X-API-Features-Granted. The header lists everything the client supports:
+//
+//   - envelope: successful responses keep the {"code","message","data"}
+//     envelope; without it only the data is returned.
+//   - problem+json: errors are returned as application/problem+json.
+//     This is the default, so the feature is granted even when unlisted.
+//   - legacy-errors: errors keep the envelope instead. It wins over
+//     problem+json and is the opt-out for clients that parse the envelope.
+//   - cursor-pagination: ?cursor= and ?limit= are checked and handed to
+//     the handler (see requestPage), which pages the list in its service
+//     and reports the next cursor in meta.next_cursor. The middleware
+//     repeats it in X-Next-Cursor and a Link header. GET /users,
+//     /organizations and /projects are paged; other lists come whole.
+//
+// Requests without the header keep enveloped successful responses and get
+// problem+json errors. With config.LegacyErrors set, problem+json is only
+// granted to clients that list it, as before it became the default.
+// Streamed downloads and non-JSON responses pass through untouched.
+func FeatureMiddleware(logger *log.Logger, config FeatureConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", FeaturesHeader)
+
+			header, declared := r.Header[http.CanonicalHeaderKey(FeaturesHeader)]
+			features := FeatureSet{FeatureEnvelope: true}
+			if declared {
+				features = ParseFeatures(strings.Join(header, ","))
+			}
+			if !config.LegacyErrors {
+				features[FeatureProblemJSON] = true
+			}
+			if features[FeatureLegacyErrors] {
+				delete(features, FeatureProblemJSON)
+			}
+
+			if !declared {
+				serveProblemErrors(w, r, next, features, logger)
+				return
+			}
+			w.Header().Set(FeaturesGrantedHeader, features.String())
+
+			page := services.PageRequest{}
+			if features[FeatureCursorPagination] && r.Method == http.MethodGet {
+				var err error
+				if page, err = parsePageRequest(r.URL.Query()); err != nil {
+					w.Header().Set("Content-Type", "application/json")
+					writeNegotiated(w, r, features, http.StatusBadRequest, []byte(mustMarshal(models.NewErrorResponse(http.StatusBadRequest, err.Error()))), page, logger)
+					return
+				}
+				r = r.WithContext(context.WithValue(r.Context(), pageRequestKey{}, page))
+			}
+
+			buffered := &featureWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(buffered, r)
+			if !buffered.buffering {
+				return
+			}
+			writeNegotiated(w, r, features, buffered.status, buffered.body.Bytes(), page, logger)
+		})
+	}
+}
+
+// serveProblemErrors serves a request that declared no features: error
+// responses are rewritten when problem+json is granted, and everything else
+// is written unchanged (standalone function)
+func serveProblemErrors(w http.ResponseWriter, r *http.Request, next http.Handler, features FeatureSet, logger *log.Logger) {
+	if !features[FeatureProblemJSON] {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	buffered := &featureWriter{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(buffered, r)
+	if !buffered.buffering {
+		return
+	}
+	if buffered.status < http.StatusBadRequest {
+		writeBody(w, buffered.status, buffered.body.Bytes(), logger)
+		return
+	}
+	writeNegotiated(w, r, features, buffered.status, buffered.body.Bytes(), services.PageRequest{}, logger)
+}
+
+// =====================================
+// Response Rewriting
+// =====================================
+
+// featureWriter buffers JSON responses so they can be rewritten, and
+// passes anything else straight through
+type featureWriter struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+// WriteHeader decides whether to buffer the response (pointer receiver)
+func (w *featureWriter) WriteHeader(status int) {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.status = status
+
+	header := w.Header()
+	w.buffering = strings.HasPrefix(header.Get("Content-Type"), "application/json") &&
+		header.Get("Content-Disposition") == "" &&
+		status != http.StatusNoContent && status != http.StatusNotModified
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Write buffers or forwards the body (pointer receiver)
+func (w *featureWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush forwards flushes of responses that are not buffered (pointer receiver)
+func (w *featureWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// writeNegotiated rewrites an enveloped JSON body for the granted features.
+// Bodies that are not envelopes are written unchanged.
+func writeNegotiated(w http.ResponseWriter, r *http.Request, features FeatureSet, status int, body []byte, page services.PageRequest, logger *log.Logger) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope["code"] == nil {
+		writeBody(w, status, body, logger)
+		return
+	}
+
+	if status >= http.StatusBadRequest {
+		if !features[FeatureProblemJSON] {
+			writeBody(w, status, body, logger)
+			return
+		}
+		var response models.APIResponse
+		_ = json.Unmarshal(body, &response)
+		w.Header().Set("Content-Type", ProblemContentType)
+		writeBody(w, status, []byte(mustMarshal(newProblem(r, status, response))), logger)
+		return
+	}
+
+	data := envelope["data"]
+	if features[FeatureCursorPagination] && r.Method == http.MethodGet && envelope["meta"] != nil {
+		var meta models.ResponseMeta
+		if err := json.Unmarshal(envelope["meta"], &meta); err == nil && meta.NextCursor != "" {
+			w.Header().Set(NextCursorHeader, meta.NextCursor)
+			w.Header().Set("Link", "<"+nextPageURL(r, meta.NextCursor, page.Limit)+`>; rel="next"`)
+		}
+	}
+
+	if !features[FeatureEnvelope] {
+		if data == nil {
+			data = json.RawMessage("null")
+		}
+		writeBody(w, status, append(data, '\n'), logger)
+		return
+	}
+	envelope["data"] = data
+	writeBody(w, status, []byte(mustMarshal(envelope)+"\n"), logger)
+}
+
+// writeBody sends a status and body (standalone function)
+func writeBody(w http.ResponseWriter, status int, body []byte, logger *log.Logger) {
+	w.Header().Del("Content-Length")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		logger.Printf("Error writing response: %v", err)
+	}
+}
+
+// mustMarshal encodes values that cannot fail to encode (standalone function)
+func mustMarshal(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(encoded)
+}
+
+// =====================================
+// Cursor Pagination
+// =====================================
+
+// pageRequestKey is the request context key of the page a client asked for
+type pageRequestKey struct{}
+
+// parsePageRequest reads the cursor and page size (standalone function)
+func parsePageRequest(query url.Values) (services.PageRequest, error) {
+	page := services.PageRequest{Limit: defaultPageLimit}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return page, errInvalidLimit
+		}
+		page.Limit = limit
+	}
+	if value := query.Get("cursor"); value != "" {
+		after, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(after) == 0 {
+			return page, errInvalidCursor
+		}
+		page.After = string(after)
+	}
+	return page, nil
+}
+
+// requestPage returns the page a client negotiating cursor-pagination asked
+// for. Without it the zero PageRequest is returned, which list reads treat
+// as every item (standalone function).
+func requestPage(r *http.Request) services.PageRequest {
+	page, _ := r.Context().Value(pageRequestKey{}).(services.PageRequest)
+	return page
+}
+
+// pageMeta describes a list page for the response, carrying the next
+// cursor; it is nil when the client did not ask for a page, leaving
+// writeJSON to count the list (standalone function)
+func pageMeta(r *http.Request, count int, next string) *models.ResponseMeta {
+	if _, paged := r.Context().Value(pageRequestKey{}).(services.PageRequest); !paged {
+		return nil
+	}
+	meta := &models.ResponseMeta{Count: count}
+	if next != "" {
+		meta.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(next))
+	}
+	return meta
+}
+
+// nextPageURL returns the request URL moved on to the next cursor (standalone function)
+func nextPageURL(r *http.Request, cursor string, limit int) string {
+	query := r.URL.Query()
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(limit))
+	next := *r.URL
+	next.RawQuery = query.Encode()
+	return next.RequestURI()
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+func TestFeatureMiddlewareErrorFormat(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, logger, http.StatusNotFound, "User not found")
+	})
+
+	tests := []struct {
+		name     string
+		config   FeatureConfig
+		features string // X-API-Features value; "-" sends no header
+		want     string
+	}{
+		{"default", FeatureConfig{}, "-", ProblemContentType},
+		{"declared without problem+json", FeatureConfig{}, "envelope", ProblemContentType},
+		{"client opt-out", FeatureConfig{}, "envelope, legacy-errors", "application/json"},
+		{"opt-out wins over problem+json", FeatureConfig{}, "problem+json, legacy-errors", "application/json"},
+		{"server opt-out", FeatureConfig{LegacyErrors: true}, "-", "application/json"},
+		{"server opt-out, client asks for problem+json", FeatureConfig{LegacyErrors: true}, "problem+json", ProblemContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/user_a", nil)
+			if tt.features != "-" {
+				req.Header.Set(FeaturesHeader, tt.features)
+			}
+			rec := httptest.NewRecorder()
+			FeatureMiddleware(logger, tt.config)(notFound).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.want {
+				t.Errorf("Content-Type = %q, want %q; body %s", got, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestFeatureMiddlewareLeavesUndeclaredSuccessUnchanged(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	const body = `{"code":200,"message":"ok","data":{"id":"user_a"}}`
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	})
+
+	rec := httptest.NewRecorder()
+	FeatureMiddleware(logger, FeatureConfig{})(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/user_a", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("response = %d %s, want 200 %s", rec.Code, rec.Body, body)
+	}
+	if granted := rec.Header().Get(FeaturesGrantedHeader); granted != "" {
+		t.Errorf("%s = %q for a request that declared no features", FeaturesGrantedHeader, granted)
+	}
+}
+
+func TestCursorPaginationPagesInService(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	users := services.NewUserService()
+	for _, id := range []string{"user_e", "user_b", "user_d", "user_a", "user_c"} {
+		if err := users.Write(context.Background(), services.CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	router := mux.NewRouter()
+	router.Use(FeatureMiddleware(logger, FeatureConfig{}))
+	SetupUserRoutes(router, NewHandler(users, logger), logger)
+
+	get := func(target, features string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if features != "" {
+			req.Header.Set(FeaturesHeader, features)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var seen []string
+	target := "/users?limit=2"
+	for pages := 0; target != ""; pages++ {
+		if pages == 5 {
+			t.Fatalf("pagination did not end; seen %v", seen)
+		}
+		rec := get(target, "envelope, cursor-pagination")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d %s", target, rec.Code, rec.Body)
+		}
+		var response struct {
+			Data []models.User       `json:"data"`
+			Meta models.ResponseMeta `json:"meta"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		for _, user := range response.Data {
+			seen = append(seen, user.ID)
+		}
+		if response.Meta.Count != len(response.Data) {
+			t.Errorf("meta.count = %d, want %d", response.Meta.Count, len(response.Data))
+		}
+		if got := rec.Header().Get(NextCursorHeader); got != response.Meta.NextCursor {
+			t.Errorf("%s = %q, meta.next_cursor = %q", NextCursorHeader, got, response.Meta.NextCursor)
+		}
+
+		target = ""
+		if link := rec.Header().Get("Link"); link != "" {
+			target = strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+		}
+	}
+	if want := []string{"user_a", "user_b", "user_c", "user_d", "user_e"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("paged users = %v, want %v", seen, want)
+	}
+
+	if rec := get("/users?limit=0", "cursor-pagination"); rec.Code != http.StatusBadRequest {
+		t.Errorf("limit=0: status = %d, want 400", rec.Code)
+	}
+	if rec := get("/users?cursor=%21", "cursor-pagination"); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad cursor: status = %d, want 400", rec.Code)
+	}
+
+	// Without the feature the whole list comes back and no cursor is set
+	rec := get("/users?limit=2", "")
+	var response models.APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if items, _ := response.Data.([]interface{}); len(items) != 5 || response.Meta == nil || response.Meta.NextCursor != "" {
+		t.Errorf("undeclared request: %d users, meta %+v; want 5 and no next cursor", len(items), response.Meta)
+	}
+	if got := rec.Header().Get(NextCursorHeader); got != "" {
+		t.Errorf("undeclared request: %s = %q", NextCursorHeader, got)
+	}
+}
@@ -1,14 +1,21 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/logging"
+	"github.com/test-repo-golang-support/pkg/metrics"
+	"github.com/test-repo-golang-support/pkg/timing"
+	"github.com/test-repo-golang-support/pkg/validation"
 	"github.com/test-repo-golang-support/services"
 )
 
@@ -30,11 +37,11 @@ func NewHandler(service *services.UserService, logger *log.Logger) *Handler {
 // HTTP Handlers
 // =====================================
 
-// GetUsers handles GET /users - returns all users
+// GetUsers handles GET /users - returns all users, or one page of them
+// with cursor-pagination
+// Soft-deleted users are only included with ?include_deleted=true
 func (h *Handler) GetUsers(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	users, err := h.service.ReadAll(ctx)
+	users, next, err := h.service.ReadUsersPage(r.Context(), includeDeleted(r), requestPage(r))
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, "Failed to fetch users")
 		return
@@ -44,21 +51,30 @@ func (h *Handler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		Code:    models.ResponseOK,
 		Message: "Users retrieved successfully",
 		Data:    users,
+		Meta:    pageMeta(r, len(users), next),
 	})
 }
 
 // GetUser handles GET /users/{id} - returns a specific user
+// Soft-deleted users are only returned with ?include_deleted=true
 func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	user, err := h.service.Read(ctx, id)
+	var user *models.User
+	var err error
+	if includeDeleted(r) {
+		user, err = h.service.ReadIncludingDeleted(ctx, id)
+	} else {
+		user, err = h.service.Read(ctx, id)
+	}
 	if err != nil {
 		h.respondError(w, http.StatusNotFound, "User not found")
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(user.Version))
 	h.respondJSON(w, http.StatusOK, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "User retrieved successfully",
@@ -71,36 +87,46 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var input struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Email     string `json:"email"`
-		Role      string `json:"role"`
+		FirstName string `json:"first_name" validate:"max=100"`
+		LastName  string `json:"last_name" validate:"max=100"`
+		Email     string `json:"email" validate:"required,email,max=254"`
+		Role      string `json:"role" validate:"max=50"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
 		return
 	}
-
-	// Validate email
-	if !services.ValidateEmail(input.Email) {
-		h.respondError(w, http.StatusBadRequest, "Invalid email format")
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+	email, err := h.service.VerifyEmail(ctx, input.Email)
+	if err != nil {
+		h.respondEmailError(w, err)
 		return
 	}
 
 	// Create new user
-	userID := services.GenerateUserID()
-	user := services.CreateUser(userID, input.FirstName, input.LastName, input.Email)
+	userID := h.service.NewID(services.IDPrefixUser)
+	user := services.CreateUser(userID, input.FirstName, input.LastName, email)
 	user.SetRole(input.Role)
 
 	// Save user
 	if err := h.service.Write(ctx, user); err != nil {
+		if errors.Is(err, services.ErrEmailTaken) {
+			h.respondError(w, http.StatusConflict, "Email is already in use")
+			return
+		}
+		if errors.Is(err, services.ErrStoreFull) {
+			h.respondError(w, http.StatusInsufficientStorage, "User store is full")
+			return
+		}
 		h.respondError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
 	h.logger.Printf("Created user: %s (%s)", user.FullName(), user.ID)
 
+	w.Header().Set("ETag", versionETag(user.Version))
 	h.respondJSON(w, http.StatusCreated, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "User created successfully",
@@ -121,35 +147,73 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !ifMatchSatisfied(r, user.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "User has been modified")
+		return
+	}
+
 	var input struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Email     string `json:"email"`
-		Role      string `json:"role"`
+		FirstName string `json:"first_name" validate:"max=100"`
+		LastName  string `json:"last_name" validate:"max=100"`
+		Email     string `json:"email" validate:"email,max=254"`
+		Role      string `json:"role" validate:"max=50"`
+		Locale    string `json:"locale" validate:"max=35"`
+		Timezone  string `json:"timezone" validate:"timezone"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
 		return
 	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	// Work on a copy so a rejected write, such as a taken email, leaves the
+	// stored user untouched
+	updated := *user
+	user = &updated
 
 	// Update user using pointer receiver methods
 	if input.FirstName != "" || input.LastName != "" {
 		user.UpdateName(input.FirstName, input.LastName)
 	}
 	if input.Email != "" {
-		user.UpdateEmail(input.Email)
+		email, err := h.service.VerifyEmail(ctx, input.Email)
+		if err != nil {
+			h.respondEmailError(w, err)
+			return
+		}
+		user.UpdateEmail(email)
 	}
 	if input.Role != "" {
 		user.SetRole(input.Role)
 	}
+	if input.Locale != "" {
+		user.SetLocale(i18n.Normalize(input.Locale))
+	}
+	if input.Timezone != "" {
+		user.SetTimezone(input.Timezone)
+	}
 
 	// Save updated user
 	if err := h.service.Write(ctx, user); err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.respondError(w, http.StatusConflict, "User has been modified")
+			return
+		}
+		if errors.Is(err, services.ErrEmailTaken) {
+			h.respondError(w, http.StatusConflict, "Email is already in use")
+			return
+		}
+		if errors.Is(err, services.ErrStoreFull) {
+			h.respondError(w, http.StatusInsufficientStorage, "User store is full")
+			return
+		}
 		h.respondError(w, http.StatusInternalServerError, "Failed to update user")
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(user.Version))
 	h.respondJSON(w, http.StatusOK, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "User updated successfully",
@@ -157,20 +221,28 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DeleteUser handles DELETE /users/{id} - deletes a user
+// DeleteUser handles DELETE /users/{id} - soft-deletes a user
 func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	id := vars["id"]
 
 	// Check if user exists
-	exists, _ := h.service.Exists(ctx, id)
-	if !exists {
+	user, err := h.service.Read(ctx, id)
+	if err != nil {
 		h.respondError(w, http.StatusNotFound, "User not found")
 		return
 	}
 
-	if err := h.service.Delete(ctx, id); err != nil {
+	if !ifMatchSatisfied(r, user.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "User has been modified")
+		return
+	}
+
+	// Deactivate a copy so a failed write leaves the stored user live
+	deleted := *user
+	deleted.Deactivate()
+	if err := h.service.Write(ctx, &deleted); err != nil {
 		h.respondError(w, http.StatusInternalServerError, "Failed to delete user")
 		return
 	}
@@ -181,11 +253,53 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreUser handles POST /users/{id}/restore - restores a soft-deleted user
+func (h *Handler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	user, err := h.service.ReadIncludingDeleted(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if !user.IsDeleted() {
+		h.respondError(w, http.StatusConflict, "User is not deleted")
+		return
+	}
+
+	// Activate a copy so a rejected write leaves the stored user deleted
+	restored := *user
+	restored.Activate()
+	if err := h.service.Write(ctx, &restored); err != nil {
+		// Another user may have taken the address while this one was deleted
+		if errors.Is(err, services.ErrEmailTaken) {
+			h.respondError(w, http.StatusConflict, "Email is already in use")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to restore user")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(restored.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "User restored successfully",
+		Data:    &restored,
+	})
+}
+
 // HealthCheck handles GET /health - returns server health status
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	h.respondJSON(w, http.StatusOK, map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().Format(time.RFC3339),
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Service is healthy",
+		Data: map[string]interface{}{
+			"status":    "healthy",
+			"timestamp": models.Now().Format(time.RFC3339),
+		},
 	})
 }
 
@@ -195,29 +309,93 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 // respondJSON sends a JSON response (pointer receiver)
 func (h *Handler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.logger.Printf("Error encoding response: %v", err)
-	}
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondEmailError rejects an address VerifyEmail refused, as a
+// validation failure of the email field (pointer receiver)
+func (h *Handler) respondEmailError(w http.ResponseWriter, err error) {
+	h.respondJSON(w, http.StatusUnprocessableEntity, models.NewErrorResponse(http.StatusUnprocessableEntity, "Validation failed", models.FieldError{
+		Field:   "email",
+		Code:    validation.RuleEmail,
+		Message: err.Error(),
+	}))
 }
 
 // respondError sends an error response (pointer receiver)
 func (h *Handler) respondError(w http.ResponseWriter, status int, message string) {
-	h.respondJSON(w, status, models.APIResponse{
-		Code:    models.ResponseError,
-		Message: message,
-	})
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Request Helpers
+// =====================================
+
+// includeDeleted reports whether the request asked for soft-deleted records
+func includeDeleted(r *http.Request) bool {
+	include, err := strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+	return err == nil && include
+}
+
+// =====================================
+// Concurrency Helpers
+// =====================================
+
+// versionETag formats an entity version as a strong ETag value
+func versionETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// ifMatchSatisfied reports whether the request's If-Match header allows
+// a write against the given entity version. A missing header always matches.
+// Callers answer a mismatch with 412 Precondition Failed; a version race
+// caught by the store's Write is still a 409 Conflict.
+func ifMatchSatisfied(r *http.Request, version int64) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return true
+	}
+
+	etag := versionETag(version)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the entity version named by the request's
+// If-Match header, for writes that are checked against it later rather
+// than now. A missing header or "*" gives 0; anything but a single
+// version ETag is an error.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || version < 1 || header != versionETag(version) {
+		return 0, errors.New("If-Match must name a single version")
+	}
+	return version, nil
 }
 
 // =====================================
 // Middleware Functions
 // =====================================
 
-// LoggingMiddleware logs incoming requests
-func LoggingMiddleware(logger *log.Logger) mux.MiddlewareFunc {
+// LoggingMiddleware logs incoming requests. With a controller, requests are
+// logged according to the sampling rule for their route template.
+func LoggingMiddleware(logger *log.Logger, control *logging.Controller) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if control != nil && !control.SampleRequest(routeTemplate(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			start := time.Now()
 			logger.Printf("Started %s %s", r.Method, r.URL.Path)
 			next.ServeHTTP(w, r)
@@ -226,12 +404,26 @@ func LoggingMiddleware(logger *log.Logger) mux.MiddlewareFunc {
 	}
 }
 
+// routeTemplate returns the path template of the matched route, or the
+// request path when no route matched
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
+
 // CORSMiddleware adds CORS headers
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, "+FeaturesHeader+", "+ActingUserHeader+", "+SandboxHeader+", "+ImpersonationHeader)
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Link, Retry-After, "+SandboxHeader+", "+FeaturesGrantedHeader+", "+NextCursorHeader+", "+
+			RateLimitLimitHeader+", "+RateLimitRemainingHeader+", "+RateLimitResetHeader+", "+
+			QuotaLimitHeader+", "+QuotaRemainingHeader+", "+QuotaResetHeader)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -249,7 +441,7 @@ func RecoveryMiddleware(logger *log.Logger) mux.MiddlewareFunc {
 			defer func() {
 				if err := recover(); err != nil {
 					logger.Printf("Panic recovered: %v", err)
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					writeError(w, logger, http.StatusInternalServerError, "Internal Server Error")
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -257,28 +449,139 @@ func RecoveryMiddleware(logger *log.Logger) mux.MiddlewareFunc {
 	}
 }
 
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status before passing it on (pointer receiver)
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush passes flushes on so streamed responses are not held back (pointer receiver)
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// EscalationMiddleware reports server errors on /organizations/{id} routes
+// to the escalation service, which alerts priority-support organizations
+func EscalationMiddleware(escalations *services.EscalationService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status < http.StatusInternalServerError {
+				return
+			}
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				return
+			}
+			template, err := route.GetPathTemplate()
+			if err != nil || !strings.Contains(template, "/organizations/{id}") {
+				return
+			}
+			escalations.Escalate(r.Context(), services.Incident{
+				OrgID:      mux.Vars(r)["id"],
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Status:     recorder.status,
+				OccurredAt: models.Now(),
+			})
+		})
+	}
+}
+
+// SlowRequestConfig configures SlowRequestMiddleware
+type SlowRequestConfig struct {
+	Threshold time.Duration // Requests taking at least this long are flagged; 0 disables
+	Trace     bool          // Also log every timed span of a flagged request
+}
+
+// SlowRequestMiddleware records service and storage timings in the request
+// context and logs a warning with the time spent in each layer for requests
+// slower than the threshold. With Trace set, the spans of a flagged request
+// are logged too, whether or not its route is sampled.
+func SlowRequestMiddleware(logger *log.Logger, config SlowRequestConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if config.Threshold <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, recorder := timing.WithRecorder(r.Context())
+			status := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(status, r.WithContext(ctx))
+
+			breakdown := recorder.Breakdown()
+			if breakdown.Total < config.Threshold {
+				return
+			}
+
+			spans := recorder.Spans()
+			logger.Printf("Warning: slow request %s %s route=%s status=%d total=%v handler=%v service=%v storage=%v spans=%d",
+				r.Method, r.URL.Path, routeTemplate(r), status.status,
+				breakdown.Total.Round(time.Microsecond), breakdown.Handler.Round(time.Microsecond),
+				breakdown.Service.Round(time.Microsecond), breakdown.Storage.Round(time.Microsecond), len(spans))
+			if !config.Trace {
+				return
+			}
+			for _, span := range spans {
+				logger.Printf("Warning: slow request trace %s %s %s%s %s at=+%v duration=%v self=%v",
+					r.Method, r.URL.Path, strings.Repeat("  ", span.Depth), span.Layer, span.Name,
+					span.Offset.Round(time.Microsecond), span.Duration.Round(time.Microsecond), span.Self.Round(time.Microsecond))
+			}
+		})
+	}
+}
+
+// MetricsMiddleware records the outcome and latency of every matched
+// request against its route template. A panicking handler is recorded as
+// a server error before the panic continues to the recovery middleware.
+func MetricsMiddleware(registry *metrics.Registry) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				status := recorder.status
+				err := recover()
+				if err != nil {
+					status = http.StatusInternalServerError
+				}
+				registry.Observe(routeTemplate(r), status, time.Since(start), start)
+				if err != nil {
+					panic(err)
+				}
+			}()
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
 // =====================================
 // Router Setup
 // =====================================
 
 // SetupRoutes configures all routes for the application
-func SetupRoutes(h *Handler, logger *log.Logger) *mux.Router {
+func SetupRoutes(h *Handler, logger *log.Logger, control *logging.Controller) *mux.Router {
 	router := mux.NewRouter()
 
 	// Apply middleware
 	router.Use(CORSMiddleware)
-	router.Use(LoggingMiddleware(logger))
+	router.Use(LoggingMiddleware(logger, control))
 	router.Use(RecoveryMiddleware(logger))
 
 	// API routes
 	api := router.PathPrefix("/api/v1").Subrouter()
 
 	// User routes
-	api.HandleFunc("/users", h.GetUsers).Methods("GET")
-	api.HandleFunc("/users/{id}", h.GetUser).Methods("GET")
-	api.HandleFunc("/users", h.CreateUser).Methods("POST")
-	api.HandleFunc("/users/{id}", h.UpdateUser).Methods("PUT")
-	api.HandleFunc("/users/{id}", h.DeleteUser).Methods("DELETE")
+	SetupUserRoutes(api, h, logger)
 
 	// Health check
 	router.HandleFunc("/health", h.HealthCheck).Methods("GET")
@@ -291,3 +594,13 @@ func SetupRoutes(h *Handler, logger *log.Logger) *mux.Router {
 	return router
 }
 
+// SetupUserRoutes configures user routes
+func SetupUserRoutes(router *mux.Router, h *Handler, logger *log.Logger) {
+	router.HandleFunc("/users", h.GetUsers).Methods("GET")
+	router.HandleFunc("/users/{id}", h.GetUser).Methods("GET")
+	router.HandleFunc("/users", UserPayloadCompat(PayloadShapeV1, logger, h.CreateUser)).Methods("POST")
+	router.HandleFunc("/users/{id}", UserPayloadCompat(PayloadShapeV1, logger, h.UpdateUser)).Methods("PUT")
+	router.HandleFunc("/users/{id}", h.DeleteUser).Methods("DELETE")
+	router.HandleFunc("/users/{id}/restore", h.RestoreUser).Methods("POST")
+}
+
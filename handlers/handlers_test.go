@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestUserRouter serves the user routes over a fresh UserService
+func newTestUserRouter(t *testing.T) (*mux.Router, *services.UserService) {
+	t.Helper()
+	logger := log.New(io.Discard, "", 0)
+	service := services.NewUserService()
+	router := mux.NewRouter()
+	SetupUserRoutes(router, NewHandler(service, logger), logger)
+	return router, service
+}
+
+func TestRestoreUserEmailClashKeepsUserDeleted(t *testing.T) {
+	ctx := context.Background()
+	router, service := newTestUserRouter(t)
+
+	original := services.CreateUser("user_a", "Ada", "One", "ada@example.com")
+	if err := service.Write(ctx, original); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+	deleted := *original
+	deleted.Deactivate()
+	if err := service.Write(ctx, &deleted); err != nil {
+		t.Fatalf("soft-deleting user_a: %v", err)
+	}
+
+	// The deleted user's address is free, so another user takes it
+	if err := service.Write(ctx, services.CreateUser("user_b", "Bea", "Two", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_b): %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/user_a/restore", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("restore status = %d, want %d; body %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+
+	if _, err := service.Read(ctx, "user_a"); err == nil {
+		t.Error("user_a is live after a rejected restore")
+	}
+	stored, err := service.ReadIncludingDeleted(ctx, "user_a")
+	if err != nil {
+		t.Fatalf("ReadIncludingDeleted(user_a): %v", err)
+	}
+	if !stored.IsDeleted() {
+		t.Error("stored user_a is no longer marked deleted")
+	}
+
+	users, err := service.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user_b" {
+		t.Errorf("ReadAll = %v, want only user_b", users)
+	}
+}
+
+func TestUpdateUserStaleIfMatch(t *testing.T) {
+	ctx := context.Background()
+	router, service := newTestUserRouter(t)
+
+	if err := service.Write(ctx, services.CreateUser("user_a", "Ada", "One", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+	user, err := service.Read(ctx, "user_a")
+	if err != nil {
+		t.Fatalf("Read(user_a): %v", err)
+	}
+	current := versionETag(user.Version)
+	stale := versionETag(user.Version - 1)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+	}{
+		{"stale version", stale, http.StatusPreconditionFailed},
+		{"current version", current, http.StatusOK},
+		{"version the update replaced", current, http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/users/user_a", strings.NewReader(`{"first_name":"Ada"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", tt.ifMatch)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want == http.StatusPreconditionFailed && !strings.Contains(rec.Body.String(), `"`+string(models.ErrorCodePreconditionFailed)+`"`) {
+				t.Errorf("body %s lacks error code %s", rec.Body, models.ErrorCodePreconditionFailed)
+			}
+		})
+	}
+}
+
+func TestRequestValidationRejectsInvalidDTOs(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+	users := services.NewUserService()
+	if err := users.Write(ctx, services.CreateUser("user_a", "Ada", "One", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+
+	router := mux.NewRouter()
+	SetupProfileRoutes(router, NewProfileHandler(services.NewProfileService(users), users, nil, logger))
+	SetupPreferencesRoutes(router, NewPreferencesHandler(services.NewPreferencesService(users, nil), logger))
+
+	tests := []struct {
+		name  string
+		path  string
+		body  string
+		field string
+	}{
+		{"bio too long", "/users/user_a/profile", `{"bio":"` + strings.Repeat("b", 501) + `"}`, "bio"},
+		{"unknown theme", "/users/user_a/settings", `{"theme":"neon"}`, "theme"},
+		{"unknown time zone", "/users/user_a/settings", `{"timezone":"Mars/Olympus"}`, "timezone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, http.StatusUnprocessableEntity, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), `"`+tt.field+`"`) {
+				t.Errorf("body %s does not name field %s", rec.Body, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// importFileField is the multipart form field carrying the CSV upload
+const importFileField = "file"
+
+// ImportHandler wraps the import service and provides HTTP handlers
+type ImportHandler struct {
+	service *services.ImportService
+	logger  *log.Logger
+}
+
+// NewImportHandler creates a new ImportHandler instance
+func NewImportHandler(service *services.ImportService, logger *log.Logger) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Import HTTP Handlers
+// =====================================
+
+// ImportUsers handles POST /imports/users - imports users from a multipart CSV upload
+func (h *ImportHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Stream the upload part by part instead of buffering the whole form
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Expected multipart/form-data upload")
+		return
+	}
+
+	var file io.Reader
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid multipart body")
+			return
+		}
+		if part.FormName() == importFileField {
+			file = part
+			break
+		}
+	}
+
+	if file == nil {
+		h.respondError(w, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+
+	result, err := h.service.ImportUsersCSV(ctx, file)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("Imported users: %d created, %d memberships, %d rejected (%s)",
+		result.UsersCreated, result.MembershipsCreated, result.RejectedCount(), result.ID)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Import completed",
+		Data:    result,
+	})
+}
+
+// GetImport handles GET /imports/{id} - returns an import summary
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	result, err := h.service.GetResult(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Import not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Import retrieved successfully",
+		Data:    result,
+	})
+}
+
+// GetImportErrors handles GET /imports/{id}/errors - downloads rejected rows as CSV
+func (h *ImportHandler) GetImportErrors(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	result, err := h.service.GetResult(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Import not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+"_errors.csv"))
+	w.WriteHeader(http.StatusOK)
+	if err := services.WriteImportErrorReport(w, result); err != nil {
+		h.logger.Printf("Error writing import error report: %v", err)
+	}
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ImportHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ImportHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Imports
+// =====================================
+
+// SetupImportRoutes configures import routes
+func SetupImportRoutes(router *mux.Router, h *ImportHandler) {
+	router.HandleFunc("/imports/users", h.ImportUsers).Methods("POST")
+	router.HandleFunc("/imports/{id}", h.GetImport).Methods("GET")
+	router.HandleFunc("/imports/{id}/errors", h.GetImportErrors).Methods("GET")
+}
+
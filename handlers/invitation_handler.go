@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// InvitationHandler handles organization invitation HTTP requests
+type InvitationHandler struct {
+	service    *services.InvitationService
+	orgService *services.OrganizationService
+	logger     *log.Logger
+}
+
+// NewInvitationHandler creates a new InvitationHandler instance
+func NewInvitationHandler(service *services.InvitationService, orgService *services.OrganizationService, logger *log.Logger) *InvitationHandler {
+	return &InvitationHandler{
+		service:    service,
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+// =====================================
+// Invitation HTTP Handlers
+// =====================================
+
+// CreateInvitation handles POST /organizations/{id}/invitations - emails a
+// token that lets the invitee join, e.g.
+// {"email":"a@b.com","role":"member","invited_by":"u1","expires_in_hours":72}
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if _, err := h.orgService.ReadOrg(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		Email          string            `json:"email" validate:"required,email"`
+		Role           models.MemberRole `json:"role"`
+		InvitedBy      string            `json:"invited_by" validate:"required"`
+		ExpiresInHours int               `json:"expires_in_hours"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	ttl := time.Duration(input.ExpiresInHours) * time.Hour
+	invitation, err := h.service.Invite(ctx, orgID, input.Email, input.Role, input.InvitedBy, ttl)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Invitation %s: %s invited %s to organization %s as %s", invitation.ID, input.InvitedBy, invitation.Email, orgID, invitation.Role)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Invitation sent successfully",
+		Data:    invitation,
+	})
+}
+
+// GetInvitations handles GET /organizations/{id}/invitations?status=pending - lists invitations
+func (h *InvitationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if _, err := h.orgService.ReadOrg(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	status := models.InvitationStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "Invalid status filter")
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(ctx, orgID, status)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Invitations retrieved successfully",
+		Data:    invitations,
+	})
+}
+
+// RevokeInvitation handles POST /organizations/{id}/invitations/{invitationId}/revoke
+// with {"revoked_by":"u1"} - the token stops working
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+	invitationID := vars["invitationId"]
+
+	var input struct {
+		RevokedBy string `json:"revoked_by" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	invitation, err := h.service.RevokeInvitation(ctx, orgID, invitationID, input.RevokedBy)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Invitation %s for organization %s revoked by %s", invitationID, orgID, input.RevokedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Invitation revoked",
+		Data:    invitation,
+	})
+}
+
+// RenewInvitation handles POST /organizations/{id}/invitations/{invitationId}/renew
+// with {"renewed_by":"u1","expires_in_hours":72} - sends a fresh token with a new expiry
+func (h *InvitationHandler) RenewInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+	invitationID := vars["invitationId"]
+
+	var input struct {
+		RenewedBy      string `json:"renewed_by" validate:"required"`
+		ExpiresInHours int    `json:"expires_in_hours"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	ttl := time.Duration(input.ExpiresInHours) * time.Hour
+	invitation, err := h.service.RenewInvitation(ctx, orgID, invitationID, input.RenewedBy, ttl)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Invitation %s for organization %s renewed by %s until %s", invitationID, orgID, input.RenewedBy, invitation.ExpiresAt.Format(time.RFC3339))
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Invitation renewed",
+		Data:    invitation,
+	})
+}
+
+// AcceptInvitation handles GET /invitations/{token}/accept - adds the
+// invitee to the organization with the invited role
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	invitation, membership, err := h.service.AcceptInvitation(ctx, token)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Invitation %s accepted: %s joined organization %s as %s", invitation.ID, membership.UserID, membership.OrgID, membership.Role)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Invitation accepted",
+		Data:    membership,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondServiceError maps invitation service errors to HTTP statuses (pointer receiver)
+func (h *InvitationHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvitationNotFound):
+		h.respondError(w, http.StatusNotFound, "Invitation not found")
+	case errors.Is(err, services.ErrInvitationNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvitationExists):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvitationClosed):
+		h.respondError(w, http.StatusGone, err.Error())
+	case errors.Is(err, services.ErrInviteeNotRegistered):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *InvitationHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *InvitationHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Invitations
+// =====================================
+
+// SetupInvitationRoutes configures invitation routes
+func SetupInvitationRoutes(router *mux.Router, h *InvitationHandler) {
+	router.HandleFunc("/organizations/{id}/invitations", h.GetInvitations).Methods("GET")
+	router.HandleFunc("/organizations/{id}/invitations", h.CreateInvitation).Methods("POST")
+	router.HandleFunc("/organizations/{id}/invitations/{invitationId}/revoke", h.RevokeInvitation).Methods("POST")
+	router.HandleFunc("/organizations/{id}/invitations/{invitationId}/renew", h.RenewInvitation).Methods("POST")
+	router.HandleFunc("/invitations/{token}/accept", h.AcceptInvitation).Methods("GET")
+}
+
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestInvitationRouter serves the invitation routes for org_a, which
+// user_admin administers; user_new and user_joined are registered but not
+// members
+func newTestInvitationRouter(t *testing.T) (*mux.Router, *services.InvitationService, *services.OrganizationService) {
+	t.Helper()
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+	users := services.NewUserService()
+	orgs := services.NewOrganizationService()
+	for _, id := range []string{"user_admin", "user_new", "user_joined"} {
+		if err := users.Write(ctx, services.CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	if err := orgs.WriteOrg(ctx, models.NewOrganization("org_a", "org_a", "user_admin")); err != nil {
+		t.Fatalf("WriteOrg: %v", err)
+	}
+	if err := orgs.AddMember(ctx, services.CreateMembership("user_admin", "org_a", models.MemberRoleAdmin)); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	invitations := services.NewInvitationService(orgs, users, services.NewLogNotifier(logger))
+	router := mux.NewRouter()
+	SetupInvitationRoutes(router, NewInvitationHandler(invitations, orgs, logger))
+	return router, invitations, orgs
+}
+
+func TestAcceptInvitationStatuses(t *testing.T) {
+	ctx := context.Background()
+	router, invitations, orgs := newTestInvitationRouter(t)
+	invite := func(email string) string {
+		invitation, err := invitations.Invite(ctx, "org_a", email, models.MemberRoleMember, "user_admin", 0)
+		if err != nil {
+			t.Fatalf("Invite(%s): %v", email, err)
+		}
+		return invitation.Token
+	}
+	newToken, joinedToken := invite("user_new@example.com"), invite("user_joined@example.com")
+
+	// user_joined becomes a member before accepting their invitation
+	if err := orgs.AddMember(ctx, services.CreateMembership("user_joined", "org_a", models.MemberRoleMember)); err != nil {
+		t.Fatalf("AddMember(user_joined): %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"open invitation", newToken, http.StatusOK},
+		{"token used twice", newToken, http.StatusNotFound},
+		{"invitee already a member", joinedToken, http.StatusConflict},
+		{"unknown token", "not-a-token", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/invitations/"+tt.token+"/accept", nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body %s", tt.name, rec.Code, tt.want, rec.Body)
+		}
+	}
+
+	if _, err := orgs.GetMembership(ctx, "user_new", "org_a"); err != nil {
+		t.Errorf("user_new is not a member after accepting: %v", err)
+	}
+}
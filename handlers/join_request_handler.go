@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// JoinRequestHandler wraps the join request service and provides HTTP handlers
+type JoinRequestHandler struct {
+	service    *services.JoinRequestService
+	orgService *services.OrganizationService
+	logger     *log.Logger
+}
+
+// NewJoinRequestHandler creates a new JoinRequestHandler instance
+func NewJoinRequestHandler(service *services.JoinRequestService, orgService *services.OrganizationService, logger *log.Logger) *JoinRequestHandler {
+	return &JoinRequestHandler{
+		service:    service,
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+// =====================================
+// Join Request HTTP Handlers
+// =====================================
+
+// CreateJoinRequest handles POST /organizations/{id}/join-requests - asks to join an organization
+func (h *JoinRequestHandler) CreateJoinRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if _, err := h.orgService.ReadOrg(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		UserID  string `json:"user_id" validate:"required"`
+		Message string `json:"message"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	request, err := h.service.RequestToJoin(ctx, orgID, input.UserID, input.Message)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Join request %s: %s asked to join organization %s", request.ID, request.UserID, orgID)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Join request submitted successfully",
+		Data:    request,
+	})
+}
+
+// GetJoinRequests handles GET /organizations/{id}/join-requests?status=pending - lists join requests
+func (h *JoinRequestHandler) GetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if _, err := h.orgService.ReadOrg(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	status := models.JoinRequestStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "Invalid status filter")
+		return
+	}
+
+	requests, err := h.service.ListJoinRequests(ctx, orgID, status)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch join requests")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Join requests retrieved successfully",
+		Data:    requests,
+	})
+}
+
+// ApproveJoinRequest handles POST /organizations/{id}/join-requests/{requestId}/approve
+func (h *JoinRequestHandler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, true)
+}
+
+// DenyJoinRequest handles POST /organizations/{id}/join-requests/{requestId}/deny
+func (h *JoinRequestHandler) DenyJoinRequest(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, false)
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// decide records an admin's decision on a join request (pointer receiver)
+func (h *JoinRequestHandler) decide(w http.ResponseWriter, r *http.Request, approve bool) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+	requestID := vars["requestId"]
+
+	if _, err := h.service.ReadJoinRequest(ctx, orgID, requestID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Join request not found")
+		return
+	}
+
+	var input struct {
+		DecidedBy string `json:"decided_by" validate:"required"`
+		Reason    string `json:"reason"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	request, err := h.service.DecideJoinRequest(ctx, orgID, requestID, input.DecidedBy, approve, input.Reason)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("Join request %s for organization %s %s by %s", requestID, orgID, request.Status, input.DecidedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Join request " + string(request.Status),
+		Data:    request,
+	})
+}
+
+// respondServiceError maps join request service errors to HTTP statuses (pointer receiver)
+func (h *JoinRequestHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrJoinNotAllowed):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrDecisionNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *JoinRequestHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *JoinRequestHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Join Requests
+// =====================================
+
+// SetupJoinRequestRoutes configures join request routes
+func SetupJoinRequestRoutes(router *mux.Router, h *JoinRequestHandler) {
+	router.HandleFunc("/organizations/{id}/join-requests", h.GetJoinRequests).Methods("GET")
+	router.HandleFunc("/organizations/{id}/join-requests", h.CreateJoinRequest).Methods("POST")
+	router.HandleFunc("/organizations/{id}/join-requests/{requestId}/approve", h.ApproveJoinRequest).Methods("POST")
+	router.HandleFunc("/organizations/{id}/join-requests/{requestId}/deny", h.DenyJoinRequest).Methods("POST")
+}
+
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+)
+
+// LocaleMiddleware negotiates the language of the response from the
+// request's Accept-Language header among the languages the message
+// catalog supports, and announces it in Content-Language. writeJSON
+// renders APIResponse messages in that language.
+func LocaleMiddleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("Content-Language", i18n.Match(i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))))
+			header.Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// responseLocale returns the language LocaleMiddleware chose for a
+// response, or the default outside of it (standalone function)
+func responseLocale(w http.ResponseWriter) string {
+	if locale := w.Header().Get("Content-Language"); locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// localize renders the message of an APIResponse in the response's
+// language; other payloads pass through unchanged (standalone function)
+func localize(w http.ResponseWriter, data interface{}) interface{} {
+	switch response := data.(type) {
+	case models.APIResponse:
+		response.Message = i18n.Localize(responseLocale(w), response.Message)
+		return response
+	case *models.APIResponse:
+		localized := *response
+		localized.Message = i18n.Localize(responseLocale(w), response.Message)
+		return &localized
+	}
+	return data
+}
+
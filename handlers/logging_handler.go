@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/logging"
+)
+
+// LoggingHandler changes log levels at runtime and reports request log sampling
+type LoggingHandler struct {
+	control *logging.Controller
+	logger  *log.Logger
+}
+
+// NewLoggingHandler creates a new LoggingHandler instance
+func NewLoggingHandler(control *logging.Controller, logger *log.Logger) *LoggingHandler {
+	return &LoggingHandler{
+		control: control,
+		logger:  logger,
+	}
+}
+
+// loggingView is the logging configuration as returned to clients
+type loggingView struct {
+	Levels   logging.LevelSettings `json:"levels"`
+	Sampling []logging.SampleStats `json:"sampling"`
+}
+
+// =====================================
+// Logging HTTP Handlers
+// =====================================
+
+// GetLogLevels handles GET /admin/v1/log-levels - returns the global and
+// per-package levels and the request sampling rules with their counters
+func (h *LoggingHandler) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Log levels retrieved successfully",
+		Data:    h.view(),
+	})
+}
+
+// UpdateLogLevels handles PUT /admin/v1/log-levels - changes the global level
+// and/or package overrides, e.g. {"global":"warn","packages":{"handlers":"debug"}}.
+// An empty package level removes the override.
+func (h *LoggingHandler) UpdateLogLevels(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Global   *string           `json:"global" validate:"max=20"`
+		Packages map[string]string `json:"packages" validate:"max=100"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	// Validate everything before applying anything
+	var global logging.Level
+	if input.Global != nil {
+		level, err := logging.ParseLevel(*input.Global)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		global = level
+	}
+	packages := make(map[string]logging.Level, len(input.Packages))
+	for pkg, name := range input.Packages {
+		if pkg == "" {
+			h.respondError(w, http.StatusBadRequest, "Package name is required")
+			return
+		}
+		if name == "" {
+			continue
+		}
+		level, err := logging.ParseLevel(name)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		packages[pkg] = level
+	}
+
+	changes := make([]string, 0, len(input.Packages)+1)
+	if input.Global != nil {
+		h.control.SetGlobalLevel(global)
+		changes = append(changes, "global="+global.String())
+	}
+	for pkg := range input.Packages {
+		if level, ok := packages[pkg]; ok {
+			h.control.SetPackageLevel(pkg, level)
+			changes = append(changes, pkg+"="+level.String())
+			continue
+		}
+		h.control.ResetPackageLevel(pkg)
+		changes = append(changes, pkg+"=reset")
+	}
+	sort.Strings(changes)
+
+	h.logger.Printf("audit action=logging.levels.update %s", strings.Join(changes, " "))
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Log levels updated successfully",
+		Data:    h.view(),
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// view captures the current levels and sampling counters (pointer receiver)
+func (h *LoggingHandler) view() loggingView {
+	return loggingView{
+		Levels:   h.control.Levels(),
+		Sampling: h.control.Sampling(),
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *LoggingHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *LoggingHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Logging
+// =====================================
+
+// SetupLoggingRoutes configures log level routes on the admin router
+func SetupLoggingRoutes(router *mux.Router, h *LoggingHandler) {
+	router.HandleFunc("/log-levels", h.GetLogLevels).Methods("GET")
+	router.HandleFunc("/log-levels", h.UpdateLogLevels).Methods("PUT")
+}
+
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// NotificationHandler manages per-user notification channel preferences
+type NotificationHandler struct {
+	notifier *services.MultiChannelNotifier
+	logger   *log.Logger
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notifier *services.MultiChannelNotifier, logger *log.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notifier: notifier,
+		logger:   logger,
+	}
+}
+
+// =====================================
+// Notification Preference HTTP Handlers
+// =====================================
+
+// GetPreferences handles GET /users/{id}/notification-preferences - returns
+// the user's channels, or the defaults if they have not chosen any
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	prefs, err := h.notifier.GetPreferences(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification preferences retrieved successfully",
+		Data:    prefs,
+	})
+}
+
+// UpdatePreferences handles PUT /users/{id}/notification-preferences - replaces
+// the user's channels. Push requires at least one device ID.
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	var input struct {
+		Email     bool     `json:"email"`
+		Push      bool     `json:"push"`
+		DeviceIDs []string `json:"device_ids" validate:"max=50"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	prefs, err := h.notifier.SetPreferences(ctx, models.NotificationPreferences{
+		UserID:    userID,
+		Email:     input.Email,
+		Push:      input.Push,
+		DeviceIDs: input.DeviceIDs,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrNotificationUserNotFound) {
+			h.respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("audit action=user.notification_preferences.update user=%s email=%t push=%t devices=%d", userID, prefs.Email, prefs.Push, len(prefs.DeviceIDs))
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification preferences updated successfully",
+		Data:    prefs,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *NotificationHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *NotificationHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Notification Preferences
+// =====================================
+
+// SetupNotificationRoutes configures notification preference routes
+func SetupNotificationRoutes(router *mux.Router, h *NotificationHandler) {
+	router.HandleFunc("/users/{id}/notification-preferences", h.GetPreferences).Methods("GET")
+	router.HandleFunc("/users/{id}/notification-preferences", h.UpdatePreferences).Methods("PUT")
+}
+
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/services"
+)
+
+// NotificationTemplateHandler manages notification templates and previews their output
+type NotificationTemplateHandler struct {
+	templates   *services.NotificationTemplateService
+	userService *services.UserService
+	orgService  *services.OrganizationService
+	logger      *log.Logger
+}
+
+// NewNotificationTemplateHandler creates a new NotificationTemplateHandler instance
+func NewNotificationTemplateHandler(templates *services.NotificationTemplateService, userService *services.UserService, orgService *services.OrganizationService, logger *log.Logger) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{
+		templates:   templates,
+		userService: userService,
+		orgService:  orgService,
+		logger:      logger,
+	}
+}
+
+// =====================================
+// Notification Template HTTP Handlers
+// =====================================
+
+// GetTemplates handles GET /admin/v1/notification-templates - lists every event's template
+func (h *NotificationTemplateHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification templates retrieved successfully",
+		Data:    h.templates.ListTemplates(r.Context()),
+	})
+}
+
+// GetTemplate handles GET /admin/v1/notification-templates/{event} - returns one template
+func (h *NotificationTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	event := mux.Vars(r)["event"]
+
+	def, err := h.templates.GetTemplate(r.Context(), event)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Notification template not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification template retrieved successfully",
+		Data:    def,
+	})
+}
+
+// UpdateTemplate handles PUT /admin/v1/notification-templates/{event} - creates
+// or replaces a template. Templates that fail to parse or to render against
+// sample data are rejected.
+func (h *NotificationTemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	event := mux.Vars(r)["event"]
+
+	var input struct {
+		Subject string `json:"subject" validate:"required,max=200"`
+		Body    string `json:"body" validate:"max=10000"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	def, err := h.templates.SetTemplate(r.Context(), models.NotificationTemplate{
+		Event:   event,
+		Subject: input.Subject,
+		Body:    input.Body,
+	})
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("audit action=notification.template.update event=%s", event)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification template updated successfully",
+		Data:    def,
+	})
+}
+
+// ResetTemplate handles DELETE /admin/v1/notification-templates/{event} -
+// restores a built-in template to its default or removes a custom one
+func (h *NotificationTemplateHandler) ResetTemplate(w http.ResponseWriter, r *http.Request) {
+	event := mux.Vars(r)["event"]
+
+	if err := h.templates.ResetTemplate(r.Context(), event); err != nil {
+		if errors.Is(err, services.ErrTemplateNotFound) {
+			h.respondError(w, http.StatusNotFound, "Notification template not found")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.logger.Printf("audit action=notification.template.reset event=%s", event)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification template reset successfully",
+	})
+}
+
+// PreviewTemplate handles POST /admin/v1/notification-templates/{event}/preview -
+// renders the stored template, or a draft given as subject and body, for a
+// real user and organization or for sample data when their IDs are omitted
+func (h *NotificationTemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	event := mux.Vars(r)["event"]
+
+	var input struct {
+		UserID  string            `json:"user_id"`
+		OrgID   string            `json:"org_id"`
+		Locale  string            `json:"locale"`
+		Vars    map[string]string `json:"vars"`
+		Subject string            `json:"subject"`
+		Body    string            `json:"body"`
+	}
+
+	if err := decodeJSON(r, &input); err != nil && !errors.Is(err, io.EOF) {
+		writeDecodeError(w, h.logger, err)
+		return
+	}
+
+	data := services.SampleNotificationData(i18n.Negotiate(input.Locale))
+	if input.UserID != "" {
+		user, err := h.userService.Read(ctx, input.UserID)
+		if err != nil {
+			h.respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		data.User = user
+	}
+	if input.OrgID != "" {
+		org, err := h.orgService.ReadOrg(ctx, input.OrgID)
+		if err != nil {
+			h.respondError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+		data.Org = org
+	}
+	for name, value := range input.Vars {
+		data.Vars[name] = value
+	}
+
+	def := models.NotificationTemplate{Event: event, Subject: input.Subject, Body: input.Body}
+	if input.Subject == "" && input.Body == "" {
+		stored, err := h.templates.GetTemplate(ctx, event)
+		if err != nil {
+			h.respondError(w, http.StatusNotFound, "Notification template not found")
+			return
+		}
+		def = stored
+	}
+
+	rendered, err := h.templates.Preview(ctx, def, data)
+	if err != nil {
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Notification template rendered successfully",
+		Data:    rendered,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *NotificationTemplateHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *NotificationTemplateHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Notification Templates
+// =====================================
+
+// SetupNotificationTemplateRoutes configures notification template routes
+// on the admin router
+func SetupNotificationTemplateRoutes(router *mux.Router, h *NotificationTemplateHandler) {
+	router.HandleFunc("/notification-templates", h.GetTemplates).Methods("GET")
+	router.HandleFunc("/notification-templates/{event}", h.GetTemplate).Methods("GET")
+	router.HandleFunc("/notification-templates/{event}", h.UpdateTemplate).Methods("PUT")
+	router.HandleFunc("/notification-templates/{event}", h.ResetTemplate).Methods("DELETE")
+	router.HandleFunc("/notification-templates/{event}/preview", h.PreviewTemplate).Methods("POST")
+}
+
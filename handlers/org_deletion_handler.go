@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// OrgDeletionHandler handles cascading organization deletion HTTP requests
+type OrgDeletionHandler struct {
+	service *services.OrgDeletionService
+	logger  *log.Logger
+}
+
+// NewOrgDeletionHandler creates a new OrgDeletionHandler instance
+func NewOrgDeletionHandler(service *services.OrgDeletionService, logger *log.Logger) *OrgDeletionHandler {
+	return &OrgDeletionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Organization Deletion HTTP Handlers
+// =====================================
+
+// DeleteOrganization handles POST /organizations/{id}/deletion - soft-deletes
+// the organization and queues the removal of it and its data. Responds 202
+// with the job; its Location is the status endpoint.
+func (h *OrgDeletionHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+
+	var input struct {
+		RequestedBy string `json:"requested_by" validate:"max=100"`
+	}
+
+	if r.ContentLength != 0 {
+		if !decodeRequest(w, h.logger, r, &input) {
+			return
+		}
+		if !validateRequest(w, h.logger, &input) {
+			return
+		}
+	}
+	if input.RequestedBy == "" {
+		input.RequestedBy = r.Header.Get(ActingUserHeader)
+	}
+
+	job, err := h.service.Enqueue(ctx, orgID, input.RequestedBy)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/org-deletions/"+job.ID)
+	h.respondJSON(w, http.StatusAccepted, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization deletion queued",
+		Data:    job,
+	})
+}
+
+// GetOrgDeletion handles GET /organizations/{id}/deletion - returns the
+// organization's latest deletion job
+func (h *OrgDeletionHandler) GetOrgDeletion(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.ReadOrgJob(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization deletion retrieved successfully",
+		Data:    job,
+	})
+}
+
+// GetDeletionJob handles GET /org-deletions/{jobId} - returns a deletion
+// job and the progress of each step
+func (h *OrgDeletionHandler) GetDeletionJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.ReadJob(r.Context(), mux.Vars(r)["jobId"])
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization deletion retrieved successfully",
+		Data:    job,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondServiceError maps deletion service errors to HTTP statuses (pointer receiver)
+func (h *OrgDeletionHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrOrgDeletionNotFound):
+		h.respondError(w, http.StatusNotFound, "Organization deletion not found")
+	case errors.Is(err, services.ErrOrgDeletionOrgNotFound):
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+	case errors.Is(err, services.ErrOrgDeletionInProgress):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrOrgDeletionQueueFull):
+		w.Header().Set("Retry-After", "60")
+		h.respondError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete organization")
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *OrgDeletionHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *OrgDeletionHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Organization Deletion
+// =====================================
+
+// SetupOrgDeletionRoutes configures cascading organization deletion routes
+func SetupOrgDeletionRoutes(router *mux.Router, h *OrgDeletionHandler) {
+	router.HandleFunc("/organizations/{id}/deletion", h.DeleteOrganization).Methods("POST")
+	router.HandleFunc("/organizations/{id}/deletion", h.GetOrgDeletion).Methods("GET")
+	router.HandleFunc("/org-deletions/{jobId}", h.GetDeletionJob).Methods("GET")
+}
+
@@ -1,19 +1,34 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/policy"
 	"github.com/test-repo-golang-support/services"
 )
 
+// orgPermissions is what a user may do in an organization, with the full
+// role matrix for context
+type orgPermissions struct {
+	OrgID       string                                `json:"org_id"`
+	UserID      string                                `json:"user_id,omitempty"`
+	Role        models.MemberRole                     `json:"role,omitempty"`
+	Permissions []policy.Action                       `json:"permissions"`
+	Grants      map[policy.Action]bool                `json:"grants"`
+	Roles       map[models.MemberRole][]policy.Action `json:"roles"`
+}
+
 // OrgHandler wraps the organization service and provides HTTP handlers
 type OrgHandler struct {
-	service *services.OrganizationService
-	logger  *log.Logger
+	service   *services.OrganizationService
+	deletions *services.OrgDeletionService // Optional; soft-deletes and restores go through it when set
+	logger    *log.Logger
 }
 
 // NewOrgHandler creates a new OrgHandler instance
@@ -24,15 +39,22 @@ func NewOrgHandler(service *services.OrganizationService, logger *log.Logger) *O
 	}
 }
 
+// SetDeletions routes soft-deletes and restores through the deletion
+// service, so neither runs while a cascading delete is removing the
+// organization (pointer receiver)
+func (h *OrgHandler) SetDeletions(deletions *services.OrgDeletionService) {
+	h.deletions = deletions
+}
+
 // =====================================
 // Organization HTTP Handlers
 // =====================================
 
-// GetOrganizations handles GET /organizations - returns all organizations
+// GetOrganizations handles GET /organizations - returns all organizations,
+// or one page of them with cursor-pagination
+// Soft-deleted organizations are only included with ?include_deleted=true
 func (h *OrgHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	orgs, err := h.service.ReadAllOrgs(ctx)
+	orgs, next, err := h.service.ReadOrgsPage(r.Context(), includeDeleted(r), requestPage(r))
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, "Failed to fetch organizations")
 		return
@@ -42,21 +64,30 @@ func (h *OrgHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
 		Code:    models.ResponseOK,
 		Message: "Organizations retrieved successfully",
 		Data:    orgs,
+		Meta:    pageMeta(r, len(orgs), next),
 	})
 }
 
 // GetOrganization handles GET /organizations/{id} - returns a specific organization
+// Soft-deleted organizations are only returned with ?include_deleted=true
 func (h *OrgHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	org, err := h.service.ReadOrg(ctx, id)
+	var org *models.Organization
+	var err error
+	if includeDeleted(r) {
+		org, err = h.service.ReadOrgIncludingDeleted(ctx, id)
+	} else {
+		org, err = h.service.ReadOrg(ctx, id)
+	}
 	if err != nil {
 		h.respondError(w, http.StatusNotFound, "Organization not found")
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(org.Version))
 	h.respondJSON(w, http.StatusOK, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "Organization retrieved successfully",
@@ -69,10 +100,10 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 
 	var input struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		Industry    string `json:"industry"`
-		OwnerID     string `json:"owner_id"`
+		Name        string `json:"name" validate:"required,max=100"`
+		Description string `json:"description" validate:"max=1000"`
+		Industry    string `json:"industry" validate:"max=100"`
+		OwnerID     string `json:"owner_id" validate:"required"`
 		Address     struct {
 			Street     string `json:"street"`
 			City       string `json:"city"`
@@ -82,28 +113,20 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 		} `json:"address"`
 		Contact struct {
 			Phone   string `json:"phone"`
-			Email   string `json:"email"`
+			Email   string `json:"email" validate:"email"`
 			Website string `json:"website"`
 		} `json:"contact"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	if input.Name == "" {
-		h.respondError(w, http.StatusBadRequest, "Organization name is required")
+	if !decodeRequest(w, h.logger, r, &input) {
 		return
 	}
-
-	if input.OwnerID == "" {
-		h.respondError(w, http.StatusBadRequest, "Owner ID is required")
+	if !validateRequest(w, h.logger, &input) {
 		return
 	}
 
 	// Create new organization
-	orgID := services.GenerateOrgID()
+	orgID := h.service.NewID(services.IDPrefixOrg)
 	org := services.CreateOrganization(orgID, input.Name, input.OwnerID)
 	org.UpdateDescription(input.Description)
 	org.SetIndustry(input.Industry)
@@ -130,6 +153,14 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 
 	// Save organization
 	if err := h.service.WriteOrg(ctx, org); err != nil {
+		if errors.Is(err, services.ErrInvalidEnumValue) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrStoreFull) {
+			h.respondError(w, http.StatusInsufficientStorage, "Organization store is full")
+			return
+		}
 		h.respondError(w, http.StatusInternalServerError, "Failed to create organization")
 		return
 	}
@@ -142,6 +173,7 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 
 	h.logger.Printf("Created organization: %s (%s)", org.DisplayName(), org.ID)
 
+	w.Header().Set("ETag", versionETag(org.Version))
 	h.respondJSON(w, http.StatusCreated, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "Organization created successfully",
@@ -156,21 +188,34 @@ func (h *OrgHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request)
 	id := vars["id"]
 
 	// Get existing organization
-	org, err := h.service.ReadOrg(ctx, id)
+	existing, err := h.service.ReadOrg(ctx, id)
 	if err != nil {
 		h.respondError(w, http.StatusNotFound, "Organization not found")
 		return
 	}
 
+	if !ifMatchSatisfied(r, existing.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Organization has been modified")
+		return
+	}
+
+	// Work on a copy so a rejected write leaves the stored organization untouched
+	updated := *existing
+	org := &updated
+
 	var input struct {
-		Name        string          `json:"name"`
-		Description string          `json:"description"`
-		Industry    string          `json:"industry"`
-		Size        models.OrgSize  `json:"size"`
+		Name          string         `json:"name" validate:"max=100"`
+		Description   string         `json:"description" validate:"max=1000"`
+		Industry      string         `json:"industry" validate:"max=100"`
+		Size          models.OrgSize `json:"size" validate:"oneof=small medium large enterprise"`
+		Slug          string         `json:"slug" validate:"max=63"`
+		PublicProfile *bool          `json:"public_profile"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
 		return
 	}
 
@@ -187,13 +232,41 @@ func (h *OrgHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request)
 	if input.Size != "" {
 		org.SetSize(input.Size)
 	}
+	if input.Slug != "" {
+		org.SetSlug(input.Slug)
+	}
+	if input.PublicProfile != nil {
+		org.SetPublicProfile(*input.PublicProfile)
+	}
+
+	if err := org.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Save updated organization
 	if err := h.service.WriteOrg(ctx, org); err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.respondError(w, http.StatusConflict, "Organization has been modified")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidEnumValue) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrSlugTaken) {
+			h.respondError(w, http.StatusConflict, "Slug is already in use")
+			return
+		}
+		if errors.Is(err, services.ErrStoreFull) {
+			h.respondError(w, http.StatusInsufficientStorage, "Organization store is full")
+			return
+		}
 		h.respondError(w, http.StatusInternalServerError, "Failed to update organization")
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(org.Version))
 	h.respondJSON(w, http.StatusOK, models.APIResponse{
 		Code:    models.ResponseOK,
 		Message: "Organization updated successfully",
@@ -201,20 +274,31 @@ func (h *OrgHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request)
 	})
 }
 
-// DeleteOrganization handles DELETE /organizations/{id} - deletes an organization
+// DeleteOrganization handles DELETE /organizations/{id} - soft-deletes an organization
+// Memberships are kept so that a restore brings them back; once the
+// retention window passes, the janitor removes the organization and its data
 func (h *OrgHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	id := vars["id"]
 
 	// Check if organization exists
-	exists, _ := h.service.OrgExists(ctx, id)
-	if !exists {
+	org, err := h.service.ReadOrg(ctx, id)
+	if err != nil {
 		h.respondError(w, http.StatusNotFound, "Organization not found")
 		return
 	}
 
-	if err := h.service.DeleteOrg(ctx, id); err != nil {
+	if !ifMatchSatisfied(r, org.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Organization has been modified")
+		return
+	}
+
+	if err := h.softDelete(ctx, org); err != nil {
+		if errors.Is(err, services.ErrOrgDeletionInProgress) {
+			h.respondError(w, http.StatusConflict, "Organization deletion already in progress")
+			return
+		}
 		h.respondError(w, http.StatusInternalServerError, "Failed to delete organization")
 		return
 	}
@@ -225,10 +309,166 @@ func (h *OrgHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// RestoreOrganization handles POST /organizations/{id}/restore - restores a soft-deleted organization
+func (h *OrgHandler) RestoreOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	org, err := h.service.ReadOrgIncludingDeleted(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	if !org.IsDeleted() {
+		h.respondError(w, http.StatusConflict, "Organization is not deleted")
+		return
+	}
+
+	restored, err := h.restore(ctx, org)
+	if err != nil {
+		if errors.Is(err, services.ErrOrgDeletionInProgress) {
+			h.respondError(w, http.StatusConflict, "Organization deletion already in progress")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to restore organization")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(restored.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization restored successfully",
+		Data:    restored,
+	})
+}
+
+// SetOrganizationVerification handles PUT /admin/v1/organizations/{id}/verification -
+// grants or revokes the verified badge shown on public profiles and embeds
+func (h *OrgHandler) SetOrganizationVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadOrg(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		Verified bool `json:"verified"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+
+	org := *existing
+	org.SetVerified(input.Verified)
+	if err := h.service.WriteOrg(ctx, &org); err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to update organization")
+		return
+	}
+
+	h.logger.Printf("audit action=org.verification org=%s verified=%t", id, input.Verified)
+
+	w.Header().Set("ETag", versionETag(org.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization verification updated successfully",
+		Data:    org,
+	})
+}
+
+// SetOrganizationSupport handles PUT /admin/v1/organizations/{id}/support -
+// sets the support tier and escalation webhook of an organization
+func (h *OrgHandler) SetOrganizationSupport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadOrg(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input models.OrgSupport
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	org := *existing
+	org.SetSupport(input)
+	if err := h.service.WriteOrg(ctx, &org); err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to update organization")
+		return
+	}
+
+	h.logger.Printf("audit action=org.support org=%s tier=%s", id, input.Tier)
+
+	w.Header().Set("ETag", versionETag(org.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization support updated successfully",
+		Data:    org,
+	})
+}
+
 // =====================================
 // Membership HTTP Handlers
 // =====================================
 
+// GetOrgPermissions handles GET /organizations/{id}/permissions?user_id= -
+// returns the actions a user may perform in the organization, for gating
+// UI controls. The user defaults to the X-Acting-User header; without
+// either, only the role matrix is returned.
+func (h *OrgHandler) GetOrgPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if exists, _ := h.service.OrgExists(ctx, orgID); !exists {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		userID = r.Header.Get(ActingUserHeader)
+	}
+
+	var role models.MemberRole
+	if userID != "" {
+		if membership, err := h.service.GetMembership(ctx, userID, orgID); err == nil {
+			role = membership.Role
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Permissions retrieved successfully",
+		Data: orgPermissions{
+			OrgID:       orgID,
+			UserID:      userID,
+			Role:        role,
+			Permissions: policy.Permissions(role),
+			Grants:      policy.Grants(role),
+			Roles:       policy.Matrix(),
+		},
+	})
+}
+
 // GetOrgMembers handles GET /organizations/{id}/members - returns all members
 func (h *OrgHandler) GetOrgMembers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -248,24 +488,26 @@ func (h *OrgHandler) GetOrgMembers(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// AddOrgMember handles POST /organizations/{id}/members - adds a new member
+// AddOrgMember handles POST /organizations/{id}/members - adds a new member.
+// Deprecated: it attaches any user ID without their consent; new clients
+// should send an invitation through POST /organizations/{id}/invitations.
 func (h *OrgHandler) AddOrgMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	orgID := vars["id"]
 
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Link", "</api/v1/organizations/"+orgID+"/invitations>; rel=\"successor-version\"")
+
 	var input struct {
-		UserID string            `json:"user_id"`
-		Role   models.MemberRole `json:"role"`
+		UserID string            `json:"user_id" validate:"required"`
+		Role   models.MemberRole `json:"role" validate:"oneof=owner admin member guest"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
 		return
 	}
-
-	if input.UserID == "" {
-		h.respondError(w, http.StatusBadRequest, "User ID is required")
+	if !validateRequest(w, h.logger, &input) {
 		return
 	}
 
@@ -312,15 +554,21 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 	userID := vars["userId"]
 
 	var input struct {
-		Role models.MemberRole `json:"role"`
+		Role models.MemberRole `json:"role" validate:"required,oneof=owner admin member guest"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
 		return
 	}
 
 	if err := h.service.UpdateMemberRole(ctx, userID, orgID, input.Role); err != nil {
+		if errors.Is(err, services.ErrInvalidEnumValue) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.respondError(w, http.StatusNotFound, "Membership not found")
 		return
 	}
@@ -331,6 +579,87 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// maxMemberOperations bounds how many operations one batch request may carry
+const maxMemberOperations = 500
+
+// BatchMemberOperations handles POST /organizations/{id}/members/batch -
+// adds, removes, and changes the role of many members in one request, e.g.
+// {"operations":[{"op":"add","user_id":"u2","role":"admin"},{"op":"remove","user_id":"u3"}]}.
+// Operations apply in order and independently; the response reports each
+// one's outcome with the status its single-member request would return.
+func (h *OrgHandler) BatchMemberOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	if _, err := h.service.ReadOrg(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		Operations []models.MemberOperation `json:"operations" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+	if len(input.Operations) > maxMemberOperations {
+		h.respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("A batch may carry at most %d operations", maxMemberOperations))
+		return
+	}
+
+	// Reject malformed items up front; the rest go to the store together
+	results := make([]models.MemberOperationResult, len(input.Operations))
+	valid := make([]models.MemberOperation, 0, len(input.Operations))
+	positions := make([]int, 0, len(input.Operations))
+	for i, op := range input.Operations {
+		results[i] = models.MemberOperationResult{Index: i, Op: op.Op, UserID: op.UserID}
+		switch {
+		case !op.Op.IsValid():
+			results[i].Status, results[i].Error = http.StatusBadRequest, "op must be add, remove, or change_role"
+		case op.UserID == "":
+			results[i].Status, results[i].Error = http.StatusBadRequest, "User ID is required"
+		case op.Op == models.MemberOperationChangeRole && op.Role == "":
+			results[i].Status, results[i].Error = http.StatusBadRequest, "role is required for change_role"
+		default:
+			if op.Op == models.MemberOperationAdd && op.Role == "" {
+				op.Role = models.MemberRoleMember
+			}
+			valid = append(valid, op)
+			positions = append(positions, i)
+		}
+	}
+
+	memberships, errs := h.service.ApplyMemberOperations(ctx, orgID, valid)
+	for j, i := range positions {
+		results[i].Status, results[i].Error = memberOperationStatus(valid[j].Op, errs[j])
+		results[i].Membership = memberships[j]
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Succeeded() {
+			succeeded++
+		}
+	}
+
+	h.logger.Printf("event=org.members_batch org=%s operations=%d succeeded=%d failed=%d", orgID, len(results), succeeded, len(results)-succeeded)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Member operations processed",
+		Data: map[string]interface{}{
+			"succeeded": succeeded,
+			"failed":    len(results) - succeeded,
+			"results":   results,
+		},
+	})
+}
+
 // GetUserOrganizations handles GET /users/{id}/organizations - returns user's organizations
 func (h *OrgHandler) GetUserOrganizations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -354,21 +683,56 @@ func (h *OrgHandler) GetUserOrganizations(w http.ResponseWriter, r *http.Request
 // Helper Methods
 // =====================================
 
+// memberOperationStatus maps a batch operation's outcome to the status and
+// message its single-member request would return (standalone function)
+func memberOperationStatus(op models.MemberOperationKind, err error) (int, string) {
+	switch {
+	case err == nil && op == models.MemberOperationAdd:
+		return http.StatusCreated, ""
+	case err == nil:
+		return http.StatusOK, ""
+	case errors.Is(err, services.ErrMembershipNotFound):
+		return http.StatusNotFound, "Membership not found"
+	case errors.Is(err, services.ErrMembershipExists):
+		return http.StatusConflict, err.Error()
+	default:
+		return http.StatusBadRequest, err.Error()
+	}
+}
+
+// softDelete marks an organization deleted, writing a copy so a failed
+// write leaves the stored organization live (pointer receiver)
+func (h *OrgHandler) softDelete(ctx context.Context, org *models.Organization) error {
+	if h.deletions != nil {
+		return h.deletions.SoftDelete(ctx, org)
+	}
+	deleted := *org
+	deleted.Deactivate()
+	return h.service.WriteOrg(ctx, &deleted)
+}
+
+// restore brings back a soft-deleted organization, writing a copy so a
+// rejected write leaves the stored organization deleted (pointer receiver)
+func (h *OrgHandler) restore(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	if h.deletions != nil {
+		return h.deletions.Restore(ctx, org)
+	}
+	restored := *org
+	restored.Activate()
+	if err := h.service.WriteOrg(ctx, &restored); err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
 // respondJSON sends a JSON response (pointer receiver)
 func (h *OrgHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.logger.Printf("Error encoding response: %v", err)
-	}
+	writeJSON(w, h.logger, status, data)
 }
 
 // respondError sends an error response (pointer receiver)
 func (h *OrgHandler) respondError(w http.ResponseWriter, status int, message string) {
-	h.respondJSON(w, status, models.APIResponse{
-		Code:    models.ResponseError,
-		Message: message,
-	})
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
 }
 
 // =====================================
@@ -383,14 +747,23 @@ func SetupOrgRoutes(router *mux.Router, h *OrgHandler) {
 	router.HandleFunc("/organizations", h.CreateOrganization).Methods("POST")
 	router.HandleFunc("/organizations/{id}", h.UpdateOrganization).Methods("PUT")
 	router.HandleFunc("/organizations/{id}", h.DeleteOrganization).Methods("DELETE")
+	router.HandleFunc("/organizations/{id}/restore", h.RestoreOrganization).Methods("POST")
 
 	// Membership routes
 	router.HandleFunc("/organizations/{id}/members", h.GetOrgMembers).Methods("GET")
 	router.HandleFunc("/organizations/{id}/members", h.AddOrgMember).Methods("POST")
+	router.HandleFunc("/organizations/{id}/members/batch", h.BatchMemberOperations).Methods("POST")
 	router.HandleFunc("/organizations/{id}/members/{userId}", h.RemoveOrgMember).Methods("DELETE")
 	router.HandleFunc("/organizations/{id}/members/{userId}", h.UpdateMemberRole).Methods("PUT")
+	router.HandleFunc("/organizations/{id}/permissions", h.GetOrgPermissions).Methods("GET")
 
 	// User organizations route
 	router.HandleFunc("/users/{id}/organizations", h.GetUserOrganizations).Methods("GET")
 }
 
+// SetupOrgAdminRoutes configures organization badge routes on the admin router
+func SetupOrgAdminRoutes(admin *mux.Router, h *OrgHandler) {
+	admin.HandleFunc("/organizations/{id}/verification", h.SetOrganizationVerification).Methods("PUT")
+	admin.HandleFunc("/organizations/{id}/support", h.SetOrganizationSupport).Methods("PUT")
+}
+
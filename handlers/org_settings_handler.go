@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// OrgSettingsHandler wraps the organization settings service and provides HTTP handlers
+type OrgSettingsHandler struct {
+	service *services.OrgSettingsService
+	logger  *log.Logger
+}
+
+// NewOrgSettingsHandler creates a new OrgSettingsHandler instance
+func NewOrgSettingsHandler(service *services.OrgSettingsService, logger *log.Logger) *OrgSettingsHandler {
+	return &OrgSettingsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Organization Settings HTTP Handlers
+// =====================================
+
+// GetSettings handles GET /organizations/{id}/settings - returns the current revision
+func (h *OrgSettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	current, err := h.service.GetSettings(ctx, orgID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings retrieved successfully",
+		Data:    current,
+	})
+}
+
+// UpdateSettings handles PUT /organizations/{id}/settings - stores a new revision
+func (h *OrgSettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	// Check if organization exists
+	if _, err := h.service.GetSettings(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		Settings  models.OrgSettings `json:"settings"`
+		ChangedBy string             `json:"changed_by" validate:"required"`
+		Reason    string             `json:"reason"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	revision, err := h.service.UpdateSettings(ctx, orgID, input.Settings, input.ChangedBy, input.Reason)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("audit action=org.settings.update org=%s revision=%d actor=%s", orgID, revision.Revision, revision.ChangedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings updated successfully",
+		Data:    revision,
+	})
+}
+
+// GetSettingsHistory handles GET /organizations/{id}/settings/history - returns all revisions
+func (h *OrgSettingsHandler) GetSettingsHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	history, err := h.service.History(ctx, orgID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings history retrieved successfully",
+		Data:    history,
+	})
+}
+
+// RollbackSettings handles POST /organizations/{id}/settings/rollback - restores an earlier revision
+func (h *OrgSettingsHandler) RollbackSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	// Check if organization exists
+	if _, err := h.service.GetSettings(ctx, orgID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var input struct {
+		Revision  int    `json:"revision"`
+		ChangedBy string `json:"changed_by" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	revision, err := h.service.Rollback(ctx, orgID, input.Revision, input.ChangedBy)
+	if err != nil {
+		h.respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	h.logger.Printf("audit action=org.settings.rollback org=%s revision=%d rollback_of=%d actor=%s", orgID, revision.Revision, revision.RollbackOf, revision.ChangedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings rolled back successfully",
+		Data:    revision,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *OrgSettingsHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *OrgSettingsHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Organization Settings
+// =====================================
+
+// SetupOrgSettingsRoutes configures organization settings routes
+func SetupOrgSettingsRoutes(router *mux.Router, h *OrgSettingsHandler) {
+	router.HandleFunc("/organizations/{id}/settings", h.GetSettings).Methods("GET")
+	router.HandleFunc("/organizations/{id}/settings", h.UpdateSettings).Methods("PUT")
+	router.HandleFunc("/organizations/{id}/settings/history", h.GetSettingsHistory).Methods("GET")
+	router.HandleFunc("/organizations/{id}/settings/rollback", h.RollbackSettings).Methods("POST")
+}
+
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/policy"
+	"github.com/test-repo-golang-support/services"
+)
+
+// ActingUserHeader names the user a request is made on behalf of
+const ActingUserHeader = "X-Acting-User"
+
+// PermissionConfig configures PermissionMiddleware
+type PermissionConfig struct {
+	AllowAnonymous bool // Let guarded requests that do not name an acting user through unchecked
+}
+
+// guardedRoutes maps organization routes to the permission they require,
+// keyed by method and route template
+var guardedRoutes = map[string]policy.Action{
+	"PUT /api/v1/organizations/{id}":                                    policy.ActionEditOrg,
+	"DELETE /api/v1/organizations/{id}":                                 policy.ActionDeleteOrg,
+	"POST /api/v1/organizations/{id}/restore":                           policy.ActionDeleteOrg,
+	"POST /api/v1/organizations/{id}/deletion":                          policy.ActionDeleteOrg,
+	"PUT /api/v1/organizations/{id}/settings":                           policy.ActionEditOrg,
+	"POST /api/v1/organizations/{id}/settings/rollback":                 policy.ActionEditOrg,
+	"POST /api/v1/organizations/{id}/members":                           policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/members/batch":                     policy.ActionManageMembers,
+	"PUT /api/v1/organizations/{id}/members/{userId}":                   policy.ActionManageMembers,
+	"DELETE /api/v1/organizations/{id}/members/{userId}":                policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/invitations":                       policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/invitations/{invitationId}/revoke": policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/invitations/{invitationId}/renew":  policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/join-requests/{requestId}/approve": policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/join-requests/{requestId}/deny":    policy.ActionManageMembers,
+	"POST /api/v1/organizations/{id}/sandbox":                           policy.ActionEditOrg,
+	"DELETE /api/v1/organizations/{id}/sandbox":                         policy.ActionEditOrg,
+	"POST /api/v1/organizations/{id}/sandbox/reset":                     policy.ActionEditOrg,
+}
+
+// guardedProjectRoutes maps project routes to the permission they require
+// in the project's organization, keyed by method and route template
+var guardedProjectRoutes = map[string]policy.Action{
+	"PUT /api/v1/projects/{id}":                     policy.ActionManageProjects,
+	"DELETE /api/v1/projects/{id}":                  policy.ActionManageProjects,
+	"POST /api/v1/projects/{id}/transfer":           policy.ActionManageProjects,
+	"POST /api/v1/projects/{id}/guests":             policy.ActionManageProjects,
+	"DELETE /api/v1/projects/{id}/guests/{userId}":  policy.ActionManageProjects,
+	"POST /api/v1/projects/{id}/members":            policy.ActionManageProjects,
+	"PUT /api/v1/projects/{id}/members/{userId}":    policy.ActionManageProjects,
+	"DELETE /api/v1/projects/{id}/members/{userId}": policy.ActionManageProjects,
+}
+
+// contributorRoutes are the project routes open to anyone who may
+// contribute to the project, see ProjectService.CanContribute
+var contributorRoutes = map[string]bool{
+	"POST /api/v1/projects/{id}/tasks":            true,
+	"PUT /api/v1/projects/{id}/tasks/{taskId}":    true,
+	"DELETE /api/v1/projects/{id}/tasks/{taskId}": true,
+}
+
+// PermissionMiddleware checks the organization routes in guardedRoutes
+// and the project routes in guardedProjectRoutes against the policy
+// permission matrix. The acting user, named in X-Acting-User, must hold
+// the route's permission in the organization, for project routes the one
+// owning the project; members may always remove themselves. Task changes
+// only need the actor to be able to contribute to the project. Requests
+// without the header are refused unless AllowAnonymous is set; requests
+// for unknown projects are left to the handlers to answer.
+func PermissionMiddleware(orgs *services.OrganizationService, projects *services.ProjectService, logger *log.Logger, config PermissionConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := r.Method + " " + routeTemplate(r)
+			action, guarded := guardedRoutes[route]
+			projectAction, projectGuarded := guardedProjectRoutes[route]
+			if !guarded && !projectGuarded && !contributorRoutes[route] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			actor := r.Header.Get(ActingUserHeader)
+			if actor == "" {
+				if config.AllowAnonymous {
+					next.ServeHTTP(w, r)
+					return
+				}
+				writeError(w, logger, http.StatusUnauthorized, ActingUserHeader+" header is required")
+				return
+			}
+
+			vars := mux.Vars(r)
+			if r.Method == http.MethodDelete && vars["userId"] == actor {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if contributorRoutes[route] {
+				allowed, err := projects.CanContribute(r.Context(), actor, vars["id"])
+				if err == nil && !allowed {
+					logger.Printf("permission denied user=%s project=%s action=contribute method=%s path=%s", actor, vars["id"], r.Method, r.URL.Path)
+					writeError(w, logger, http.StatusForbidden, i18n.T(responseLocale(w), i18n.MsgContributeDenied, actor, vars["id"]))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			orgID := vars["id"]
+			if projectGuarded {
+				project, err := projects.ReadProject(r.Context(), vars["id"])
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				orgID, action = project.OrgID, projectAction
+			}
+
+			membership, err := orgs.GetMembership(r.Context(), actor, orgID)
+			if err != nil || !policy.Allows(membership.Role, action) {
+				logger.Printf("permission denied user=%s org=%s action=%s method=%s path=%s", actor, orgID, action, r.Method, r.URL.Path)
+				writeError(w, logger, http.StatusForbidden, i18n.T(responseLocale(w), i18n.MsgPermissionDenied, actor, action, orgID))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestPermissionRouter serves every guarded route with a handler that
+// answers 204, behind PermissionMiddleware. proj_a belongs to org_a;
+// user_viewer and user_editor are guests of it from org_b.
+func newTestPermissionRouter(t *testing.T, config PermissionConfig) *mux.Router {
+	t.Helper()
+	ctx := context.Background()
+	orgs := services.NewOrganizationService()
+	projects := services.NewProjectService(orgs)
+	for _, id := range []string{"org_a", "org_b"} {
+		if err := orgs.WriteOrg(ctx, models.NewOrganization(id, id, "user_owner")); err != nil {
+			t.Fatalf("WriteOrg(%s): %v", id, err)
+		}
+	}
+	for _, m := range []struct {
+		user, org string
+		role      models.MemberRole
+	}{
+		{"user_owner", "org_a", models.MemberRoleOwner},
+		{"user_admin", "org_a", models.MemberRoleAdmin},
+		{"user_member", "org_a", models.MemberRoleMember},
+		{"user_viewer", "org_b", models.MemberRoleMember},
+		{"user_editor", "org_b", models.MemberRoleMember},
+	} {
+		if err := orgs.AddMember(ctx, services.CreateMembership(m.user, m.org, m.role)); err != nil {
+			t.Fatalf("AddMember(%s, %s): %v", m.user, m.org, err)
+		}
+	}
+	if err := projects.WriteProject(ctx, services.CreateProject("proj_a", "Alpha", "user_owner", "org_a")); err != nil {
+		t.Fatalf("WriteProject: %v", err)
+	}
+	for user, role := range map[string]models.ProjectGrantRole{
+		"user_viewer": models.ProjectGrantViewer,
+		"user_editor": models.ProjectGrantEditor,
+	} {
+		grant := models.NewProjectGrant("grant_"+user, "proj_a", user, "org_b", role, "user_owner")
+		if err := projects.GrantGuestAccess(ctx, grant); err != nil {
+			t.Fatalf("GrantGuestAccess(%s): %v", user, err)
+		}
+	}
+
+	router := mux.NewRouter()
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(PermissionMiddleware(orgs, projects, log.New(io.Discard, "", 0), config))
+	routes := make([]string, 0, len(guardedRoutes)+len(guardedProjectRoutes)+len(contributorRoutes))
+	for route := range guardedRoutes {
+		routes = append(routes, route)
+	}
+	for route := range guardedProjectRoutes {
+		routes = append(routes, route)
+	}
+	for route := range contributorRoutes {
+		routes = append(routes, route)
+	}
+	for _, route := range routes {
+		method, template, _ := strings.Cut(route, " ")
+		api.HandleFunc(strings.TrimPrefix(template, "/api/v1"), func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}).Methods(method)
+	}
+	return router
+}
+
+// guardedRequest builds a request for a guarded route with every path
+// variable filled in, id with org_a or proj_a and userId with the given member
+func guardedRequest(route, member string) *http.Request {
+	method, template, _ := strings.Cut(route, " ")
+	id := "org_a"
+	if strings.HasPrefix(template, "/api/v1/projects/") {
+		id = "proj_a"
+	}
+	path := strings.NewReplacer("{id}", id, "{userId}", member, "{invitationId}", "inv_1", "{requestId}", "req_1", "{taskId}", "task_1").Replace(template)
+	return httptest.NewRequest(method, path, nil)
+}
+
+func TestPermissionMiddlewareGuardedRoutes(t *testing.T) {
+	router := newTestPermissionRouter(t, PermissionConfig{})
+
+	for route, action := range guardedRoutes {
+		t.Run(route, func(t *testing.T) {
+			tests := []struct {
+				actor string
+				want  int
+			}{
+				{"", http.StatusUnauthorized},
+				{"user_outsider", http.StatusForbidden},
+				{"user_member", http.StatusForbidden},
+				{"user_owner", http.StatusNoContent},
+			}
+			for _, tt := range tests {
+				req := guardedRequest(route, "user_other")
+				if tt.actor != "" {
+					req.Header.Set(ActingUserHeader, tt.actor)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("actor %q: status = %d, want %d (action %s)", tt.actor, rec.Code, tt.want, action)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionMiddlewareAllowAnonymous(t *testing.T) {
+	router := newTestPermissionRouter(t, PermissionConfig{AllowAnonymous: true})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, guardedRequest("DELETE /api/v1/organizations/{id}", ""))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("anonymous request: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestPermissionMiddlewareMemberRemovesSelf(t *testing.T) {
+	router := newTestPermissionRouter(t, PermissionConfig{})
+
+	req := guardedRequest("DELETE /api/v1/organizations/{id}/members/{userId}", "user_member")
+	req.Header.Set(ActingUserHeader, "user_member")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("self-removal: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestPermissionMiddlewareProjectRoutes(t *testing.T) {
+	router := newTestPermissionRouter(t, PermissionConfig{})
+
+	for route := range guardedProjectRoutes {
+		t.Run(route, func(t *testing.T) {
+			tests := []struct {
+				actor string
+				want  int
+			}{
+				{"", http.StatusUnauthorized},
+				{"user_outsider", http.StatusForbidden},
+				{"user_member", http.StatusForbidden},
+				{"user_editor", http.StatusForbidden},
+				{"user_admin", http.StatusNoContent},
+				{"user_owner", http.StatusNoContent},
+			}
+			for _, tt := range tests {
+				req := guardedRequest(route, "user_other")
+				if tt.actor != "" {
+					req.Header.Set(ActingUserHeader, tt.actor)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("actor %q: status = %d, want %d", tt.actor, rec.Code, tt.want)
+				}
+			}
+		})
+	}
+
+	for route := range contributorRoutes {
+		t.Run(route, func(t *testing.T) {
+			tests := []struct {
+				actor string
+				want  int
+			}{
+				{"", http.StatusUnauthorized},
+				{"user_outsider", http.StatusForbidden},
+				{"user_viewer", http.StatusForbidden},
+				{"user_editor", http.StatusNoContent},
+				{"user_member", http.StatusNoContent},
+			}
+			for _, tt := range tests {
+				req := guardedRequest(route, "user_other")
+				if tt.actor != "" {
+					req.Header.Set(ActingUserHeader, tt.actor)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("actor %q: status = %d, want %d", tt.actor, rec.Code, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionMiddlewareUnknownProject(t *testing.T) {
+	router := newTestPermissionRouter(t, PermissionConfig{})
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/proj_missing", nil)
+	req.Header.Set(ActingUserHeader, "user_owner")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("unknown project: status = %d, want the handler's %d", rec.Code, http.StatusNoContent)
+	}
+}
+
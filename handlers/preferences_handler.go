@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// PreferencesHandler serves per-user settings
+type PreferencesHandler struct {
+	service *services.PreferencesService
+	logger  *log.Logger
+}
+
+// NewPreferencesHandler creates a new PreferencesHandler instance
+func NewPreferencesHandler(service *services.PreferencesService, logger *log.Logger) *PreferencesHandler {
+	return &PreferencesHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// User Settings HTTP Handlers
+// =====================================
+
+// GetSettings handles GET /users/{id}/settings - returns the user's locale,
+// time zone, theme, and notification toggles
+func (h *PreferencesHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	settings, err := h.service.GetSettings(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings retrieved successfully",
+		Data:    settings,
+	})
+}
+
+// UpdateSettings handles PUT /users/{id}/settings - changes the settings
+// present in the body, e.g. {"theme":"dark","notifications":{"push":false}}
+func (h *PreferencesHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	var input models.UserSettingsUpdate
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(ctx, userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSettingsUserNotFound):
+			h.respondError(w, http.StatusNotFound, "User not found")
+		case errors.Is(err, services.ErrVersionConflict):
+			h.respondError(w, http.StatusConflict, "User has been modified")
+		case errors.Is(err, services.ErrStoreFull):
+			h.respondError(w, http.StatusInsufficientStorage, "User store is full")
+		default:
+			h.respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	h.logger.Printf("Updated settings for user %s", userID)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Settings updated successfully",
+		Data:    settings,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *PreferencesHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *PreferencesHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for User Settings
+// =====================================
+
+// SetupPreferencesRoutes configures user settings routes
+func SetupPreferencesRoutes(router *mux.Router, h *PreferencesHandler) {
+	router.HandleFunc("/users/{id}/settings", h.GetSettings).Methods("GET")
+	router.HandleFunc("/users/{id}/settings", h.UpdateSettings).Methods("PUT")
+}
+
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/validation"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem documents
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes error codes to form problem type URIs, e.g.
+// "urn:problem-type:not_found"
+const problemTypeBase = "urn:problem-type:"
+
+// problemDetails is an RFC 9457 problem document. Code and Errors are
+// extension members carrying the typed error of the envelope.
+type problemDetails struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Code     models.ErrorCode    `json:"code,omitempty"`
+	Errors   []models.FieldError `json:"errors,omitempty"`
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// newProblem converts an error envelope to a problem document. Envelopes
+// written without an error code get the status's default (standalone function).
+func newProblem(r *http.Request, status int, envelope models.APIResponse) problemDetails {
+	code := envelope.ErrorCode
+	if code == "" {
+		code = models.ErrorCodeForStatus(status)
+	}
+	return problemDetails{
+		Type:     problemTypeBase + string(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   envelope.Message,
+		Instance: r.URL.Path,
+		Code:     code,
+		Errors:   envelope.Errors,
+	}
+}
+
+// writeError sends an error envelope from middleware that has no handler
+// helpers to hand (standalone function)
+func writeError(w http.ResponseWriter, logger *log.Logger, status int, message string, fields ...models.FieldError) {
+	writeJSON(w, logger, status, models.NewErrorResponse(status, message, fields...))
+}
+
+// validateRequest checks a decoded request body against its validate tags
+// and answers 422 with the field errors when it fails; a malformed tag is
+// a server bug and answers 500 (standalone function)
+func validateRequest(w http.ResponseWriter, logger *log.Logger, input interface{}) bool {
+	fields, err := validation.Struct(input)
+	if err != nil {
+		logger.Printf("event=validation_tag_invalid type=%T error=%q", input, err)
+		writeError(w, logger, http.StatusInternalServerError, "Failed to validate request")
+		return false
+	}
+	if len(fields) == 0 {
+		return true
+	}
+	writeError(w, logger, http.StatusUnprocessableEntity, "Validation failed", fields...)
+	return false
+}
+
@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// maxAvatarBytes caps the size of an uploaded avatar image
+const maxAvatarBytes = 2 << 20
+
+// avatarExtensions maps accepted image types, sniffed from the content
+// rather than trusted from the client, to file extensions
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProfileHandler handles user profile endpoints
+type ProfileHandler struct {
+	service     *services.ProfileService
+	userService *services.UserService
+	avatars     interfaces.BlobStorage
+	logger      *log.Logger
+}
+
+// NewProfileHandler creates a new ProfileHandler instance
+func NewProfileHandler(service *services.ProfileService, userService *services.UserService, avatars interfaces.BlobStorage, logger *log.Logger) *ProfileHandler {
+	return &ProfileHandler{
+		service:     service,
+		userService: userService,
+		avatars:     avatars,
+		logger:      logger,
+	}
+}
+
+// =====================================
+// Profile HTTP Handlers
+// =====================================
+
+// GetProfile handles GET /users/{id}/profile - returns a user's profile
+func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	if exists, _ := h.userService.Exists(ctx, userID); !exists {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	profile, err := h.service.GetByUserID(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Profile not found")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(profile.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Profile retrieved successfully",
+		Data:    profile,
+	})
+}
+
+// PutProfile handles PUT /users/{id}/profile - creates or replaces a user's profile
+func (h *ProfileHandler) PutProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	if exists, _ := h.userService.Exists(ctx, userID); !exists {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	var input struct {
+		Bio       string `json:"bio" validate:"max=500"`
+		AvatarURL string `json:"avatar_url" validate:"max=2048"`
+		Website   string `json:"website" validate:"max=2048"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	// Copy the stored profile so a rejected write leaves it untouched
+	status := http.StatusOK
+	var profile models.Profile
+	if existing, err := h.service.GetByUserID(ctx, userID); err == nil {
+		if !ifMatchSatisfied(r, existing.Version) {
+			h.respondError(w, http.StatusPreconditionFailed, "Profile has been modified")
+			return
+		}
+		profile = *existing
+	} else {
+		profile = *models.NewProfile(h.service.NewID(services.IDPrefixProfile), userID)
+		status = http.StatusCreated
+	}
+	previous := profile.AvatarURL
+
+	profile.SetBio(input.Bio)
+	profile.SetAvatarURL(input.AvatarURL)
+	profile.SetWebsite(input.Website)
+
+	if err := profile.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.SaveProfile(ctx, &profile); err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.respondError(w, http.StatusConflict, "Profile has been modified")
+			return
+		}
+		if errors.Is(err, services.ErrProfileUserNotFound) {
+			h.respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to save profile")
+		return
+	}
+
+	if previous != profile.AvatarURL {
+		h.deleteAvatar(r, userID, previous)
+	}
+
+	h.logger.Printf("Saved profile %s for user %s", profile.ID, userID)
+
+	w.Header().Set("ETag", versionETag(profile.Version))
+	h.respondJSON(w, status, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Profile saved successfully",
+		Data:    profile,
+	})
+}
+
+// DeleteProfile handles DELETE /users/{id}/profile - removes a user's profile
+func (h *ProfileHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	profile, err := h.service.GetByUserID(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Profile not found")
+		return
+	}
+
+	if err := h.service.DeleteProfile(ctx, profile.ID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Profile not found")
+		return
+	}
+	h.deleteAvatar(r, userID, profile.AvatarURL)
+
+	h.logger.Printf("Deleted profile %s for user %s", profile.ID, userID)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Profile deleted successfully",
+	})
+}
+
+// UploadAvatar handles POST /users/{id}/avatar - accepts a multipart
+// "avatar" image, stores it, and points the profile's avatar_url at it
+func (h *ProfileHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	if exists, _ := h.userService.Exists(ctx, userID); !exists {
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	data, status, message := readAvatar(w, r)
+	if message != "" {
+		h.respondError(w, status, message)
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := avatarExtensions[contentType]
+	if !ok {
+		h.respondError(w, http.StatusUnsupportedMediaType, "Avatar must be a PNG, JPEG, GIF, or WebP image")
+		return
+	}
+
+	// Copy the stored profile so a rejected write leaves it untouched
+	var profile models.Profile
+	if existing, err := h.service.GetByUserID(ctx, userID); err == nil {
+		profile = *existing
+	} else {
+		profile = *models.NewProfile(h.service.NewID(services.IDPrefixProfile), userID)
+	}
+	previous := profile.AvatarURL
+
+	key := fmt.Sprintf("%s/%s%s", userID, h.service.NewID(services.IDPrefixAvatar), ext)
+	url, err := h.avatars.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		h.logger.Printf("Failed to store avatar %s: %v", key, err)
+		h.respondError(w, http.StatusBadGateway, "Failed to store avatar")
+		return
+	}
+
+	profile.SetAvatarURL(url)
+	if err := h.service.SaveProfile(ctx, &profile); err != nil {
+		if err := h.avatars.Delete(ctx, key); err != nil {
+			h.logger.Printf("Failed to remove orphaned avatar %s: %v", key, err)
+		}
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.respondError(w, http.StatusConflict, "Profile has been modified")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to save profile")
+		return
+	}
+	h.deleteAvatar(r, userID, previous)
+
+	h.logger.Printf("Stored avatar %s for user %s (%d bytes)", key, userID, len(data))
+
+	w.Header().Set("ETag", versionETag(profile.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Avatar uploaded successfully",
+		Data:    profile,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// readAvatar reads the "avatar" part of a multipart upload, returning the
+// HTTP status and message to respond with when the upload is rejected
+func readAvatar(w http.ResponseWriter, r *http.Request) ([]byte, int, string) {
+	tooLargeMessage := fmt.Sprintf("Avatar must be at most %d bytes", maxAvatarBytes)
+
+	// Leave room for multipart framing around the image itself
+	r.Body = http.MaxBytesReader(w, r.Body, maxAvatarBytes+64<<10)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, http.StatusBadRequest, "Expected a multipart/form-data upload"
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, http.StatusBadRequest, "Missing avatar file"
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, "Invalid multipart body"
+		}
+		if part.FormName() != "avatar" {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxAvatarBytes+1))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, http.StatusRequestEntityTooLarge, tooLargeMessage
+			}
+			return nil, http.StatusBadRequest, "Invalid multipart body"
+		}
+		if len(data) > maxAvatarBytes {
+			return nil, http.StatusRequestEntityTooLarge, tooLargeMessage
+		}
+		if len(data) == 0 {
+			return nil, http.StatusBadRequest, "Avatar file is empty"
+		}
+		return data, http.StatusOK, ""
+	}
+}
+
+// deleteAvatar removes a replaced avatar from storage. Only URLs produced
+// for this user are touched; failures are logged since the profile no
+// longer references the object (pointer receiver).
+func (h *ProfileHandler) deleteAvatar(r *http.Request, userID, url string) {
+	if url == "" || !strings.Contains(url, "/"+userID+"/") {
+		return
+	}
+	key := userID + "/" + path.Base(url)
+	if err := h.avatars.Delete(r.Context(), key); err != nil {
+		h.logger.Printf("Failed to remove avatar %s: %v", key, err)
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ProfileHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ProfileHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Profiles
+// =====================================
+
+// SetupProfileRoutes configures profile routes
+func SetupProfileRoutes(router *mux.Router, h *ProfileHandler) {
+	router.HandleFunc("/users/{id}/profile", h.GetProfile).Methods("GET")
+	router.HandleFunc("/users/{id}/profile", h.PutProfile).Methods("PUT")
+	router.HandleFunc("/users/{id}/profile", h.DeleteProfile).Methods("DELETE")
+	router.HandleFunc("/users/{id}/avatar", h.UploadAvatar).Methods("POST")
+}
+
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// ProjectArchiveHandler exposes the project auto-archive job over HTTP
+type ProjectArchiveHandler struct {
+	archiver *services.ProjectArchiver
+	logger   *log.Logger
+}
+
+// NewProjectArchiveHandler creates a new ProjectArchiveHandler instance
+func NewProjectArchiveHandler(archiver *services.ProjectArchiver, logger *log.Logger) *ProjectArchiveHandler {
+	return &ProjectArchiveHandler{
+		archiver: archiver,
+		logger:   logger,
+	}
+}
+
+// =====================================
+// Project Archive HTTP Handlers
+// =====================================
+
+// GetProjectArchive handles GET /admin/v1/project-archive - returns settings,
+// the latest run, and recent archive events
+func (h *ProjectArchiveHandler) GetProjectArchive(w http.ResponseWriter, r *http.Request) {
+	config := h.archiver.Config()
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project auto-archive retrieved successfully",
+		Data: map[string]interface{}{
+			"enabled":  h.archiver.Enabled(),
+			"after":    config.After.String(),
+			"interval": config.Interval.String(),
+			"dry_run":  config.DryRun,
+			"last_run": h.archiver.LastRun(),
+			"events":   h.archiver.Events(),
+		},
+	})
+}
+
+// RunProjectArchive handles POST /admin/v1/project-archive/run - runs the archiver immediately
+func (h *ProjectArchiveHandler) RunProjectArchive(w http.ResponseWriter, r *http.Request) {
+	report, err := h.archiver.Archive(r.Context(), models.Now())
+	if err != nil {
+		if errors.Is(err, services.ErrProjectArchiveDisabled) {
+			h.respondError(w, http.StatusConflict, "Project auto-archive is disabled; set PROJECT_ARCHIVE_AFTER to enable it")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to archive stale projects")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project auto-archive completed successfully",
+		Data:    report,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ProjectArchiveHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ProjectArchiveHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Project Archive
+// =====================================
+
+// SetupProjectArchiveRoutes configures project auto-archive routes on the
+// admin router
+func SetupProjectArchiveRoutes(router *mux.Router, h *ProjectArchiveHandler) {
+	router.HandleFunc("/project-archive", h.GetProjectArchive).Methods("GET")
+	router.HandleFunc("/project-archive/run", h.RunProjectArchive).Methods("POST")
+}
+
@@ -0,0 +1,615 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// ProjectHandler wraps the project service and provides HTTP handlers
+type ProjectHandler struct {
+	service *services.ProjectService
+	logger  *log.Logger
+}
+
+// NewProjectHandler creates a new ProjectHandler instance
+func NewProjectHandler(service *services.ProjectService, logger *log.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Project HTTP Handlers
+// =====================================
+
+// GetProjects handles GET /projects - returns all projects, or one page of
+// them with cursor-pagination
+// Archived projects are only included with ?include_deleted=true
+func (h *ProjectHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	projects, next, err := h.service.ReadProjectsPage(r.Context(), includeDeleted(r), requestPage(r))
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Projects retrieved successfully",
+		Data:    projects,
+		Meta:    pageMeta(r, len(projects), next),
+	})
+}
+
+// GetProject handles GET /projects/{id} - returns a specific project
+// Archived projects are only returned with ?include_deleted=true
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var project *models.Project
+	var err error
+	if includeDeleted(r) {
+		project, err = h.service.ReadProjectIncludingDeleted(ctx, id)
+	} else {
+		project, err = h.service.ReadProject(ctx, id)
+	}
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(project.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project retrieved successfully",
+		Data:    project,
+	})
+}
+
+// GetOrgProjects handles GET /organizations/{id}/projects - returns an organization's projects
+func (h *ProjectHandler) GetOrgProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgID := vars["id"]
+
+	projects, err := h.service.ReadProjectsByOrg(ctx, orgID)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Projects retrieved successfully",
+		Data:    projects,
+	})
+}
+
+// CreateProject handles POST /projects - creates a new project
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var input struct {
+		Name        string               `json:"name" validate:"required"`
+		Description string               `json:"description"`
+		OwnerID     string               `json:"owner_id" validate:"required"`
+		OrgID       string               `json:"org_id" validate:"required"`
+		Status      models.ProjectStatus `json:"status" validate:"oneof=active archived draft"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	project := services.CreateProject(h.service.NewID(services.IDPrefixProject), input.Name, input.OwnerID, input.OrgID)
+	project.UpdateDescription(input.Description)
+	if input.Status != "" {
+		project.SetStatus(input.Status)
+	}
+
+	if err := h.service.WriteProject(ctx, project); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("Created project: %s (%s)", project.DisplayName(), project.ID)
+
+	w.Header().Set("ETag", versionETag(project.Version))
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project created successfully",
+		Data:    project,
+	})
+}
+
+// UpdateProject handles PUT /projects/{id} - updates an existing project
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadProject(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if !ifMatchSatisfied(r, existing.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Project has been modified")
+		return
+	}
+
+	// Work on a copy so a rejected write leaves the stored project untouched
+	updated := *existing
+	project := &updated
+
+	var input struct {
+		Name        string               `json:"name"`
+		Description string               `json:"description"`
+		Status      models.ProjectStatus `json:"status" validate:"oneof=active archived draft"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	if input.Name != "" {
+		project.UpdateName(input.Name)
+	}
+	if input.Description != "" {
+		project.UpdateDescription(input.Description)
+	}
+	if input.Status == models.ProjectStatusArchived {
+		project.Archive()
+	} else if input.Status != "" {
+		project.SetStatus(input.Status)
+	}
+
+	if err := h.service.WriteProject(ctx, project); err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.respondError(w, http.StatusConflict, "Project has been modified")
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to update project")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(project.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project updated successfully",
+		Data:    project,
+	})
+}
+
+// DeleteProject handles DELETE /projects/{id} - archives a project
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	existing, err := h.service.ReadProject(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if !ifMatchSatisfied(r, existing.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Project has been modified")
+		return
+	}
+
+	updated := *existing
+	updated.Archive()
+	if err := h.service.WriteProject(ctx, &updated); err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to delete project")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project deleted successfully",
+	})
+}
+
+// TransferProject handles POST /projects/{id}/transfer - moves a project to another organization
+// on behalf of the user named in X-Acting-User
+func (h *ProjectHandler) TransferProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	actor := r.Header.Get(ActingUserHeader)
+	if actor == "" {
+		h.respondError(w, http.StatusUnauthorized, ActingUserHeader+" header is required")
+		return
+	}
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	var input struct {
+		TargetOrgID string `json:"target_org_id" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	transfer, err := h.service.TransferProject(ctx, id, input.TargetOrgID, actor)
+	if err != nil {
+		if errors.Is(err, services.ErrTransferNotAllowed) {
+			h.respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("event=project.transferred project=%s from=%s to=%s actor=%s added_memberships=%d dropped_assignments=%d dropped_grants=%d",
+		id, transfer.FromOrgID, transfer.ToOrgID, transfer.RequestedBy, len(transfer.AddedMemberships), len(transfer.DroppedAssignments), len(transfer.DroppedGrants))
+	for _, userID := range transfer.DroppedAssignments {
+		h.logger.Printf("event=project.member_removed project=%s user=%s actor=%s", id, userID, transfer.RequestedBy)
+	}
+	for _, userID := range transfer.DroppedGrants {
+		h.logger.Printf("event=project.guest_revoked project=%s user=%s actor=%s", id, userID, transfer.RequestedBy)
+	}
+
+	w.Header().Set("ETag", versionETag(transfer.Project.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project transferred successfully",
+		Data:    transfer,
+	})
+}
+
+// =====================================
+// Guest Access HTTP Handlers
+// =====================================
+
+// GetProjectGuests handles GET /projects/{id}/guests - lists guest grants
+func (h *ProjectHandler) GetProjectGuests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	grants, err := h.service.ListGuests(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch guests")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Guests retrieved successfully",
+		Data:    grants,
+	})
+}
+
+// AddProjectGuest handles POST /projects/{id}/guests - grants guest access to a user from another organization
+// on behalf of the user named in X-Acting-User
+func (h *ProjectHandler) AddProjectGuest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	actor := r.Header.Get(ActingUserHeader)
+	if actor == "" {
+		h.respondError(w, http.StatusUnauthorized, ActingUserHeader+" header is required")
+		return
+	}
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	var input struct {
+		UserID    string                  `json:"user_id" validate:"required"`
+		HomeOrgID string                  `json:"home_org_id" validate:"required"`
+		Role      models.ProjectGrantRole `json:"role"`
+		ExpiresAt *time.Time              `json:"expires_at"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	if input.Role == "" {
+		input.Role = models.ProjectGrantViewer
+	}
+
+	grant := models.NewProjectGrant(h.service.NewID(services.IDPrefixGrant), id, input.UserID, input.HomeOrgID, input.Role, actor)
+	grant.ExpiresAt = input.ExpiresAt
+
+	if err := h.service.GrantGuestAccess(ctx, grant); err != nil {
+		if errors.Is(err, services.ErrGrantNotAllowed) {
+			h.respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.logger.Printf("Granted %s guest access to project %s for %s (home org %s)", grant.Role, id, grant.UserID, grant.HomeOrgID)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Guest access granted successfully",
+		Data:    grant,
+	})
+}
+
+// RemoveProjectGuest handles DELETE /projects/{id}/guests/{userId} - revokes guest access
+func (h *ProjectHandler) RemoveProjectGuest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["userId"]
+
+	if err := h.service.RevokeGuestAccess(ctx, id, userID); err != nil {
+		h.respondError(w, http.StatusNotFound, "Grant not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Guest access revoked successfully",
+	})
+}
+
+// GetProjectAccess handles GET /projects/{id}/access/{userId} - reports how a user can reach a project
+func (h *ProjectHandler) GetProjectAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["userId"]
+
+	access, err := h.service.CheckAccess(ctx, userID, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project access retrieved successfully",
+		Data:    access,
+	})
+}
+
+// =====================================
+// Project Member HTTP Handlers
+// =====================================
+
+// GetProjectMembers handles GET /projects/{id}/members - lists the members assigned to a project
+func (h *ProjectHandler) GetProjectMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	members, err := h.service.ListMembers(ctx, id)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project members retrieved successfully",
+		Data:    members,
+	})
+}
+
+// AddProjectMember handles POST /projects/{id}/members - assigns a member of the project's organization,
+// e.g. {"user_id":"u2","role":"contributor","assigned_by":"u1"}
+func (h *ProjectHandler) AddProjectMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	var input struct {
+		UserID     string                   `json:"user_id" validate:"required"`
+		Role       models.ProjectMemberRole `json:"role"`
+		AssignedBy string                   `json:"assigned_by" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	if input.Role == "" {
+		input.Role = models.ProjectMemberContributor
+	}
+
+	member := models.NewProjectMember(h.service.NewID(services.IDPrefixProjectMember), id, input.UserID, input.Role, input.AssignedBy)
+	if err := h.service.AssignMember(ctx, member); err != nil {
+		h.respondMemberError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=project.member_assigned project=%s user=%s role=%s actor=%s", id, member.UserID, member.Role, member.AssignedBy)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project member assigned successfully",
+		Data:    member,
+	})
+}
+
+// UpdateProjectMember handles PUT /projects/{id}/members/{userId} - changes an assigned member's role,
+// e.g. {"role":"lead","updated_by":"u1"}
+func (h *ProjectHandler) UpdateProjectMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["userId"]
+
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	var input struct {
+		Role      models.ProjectMemberRole `json:"role" validate:"required,oneof=lead contributor viewer"`
+		UpdatedBy string                   `json:"updated_by" validate:"required"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	member, err := h.service.UpdateMemberRole(ctx, id, userID, input.Role, input.UpdatedBy)
+	if err != nil {
+		h.respondMemberError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=project.member_updated project=%s user=%s role=%s actor=%s", id, userID, member.Role, input.UpdatedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project member updated successfully",
+		Data:    member,
+	})
+}
+
+// RemoveProjectMember handles DELETE /projects/{id}/members/{userId}?removed_by= - unassigns a member
+func (h *ProjectHandler) RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["userId"]
+	removedBy := r.URL.Query().Get("removed_by")
+
+	if _, err := h.service.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if err := h.service.UnassignMember(ctx, id, userID, removedBy); err != nil {
+		h.respondMemberError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=project.member_removed project=%s user=%s actor=%s", id, userID, removedBy)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Project member removed successfully",
+	})
+}
+
+// GetUserProjects handles GET /users/{id}/projects - returns projects visible to a user,
+// through organization membership or guest grants
+func (h *ProjectHandler) GetUserProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	projects, err := h.service.ReadProjectsVisibleTo(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "User projects retrieved successfully",
+		Data:    projects,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondMemberError maps project membership errors to HTTP statuses (pointer receiver)
+func (h *ProjectHandler) respondMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrAssignmentNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrAssigneeNotInOrg):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrProjectMemberNotFound):
+		h.respondError(w, http.StatusNotFound, "Project member not found")
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ProjectHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ProjectHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Projects
+// =====================================
+
+// SetupProjectRoutes configures project routes
+func SetupProjectRoutes(router *mux.Router, h *ProjectHandler) {
+	router.HandleFunc("/projects", h.GetProjects).Methods("GET")
+	router.HandleFunc("/projects/{id}", h.GetProject).Methods("GET")
+	router.HandleFunc("/projects", h.CreateProject).Methods("POST")
+	router.HandleFunc("/projects/{id}", h.UpdateProject).Methods("PUT")
+	router.HandleFunc("/projects/{id}", h.DeleteProject).Methods("DELETE")
+	router.HandleFunc("/projects/{id}/transfer", h.TransferProject).Methods("POST")
+
+	// Guest access routes
+	router.HandleFunc("/projects/{id}/guests", h.GetProjectGuests).Methods("GET")
+	router.HandleFunc("/projects/{id}/guests", h.AddProjectGuest).Methods("POST")
+	router.HandleFunc("/projects/{id}/guests/{userId}", h.RemoveProjectGuest).Methods("DELETE")
+	router.HandleFunc("/projects/{id}/access/{userId}", h.GetProjectAccess).Methods("GET")
+
+	// Project membership
+	router.HandleFunc("/projects/{id}/members", h.GetProjectMembers).Methods("GET")
+	router.HandleFunc("/projects/{id}/members", h.AddProjectMember).Methods("POST")
+	router.HandleFunc("/projects/{id}/members/{userId}", h.UpdateProjectMember).Methods("PUT")
+	router.HandleFunc("/projects/{id}/members/{userId}", h.RemoveProjectMember).Methods("DELETE")
+
+	router.HandleFunc("/organizations/{id}/projects", h.GetOrgProjects).Methods("GET")
+	router.HandleFunc("/users/{id}/projects", h.GetUserProjects).Methods("GET")
+}
+
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestProjectRouter serves the project routes with proj_a in org_src;
+// user_owner owns org_src and org_dst, user_outsider belongs to neither
+func newTestProjectRouter(t *testing.T) (*mux.Router, *services.ProjectService) {
+	t.Helper()
+	ctx := context.Background()
+	orgs := services.NewOrganizationService()
+	projects := services.NewProjectService(orgs)
+
+	for _, id := range []string{"org_src", "org_dst", "org_other"} {
+		if err := orgs.WriteOrg(ctx, models.NewOrganization(id, id, "user_owner")); err != nil {
+			t.Fatalf("WriteOrg(%s): %v", id, err)
+		}
+	}
+	for _, m := range []struct {
+		user, org string
+		role      models.MemberRole
+	}{
+		{"user_owner", "org_src", models.MemberRoleOwner},
+		{"user_owner", "org_dst", models.MemberRoleOwner},
+		{"user_guest", "org_other", models.MemberRoleMember},
+		{"user_outsider", "org_other", models.MemberRoleMember},
+	} {
+		if err := orgs.AddMember(ctx, services.CreateMembership(m.user, m.org, m.role)); err != nil {
+			t.Fatalf("AddMember(%s, %s): %v", m.user, m.org, err)
+		}
+	}
+	if err := projects.WriteProject(ctx, services.CreateProject("proj_a", "Alpha", "user_owner", "org_src")); err != nil {
+		t.Fatalf("WriteProject: %v", err)
+	}
+
+	router := mux.NewRouter()
+	SetupProjectRoutes(router, NewProjectHandler(projects, log.New(io.Discard, "", 0)))
+	return router, projects
+}
+
+func TestProjectActorComesFromHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		body  string
+		actor string
+		want  int
+	}{
+		{"transfer by outsider", "/projects/proj_a/transfer", `{"target_org_id":"org_dst"}`, "user_outsider", http.StatusForbidden},
+		{"transfer without actor", "/projects/proj_a/transfer", `{"target_org_id":"org_dst"}`, "", http.StatusUnauthorized},
+		{"transfer naming owner in body", "/projects/proj_a/transfer", `{"target_org_id":"org_dst","requested_by":"user_owner"}`, "user_outsider", http.StatusBadRequest},
+		{"guest by outsider", "/projects/proj_a/guests", `{"user_id":"user_guest","home_org_id":"org_other"}`, "user_outsider", http.StatusForbidden},
+		{"guest without actor", "/projects/proj_a/guests", `{"user_id":"user_guest","home_org_id":"org_other"}`, "", http.StatusUnauthorized},
+		{"guest naming owner in body", "/projects/proj_a/guests", `{"user_id":"user_guest","home_org_id":"org_other","granted_by":"user_owner"}`, "user_outsider", http.StatusBadRequest},
+		{"guest by owner", "/projects/proj_a/guests", `{"user_id":"user_guest","home_org_id":"org_other"}`, "user_owner", http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, projects := newTestProjectRouter(t)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.actor != "" {
+				req.Header.Set(ActingUserHeader, tt.actor)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+
+			project, err := projects.ReadProject(context.Background(), "proj_a")
+			if err != nil {
+				t.Fatalf("ReadProject: %v", err)
+			}
+			if project.OrgID != "org_src" {
+				t.Errorf("project moved to %s", project.OrgID)
+			}
+		})
+	}
+}
+
+func TestTransferProjectByOwner(t *testing.T) {
+	router, projects := newTestProjectRouter(t)
+	req := httptest.NewRequest(http.MethodPost, "/projects/proj_a/transfer", strings.NewReader(`{"target_org_id":"org_dst"}`))
+	req.Header.Set(ActingUserHeader, "user_owner")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	project, err := projects.ReadProject(context.Background(), "proj_a")
+	if err != nil {
+		t.Fatalf("ReadProject: %v", err)
+	}
+	if project.OrgID != "org_dst" {
+		t.Errorf("OrgID = %s, want org_dst", project.OrgID)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// publicProfileMaxAge is how long shared caches may serve a public profile
+const publicProfileMaxAge = "300"
+
+// PublicHandler serves unauthenticated, read-only views of opted-in organizations
+type PublicHandler struct {
+	orgService *services.OrganizationService
+	logger     *log.Logger
+}
+
+// NewPublicHandler creates a new PublicHandler instance
+func NewPublicHandler(orgService *services.OrganizationService, logger *log.Logger) *PublicHandler {
+	return &PublicHandler{
+		orgService: orgService,
+		logger:     logger,
+	}
+}
+
+// =====================================
+// Public HTTP Handlers
+// =====================================
+
+// GetPublicDirectory handles GET /public/organizations?q=term - lists opted-in
+// organizations whose name, description, or industry matches the search term
+func (h *PublicHandler) GetPublicDirectory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgs, err := h.orgService.FindPublicOrgs(ctx, r.URL.Query().Get("q"))
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch organizations")
+		return
+	}
+
+	profiles := make([]models.PublicOrgProfile, 0, len(orgs))
+	for _, org := range orgs {
+		profiles = append(profiles, org.PublicProfile())
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age="+publicProfileMaxAge)
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization directory retrieved successfully",
+		Data:    profiles,
+	})
+}
+
+// GetPublicOrganization handles GET /public/organizations/{slug} - returns a public profile.
+// Organizations that have not opted in are reported as not found.
+func (h *PublicHandler) GetPublicOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	slug := vars["slug"]
+
+	org, err := h.orgService.FindOrgBySlug(ctx, slug)
+	if err != nil || !org.HasPublicProfile() {
+		w.Header().Set("X-Robots-Tag", "noindex")
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	etag := "W/" + versionETag(org.Version)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", org.UpdatedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "public, max-age="+publicProfileMaxAge)
+	w.Header().Set("X-Robots-Tag", "index, follow")
+
+	if noneMatchSatisfied(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization profile retrieved successfully",
+		Data:    org.PublicProfile(),
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// noneMatchSatisfied reports whether If-None-Match names the current ETag,
+// using weak comparison as required for GET
+func noneMatchSatisfied(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *PublicHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *PublicHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Public Profiles
+// =====================================
+
+// SetupPublicRoutes configures unauthenticated routes on the root router,
+// outside the /api/v1 prefix
+func SetupPublicRoutes(router *mux.Router, h *PublicHandler) {
+	router.HandleFunc("/public/organizations", h.GetPublicDirectory).Methods("GET")
+	router.HandleFunc("/public/organizations/{slug}", h.GetPublicOrganization).Methods("GET")
+}
+
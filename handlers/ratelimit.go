@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Rate limit and quota response headers. Reset values are the whole
+// seconds until the window starts over.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+	QuotaLimitHeader         = "X-Quota-Limit"
+	QuotaRemainingHeader     = "X-Quota-Remaining"
+	QuotaResetHeader         = "X-Quota-Reset"
+)
+
+// Default windows used when a RateLimitConfig window is zero
+const (
+	DefaultRateLimitWindow = time.Minute
+	DefaultQuotaPeriod     = 24 * time.Hour
+)
+
+// Default limits per support tier, applied unless configured otherwise
+const (
+	DefaultRateLimit         = 300
+	DefaultQuota             = 50000
+	DefaultPriorityRateLimit = 1200
+	DefaultPriorityQuota     = 250000
+)
+
+// maxRateLimitClients bounds how many clients are tracked before expired
+// windows are swept
+const maxRateLimitClients = 10000
+
+// RateLimitConfig configures RateLimitMiddleware. Limit and Quota apply
+// to standard-tier callers; callers in a priority-support organization get
+// PriorityLimit and PriorityQuota, or the standard ones when those are
+// zero. A zero Limit or Quota turns that check off for every tier.
+type RateLimitConfig struct {
+	Limit         int           // Requests per client per Window
+	Window        time.Duration // Short window, e.g. a minute
+	Quota         int           // Requests per client per QuotaPeriod
+	QuotaPeriod   time.Duration // Long window, e.g. a day
+	PriorityLimit int           // Requests per Window for priority-tier callers
+	PriorityQuota int           // Requests per QuotaPeriod for priority-tier callers
+}
+
+// DefaultRateLimitConfig returns the limits the server ships with
+// (standalone function)
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Limit:         DefaultRateLimit,
+		Window:        DefaultRateLimitWindow,
+		Quota:         DefaultQuota,
+		QuotaPeriod:   DefaultQuotaPeriod,
+		PriorityLimit: DefaultPriorityRateLimit,
+		PriorityQuota: DefaultPriorityQuota,
+	}
+}
+
+// limits returns the rate limit and quota for a support tier (value receiver)
+func (c RateLimitConfig) limits(tier models.SupportTier) (int, int) {
+	if tier != models.SupportTierPriority {
+		return c.Limit, c.Quota
+	}
+	limit, quota := c.Limit, c.Quota
+	if limit > 0 && c.PriorityLimit > 0 {
+		limit = c.PriorityLimit
+	}
+	if quota > 0 && c.PriorityQuota > 0 {
+		quota = c.PriorityQuota
+	}
+	return limit, quota
+}
+
+// usageWindow counts requests in one fixed window
+type usageWindow struct {
+	start time.Time
+	count int
+}
+
+// clientUsage is one client's rate and quota windows, and the support
+// tier it was last seen with
+type clientUsage struct {
+	rate  usageWindow
+	quota usageWindow
+	tier  models.SupportTier
+}
+
+// rateLimiter counts requests per client in fixed windows
+type rateLimiter struct {
+	config  RateLimitConfig
+	clients map[string]*clientUsage
+	mu      sync.Mutex
+}
+
+// usageReport is what a client has left after a request
+type usageReport struct {
+	allowed        bool
+	tier           models.SupportTier
+	limit          int
+	quota          int
+	rateRemaining  int
+	rateReset      time.Duration
+	quotaRemaining int
+	quotaReset     time.Duration
+}
+
+// RateLimitMiddleware limits each client to Limit requests per Window and
+// Quota requests per QuotaPeriod, or the priority limits when the client
+// belongs to a priority-support organization. A client is the acting user
+// named in X-Acting-User, or the address for anonymous requests; its tier
+// is looked up when the client is first seen and again each Window. Every
+// response carries X-RateLimit-* and X-Quota-* headers describing what the
+// client has left, so clients can pace themselves before they are refused;
+// a client over either limit gets 429 with Retry-After. Refused requests
+// do not count.
+func RateLimitMiddleware(orgs *services.OrganizationService, logger *log.Logger, config RateLimitConfig) mux.MiddlewareFunc {
+	if config.Window <= 0 {
+		config.Window = DefaultRateLimitWindow
+	}
+	if config.QuotaPeriod <= 0 {
+		config.QuotaPeriod = DefaultQuotaPeriod
+	}
+	limiter := &rateLimiter{
+		config:  config,
+		clients: make(map[string]*clientUsage),
+	}
+
+	return func(next http.Handler) http.Handler {
+		if config.Limit <= 0 && config.Quota <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, user := rateLimitClient(r)
+			report := limiter.take(client, time.Now(), func() models.SupportTier {
+				return callerTier(r.Context(), orgs, user)
+			})
+			header := w.Header()
+			if report.limit > 0 {
+				header.Set(RateLimitLimitHeader, strconv.Itoa(report.limit))
+				header.Set(RateLimitRemainingHeader, strconv.Itoa(report.rateRemaining))
+				header.Set(RateLimitResetHeader, strconv.Itoa(ceilSeconds(report.rateReset)))
+			}
+			if report.quota > 0 {
+				header.Set(QuotaLimitHeader, strconv.Itoa(report.quota))
+				header.Set(QuotaRemainingHeader, strconv.Itoa(report.quotaRemaining))
+				header.Set(QuotaResetHeader, strconv.Itoa(ceilSeconds(report.quotaReset)))
+			}
+
+			if !report.allowed {
+				retryAfter := report.rateReset
+				if report.quota > 0 && report.quotaRemaining == 0 {
+					retryAfter = report.quotaReset
+				}
+				logger.Printf("rate limited client=%s tier=%s method=%s path=%s retry_after=%ds", client, report.tier, r.Method, r.URL.Path, ceilSeconds(retryAfter))
+
+				header.Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+				writeJSON(w, logger, http.StatusTooManyRequests, models.NewErrorResponse(http.StatusTooManyRequests, i18n.T(responseLocale(w), i18n.MsgRateLimitExceeded)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on rateLimiter
+// =====================================
+
+// take counts a request from a client if it is within both of its tier's
+// limits and reports what the client has left. The tier is resolved for
+// new clients and whenever the rate window starts over (pointer receiver).
+func (l *rateLimiter) take(client string, now time.Time, resolveTier func() models.SupportTier) usageReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage, exists := l.clients[client]
+	if !exists {
+		if len(l.clients) >= maxRateLimitClients {
+			l.sweep(now)
+		}
+		usage = &clientUsage{}
+		l.clients[client] = usage
+	}
+	if usage.rate.roll(now, l.config.Window) || !exists {
+		usage.tier = resolveTier()
+	}
+	usage.quota.roll(now, l.config.QuotaPeriod)
+
+	limit, quota := l.config.limits(usage.tier)
+	allowed := (limit <= 0 || usage.rate.count < limit) &&
+		(quota <= 0 || usage.quota.count < quota)
+	if allowed {
+		usage.rate.count++
+		usage.quota.count++
+	}
+
+	return usageReport{
+		allowed:        allowed,
+		tier:           usage.tier,
+		limit:          limit,
+		quota:          quota,
+		rateRemaining:  remaining(limit, usage.rate.count),
+		rateReset:      usage.rate.start.Add(l.config.Window).Sub(now),
+		quotaRemaining: remaining(quota, usage.quota.count),
+		quotaReset:     usage.quota.start.Add(l.config.QuotaPeriod).Sub(now),
+	}
+}
+
+// sweep drops clients whose windows have both ended; callers hold l.mu
+// (pointer receiver)
+func (l *rateLimiter) sweep(now time.Time) {
+	for client, usage := range l.clients {
+		if !now.Before(usage.rate.start.Add(l.config.Window)) && !now.Before(usage.quota.start.Add(l.config.QuotaPeriod)) {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// roll starts a new window once the current one has ended and reports
+// whether it did. Windows are aligned to multiples of their length, so a
+// daily quota resets at midnight UTC (pointer receiver).
+func (w *usageWindow) roll(now time.Time, length time.Duration) bool {
+	start := now.Truncate(length)
+	if w.start.Equal(start) {
+		return false
+	}
+	w.start = start
+	w.count = 0
+	return true
+}
+
+// =====================================
+// Helper Functions
+// =====================================
+
+// rateLimitClient returns the key a request is counted under and the
+// acting user, if any: acting users are counted across addresses,
+// anonymous requests by address (standalone function)
+func rateLimitClient(r *http.Request) (string, string) {
+	if user := r.Header.Get(ActingUserHeader); user != "" {
+		return "user:" + user, user
+	}
+	return "addr:" + clientAddress(r), ""
+}
+
+// callerTier returns the best support tier among the organizations a user
+// belongs to; anonymous callers are standard tier (standalone function)
+func callerTier(ctx context.Context, orgs *services.OrganizationService, userID string) models.SupportTier {
+	if orgs == nil || userID == "" {
+		return models.SupportTierStandard
+	}
+	return orgs.SupportTierOf(ctx, userID)
+}
+
+// clientAddress returns the host part of the client address (standalone function)
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// remaining returns how many requests are left under a limit (standalone function)
+func remaining(limit, used int) int {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// ceilSeconds rounds a duration up to whole seconds (standalone function)
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// newTestRateLimitRouter serves GET /ping behind RateLimitMiddleware;
+// user_priority belongs to a priority-support organization, user_standard
+// to a standard one
+func newTestRateLimitRouter(t *testing.T, config RateLimitConfig) *mux.Router {
+	t.Helper()
+	ctx := context.Background()
+	orgs := services.NewOrganizationService()
+
+	priority := models.NewOrganization("org_priority", "Priority", "user_priority")
+	priority.Support.Tier = models.SupportTierPriority
+	for _, org := range []*models.Organization{priority, models.NewOrganization("org_standard", "Standard", "user_standard")} {
+		if err := orgs.WriteOrg(ctx, org); err != nil {
+			t.Fatalf("WriteOrg(%s): %v", org.ID, err)
+		}
+		if err := orgs.AddMember(ctx, services.CreateMembership(org.OwnerID, org.ID, models.MemberRoleOwner)); err != nil {
+			t.Fatalf("AddMember(%s): %v", org.OwnerID, err)
+		}
+	}
+
+	router := mux.NewRouter()
+	router.Use(RateLimitMiddleware(orgs, log.New(io.Discard, "", 0), config))
+	router.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodGet)
+	return router
+}
+
+func TestRateLimitMiddlewareTiers(t *testing.T) {
+	config := RateLimitConfig{Limit: 2, Quota: 100, PriorityLimit: 4, PriorityQuota: 200}
+
+	tests := []struct {
+		name      string
+		actor     string
+		addr      string
+		wantLimit int
+		wantQuota int
+	}{
+		{"standard member", "user_standard", "10.0.0.1:1000", 2, 100},
+		{"priority member", "user_priority", "10.0.0.1:1000", 4, 200},
+		{"non-member", "user_nobody", "10.0.0.1:1000", 2, 100},
+		{"anonymous", "", "10.0.0.2:1000", 2, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRateLimitRouter(t, config)
+			for i := 0; i <= tt.wantLimit; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+				req.RemoteAddr = tt.addr
+				if tt.actor != "" {
+					req.Header.Set(ActingUserHeader, tt.actor)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				want := http.StatusNoContent
+				if i == tt.wantLimit {
+					want = http.StatusTooManyRequests
+				}
+				if rec.Code != want {
+					t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, want)
+				}
+				if got := rec.Header().Get(RateLimitLimitHeader); got != strconv.Itoa(tt.wantLimit) {
+					t.Errorf("request %d: %s = %s, want %d", i+1, RateLimitLimitHeader, got, tt.wantLimit)
+				}
+				if got := rec.Header().Get(QuotaLimitHeader); got != strconv.Itoa(tt.wantQuota) {
+					t.Errorf("request %d: %s = %s, want %d", i+1, QuotaLimitHeader, got, tt.wantQuota)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddlewareCountsActingUsersSeparately(t *testing.T) {
+	router := newTestRateLimitRouter(t, RateLimitConfig{Limit: 1})
+
+	for _, actor := range []string{"user_standard", "user_nobody", ""} {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = "10.0.0.1:1000"
+		if actor != "" {
+			req.Header.Set(ActingUserHeader, actor)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("actor %q from a shared address: status = %d, want %d", actor, rec.Code, http.StatusNoContent)
+		}
+	}
+}
+
+func TestDefaultRateLimitConfigIsOn(t *testing.T) {
+	config := DefaultRateLimitConfig()
+	if config.Limit <= 0 || config.Quota <= 0 {
+		t.Fatalf("default limits are off: %+v", config)
+	}
+	for _, tier := range []models.SupportTier{models.SupportTierStandard, models.SupportTierPriority} {
+		limit, quota := config.limits(tier)
+		if limit <= 0 || quota <= 0 {
+			t.Errorf("%s tier: limits(%s) = %d, %d", tier, tier, limit, quota)
+		}
+	}
+	if priority, _ := config.limits(models.SupportTierPriority); priority <= config.Limit {
+		t.Errorf("priority limit %d is not above the standard %d", priority, config.Limit)
+	}
+}
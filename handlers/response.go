@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// writeJSON sends a JSON response. Every handler's respondJSON, and the
+// middleware that answer on their own, write through here so responses
+// share one envelope: APIResponse messages are localized and list data
+// is described in Meta (standalone function).
+func writeJSON(w http.ResponseWriter, logger *log.Logger, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(withMeta(localize(w, data))); err != nil {
+		logger.Printf("Error encoding response: %v", err)
+	}
+}
+
+// withMeta fills in the Meta of an APIResponse whose Data is a list and
+// has none yet; other payloads pass through unchanged (standalone function)
+func withMeta(data interface{}) interface{} {
+	switch response := data.(type) {
+	case models.APIResponse:
+		response.Meta = listMeta(response.Data, response.Meta)
+		return response
+	case *models.APIResponse:
+		described := *response
+		described.Meta = listMeta(response.Data, response.Meta)
+		return &described
+	}
+	return data
+}
+
+// listMeta returns meta, or a new ResponseMeta counting data when it is a
+// list (standalone function)
+func listMeta(data interface{}, meta *models.ResponseMeta) *models.ResponseMeta {
+	if meta != nil || data == nil {
+		return meta
+	}
+	value := reflect.ValueOf(data)
+	if kind := value.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil
+	}
+	return &models.ResponseMeta{Count: value.Len()}
+}
+
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// RetentionHandler exposes the retention janitor over HTTP
+type RetentionHandler struct {
+	janitor *services.RetentionJanitor
+	logger  *log.Logger
+}
+
+// NewRetentionHandler creates a new RetentionHandler instance
+func NewRetentionHandler(janitor *services.RetentionJanitor, logger *log.Logger) *RetentionHandler {
+	return &RetentionHandler{
+		janitor: janitor,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Retention HTTP Handlers
+// =====================================
+
+// GetRetentionStats handles GET /admin/v1/retention - returns settings and purge counts
+func (h *RetentionHandler) GetRetentionStats(w http.ResponseWriter, r *http.Request) {
+	config := h.janitor.Config()
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Retention stats retrieved successfully",
+		Data: map[string]interface{}{
+			"window":   config.Window.String(),
+			"interval": config.Interval.String(),
+			"dry_run":  config.DryRun,
+			"stats":    h.janitor.Stats(),
+		},
+	})
+}
+
+// Purge handles POST /admin/v1/retention/purge - runs the janitor immediately
+func (h *RetentionHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.janitor.Purge(ctx, models.Now())
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to purge deleted records")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Retention purge completed successfully",
+		Data:    report,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *RetentionHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *RetentionHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Retention
+// =====================================
+
+// SetupRetentionRoutes configures retention routes on the admin router
+func SetupRetentionRoutes(router *mux.Router, h *RetentionHandler) {
+	router.HandleFunc("/retention", h.GetRetentionStats).Methods("GET")
+	router.HandleFunc("/retention/purge", h.Purge).Methods("POST")
+}
+
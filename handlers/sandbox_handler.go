@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// SandboxHeader selects the sandbox a request is served from. Responses
+// served from a sandbox carry it too, so clients can tell them apart.
+const SandboxHeader = "X-Sandbox"
+
+// sandboxPathPrefix is where sandboxes are addressable by subpath, e.g.
+// /sandboxes/sbx_1/api/v1/users
+const sandboxPathPrefix = "/sandboxes/"
+
+// SandboxHandler manages organization sandboxes and serves API requests
+// addressed to one
+type SandboxHandler struct {
+	service     *services.SandboxService
+	logger      *log.Logger
+	permissions PermissionConfig
+	routers     map[string]sandboxRouter // Sandbox ID -> router over its current environment
+	mu          sync.Mutex
+}
+
+// sandboxRouter is the API router built over one sandbox environment
+type sandboxRouter struct {
+	env    *services.SandboxEnvironment
+	router *mux.Router
+}
+
+// NewSandboxHandler creates a new SandboxHandler instance
+func NewSandboxHandler(service *services.SandboxService, logger *log.Logger, permissions PermissionConfig) *SandboxHandler {
+	return &SandboxHandler{
+		service:     service,
+		logger:      logger,
+		permissions: permissions,
+		routers:     make(map[string]sandboxRouter),
+	}
+}
+
+// =====================================
+// Sandbox HTTP Handlers
+// =====================================
+
+// CreateSandbox handles POST /organizations/{id}/sandbox - copies the
+// organization into a new sandbox, e.g. {"created_by":"u1"}
+func (h *SandboxHandler) CreateSandbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+
+	var input struct {
+		CreatedBy string `json:"created_by" validate:"max=100"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	sandbox, err := h.service.CreateSandbox(ctx, orgID, sandboxRequester(r, input.CreatedBy))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=sandbox.created sandbox=%s org=%s users=%d projects=%d", sandbox.ID, orgID, sandbox.Users, sandbox.Projects)
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Sandbox created successfully",
+		Data:    sandbox,
+	})
+}
+
+// GetSandbox handles GET /organizations/{id}/sandbox - retrieves the
+// organization's sandbox
+func (h *SandboxHandler) GetSandbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+
+	sandbox, err := h.service.ReadSandbox(ctx, orgID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Sandbox retrieved successfully",
+		Data:    sandbox,
+	})
+}
+
+// ResetSandbox handles POST /organizations/{id}/sandbox/reset - discards
+// sandbox writes and copies the organization again, e.g. {"requested_by":"u1"}
+func (h *SandboxHandler) ResetSandbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+
+	var input struct {
+		RequestedBy string `json:"requested_by" validate:"max=100"`
+	}
+
+	if r.ContentLength != 0 {
+		if !decodeRequest(w, h.logger, r, &input) {
+			return
+		}
+		if !validateRequest(w, h.logger, &input) {
+			return
+		}
+	}
+
+	sandbox, err := h.service.ResetSandbox(ctx, orgID, sandboxRequester(r, input.RequestedBy))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=sandbox.reset sandbox=%s org=%s resets=%d", sandbox.ID, orgID, sandbox.Resets)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Sandbox reset successfully",
+		Data:    sandbox,
+	})
+}
+
+// DeleteSandbox handles DELETE /organizations/{id}/sandbox?requested_by= -
+// removes the organization's sandbox
+func (h *SandboxHandler) DeleteSandbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+
+	if err := h.service.DeleteSandbox(ctx, orgID, sandboxRequester(r, r.URL.Query().Get("requested_by"))); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=sandbox.deleted org=%s", orgID)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Sandbox deleted successfully",
+	})
+}
+
+// GetSandboxes handles GET /admin/v1/sandboxes - lists every sandbox
+func (h *SandboxHandler) GetSandboxes(w http.ResponseWriter, r *http.Request) {
+	sandboxes, err := h.service.ListSandboxes(r.Context())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Sandboxes retrieved successfully",
+		Data:    sandboxes,
+	})
+}
+
+// ServeSandboxPath handles /sandboxes/{sandboxId}/... - serves the rest of
+// the path from the sandbox, e.g. /sandboxes/sbx_1/api/v1/users
+func (h *SandboxHandler) ServeSandboxPath(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["sandboxId"]
+	rest := strings.TrimPrefix(r.URL.Path, sandboxPathPrefix+id)
+
+	stripped := r.Clone(r.Context())
+	stripped.URL.Path = rest
+	stripped.URL.RawPath = ""
+	h.serveSandbox(w, stripped, id)
+}
+
+// =====================================
+// Sandbox Dispatch
+// =====================================
+
+// SandboxMiddleware serves API requests that carry an X-Sandbox header from
+// that sandbox instead of production
+func SandboxMiddleware(h *SandboxHandler) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(SandboxHeader)
+			if id == "" || !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h.serveSandbox(w, r, id)
+		})
+	}
+}
+
+// serveSandbox routes a request through the sandbox's own API router
+// (pointer receiver)
+func (h *SandboxHandler) serveSandbox(w http.ResponseWriter, r *http.Request, id string) {
+	env, err := h.service.Environment(id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set(SandboxHeader, id)
+	h.routerFor(env).ServeHTTP(w, r)
+}
+
+// routerFor returns the API router over a sandbox environment, building a
+// new one the first time and after every reset (pointer receiver)
+func (h *SandboxHandler) routerFor(env *services.SandboxEnvironment) *mux.Router {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := env.Sandbox.ID
+	if cached, exists := h.routers[id]; exists && cached.env == env {
+		return cached.router
+	}
+
+	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.respondError(w, http.StatusNotFound, "Route is not available in sandboxes")
+	})
+
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(PermissionMiddleware(env.Orgs, env.Projects, h.logger, h.permissions))
+	SetupUserRoutes(api, NewHandler(env.Users, h.logger), h.logger)
+	SetupOrgRoutes(api, NewOrgHandler(env.Orgs, h.logger))
+	SetupProjectRoutes(api, NewProjectHandler(env.Projects, h.logger))
+
+	// Drop routers of deleted sandboxes while here
+	for cachedID := range h.routers {
+		if _, err := h.service.Environment(cachedID); err != nil {
+			delete(h.routers, cachedID)
+		}
+	}
+	h.routers[id] = sandboxRouter{env: env, router: router}
+	return router
+}
+
+// respondServiceError maps sandbox service errors to HTTP statuses (pointer receiver)
+func (h *SandboxHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrSandboxNotFound):
+		h.respondError(w, http.StatusNotFound, "Sandbox not found")
+	case errors.Is(err, services.ErrSandboxOrgNotFound):
+		h.respondError(w, http.StatusNotFound, "Organization not found")
+	case errors.Is(err, services.ErrSandboxExists):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrSandboxNotAllowed):
+		h.respondError(w, http.StatusForbidden, err.Error())
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *SandboxHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *SandboxHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// sandboxRequester returns the user a sandbox request names, falling back
+// to the X-Acting-User header (standalone function)
+func sandboxRequester(r *http.Request, named string) string {
+	if named != "" {
+		return named
+	}
+	return r.Header.Get(ActingUserHeader)
+}
+
+// =====================================
+// Route Setup for Sandboxes
+// =====================================
+
+// SetupSandboxRoutes configures sandbox management routes
+func SetupSandboxRoutes(router *mux.Router, h *SandboxHandler) {
+	router.HandleFunc("/organizations/{id}/sandbox", h.GetSandbox).Methods("GET")
+	router.HandleFunc("/organizations/{id}/sandbox", h.CreateSandbox).Methods("POST")
+	router.HandleFunc("/organizations/{id}/sandbox", h.DeleteSandbox).Methods("DELETE")
+	router.HandleFunc("/organizations/{id}/sandbox/reset", h.ResetSandbox).Methods("POST")
+}
+
+// SetupSandboxAdminRoutes configures sandbox routes on the admin router
+func SetupSandboxAdminRoutes(admin *mux.Router, h *SandboxHandler) {
+	admin.HandleFunc("/sandboxes", h.GetSandboxes).Methods("GET")
+}
+
+// SetupSandboxPathRoutes makes sandboxes addressable by subpath on the
+// top-level router
+func SetupSandboxPathRoutes(router *mux.Router, h *SandboxHandler) {
+	router.PathPrefix(sandboxPathPrefix + "{sandboxId}/").HandlerFunc(h.ServeSandboxPath)
+}
+
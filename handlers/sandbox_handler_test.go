@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// testSandbox is the production stores behind the sandbox test router and
+// the sandbox taken of org_a
+type testSandbox struct {
+	router    *mux.Router
+	users     *services.UserService
+	orgs      *services.OrganizationService
+	projects  *services.ProjectService
+	sandboxes *services.SandboxService
+	id        string
+}
+
+// newTestSandbox serves the production user, organization and project
+// routes behind SandboxMiddleware, with sandboxes also addressable by
+// subpath. org_a is owned by user_owner, has user_member as a member and
+// owns proj_a; its sandbox is taken before the test runs.
+func newTestSandbox(t *testing.T) *testSandbox {
+	t.Helper()
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+	users := services.NewUserService()
+	orgs := services.NewOrganizationService()
+	projects := services.NewProjectService(orgs)
+
+	for _, id := range []string{"user_owner", "user_member"} {
+		if err := users.Write(ctx, services.CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	if err := orgs.WriteOrg(ctx, models.NewOrganization("org_a", "Alpha", "user_owner")); err != nil {
+		t.Fatalf("WriteOrg: %v", err)
+	}
+	for user, role := range map[string]models.MemberRole{"user_owner": models.MemberRoleOwner, "user_member": models.MemberRoleMember} {
+		if err := orgs.AddMember(ctx, services.CreateMembership(user, "org_a", role)); err != nil {
+			t.Fatalf("AddMember(%s): %v", user, err)
+		}
+	}
+	if err := projects.WriteProject(ctx, services.CreateProject("proj_a", "Alpha", "user_owner", "org_a")); err != nil {
+		t.Fatalf("WriteProject: %v", err)
+	}
+
+	sandboxes := services.NewSandboxService(users, orgs, projects)
+	sandbox, err := sandboxes.CreateSandbox(ctx, "org_a", "user_owner")
+	if err != nil {
+		t.Fatalf("CreateSandbox: %v", err)
+	}
+	h := NewSandboxHandler(sandboxes, logger, PermissionConfig{})
+
+	router := mux.NewRouter()
+	router.Use(SandboxMiddleware(h))
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(PermissionMiddleware(orgs, projects, logger, PermissionConfig{}))
+	SetupUserRoutes(api, NewHandler(users, logger), logger)
+	SetupOrgRoutes(api, NewOrgHandler(orgs, logger))
+	SetupProjectRoutes(api, NewProjectHandler(projects, logger))
+	SetupSandboxPathRoutes(router, h)
+
+	return &testSandbox{router: router, users: users, orgs: orgs, projects: projects, sandboxes: sandboxes, id: sandbox.ID}
+}
+
+// serve sends a request as user_owner, from the sandbox named by header
+// or path when sandbox is set (pointer receiver)
+func (s *testSandbox) serve(method, path, body, sandbox string, byPath bool) *httptest.ResponseRecorder {
+	if sandbox != "" && byPath {
+		path = sandboxPathPrefix + sandbox + path
+	}
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ActingUserHeader, "user_owner")
+	if sandbox != "" && !byPath {
+		req.Header.Set(SandboxHeader, sandbox)
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// snapshot renders everything in the production stores, so two snapshots
+// are equal only if nothing was written in between (pointer receiver)
+func (s *testSandbox) snapshot(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+	users, err := s.users.ReadAllIncludingDeleted(ctx)
+	if err != nil {
+		t.Fatalf("ReadAllIncludingDeleted: %v", err)
+	}
+	orgs, err := s.orgs.ReadAllOrgsIncludingDeleted(ctx)
+	if err != nil {
+		t.Fatalf("ReadAllOrgsIncludingDeleted: %v", err)
+	}
+	members, err := s.orgs.GetMembers(ctx, "org_a")
+	if err != nil {
+		t.Fatalf("GetMembers: %v", err)
+	}
+	projects, err := s.projects.ReadAllProjectsIncludingDeleted(ctx)
+	if err != nil {
+		t.Fatalf("ReadAllProjectsIncludingDeleted: %v", err)
+	}
+	return strings.Join([]string{sortedJSON(t, users), sortedJSON(t, orgs), sortedJSON(t, members), sortedJSON(t, projects)}, "\n")
+}
+
+// sortedJSON renders a list independently of its order
+func sortedJSON[T any](t *testing.T, items []T) string {
+	t.Helper()
+	rendered := make([]string, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		rendered = append(rendered, string(data))
+	}
+	sort.Strings(rendered)
+	return strings.Join(rendered, "\n")
+}
+
+func TestSandboxWritesStayInSandbox(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		check  func(env *services.SandboxEnvironment) bool // Whether the sandbox saw the write
+	}{
+		{"create user", http.MethodPost, "/api/v1/users", `{"first_name":"Grace","last_name":"Hopper","email":"grace@example.com"}`,
+			func(env *services.SandboxEnvironment) bool {
+				all, _ := env.Users.ReadAll(ctx)
+				for _, user := range all {
+					if user.Email == "grace@example.com" {
+						return true
+					}
+				}
+				return false
+			}},
+		{"update user", http.MethodPut, "/api/v1/users/user_member", `{"first_name":"Renamed"}`,
+			func(env *services.SandboxEnvironment) bool {
+				user, err := env.Users.Read(ctx, "user_member")
+				return err == nil && user.FirstName == "Renamed"
+			}},
+		{"delete user", http.MethodDelete, "/api/v1/users/user_member", "",
+			func(env *services.SandboxEnvironment) bool {
+				_, err := env.Users.Read(ctx, "user_member")
+				return err != nil
+			}},
+		{"create organization", http.MethodPost, "/api/v1/organizations", `{"name":"Beta","owner_id":"user_owner"}`,
+			func(env *services.SandboxEnvironment) bool {
+				orgs, _ := env.Orgs.ReadAllOrgs(ctx)
+				return len(orgs) == 2
+			}},
+		{"update organization", http.MethodPut, "/api/v1/organizations/org_a", `{"name":"Renamed","size":"small"}`,
+			func(env *services.SandboxEnvironment) bool {
+				org, err := env.Orgs.ReadOrg(ctx, "org_a")
+				return err == nil && org.Name == "Renamed"
+			}},
+		{"create project", http.MethodPost, "/api/v1/projects", `{"name":"Beta","owner_id":"user_owner","org_id":"org_a","status":"active"}`,
+			func(env *services.SandboxEnvironment) bool {
+				projects, _ := env.Projects.ReadProjectsByOrg(ctx, "org_a")
+				return len(projects) == 2
+			}},
+		{"delete project", http.MethodDelete, "/api/v1/projects/proj_a", "",
+			func(env *services.SandboxEnvironment) bool {
+				_, err := env.Projects.ReadProject(ctx, "proj_a")
+				return err != nil
+			}},
+	}
+	for _, tt := range tests {
+		for _, byPath := range []bool{false, true} {
+			addressed := "by header"
+			if byPath {
+				addressed = "by path"
+			}
+			t.Run(tt.name+" "+addressed, func(t *testing.T) {
+				s := newTestSandbox(t)
+				before := s.snapshot(t)
+
+				rec := s.serve(tt.method, tt.path, tt.body, s.id, byPath)
+				if rec.Code < 200 || rec.Code >= 300 {
+					t.Fatalf("status = %d, want 2xx; body %s", rec.Code, rec.Body)
+				}
+				if got := rec.Header().Get(SandboxHeader); got != s.id {
+					t.Errorf("%s = %q, want %q", SandboxHeader, got, s.id)
+				}
+
+				env, err := s.sandboxes.Environment(s.id)
+				if err != nil {
+					t.Fatalf("Environment: %v", err)
+				}
+				if !tt.check(env) {
+					t.Errorf("sandbox does not show the write")
+				}
+				if after := s.snapshot(t); after != before {
+					t.Errorf("sandbox write reached production:\nbefore %s\nafter  %s", before, after)
+				}
+			})
+		}
+	}
+}
+
+func TestSandboxResetDiscardsWrites(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSandbox(t)
+	if rec := s.serve(http.MethodDelete, "/api/v1/users/user_member", "", s.id, false); rec.Code < 200 || rec.Code >= 300 {
+		t.Fatalf("delete: status = %d; body %s", rec.Code, rec.Body)
+	}
+	if _, err := s.sandboxes.ResetSandbox(ctx, "org_a", "user_owner"); err != nil {
+		t.Fatalf("ResetSandbox: %v", err)
+	}
+
+	// The copy is retaken from production, where user_member was never deleted
+	if rec := s.serve(http.MethodGet, "/api/v1/users/user_member", "", s.id, false); rec.Code != http.StatusOK {
+		t.Errorf("user_member after reset: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestSandboxMiddlewareRouting(t *testing.T) {
+	const body = `{"first_name":"Grace","last_name":"Hopper","email":"grace@example.com"}`
+	tests := []struct {
+		name       string
+		sandbox    string // "current" names the test's sandbox
+		byPath     bool
+		want       int
+		production bool // Whether the user is written to production
+	}{
+		{"no sandbox", "", false, http.StatusCreated, true},
+		{"sandbox by header", "current", false, http.StatusCreated, false},
+		{"sandbox by path", "current", true, http.StatusCreated, false},
+		{"unknown sandbox by header", "sbx_unknown", false, http.StatusNotFound, false},
+		{"unknown sandbox by path", "sbx_unknown", true, http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSandbox(t)
+			sandbox := tt.sandbox
+			if sandbox == "current" {
+				sandbox = s.id
+			}
+			before := s.snapshot(t)
+
+			rec := s.serve(http.MethodPost, "/api/v1/users", body, sandbox, tt.byPath)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+			if changed := s.snapshot(t) != before; changed != tt.production {
+				t.Errorf("production changed = %v, want %v", changed, tt.production)
+			}
+		})
+	}
+}
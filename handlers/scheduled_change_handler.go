@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// ScheduledChangeHandler handles scheduled change HTTP requests
+type ScheduledChangeHandler struct {
+	scheduler *services.ChangeScheduler
+	logger    *log.Logger
+}
+
+// NewScheduledChangeHandler creates a new ScheduledChangeHandler instance
+func NewScheduledChangeHandler(scheduler *services.ChangeScheduler, logger *log.Logger) *ScheduledChangeHandler {
+	return &ScheduledChangeHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// =====================================
+// Scheduled Change HTTP Handlers
+// =====================================
+
+// CreateScheduledChange handles POST /scheduled-changes - schedules an
+// update to take effect later, e.g.
+// {"target":"membership","target_id":"u1","org_id":"o1","changes":{"role":"admin"},"effective_at":"2026-10-19T00:00:00Z","requested_by":"u2"}
+// or {"target":"organization","target_id":"o1","changes":{"name":"Acme Inc"},"effective_at":"..."}.
+// If-Match with the ETag of the user or organization pins the change to
+// that version: it fails if the target is edited before it takes effect.
+func (h *ScheduledChangeHandler) CreateScheduledChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	targetVersion, err := ifMatchVersion(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var input struct {
+		Target      models.ScheduledChangeTarget `json:"target" validate:"required,oneof=user organization membership"`
+		TargetID    string                       `json:"target_id" validate:"required"`
+		OrgID       string                       `json:"org_id"`
+		Changes     json.RawMessage              `json:"changes"`
+		EffectiveAt time.Time                    `json:"effective_at" validate:"required"`
+		RequestedBy string                       `json:"requested_by"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	change, err := h.scheduler.Schedule(ctx, input.Target, input.TargetID, input.OrgID, input.Changes, input.EffectiveAt, targetVersion, input.RequestedBy)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Change scheduled successfully",
+		Data:    change,
+	})
+}
+
+// GetScheduledChanges handles GET /scheduled-changes?target=&target_id=&org_id=&status=
+// - lists scheduled changes, soonest first
+func (h *ScheduledChangeHandler) GetScheduledChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	filter := services.ScheduledChangeFilter{
+		Target:   models.ScheduledChangeTarget(query.Get("target")),
+		TargetID: query.Get("target_id"),
+		OrgID:    query.Get("org_id"),
+		Status:   models.ScheduledChangeStatus(query.Get("status")),
+	}
+	if filter.Target != "" && !filter.Target.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "target must be user, organization, or membership")
+		return
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "status must be pending, applied, cancelled, or failed")
+		return
+	}
+
+	changes, err := h.scheduler.ListChanges(ctx, filter)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve scheduled changes")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Scheduled changes retrieved successfully",
+		Data:    changes,
+	})
+}
+
+// GetScheduledChange handles GET /scheduled-changes/{id} - retrieves a scheduled change
+func (h *ScheduledChangeHandler) GetScheduledChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	change, err := h.scheduler.ReadChange(ctx, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Scheduled change retrieved successfully",
+		Data:    change,
+	})
+}
+
+// CancelScheduledChange handles POST /scheduled-changes/{id}/cancel -
+// cancels a pending change, e.g. {"cancelled_by":"u1"}
+func (h *ScheduledChangeHandler) CancelScheduledChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var input struct {
+		CancelledBy string `json:"cancelled_by" validate:"max=100"`
+	}
+
+	if r.ContentLength != 0 {
+		if !decodeRequest(w, h.logger, r, &input) {
+			return
+		}
+		if !validateRequest(w, h.logger, &input) {
+			return
+		}
+	}
+
+	change, err := h.scheduler.CancelChange(ctx, id, input.CancelledBy)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Scheduled change cancelled",
+		Data:    change,
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondServiceError maps scheduler errors to HTTP statuses (pointer receiver)
+func (h *ScheduledChangeHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrScheduledChangeNotFound):
+		h.respondError(w, http.StatusNotFound, "Scheduled change not found")
+	case errors.Is(err, services.ErrScheduledChangeClosed):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrScheduledChangeInvalid):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrVersionConflict):
+		h.respondError(w, http.StatusPreconditionFailed, "Target has been modified")
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *ScheduledChangeHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *ScheduledChangeHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Scheduled Changes
+// =====================================
+
+// SetupScheduledChangeRoutes configures scheduled change routes
+func SetupScheduledChangeRoutes(router *mux.Router, h *ScheduledChangeHandler) {
+	router.HandleFunc("/scheduled-changes", h.GetScheduledChanges).Methods("GET")
+	router.HandleFunc("/scheduled-changes", h.CreateScheduledChange).Methods("POST")
+	router.HandleFunc("/scheduled-changes/{id}", h.GetScheduledChange).Methods("GET")
+	router.HandleFunc("/scheduled-changes/{id}/cancel", h.CancelScheduledChange).Methods("POST")
+}
+
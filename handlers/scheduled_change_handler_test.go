@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+func TestCreateScheduledChangeIfMatch(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+	users := services.NewUserService()
+	if err := users.Write(ctx, services.CreateUser("user_a", "Ada", "Lovelace", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+	user, _ := users.Read(ctx, "user_a")
+	router := mux.NewRouter()
+	SetupScheduledChangeRoutes(router, NewScheduledChangeHandler(services.NewChangeScheduler(users, services.NewOrganizationService(), services.SchedulerConfig{}, logger), logger))
+
+	body := `{"target":"user","target_id":"user_a","changes":{"first_name":"Augusta"},"effective_at":"` +
+		models.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+		pinned  int64
+	}{
+		{"no If-Match", "", http.StatusCreated, 0},
+		{"any version", "*", http.StatusCreated, 0},
+		{"current version", versionETag(user.Version), http.StatusCreated, user.Version},
+		{"stale version", versionETag(user.Version + 1), http.StatusPreconditionFailed, 0},
+		{"several versions", versionETag(user.Version) + ", " + versionETag(user.Version+1), http.StatusBadRequest, 0},
+		{"not a version", `"abc"`, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/scheduled-changes", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+			if rec.Code != http.StatusCreated {
+				return
+			}
+
+			var response struct {
+				Data models.ScheduledChange `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if response.Data.TargetVersion != tt.pinned {
+				t.Errorf("target_version = %d, want %d", response.Data.TargetVersion, tt.pinned)
+			}
+		})
+	}
+}
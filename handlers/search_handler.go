@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// SearchHandler wraps the search service and provides HTTP handlers
+type SearchHandler struct {
+	service *services.SearchService
+	logger  *log.Logger
+}
+
+// NewSearchHandler creates a new SearchHandler instance
+func NewSearchHandler(service *services.SearchService, logger *log.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Search HTTP Handlers
+// =====================================
+
+// Search handles GET /search?q=...&type=user,organization - full-text search.
+// Any additional query parameter is applied as an exact field filter.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := r.URL.Query()
+
+	query := params.Get("q")
+	if strings.TrimSpace(query) == "" {
+		h.respondError(w, http.StatusBadRequest, "Search query is required")
+		return
+	}
+
+	filters := make(map[string]interface{})
+	for key, values := range params {
+		if key == "q" || len(values) == 0 || values[0] == "" {
+			continue
+		}
+		if key == "type" {
+			filters[key] = strings.Split(values[0], ",")
+			continue
+		}
+		filters[key] = values[0]
+	}
+
+	results, err := h.service.SearchWithFilters(ctx, query, filters)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Search completed successfully",
+		Data:    results,
+	})
+}
+
+// Reindex handles POST /admin/v1/reindex - rebuilds the search index from scratch
+func (h *SearchHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := h.service.Reindex(ctx); err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to rebuild search index")
+		return
+	}
+
+	count := h.service.DocumentCount()
+	h.logger.Printf("Rebuilt search index: %d documents", count)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Search index rebuilt successfully",
+		Data: map[string]int{
+			"documents": count,
+		},
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *SearchHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *SearchHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Search
+// =====================================
+
+// SetupSearchRoutes configures search routes
+func SetupSearchRoutes(router *mux.Router, h *SearchHandler) {
+	router.HandleFunc("/search", h.Search).Methods("GET")
+}
+
+// SetupSearchAdminRoutes configures search index routes on the admin router
+func SetupSearchAdminRoutes(admin *mux.Router, h *SearchHandler) {
+	admin.HandleFunc("/reindex", h.Reindex).Methods("POST")
+}
+
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Cache lifetimes for crawler files; robots.txt changes only with configuration
+const (
+	sitemapMaxAge = "3600"
+	robotsMaxAge  = "86400"
+)
+
+// SitemapHandler serves sitemap.xml and robots.txt for public profiles
+type SitemapHandler struct {
+	service *services.SitemapService
+	logger  *log.Logger
+}
+
+// NewSitemapHandler creates a new SitemapHandler instance
+func NewSitemapHandler(service *services.SitemapService, logger *log.Logger) *SitemapHandler {
+	return &SitemapHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Crawler HTTP Handlers
+// =====================================
+
+// GetSitemap handles GET /sitemap.xml - lists every public organization profile
+func (h *SitemapHandler) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sitemap, err := h.service.Sitemap(ctx, h.service.BaseURL(requestOrigin(r)))
+	if err != nil {
+		h.logger.Printf("Error building sitemap: %v", err)
+		http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+
+	etag := fmt.Sprintf("W/\"%d\"", sitemap.Revision)
+	w.Header().Set("ETag", etag)
+	if !sitemap.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", sitemap.LastModified.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+sitemapMaxAge)
+
+	if noneMatchSatisfied(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(sitemap.Body); err != nil {
+		h.logger.Printf("Error writing sitemap: %v", err)
+	}
+}
+
+// GetRobots handles GET /robots.txt - returns the configured crawler rules
+func (h *SitemapHandler) GetRobots(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age="+robotsMaxAge)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(h.service.Robots(h.service.BaseURL(requestOrigin(r))))); err != nil {
+		h.logger.Printf("Error writing robots.txt: %v", err)
+	}
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// requestOrigin derives scheme://host from the request, honouring
+// X-Forwarded-Proto set by a TLS-terminating proxy
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+// =====================================
+// Route Setup for Crawler Files
+// =====================================
+
+// SetupSitemapRoutes configures sitemap.xml and robots.txt on the root router
+func SetupSitemapRoutes(router *mux.Router, h *SitemapHandler) {
+	router.HandleFunc("/sitemap.xml", h.GetSitemap).Methods("GET")
+	router.HandleFunc("/robots.txt", h.GetRobots).Methods("GET")
+}
+
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// SLAHandler reports how asynchronous operations meet their SLA targets
+type SLAHandler struct {
+	tracker *services.SLATracker
+	logger  *log.Logger
+}
+
+// NewSLAHandler creates a new SLAHandler instance
+func NewSLAHandler(tracker *services.SLATracker, logger *log.Logger) *SLAHandler {
+	return &SLAHandler{
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// slaView is the SLA overview as returned to clients
+type slaView struct {
+	Targets  map[string]string    `json:"targets"`
+	Report   *services.SLAReport  `json:"report"`
+	Breaches []services.SLABreach `json:"breaches"`
+}
+
+// =====================================
+// SLA HTTP Handlers
+// =====================================
+
+// GetSLAs handles GET /admin/v1/slas - returns the targets, compliance per
+// organization and operation, and recent breach events
+func (h *SLAHandler) GetSLAs(w http.ResponseWriter, r *http.Request) {
+	report, err := h.tracker.Report(r.Context(), "", models.Now())
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute SLA compliance")
+		return
+	}
+
+	config := h.tracker.Config()
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "SLAs retrieved successfully",
+		Data: slaView{
+			Targets: map[string]string{
+				services.SLAOrgDeletion:     config.OrgDeletion.String(),
+				services.SLAScheduledChange: config.ScheduledChange.String(),
+			},
+			Report:   report,
+			Breaches: h.tracker.Breaches(),
+		},
+	})
+}
+
+// GetOrgSLAs handles GET /admin/v1/slas/orgs/{id} - returns one
+// organization's compliance and every tracked operation behind it
+func (h *SLAHandler) GetOrgSLAs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["id"]
+	now := models.Now()
+
+	report, err := h.tracker.Report(ctx, orgID, now)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute SLA compliance")
+		return
+	}
+	records, err := h.tracker.Records(ctx, orgID, now)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to compute SLA compliance")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Organization SLAs retrieved successfully",
+		Data: map[string]interface{}{
+			"report":     report,
+			"operations": records,
+		},
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *SLAHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *SLAHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for SLAs
+// =====================================
+
+// SetupSLARoutes configures SLA compliance routes on the admin router
+func SetupSLARoutes(router *mux.Router, h *SLAHandler) {
+	router.HandleFunc("/slas", h.GetSLAs).Methods("GET")
+	router.HandleFunc("/slas/orgs/{id}", h.GetOrgSLAs).Methods("GET")
+}
+
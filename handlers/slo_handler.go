@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/metrics"
+	"github.com/test-repo-golang-support/services"
+)
+
+// SLOHandler reports service level objectives and the request metrics behind them
+type SLOHandler struct {
+	monitor *services.SLOMonitor
+	metrics *metrics.Registry
+	logger  *log.Logger
+}
+
+// NewSLOHandler creates a new SLOHandler instance
+func NewSLOHandler(monitor *services.SLOMonitor, registry *metrics.Registry, logger *log.Logger) *SLOHandler {
+	return &SLOHandler{
+		monitor: monitor,
+		metrics: registry,
+		logger:  logger,
+	}
+}
+
+// sloView is the SLO overview as returned to clients
+type sloView struct {
+	Objectives []services.SLOStatus    `json:"objectives"`
+	Rules      []services.BurnRateRule `json:"rules"`
+	Alerts     []services.SLOAlert     `json:"alerts"`
+}
+
+// =====================================
+// SLO HTTP Handlers
+// =====================================
+
+// GetSLOs handles GET /admin/v1/slos - returns every objective with its burn
+// rates and severity, the alerting rules, and recent alert events
+func (h *SLOHandler) GetSLOs(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "SLOs retrieved successfully",
+		Data: sloView{
+			Objectives: h.monitor.Statuses(time.Now()),
+			Rules:      h.monitor.Rules(),
+			Alerts:     h.monitor.Alerts(),
+		},
+	})
+}
+
+// GetSLO handles GET /admin/v1/slos/{name} - returns one objective's status
+func (h *SLOHandler) GetSLO(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	status, err := h.monitor.Status(vars["name"], time.Now())
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "SLO not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "SLO retrieved successfully",
+		Data:    status,
+	})
+}
+
+// GetRouteMetrics handles GET /admin/v1/metrics/routes?window=1h - returns
+// request counts, server errors and the latency histogram per route
+func (h *SLOHandler) GetRouteMetrics(w http.ResponseWriter, r *http.Request) {
+	window := time.Hour
+	if value := r.URL.Query().Get("window"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			h.respondError(w, http.StatusBadRequest, "Invalid window")
+			return
+		}
+		window = parsed
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Route metrics retrieved successfully",
+		Data: map[string]interface{}{
+			"window":         window.String(),
+			"latency_bounds": latencyBoundNames(h.metrics.Config().LatencyBounds),
+			"routes":         h.metrics.Routes(window, time.Now()),
+		},
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// latencyBoundNames formats histogram bounds, ending with the overflow bucket
+func latencyBoundNames(bounds []time.Duration) []string {
+	names := make([]string, 0, len(bounds)+1)
+	for _, bound := range bounds {
+		names = append(names, "<="+bound.String())
+	}
+	if len(bounds) > 0 {
+		names = append(names, ">"+bounds[len(bounds)-1].String())
+	}
+	return names
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *SLOHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *SLOHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for SLOs
+// =====================================
+
+// SetupSLORoutes configures SLO and request metrics routes on the admin router
+func SetupSLORoutes(router *mux.Router, h *SLOHandler) {
+	router.HandleFunc("/slos", h.GetSLOs).Methods("GET")
+	router.HandleFunc("/slos/{name}", h.GetSLO).Methods("GET")
+	router.HandleFunc("/metrics/routes", h.GetRouteMetrics).Methods("GET")
+}
+
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// StoreHandler reports the approximate memory use of the in-memory stores
+// and how many entities they hold
+type StoreHandler struct {
+	userService    *services.UserService
+	orgService     *services.OrganizationService
+	profileService *services.ProfileService
+	logger         *log.Logger
+}
+
+// EntityStats counts the entities held by each service; memberships are
+// counted with the organizations they belong to
+type EntityStats struct {
+	Users    services.UserStats    `json:"users"`
+	Orgs     services.OrgStats     `json:"orgs"`
+	Profiles services.ProfileStats `json:"profiles"`
+}
+
+// NewStoreHandler creates a new StoreHandler instance
+func NewStoreHandler(userService *services.UserService, orgService *services.OrganizationService, profileService *services.ProfileService, logger *log.Logger) *StoreHandler {
+	return &StoreHandler{
+		userService:    userService,
+		orgService:     orgService,
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// =====================================
+// Store HTTP Handlers
+// =====================================
+
+// GetStoreStats handles GET /admin/v1/stores - lists entity counts, approximate
+// bytes, limits, and rejected or evicted writes for each store
+func (h *StoreHandler) GetStoreStats(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Store usage retrieved successfully",
+		Data: []services.StoreStats{
+			h.userService.StoreStats(),
+			h.orgService.StoreStats(),
+		},
+	})
+}
+
+// GetStats handles GET /admin/v1/stats - counts users, organizations,
+// memberships, and profiles. Each service is counted under its own lock,
+// so the counts are consistent per service but not across services.
+func (h *StoreHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Statistics retrieved successfully",
+		Data: EntityStats{
+			Users:    h.userService.Stats(),
+			Orgs:     h.orgService.Stats(),
+			Profiles: h.profileService.Stats(),
+		},
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *StoreHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// =====================================
+// Route Setup for Store Usage
+// =====================================
+
+// SetupStoreRoutes configures store usage and statistics routes on the
+// admin router
+func SetupStoreRoutes(router *mux.Router, h *StoreHandler) {
+	router.HandleFunc("/stores", h.GetStoreStats).Methods("GET")
+	router.HandleFunc("/stats", h.GetStats).Methods("GET")
+}
+
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// TaskHandler handles project task HTTP requests
+type TaskHandler struct {
+	service  *services.TaskService
+	projects *services.ProjectService
+	logger   *log.Logger
+}
+
+// NewTaskHandler creates a new TaskHandler instance
+func NewTaskHandler(service *services.TaskService, projects *services.ProjectService, logger *log.Logger) *TaskHandler {
+	return &TaskHandler{
+		service:  service,
+		projects: projects,
+		logger:   logger,
+	}
+}
+
+// =====================================
+// Task HTTP Handlers
+// =====================================
+
+// GetTasks handles GET /projects/{id}/tasks?status=&assignee_id= - lists a project's tasks
+func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+	query := r.URL.Query()
+
+	if _, err := h.projects.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	filter := services.TaskFilter{
+		Status:     models.TaskStatus(query.Get("status")),
+		AssigneeID: query.Get("assignee_id"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		h.respondError(w, http.StatusBadRequest, "status must be todo, in_progress, or done")
+		return
+	}
+
+	tasks, err := h.service.ListTasks(ctx, id, filter)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "Failed to fetch tasks")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Tasks retrieved successfully",
+		Data:    tasks,
+	})
+}
+
+// GetTask handles GET /projects/{id}/tasks/{taskId} - returns a specific task
+func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	if _, err := h.projects.ReadProject(ctx, vars["id"]); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	task, err := h.service.ReadTask(ctx, vars["id"], vars["taskId"])
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(task.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Task retrieved successfully",
+		Data:    task,
+	})
+}
+
+// CreateTask handles POST /projects/{id}/tasks - creates a task,
+// e.g. {"title":"Write docs","assignee_id":"u2","due_date":"2024-07-01T00:00:00Z"}
+func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+
+	if _, err := h.projects.ReadProject(ctx, id); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	var input struct {
+		Title      string            `json:"title" validate:"required"`
+		Status     models.TaskStatus `json:"status" validate:"oneof=todo in_progress done"`
+		AssigneeID string            `json:"assignee_id"`
+		DueDate    *time.Time        `json:"due_date"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	task := models.NewTask(h.service.NewID(services.IDPrefixTask), id, input.Title)
+	if input.Status != "" {
+		task.Status = input.Status
+	}
+	task.AssigneeID = input.AssigneeID
+	task.DueDate = input.DueDate
+
+	if err := h.service.WriteTask(ctx, task); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.logger.Printf("event=task.created task=%s project=%s assignee=%s", task.ID, id, task.AssigneeID)
+
+	w.Header().Set("ETag", versionETag(task.Version))
+	h.respondJSON(w, http.StatusCreated, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Task created successfully",
+		Data:    task,
+	})
+}
+
+// UpdateTask handles PUT /projects/{id}/tasks/{taskId} - updates a task.
+// Omitted fields are left alone; "assignee_id":"" unassigns the task.
+func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	if _, err := h.projects.ReadProject(ctx, vars["id"]); err != nil {
+		h.respondError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	task, err := h.service.ReadTask(ctx, vars["id"], vars["taskId"])
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if !ifMatchSatisfied(r, task.Version) {
+		h.respondError(w, http.StatusPreconditionFailed, "Task has been modified")
+		return
+	}
+
+	var input struct {
+		Title      string            `json:"title"`
+		Status     models.TaskStatus `json:"status" validate:"oneof=todo in_progress done"`
+		AssigneeID *string           `json:"assignee_id"`
+		DueDate    *time.Time        `json:"due_date"`
+	}
+
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
+		return
+	}
+
+	if input.Title != "" {
+		task.Title = input.Title
+	}
+	if input.Status != "" {
+		task.Status = input.Status
+	}
+	if input.AssigneeID != nil {
+		task.AssigneeID = *input.AssigneeID
+	}
+	if input.DueDate != nil {
+		task.DueDate = input.DueDate
+	}
+	task.UpdatedAt = models.Now()
+
+	if err := h.service.WriteTask(ctx, task); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(task.Version))
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Task updated successfully",
+		Data:    task,
+	})
+}
+
+// DeleteTask handles DELETE /projects/{id}/tasks/{taskId} - deletes a task
+func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	if err := h.service.DeleteTask(ctx, vars["id"], vars["taskId"]); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Task deleted successfully",
+	})
+}
+
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondServiceError maps task service errors to HTTP statuses (pointer receiver)
+func (h *TaskHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrTaskNotFound):
+		h.respondError(w, http.StatusNotFound, "Task not found")
+	case errors.Is(err, services.ErrTaskInvalid):
+		h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrVersionConflict):
+		h.respondError(w, http.StatusConflict, "Task has been modified")
+	default:
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// respondJSON sends a JSON response (pointer receiver)
+func (h *TaskHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, h.logger, status, data)
+}
+
+// respondError sends an error response (pointer receiver)
+func (h *TaskHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// =====================================
+// Route Setup for Tasks
+// =====================================
+
+// SetupTaskRoutes configures project task routes
+func SetupTaskRoutes(router *mux.Router, h *TaskHandler) {
+	router.HandleFunc("/projects/{id}/tasks", h.GetTasks).Methods("GET")
+	router.HandleFunc("/projects/{id}/tasks", h.CreateTask).Methods("POST")
+	router.HandleFunc("/projects/{id}/tasks/{taskId}", h.GetTask).Methods("GET")
+	router.HandleFunc("/projects/{id}/tasks/{taskId}", h.UpdateTask).Methods("PUT")
+	router.HandleFunc("/projects/{id}/tasks/{taskId}", h.DeleteTask).Methods("DELETE")
+}
+
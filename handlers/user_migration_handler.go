@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -10,44 +10,95 @@ import (
 	"github.com/test-repo-golang-support/services"
 )
 
-// UserMigrationHandler handles user migration endpoints
-// This demonstrates knowledge graph traversal:
-// - Uses models.UserRefactored (new)
-// - Should detect that other handlers still use old models.User
+// UserMigrationHandler handles migration from models.User to models.UserRefactored
 type UserMigrationHandler struct {
 	migrationService *services.UserMigrationService
+	backfills        *services.BackfillOrchestrator
 	logger           *log.Logger
 }
 
 // NewUserMigrationHandler creates a new migration handler
-func NewUserMigrationHandler(service *services.UserMigrationService, logger *log.Logger) *UserMigrationHandler {
+func NewUserMigrationHandler(service *services.UserMigrationService, backfills *services.BackfillOrchestrator, logger *log.Logger) *UserMigrationHandler {
 	return &UserMigrationHandler{
 		migrationService: service,
+		backfills:        backfills,
 		logger:           logger,
 	}
 }
 
-// MigrateUser handles POST /migrate/users/{id} - migrates a user
-// BUG: This handler uses UserRefactored but the main handlers still use User
-// Knowledge graph should detect that both User and UserRefactored are used
-// and flag potential inconsistencies
+// userMigrationStatus reports the migrated and failed counts together with
+// the progress of the batch job and its dry run
+type userMigrationStatus struct {
+	services.MigrationStatus
+	Job    *services.BackfillProgress `json:"job"`
+	DryRun *services.BackfillProgress `json:"dry_run"`
+}
+
+// =====================================
+// User Migration HTTP Handlers
+// =====================================
+
+// StartMigration handles POST /migrate/users?dry_run=true - starts the batch
+// migration of every user; a dry run only validates the field mapping
+func (h *UserMigrationHandler) StartMigration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := services.UserBackfillName
+	if r.URL.Query().Get("dry_run") == "true" {
+		name = services.UserBackfillDryRunName
+	}
+
+	progress, err := h.backfills.Start(ctx, name, r.URL.Query().Get("restart") == "true")
+	if err != nil {
+		if errors.Is(err, services.ErrBackfillState) {
+			h.respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "Failed to start migration")
+		return
+	}
+
+	h.logger.Printf("audit action=migration.start name=%s total=%d", name, progress.Total)
+	h.respondJSON(w, http.StatusAccepted, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "User migration started",
+		Data:    progress,
+	})
+}
+
+// GetMigrationStatus handles GET /migrate/users/status - reports migrated and
+// failed counts and the progress of the batch job
+func (h *UserMigrationHandler) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := userMigrationStatus{MigrationStatus: h.migrationService.Status(ctx)}
+	status.Job, _ = h.backfills.Progress(services.UserBackfillName)
+	status.DryRun, _ = h.backfills.Progress(services.UserBackfillDryRunName)
+
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "Migration status retrieved successfully",
+		Data:    status,
+	})
+}
+
+// MigrateUser handles POST /migrate/users/{id} - migrates a single user
 func (h *UserMigrationHandler) MigrateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
-	// BUG: Creating UserRefactored but other parts of codebase use User
-	// Knowledge graph should detect this type mismatch across files
-	newUser := &models.UserRefactored{
-		BaseEntity: models.BaseEntity{
-			ID: userID,
-		},
-		EmailAddress: "new@example.com", // Using new field name
+	newUser, err := h.migrationService.MigrateUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMapping) {
+			h.respondError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		h.respondError(w, http.StatusNotFound, "User not found")
+		return
 	}
 
-	// BUG: Accessing EmailAddress directly - but if this was passed from
-	// old User model, it would have Email field, not EmailAddress
-	// Knowledge graph should detect field name mismatch
-	h.logger.Printf("Migrated user: %s with email: %s", newUser.ID, newUser.EmailAddress)
+	h.logger.Printf("Migrated user: %s", newUser.ID)
 
 	h.respondJSON(w, http.StatusOK, models.APIResponse{
 		Code:    models.ResponseOK,
@@ -56,34 +107,42 @@ func (h *UserMigrationHandler) MigrateUser(w http.ResponseWriter, r *http.Reques
 	})
 }
 
-// CreateRefactoredUser handles POST /users/refactored - creates a refactored user
-// BUG: This creates UserRefactored but the main CreateUser handler creates User
-// Knowledge graph should detect that two different user types are being created
-// and flag potential data inconsistency
+// CreateRefactoredUser handles POST /users/refactored - creates a user directly in the new shape
 func (h *UserMigrationHandler) CreateRefactoredUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	var input struct {
 		FirstName string `json:"first_name"`
 		LastName  string `json:"last_name"`
-		Email     string `json:"email"` // JSON field is still "email" but struct field is EmailAddress
+		Email     string `json:"email_address" validate:"required,email"` // v2 payload shape; v1 "email" is remapped by UserPayloadCompat
 		Role      string `json:"role"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeRequest(w, h.logger, r, &input) {
+		return
+	}
+	if !validateRequest(w, h.logger, &input) {
 		return
 	}
 
-	// BUG: Using UpdateEmailAddress with wrong signature
-	// Should pass 2 args: (email, verified) but only passing email
-	// Knowledge graph should detect method signature mismatch
+	now := models.Now()
 	user := &models.UserRefactored{
 		BaseEntity: models.BaseEntity{
-			ID: services.GenerateUserID(),
+			ID:        h.migrationService.NewID(services.IDPrefixUser),
+			CreatedAt: now,
+			Version:   1,
 		},
 		FirstName: input.FirstName,
 		LastName:  input.LastName,
+		Role:      input.Role,
+		Active:    true,
+	}
+	user.UpdateEmailAddress(input.Email, false)
+
+	if err := h.migrationService.WriteMigratedUser(ctx, user); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
-	user.UpdateEmailAddress(input.Email) // BUG: Missing second parameter (verified bool)
 
 	h.respondJSON(w, http.StatusCreated, models.APIResponse{
 		Code:    models.ResponseOK,
@@ -92,40 +151,48 @@ func (h *UserMigrationHandler) CreateRefactoredUser(w http.ResponseWriter, r *ht
 	})
 }
 
-// GetUserEmail handles GET /users/{id}/email - gets user email
-// BUG: This method might receive old User type but tries to access EmailAddress
-// Knowledge graph should detect type mismatch
+// GetUserEmail handles GET /users/{id}/email - gets a migrated user's email address
 func (h *UserMigrationHandler) GetUserEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
-	// BUG: Assuming UserRefactored but might receive old User type
-	// Knowledge graph should detect that User.Email and UserRefactored.EmailAddress
-	// are related but different field names
-	user := &models.UserRefactored{
-		BaseEntity: models.BaseEntity{ID: userID},
+	user, err := h.migrationService.ReadMigratedUser(ctx, userID)
+	if err != nil {
+		h.respondError(w, http.StatusNotFound, "User not migrated")
+		return
 	}
 
-	// BUG: If this was an old User, it would have .Email, not .EmailAddress
-	// Knowledge graph should detect this field access mismatch
-	email := user.EmailAddress // Would fail if user was old User type with Email field
-
-	h.respondJSON(w, http.StatusOK, map[string]string{
-		"email": email,
+	h.respondJSON(w, http.StatusOK, models.APIResponse{
+		Code:    models.ResponseOK,
+		Message: "User email retrieved successfully",
+		Data: map[string]string{
+			"email": user.GetEmail(),
+		},
 	})
 }
 
-// Helper methods
+// =====================================
+// Helper Methods
+// =====================================
+
+// respondJSON sends a JSON response (pointer receiver)
 func (h *UserMigrationHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, h.logger, status, data)
 }
 
+// respondError sends an error response (pointer receiver)
 func (h *UserMigrationHandler) respondError(w http.ResponseWriter, status int, message string) {
-	h.respondJSON(w, status, models.APIResponse{
-		Code:    models.ResponseError,
-		Message: message,
-	})
+	h.respondJSON(w, status, models.NewErrorResponse(status, message))
+}
+
+// SetupUserMigrationRoutes configures user migration routes
+// v2 payloads are guarded by UserPayloadCompat so v1-shaped bodies are remapped
+func SetupUserMigrationRoutes(router *mux.Router, h *UserMigrationHandler) {
+	router.HandleFunc("/migrate/users", h.StartMigration).Methods("POST")
+	router.HandleFunc("/migrate/users/status", h.GetMigrationStatus).Methods("GET")
+	router.HandleFunc("/migrate/users/{id}", h.MigrateUser).Methods("POST")
+	router.HandleFunc("/users/refactored", UserPayloadCompat(PayloadShapeV2, h.logger, h.CreateRefactoredUser)).Methods("POST")
+	router.HandleFunc("/users/{id}/email", h.GetUserEmail).Methods("GET")
 }
 
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/test-repo-golang-support/pkg/validation"
+)
+
+// TestValidateTagsAreWellFormed checks every validate tag in the request
+// DTOs and models once, so a typo fails here instead of at request time
+func TestValidateTagsAreWellFormed(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{"*.go", "../models/*.go"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatalf("glob %s: %v", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+
+	checked := 0
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", file, err)
+		}
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			field, ok := node.(*ast.Field)
+			if !ok || field.Tag == nil {
+				return true
+			}
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				t.Errorf("%s: unquote tag: %v", fset.Position(field.Pos()), err)
+				return true
+			}
+			tag, ok := reflect.StructTag(raw).Lookup(validation.Tag)
+			if !ok {
+				return true
+			}
+			checked++
+			if err := validation.CheckTag(tag); err != nil {
+				t.Errorf("%s: %v", fset.Position(field.Pos()), err)
+			}
+			return true
+		})
+	}
+	if checked == 0 {
+		t.Fatal("found no validate tags")
+	}
+}
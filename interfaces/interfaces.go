@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 
 	"github.com/test-repo-golang-support/models"
 )
@@ -18,6 +19,11 @@ type Writer interface {
 	Delete(ctx context.Context, id string) error
 }
 
+// BatchWriter writes many users at once; errs[i] is the outcome for users[i]
+type BatchWriter interface {
+	WriteMany(ctx context.Context, users []*models.User) []error
+}
+
 // ReadWriter combines Reader and Writer interfaces
 // Demonstrates interface embedding
 type ReadWriter interface {
@@ -132,6 +138,16 @@ type MultiChannelNotifier interface {
 	PushNotifier  // Embedded interface
 }
 
+// UserNotifier delivers a notification to one user over the channels they prefer
+type UserNotifier interface {
+	NotifyUser(ctx context.Context, userID, subject, body string) error
+}
+
+// EventNotifier renders an event's notification template and delivers it to one user
+type EventNotifier interface {
+	NotifyEvent(ctx context.Context, userID, event string, data models.NotificationData) error
+}
+
 // Searchable interface for search operations
 type Searchable interface {
 	Search(ctx context.Context, query string) ([]interface{}, error)
@@ -168,6 +184,13 @@ type OrgWriter interface {
 	DeleteOrg(ctx context.Context, id string) error
 }
 
+// OrgBatchWriter writes many organizations or memberships at once;
+// errs[i] is the outcome for the item at index i
+type OrgBatchWriter interface {
+	WriteOrgs(ctx context.Context, orgs []*models.Organization) []error
+	AddMembers(ctx context.Context, memberships []*models.Membership) []error
+}
+
 // OrgReadWriter combines OrgReader and OrgWriter
 type OrgReadWriter interface {
 	OrgReader // Embedded interface
@@ -196,3 +219,28 @@ type OrgService interface {
 	MembershipManager // Embedded interface
 }
 
+// =====================================
+// Lifecycle Interfaces
+// =====================================
+
+// Initializer is implemented by components that need setup after construction
+type Initializer interface {
+	Initialize(ctx context.Context) error
+}
+
+// Shutdowner is implemented by components that hold resources to release
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// =====================================
+// Storage Interfaces
+// =====================================
+
+// BlobStorage stores opaque objects, such as uploaded images, under a key
+// and returns the URL clients fetch them from
+type BlobStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
@@ -24,24 +24,20 @@ func NewAuthenticator(secretKey string, tokenExpiry time.Duration) *Authenticato
 }
 
 // ValidateToken validates a token
-// BUG: Calls non-existent method Validate() on token string
 func (a *Authenticator) ValidateToken(token string) (bool, error) {
-	// BUG: token is a string, doesn't have Validate() method
-	if !token.Validate() {
+	if !NewValidator(a.secretKey).Validate(token) {
 		return false, errors.New("invalid token")
 	}
 	return true, nil
 }
 
 // GenerateToken generates a new token for a user
-// BUG: References a.secret instead of a.secretKey (typo)
 func (a *Authenticator) GenerateToken(userID string) (string, error) {
 	if userID == "" {
 		return "", errors.New("user ID is required")
 	}
-	
-	// BUG: Should be a.secretKey, not a.secret
-	token := a.secret + ":" + userID
+
+	token := a.secretKey + ":" + userID
 	return token, nil
 }
 
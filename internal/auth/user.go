@@ -14,17 +14,13 @@ type User struct {
 }
 
 // GetUserByEmail retrieves a user by email
-// BUG: Calls ValidateToken with wrong number of arguments (2 instead of 1)
-// BUG: Imported with alias 'auth' but calling methods without prefix
 func GetUserByEmail(email string) (*User, error) {
 	if email == "" {
 		return nil, errors.New("email is required")
 	}
-	
-	// BUG: Imported as 'auth' but not using the prefix
-	// BUG: ValidateToken expects 1 argument but passing 2
+
 	authenticator := NewAuthenticator("secret", 3600)
-	valid, err := authenticator.ValidateToken("token", email)
+	valid, err := authenticator.ValidateToken("token")
 	if err != nil {
 		return nil, err
 	}
@@ -46,9 +42,8 @@ func AuthenticateUser(email, password string) (*User, error) {
 	if err != nil {
 		return nil, err
 	}
-	
-	// BUG: Calls HashPasswords() (plural) which doesn't exist - should be HashPassword()
-	hashedPassword := utils.HashPasswords(password)
+
+	hashedPassword := utils.HashPassword(password)
 	
 	// Password validation would happen here
 	if user.PasswordHash != hashedPassword {
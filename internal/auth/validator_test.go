@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/test-repo-golang-support/pkg/conformance"
+)
+
+func TestJWTValidatorConformance(t *testing.T) {
+	conformance.RunTokenValidatorSuite(t, NewValidator("secret"), conformance.TokenCases{
+		Valid: []string{"token", "secret:user_123"},
+	})
+}
+
@@ -2,58 +2,104 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/test-repo-golang-support/handlers"
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/pkg/asynclog"
+	"github.com/test-repo-golang-support/pkg/container"
+	"github.com/test-repo-golang-support/pkg/logging"
+	"github.com/test-repo-golang-support/pkg/runtimeconfig"
+	"github.com/test-repo-golang-support/pkg/seed"
+	"github.com/test-repo-golang-support/pkg/storage"
 	"github.com/test-repo-golang-support/services"
 )
 
 const (
 	defaultPort    = "8081"
 	defaultTimeout = 15 * time.Second
+
+	defaultSlowRequestThreshold = time.Second
 )
 
 func main() {
+	os.Exit(runCLI(os.Args[1:]))
+}
+
+// serve runs the HTTP server until it receives SIGINT or SIGTERM and
+// returns the exit code; startup failures exit through fatalf
+func serve(opts serveOptions) int {
 	// Initialize logger
 	logger := log.New(os.Stdout, "[SERVER] ", log.LstdFlags|log.Lshortfile)
 
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
-	}
-
-	// Initialize services
-	userService := services.NewUserService()
-	orgService := services.NewOrganizationService()
+	// Move log writes, including audit and event lines, off the request path,
+	// filtering by level and sampling request logs before they are queued
+	logs := asynclog.New(os.Stdout, logBufferConfigFromEnv(logger))
+	control := logging.NewController(logs, loggingConfigFromEnv(logger))
+	logger.SetOutput(control)
 
-	// Seed some initial data
-	seedData(userService, orgService)
+	port := opts.port
 
-	// Initialize handlers
-	handler := handlers.NewHandler(userService, logger)
-	orgHandler := handlers.NewOrgHandler(orgService, logger)
+	// Size the Go runtime to the container before anything else starts
+	runtimeSettings := runtimeconfig.Apply(runtimeConfigFromEnv(logger), logger)
 
-	// Setup routes
-	router := handlers.SetupRoutes(handler, logger)
+	// Construct all components in dependency order
+	app := newContainer(logger, logs, control, appConfig{
+		Port:           port,
+		Retention:      retentionConfigFromEnv(logger),
+		Sitemap:        sitemapConfigFromEnv(logger),
+		Backfill:       backfillConfigFromEnv(logger),
+		Limits:         storeLimitsFromEnv(logger),
+		Avatars:        avatarStorageFromEnv(logger),
+		Runtime:        runtimeSettings,
+		SlowRequests:   slowRequestConfigFromEnv(logger),
+		Dedup:          dedupConfigFromEnv(logger),
+		SLOs:           sloConfigFromEnv(logger),
+		Scheduler:      schedulerConfigFromEnv(logger),
+		RateLimits:     rateLimitConfigFromEnv(logger),
+		Permissions:    permissionConfigFromEnv(logger),
+		ProjectArchive: projectArchiveConfigFromEnv(logger),
+		Admin:          adminConfigFromEnv(logger),
+		Email:          emailConfigFromEnv(logger),
+		Bodies:         requestBodyConfigFromEnv(logger),
+		Features:       featureConfigFromEnv(logger),
+	})
+	if err := app.Start(context.Background()); err != nil {
+		fatalf(logger, logs, "Failed to start application: %v", err)
+	}
+	logger.Printf("Started components: %s", strings.Join(app.Order(), ", "))
 
-	// Setup organization routes
-	api := router.PathPrefix("/api/v1").Subrouter()
-	handlers.SetupOrgRoutes(api, orgHandler)
+	userService, err := container.Get[*services.UserService](app, componentUserService)
+	if err != nil {
+		fatalf(logger, logs, "Failed to resolve user service: %v", err)
+	}
+	orgService, err := container.Get[*services.OrganizationService](app, componentOrgService)
+	if err != nil {
+		fatalf(logger, logs, "Failed to resolve organization service: %v", err)
+	}
+	server, err := container.Get[*http.Server](app, componentServer)
+	if err != nil {
+		fatalf(logger, logs, "Failed to resolve HTTP server: %v", err)
+	}
 
-	// Create HTTP server
-	server := &http.Server{
-		Addr:         fmt.Sprintf(":%s", port),
-		Handler:      router,
-		ReadTimeout:  defaultTimeout,
-		WriteTimeout: defaultTimeout,
-		IdleTimeout:  60 * time.Second,
+	// Seed initial data from fixtures
+	if seedConfig := seedConfigFor(logger, opts); seedConfig.Disabled {
+		logger.Printf("Seeding disabled")
+	} else {
+		seeded, err := seed.Run(context.Background(), seedConfig, userService, orgService)
+		if err != nil {
+			fatalf(logger, logs, "Failed to seed data: %v", err)
+		}
+		logger.Printf("Seeded %d users, %d organizations, %d memberships", seeded.Users, seeded.Organizations, seeded.Memberships)
 	}
 
 	// Channel to listen for shutdown signals
@@ -65,7 +111,7 @@ func main() {
 		logger.Printf("Starting server on port %s", port)
 		logger.Printf("API endpoints available at http://localhost:%s/api/v1", port)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatalf("Server failed to start: %v", err)
+			fatalf(logger, logs, "Server failed to start: %v", err)
 		}
 	}()
 
@@ -77,64 +123,537 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Attempt graceful shutdown
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Fatalf("Server forced to shutdown: %v", err)
+	// Attempt graceful shutdown, stopping components in reverse construction order
+	if err := app.Shutdown(ctx); err != nil {
+		fatalf(logger, logs, "Server forced to shutdown: %v", err)
 	}
 
+	if dropped := logs.Stats().Dropped; dropped > 0 {
+		logger.Printf("Log buffer overflowed: %d lines dropped", dropped)
+	}
 	logger.Println("Server stopped gracefully")
+	return exitOK
+}
+
+// fatalf logs like logger.Fatalf but flushes the async log buffer first,
+// so the reason for exiting and everything queued before it is not lost
+func fatalf(logger *log.Logger, logs *asynclog.Writer, format string, v ...interface{}) {
+	_ = logger.Output(2, fmt.Sprintf(format, v...))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	_ = logs.Shutdown(ctx)
+	cancel()
+	os.Exit(exitFailure)
+}
+
+// loggingConfigFromEnv reads LOG_LEVEL, LOG_LEVELS ("handlers=debug,services=warn")
+// and LOG_SAMPLE_ROUTES ("/health=0,/api/v1/users*=100", logging one in N
+// requests per route template)
+func loggingConfigFromEnv(logger *log.Logger) logging.Config {
+	config := logging.Config{Level: logging.LevelInfo, Packages: make(map[string]logging.Level)}
+
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		level, err := logging.ParseLevel(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid LOG_LEVEL: %v", err)
+		} else {
+			config.Level = level
+		}
+	}
+	if value := os.Getenv("LOG_LEVELS"); value != "" {
+		for _, pair := range strings.Split(value, ",") {
+			pkg, name, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			level, err := logging.ParseLevel(name)
+			if pkg == "" || err != nil {
+				logger.Printf("Ignoring invalid LOG_LEVELS entry %q", pair)
+				continue
+			}
+			config.Packages[pkg] = level
+		}
+	}
+	if value := os.Getenv("LOG_SAMPLE_ROUTES"); value != "" {
+		rules, err := logging.ParseSampleRules(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid LOG_SAMPLE_ROUTES: %v", err)
+		} else {
+			config.Sampling = rules
+		}
+	}
+
+	return config
+}
+
+// logBufferConfigFromEnv reads LOG_BUFFER_SIZE and LOG_BUFFER_POLICY
+// ("block" waits for room, "drop" discards and counts overflowing lines)
+func logBufferConfigFromEnv(logger *log.Logger) asynclog.Config {
+	config := asynclog.Config{BufferSize: intFromEnv(logger, "LOG_BUFFER_SIZE")}
+
+	if value := os.Getenv("LOG_BUFFER_POLICY"); value != "" {
+		switch policy := asynclog.Policy(value); policy {
+		case asynclog.PolicyBlock, asynclog.PolicyDrop:
+			config.Policy = policy
+		default:
+			logger.Printf("Ignoring invalid LOG_BUFFER_POLICY %q", value)
+		}
+	}
+
+	return config
+}
+
+// runtimeConfigFromEnv reads MEMORY_LIMIT_RATIO, the share of the
+// container memory limit to use as GOMEMLIMIT when GOMEMLIMIT is unset
+func runtimeConfigFromEnv(logger *log.Logger) runtimeconfig.Config {
+	var config runtimeconfig.Config
+
+	if value := os.Getenv("MEMORY_LIMIT_RATIO"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio <= 0 || ratio > 1 {
+			logger.Printf("Ignoring invalid MEMORY_LIMIT_RATIO %q", value)
+		} else {
+			config.MemoryLimitRatio = ratio
+		}
+	}
+
+	return config
+}
+
+// slowRequestConfigFromEnv reads SLOW_REQUEST_THRESHOLD (default 1s, 0
+// disables) and SLOW_REQUEST_TRACE, which logs the timed spans of slow requests
+func slowRequestConfigFromEnv(logger *log.Logger) handlers.SlowRequestConfig {
+	config := handlers.SlowRequestConfig{Threshold: defaultSlowRequestThreshold}
+
+	if value := os.Getenv("SLOW_REQUEST_THRESHOLD"); value != "" {
+		threshold, err := time.ParseDuration(value)
+		if err != nil || threshold < 0 {
+			logger.Printf("Ignoring invalid SLOW_REQUEST_THRESHOLD %q", value)
+		} else {
+			config.Threshold = threshold
+		}
+	}
+	if value := os.Getenv("SLOW_REQUEST_TRACE"); value != "" {
+		trace, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid SLOW_REQUEST_TRACE %q: %v", value, err)
+		}
+		config.Trace = trace
+	}
+
+	return config
 }
 
-// seedData adds some initial test users and organizations
-func seedData(userSvc *services.UserService, orgSvc *services.OrganizationService) {
-	ctx := context.Background()
-
-	// Create test users
-	users := []*struct {
-		firstName string
-		lastName  string
-		email     string
-		role      string
-	}{
-		{"John", "Doe", "john.doe@example.com", "admin"},
-		{"Jane", "Smith", "jane.smith@example.com", "user"},
-		{"Bob", "Wilson", "bob.wilson@example.com", "user"},
-	}
-
-	for i, u := range users {
-		user := services.CreateUser(
-			fmt.Sprintf("user_%d", i+1),
-			u.firstName,
-			u.lastName,
-			u.email,
-		)
-		user.SetRole(u.role)
-		_ = userSvc.Write(ctx, user)
-	}
-
-	// Create test organizations
-	orgs := []*struct {
-		name     string
-		industry string
-		ownerID  string
-	}{
-		{"Acme Corp", "Technology", "user_1"},
-		{"Global Industries", "Manufacturing", "user_2"},
-	}
-
-	for i, o := range orgs {
-		org := services.CreateOrganization(
-			fmt.Sprintf("org_%d", i+1),
-			o.name,
-			o.ownerID,
-		)
-		org.SetIndustry(o.industry)
-		_ = orgSvc.WriteOrg(ctx, org)
-
-		// Add owner as member
-		membership := services.CreateMembership(o.ownerID, org.ID, "owner")
-		_ = orgSvc.AddMember(ctx, membership)
+// dedupConfigFromEnv reads DEDUP_WINDOW, how long identical create-user and
+// create-organization submissions are answered with the first result
+// (e.g. "5s"); unset or 0 disables deduplication
+func dedupConfigFromEnv(logger *log.Logger) handlers.DedupConfig {
+	var config handlers.DedupConfig
+
+	if value := os.Getenv("DEDUP_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window < 0 {
+			logger.Printf("Ignoring invalid DEDUP_WINDOW %q", value)
+		} else {
+			config.Window = window
+		}
+	}
+
+	return config
+}
+
+// requestBodyConfigFromEnv reads MAX_REQUEST_BODY_BYTES, the largest JSON
+// request body accepted (default 1 MiB)
+func requestBodyConfigFromEnv(logger *log.Logger) handlers.RequestBodyConfig {
+	var config handlers.RequestBodyConfig
+
+	if value := os.Getenv("MAX_REQUEST_BODY_BYTES"); value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || maxBytes <= 0 {
+			logger.Printf("Ignoring invalid MAX_REQUEST_BODY_BYTES %q", value)
+		} else {
+			config.MaxBytes = maxBytes
+		}
 	}
+
+	return config
+}
+
+// permissionConfigFromEnv reads PERMISSIONS_REQUIRE_ACTOR (default true);
+// setting it to false lets guarded organization requests that lack an
+// X-Acting-User header through unchecked
+func permissionConfigFromEnv(logger *log.Logger) handlers.PermissionConfig {
+	var config handlers.PermissionConfig
+
+	if value := os.Getenv("PERMISSIONS_REQUIRE_ACTOR"); value != "" {
+		require, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid PERMISSIONS_REQUIRE_ACTOR %q: %v", value, err)
+		} else {
+			config.AllowAnonymous = !require
+		}
+	}
+
+	return config
+}
+
+// featureConfigFromEnv reads API_LEGACY_ERRORS (default false); setting it
+// to true keeps enveloped errors for clients that do not list problem+json
+// in X-API-Features
+func featureConfigFromEnv(logger *log.Logger) handlers.FeatureConfig {
+	var config handlers.FeatureConfig
+
+	if value := os.Getenv("API_LEGACY_ERRORS"); value != "" {
+		legacy, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid API_LEGACY_ERRORS %q: %v", value, err)
+		} else {
+			config.LegacyErrors = legacy
+		}
+	}
+
+	return config
+}
+
+// rateLimitConfigFromEnv reads RATE_LIMIT and RATE_LIMIT_WINDOW, the
+// requests each client may make per window (e.g. 120 per "1m"), and
+// RATE_LIMIT_QUOTA and RATE_LIMIT_QUOTA_PERIOD, the requests each client
+// may make per period (e.g. 10000 per "24h"). RATE_LIMIT_PRIORITY and
+// RATE_LIMIT_PRIORITY_QUOTA are the same limits for callers in
+// priority-support organizations. Unset values keep the defaults; 0 turns
+// a limit off.
+func rateLimitConfigFromEnv(logger *log.Logger) handlers.RateLimitConfig {
+	config := handlers.DefaultRateLimitConfig()
+
+	for name, limit := range map[string]*int{
+		"RATE_LIMIT":                &config.Limit,
+		"RATE_LIMIT_QUOTA":          &config.Quota,
+		"RATE_LIMIT_PRIORITY":       &config.PriorityLimit,
+		"RATE_LIMIT_PRIORITY_QUOTA": &config.PriorityQuota,
+	} {
+		if value := os.Getenv(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				logger.Printf("Ignoring invalid %s %q", name, value)
+				continue
+			}
+			*limit = n
+		}
+	}
+	for name, window := range map[string]*time.Duration{"RATE_LIMIT_WINDOW": &config.Window, "RATE_LIMIT_QUOTA_PERIOD": &config.QuotaPeriod} {
+		if value := os.Getenv(name); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				logger.Printf("Ignoring invalid %s %q", name, value)
+				continue
+			}
+			*window = d
+		}
+	}
+
+	return config
+}
+
+// adminConfigFromEnv reads ADMIN_TOKEN, the bearer token the admin API
+// requires. The admin API is disabled without it.
+func adminConfigFromEnv(logger *log.Logger) handlers.AdminConfig {
+	config := handlers.AdminConfig{Token: os.Getenv("ADMIN_TOKEN")}
+	if config.Token == "" {
+		logger.Printf("Admin API disabled: ADMIN_TOKEN is not set")
+	}
+	return config
+}
+
+// projectArchiveConfigFromEnv reads PROJECT_ARCHIVE_AFTER, how long a
+// project may go without updates before it is archived (unset disables the
+// job), PROJECT_ARCHIVE_INTERVAL and PROJECT_ARCHIVE_DRY_RUN
+func projectArchiveConfigFromEnv(logger *log.Logger) services.ProjectArchiveConfig {
+	var config services.ProjectArchiveConfig
+
+	if value := os.Getenv("PROJECT_ARCHIVE_AFTER"); value != "" {
+		after, err := time.ParseDuration(value)
+		if err != nil || after <= 0 {
+			logger.Printf("Ignoring invalid PROJECT_ARCHIVE_AFTER %q", value)
+		} else {
+			config.After = after
+		}
+	}
+	if value := os.Getenv("PROJECT_ARCHIVE_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Printf("Ignoring invalid PROJECT_ARCHIVE_INTERVAL %q", value)
+		} else {
+			config.Interval = interval
+		}
+	}
+	if value := os.Getenv("PROJECT_ARCHIVE_DRY_RUN"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid PROJECT_ARCHIVE_DRY_RUN %q: %v", value, err)
+		}
+		config.DryRun = dryRun
+	}
+
+	return config
+}
+
+// emailConfigFromEnv reads EMAIL_CHECK_MX, which rejects addresses whose
+// domain does not accept mail, and EMAIL_MX_TIMEOUT, the bound on each
+// lookup (default 3s)
+func emailConfigFromEnv(logger *log.Logger) services.EmailConfig {
+	var config services.EmailConfig
+
+	if value := os.Getenv("EMAIL_CHECK_MX"); value != "" {
+		check, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid EMAIL_CHECK_MX %q: %v", value, err)
+		}
+		config.CheckMX = check
+	}
+	if value := os.Getenv("EMAIL_MX_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			logger.Printf("Ignoring invalid EMAIL_MX_TIMEOUT %q", value)
+		} else {
+			config.Timeout = timeout
+		}
+	}
+
+	return config
+}
+
+// schedulerConfigFromEnv reads SCHEDULER_INTERVAL, how often scheduled
+// changes that have come due are applied; unset or invalid values fall
+// back to the default
+func schedulerConfigFromEnv(logger *log.Logger) services.SchedulerConfig {
+	var config services.SchedulerConfig
+
+	if value := os.Getenv("SCHEDULER_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Printf("Ignoring invalid SCHEDULER_INTERVAL %q", value)
+		} else {
+			config.Interval = interval
+		}
+	}
+
+	return config
+}
+
+// sloConfigFromEnv reads SLO_OBJECTIVES, a JSON array of objectives such as
+// [{"name":"users","routes":["/api/v1/users*"],"availability_target":99.9,
+// "latency_threshold_ms":300,"latency_target":99}], and SLO_EVALUATION_INTERVAL.
+// Without valid objectives the whole API gets a default objective.
+func sloConfigFromEnv(logger *log.Logger) services.SLOConfig {
+	config := services.SLOConfig{Objectives: services.DefaultSLObjectives()}
+
+	if value := os.Getenv("SLO_OBJECTIVES"); value != "" {
+		var objectives []services.SLObjective
+		if err := json.Unmarshal([]byte(value), &objectives); err != nil {
+			logger.Printf("Ignoring invalid SLO_OBJECTIVES: %v", err)
+		} else {
+			valid := objectives[:0]
+			for _, objective := range objectives {
+				if err := objective.Validate(); err != nil {
+					logger.Printf("Ignoring invalid SLO_OBJECTIVES entry: %v", err)
+					continue
+				}
+				valid = append(valid, objective)
+			}
+			if len(valid) > 0 {
+				config.Objectives = valid
+			}
+		}
+	}
+	if value := os.Getenv("SLO_EVALUATION_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			logger.Printf("Ignoring invalid SLO_EVALUATION_INTERVAL %q", value)
+		} else {
+			config.Interval = interval
+		}
+	}
+
+	return config
+}
+
+// retentionConfigFromEnv reads RETENTION_WINDOW, RETENTION_INTERVAL,
+// RETENTION_DRY_RUN and the SLA targets the janitor checks,
+// SLA_ORG_DELETION and SLA_SCHEDULED_CHANGE; unset or invalid values fall
+// back to the defaults
+func retentionConfigFromEnv(logger *log.Logger) services.RetentionConfig {
+	var config services.RetentionConfig
+
+	if value := os.Getenv("RETENTION_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid RETENTION_WINDOW %q: %v", value, err)
+		}
+		config.Window = window
+	}
+	if value := os.Getenv("RETENTION_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid RETENTION_INTERVAL %q: %v", value, err)
+		}
+		config.Interval = interval
+	}
+	if value := os.Getenv("RETENTION_DRY_RUN"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid RETENTION_DRY_RUN %q: %v", value, err)
+		}
+		config.DryRun = dryRun
+	}
+	if value := os.Getenv("SLA_ORG_DELETION"); value != "" {
+		target, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid SLA_ORG_DELETION %q: %v", value, err)
+		}
+		config.SLAs.OrgDeletion = target
+	}
+	if value := os.Getenv("SLA_SCHEDULED_CHANGE"); value != "" {
+		target, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid SLA_SCHEDULED_CHANGE %q: %v", value, err)
+		}
+		config.SLAs.ScheduledChange = target
+	}
+
+	return config
+}
+
+// sitemapConfigFromEnv reads PUBLIC_BASE_URL, ROBOTS_DISALLOW_ALL and
+// ROBOTS_DISALLOW (comma-separated path prefixes)
+func sitemapConfigFromEnv(logger *log.Logger) services.SitemapConfig {
+	config := services.SitemapConfig{
+		BaseURL: os.Getenv("PUBLIC_BASE_URL"),
+	}
+
+	if value := os.Getenv("ROBOTS_DISALLOW_ALL"); value != "" {
+		disallowAll, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid ROBOTS_DISALLOW_ALL %q: %v", value, err)
+		}
+		config.DisallowAll = disallowAll
+	}
+	if value := os.Getenv("ROBOTS_DISALLOW"); value != "" {
+		config.Disallow = strings.Split(value, ",")
+	}
+
+	return config
+}
+
+// backfillConfigFromEnv reads BACKFILL_BATCH_SIZE and BACKFILL_BATCH_INTERVAL;
+// unset or invalid values fall back to the defaults
+func backfillConfigFromEnv(logger *log.Logger) services.BackfillConfig {
+	var config services.BackfillConfig
+
+	if value := os.Getenv("BACKFILL_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid BACKFILL_BATCH_SIZE %q: %v", value, err)
+		}
+		config.BatchSize = size
+	}
+	if value := os.Getenv("BACKFILL_BATCH_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid BACKFILL_BATCH_INTERVAL %q: %v", value, err)
+		}
+		config.BatchInterval = interval
+	}
+
+	return config
+}
+
+// storeLimitsFromEnv reads USER_STORE_MAX_ENTITIES, USER_STORE_MAX_BYTES,
+// ORG_STORE_MAX_ENTITIES, ORG_STORE_MAX_BYTES and STORE_LIMIT_POLICY
+// (reject or evict_deleted); unset or invalid values leave stores unlimited
+func storeLimitsFromEnv(logger *log.Logger) services.StoreLimitsConfig {
+	policy := services.StoreLimitPolicy(os.Getenv("STORE_LIMIT_POLICY"))
+	if policy != "" && !policy.IsValid() {
+		logger.Printf("Ignoring invalid STORE_LIMIT_POLICY %q", policy)
+		policy = ""
+	}
+
+	config := services.StoreLimitsConfig{
+		Users: services.StoreLimits{Policy: policy},
+		Orgs:  services.StoreLimits{Policy: policy},
+	}
+	config.Users.MaxEntities = intFromEnv(logger, "USER_STORE_MAX_ENTITIES")
+	config.Users.MaxBytes = int64(intFromEnv(logger, "USER_STORE_MAX_BYTES"))
+	config.Orgs.MaxEntities = intFromEnv(logger, "ORG_STORE_MAX_ENTITIES")
+	config.Orgs.MaxBytes = int64(intFromEnv(logger, "ORG_STORE_MAX_BYTES"))
+
+	return config
+}
+
+// avatarStorageFromEnv selects where uploaded avatars are stored. With
+// AVATAR_STORAGE=s3 it reads S3_ENDPOINT, S3_REGION, S3_BUCKET,
+// S3_PUBLIC_URL, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY; otherwise
+// avatars are written under AVATAR_DIR (default data/avatars) and served
+// by this server
+func avatarStorageFromEnv(logger *log.Logger) interfaces.BlobStorage {
+	switch backend := os.Getenv("AVATAR_STORAGE"); backend {
+	case "s3":
+		config := storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		}
+		if config.Region == "" {
+			config.Region = "us-east-1"
+		}
+		if config.Endpoint == "" {
+			config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+		}
+		return storage.NewS3Storage(config)
+	case "", "local":
+	default:
+		logger.Printf("Ignoring unknown AVATAR_STORAGE %q; using local disk", backend)
+	}
+
+	dir := os.Getenv("AVATAR_DIR")
+	if dir == "" {
+		dir = "data/avatars"
+	}
+	return storage.NewLocalStorage(dir, "/avatars")
+}
+
+// intFromEnv reads a non-negative integer, returning 0 when unset or invalid
+func intFromEnv(logger *log.Logger, name string) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		logger.Printf("Ignoring invalid %s %q", name, value)
+		return 0
+	}
+	return n
+}
+
+// seedConfigFromEnv reads SEED_FILE, a .json or .yaml fixture file
+// (unset seeds the built-in fixtures), and SEED_DISABLED. Seeding is off
+// when APP_ENV is production unless SEED_DISABLED=false says otherwise.
+func seedConfigFromEnv(logger *log.Logger) seed.Config {
+	config := seed.Config{
+		File:     os.Getenv("SEED_FILE"),
+		Disabled: strings.EqualFold(os.Getenv("APP_ENV"), "production"),
+	}
+
+	if value := os.Getenv("SEED_DISABLED"); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Printf("Ignoring invalid SEED_DISABLED %q: %v", value, err)
+		} else {
+			config.Disabled = disabled
+		}
+	}
+
+	return config
 }
 
 // init function runs before main
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"net/http"
+)
+
+// ErrorCode is a machine-readable error identifier carried by error
+// responses, so clients can tell failures apart without parsing messages
+type ErrorCode string
+
+// Error code constants
+const (
+	ErrorCodeInvalidRequest       ErrorCode = "invalid_request"        // 400: malformed body or parameters
+	ErrorCodeUnauthorized         ErrorCode = "unauthorized"           // 401: missing or invalid credentials
+	ErrorCodeForbidden            ErrorCode = "forbidden"              // 403: the actor may not do this
+	ErrorCodeNotFound             ErrorCode = "not_found"              // 404
+	ErrorCodeConflict             ErrorCode = "conflict"               // 409: duplicate or concurrent modification
+	ErrorCodeGone                 ErrorCode = "gone"                   // 410
+	ErrorCodePreconditionFailed   ErrorCode = "precondition_failed"    // 412: If-Match did not match
+	ErrorCodePayloadTooLarge      ErrorCode = "payload_too_large"      // 413
+	ErrorCodeUnsupportedMediaType ErrorCode = "unsupported_media_type" // 415
+	ErrorCodeValidationFailed     ErrorCode = "validation_failed"      // 422: see the field errors
+	ErrorCodePreconditionRequired ErrorCode = "precondition_required"  // 428: If-Match is required
+	ErrorCodeRateLimited          ErrorCode = "rate_limited"           // 429
+	ErrorCodeInternal             ErrorCode = "internal_error"         // 500
+	ErrorCodeUnavailable          ErrorCode = "unavailable"            // 503
+)
+
+// errorCodesByStatus maps HTTP statuses to their default error code
+var errorCodesByStatus = map[int]ErrorCode{
+	http.StatusBadRequest:            ErrorCodeInvalidRequest,
+	http.StatusUnauthorized:          ErrorCodeUnauthorized,
+	http.StatusForbidden:             ErrorCodeForbidden,
+	http.StatusNotFound:              ErrorCodeNotFound,
+	http.StatusConflict:              ErrorCodeConflict,
+	http.StatusGone:                  ErrorCodeGone,
+	http.StatusPreconditionFailed:    ErrorCodePreconditionFailed,
+	http.StatusRequestEntityTooLarge: ErrorCodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:  ErrorCodeUnsupportedMediaType,
+	http.StatusUnprocessableEntity:   ErrorCodeValidationFailed,
+	http.StatusPreconditionRequired:  ErrorCodePreconditionRequired,
+	http.StatusTooManyRequests:       ErrorCodeRateLimited,
+	http.StatusInternalServerError:   ErrorCodeInternal,
+	http.StatusServiceUnavailable:    ErrorCodeUnavailable,
+}
+
+// FieldError describes why one field of a request was rejected
+type FieldError struct {
+	Field   string `json:"field"`   // JSON name of the field, e.g. "email"
+	Code    string `json:"code"`    // Rule that failed, e.g. "required"
+	Message string `json:"message"` // Human-readable explanation
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// ErrorCodeForStatus returns the default error code of an HTTP error
+// status; unlisted 4xx statuses are invalid requests and 5xx statuses
+// internal errors (standalone function)
+func ErrorCodeForStatus(status int) ErrorCode {
+	if code, exists := errorCodesByStatus[status]; exists {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrorCodeInternal
+	}
+	return ErrorCodeInvalidRequest
+}
+
+// NewErrorResponse creates the error envelope for a status, with the
+// status's default error code (standalone function)
+func NewErrorResponse(status int, message string, fields ...FieldError) APIResponse {
+	return APIResponse{
+		Code:      ResponseError,
+		Message:   message,
+		ErrorCode: ErrorCodeForStatus(status),
+		Errors:    fields,
+	}
+}
+
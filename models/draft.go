@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DraftKind is the kind of resource a draft publishes as
+type DraftKind string
+
+// Draft kind constants
+const (
+	DraftKindUser         DraftKind = "user"
+	DraftKindOrganization DraftKind = "organization"
+	DraftKindProject      DraftKind = "project"
+)
+
+// Draft is a user, organization, or project being assembled over several
+// steps. Its fields are the body the resource's create endpoint accepts;
+// they are only checked for shape until the draft is published. Drafts
+// are kept apart from live records, so no listing or search shows them.
+type Draft struct {
+	BaseEntity                 // Embedded struct
+	Kind       DraftKind       `json:"kind"`
+	CreatedBy  UserID          `json:"created_by,omitempty"`
+	Fields     json.RawMessage `json:"fields"`
+}
+
+// DraftPublication is the result of publishing a draft
+type DraftPublication struct {
+	DraftID     string      `json:"draft_id"`
+	Kind        DraftKind   `json:"kind"`
+	ResourceID  string      `json:"resource_id"`
+	Resource    interface{} `json:"resource"`
+	PublishedAt time.Time   `json:"published_at"`
+}
+
+// =====================================
+// Value Receiver Methods on DraftKind
+// =====================================
+
+// IsValid checks if the kind is a known draft kind (value receiver)
+func (k DraftKind) IsValid() bool {
+	switch k {
+	case DraftKindUser, DraftKindOrganization, DraftKindProject:
+		return true
+	}
+	return false
+}
+
+// =====================================
+// Constructor Functions for Draft
+// =====================================
+
+// NewDraft creates a new Draft
+func NewDraft(id string, kind DraftKind, createdBy UserID, fields json.RawMessage) *Draft {
+	now := Now()
+	return &Draft{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Kind:      kind,
+		CreatedBy: createdBy,
+		Fields:    fields,
+	}
+}
+
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"github.com/test-repo-golang-support/pkg/i18n"
+)
+
+// EnumKind names a managed enumeration
+type EnumKind string
+
+// Managed enumeration kinds
+const (
+	EnumKindOrgSize    EnumKind = "org_size"
+	EnumKindMemberRole EnumKind = "member_role"
+)
+
+// EnumValue is an allowed value with localized display labels
+type EnumValue struct {
+	Value   string            `json:"value"`
+	Labels  map[string]string `json:"labels"`
+	Builtin bool              `json:"builtin"`
+}
+
+// EnumDefinition lists the allowed values of an enumeration. Only
+// extensible enumerations accept values added at runtime.
+type EnumDefinition struct {
+	Kind       EnumKind    `json:"kind"`
+	Extensible bool        `json:"extensible"`
+	Values     []EnumValue `json:"values"`
+}
+
+// =====================================
+// Value Receiver Methods on Enums
+// =====================================
+
+// Label returns the display label for a locale such as "de-AT", falling
+// back to the base language, then English, then the raw value (value receiver)
+func (v EnumValue) Label(locale string) string {
+	if label, ok := i18n.Pick(v.Labels, locale); ok {
+		return label
+	}
+	return v.Value
+}
+
+// Has checks if value is allowed (value receiver)
+func (d EnumDefinition) Has(value string) bool {
+	for _, v := range d.Values {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone returns a deep copy of the definition (value receiver)
+func (d EnumDefinition) Clone() EnumDefinition {
+	clone := d
+	clone.Values = make([]EnumValue, len(d.Values))
+	for i, v := range d.Values {
+		labels := make(map[string]string, len(v.Labels))
+		for locale, label := range v.Labels {
+			labels[locale] = label
+		}
+		v.Labels = labels
+		clone.Values[i] = v
+	}
+	return clone
+}
+
+// =====================================
+// Built-in Enumerations
+// =====================================
+
+// BuiltinEnums returns the enumerations backed by OrgSize and MemberRole.
+// Org size bands are extensible; member roles are not, since roles drive
+// authorization decisions.
+func BuiltinEnums() []EnumDefinition {
+	return []EnumDefinition{
+		{
+			Kind:       EnumKindOrgSize,
+			Extensible: true,
+			Values: []EnumValue{
+				builtinEnumValue(string(OrgSizeSmall), "Small", "Pequeña", "Klein"),
+				builtinEnumValue(string(OrgSizeMedium), "Medium", "Mediana", "Mittel"),
+				builtinEnumValue(string(OrgSizeLarge), "Large", "Grande", "Groß"),
+				builtinEnumValue(string(OrgSizeEnterprise), "Enterprise", "Empresa", "Konzern"),
+			},
+		},
+		{
+			Kind:       EnumKindMemberRole,
+			Extensible: false,
+			Values: []EnumValue{
+				builtinEnumValue(string(MemberRoleOwner), "Owner", "Propietario", "Inhaber"),
+				builtinEnumValue(string(MemberRoleAdmin), "Admin", "Administrador", "Administrator"),
+				builtinEnumValue(string(MemberRoleMember), "Member", "Miembro", "Mitglied"),
+				builtinEnumValue(string(MemberRoleGuest), "Guest", "Invitado", "Gast"),
+			},
+		},
+	}
+}
+
+// builtinEnumValue creates a built-in value with English, Spanish, and German labels
+func builtinEnumValue(value, en, es, de string) EnumValue {
+	return EnumValue{
+		Value:   value,
+		Labels:  map[string]string{"en": en, "es": es, "de": de},
+		Builtin: true,
+	}
+}
+
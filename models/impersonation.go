@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// Impersonation lets an administrator act as a user for support. API
+// requests that present its secret token are made on the user's behalf
+// until it expires or is ended; only a hash of the token is kept.
+type Impersonation struct {
+	BaseEntity            // Embedded struct
+	AdminID    UserID     `json:"admin_id"`
+	UserID     UserID     `json:"user_id"`
+	Reason     string     `json:"reason"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	TokenHash  string     `json:"-"`
+	Token      string     `json:"token,omitempty"` // Only set in the response that issues it
+}
+
+// =====================================
+// Value Receiver Methods on Impersonation
+// =====================================
+
+// IsActive checks if the impersonation has neither ended nor expired (value receiver)
+func (i Impersonation) IsActive(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// =====================================
+// Constructor Functions for Impersonation
+// =====================================
+
+// NewImpersonation creates a new Impersonation
+func NewImpersonation(id string, adminID, userID UserID, reason string, expiresAt time.Time) *Impersonation {
+	now := Now()
+	return &Impersonation{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		AdminID:   adminID,
+		UserID:    userID,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+	}
+}
+
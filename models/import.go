@@ -0,0 +1,87 @@
+package models
+
+// ImportStatus represents the state of a bulk import
+type ImportStatus string
+
+// Import status constants
+const (
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+// ImportRowError describes a CSV row that was rejected during import
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Email  string   `json:"email"`
+	Reason string   `json:"reason"`
+	Record []string `json:"-"` // Original CSV fields, used for the error report
+}
+
+// ImportResult tracks the outcome of a user/membership CSV import
+// Demonstrates struct embedding
+type ImportResult struct {
+	BaseEntity                          // Embedded struct
+	Status             ImportStatus     `json:"status"`
+	RowsProcessed      int              `json:"rows_processed"`
+	UsersCreated       int              `json:"users_created"`
+	MembershipsCreated int              `json:"memberships_created"`
+	Rejected           []ImportRowError `json:"rejected"`
+	Header             []string         `json:"-"` // CSV header, used for the error report
+}
+
+// =====================================
+// Value Receiver Methods on ImportResult
+// =====================================
+
+// RejectedCount returns the number of rejected rows (value receiver)
+func (r ImportResult) RejectedCount() int {
+	return len(r.Rejected)
+}
+
+// HasErrors checks if any rows were rejected (value receiver)
+func (r ImportResult) HasErrors() bool {
+	return len(r.Rejected) > 0
+}
+
+// =====================================
+// Pointer Receiver Methods on ImportResult
+// =====================================
+
+// Reject records a rejected row (pointer receiver)
+func (r *ImportResult) Reject(row int, email, reason string, record []string) {
+	r.Rejected = append(r.Rejected, ImportRowError{
+		Row:    row,
+		Email:  email,
+		Reason: reason,
+		Record: record,
+	})
+	r.UpdatedAt = Now()
+}
+
+// Complete marks the import as finished (pointer receiver)
+func (r *ImportResult) Complete() {
+	r.Status = ImportStatusCompleted
+	r.UpdatedAt = Now()
+}
+
+// Fail marks the import as failed (pointer receiver)
+func (r *ImportResult) Fail() {
+	r.Status = ImportStatusFailed
+	r.UpdatedAt = Now()
+}
+
+// NewImportResult creates a new running ImportResult
+func NewImportResult(id string) *ImportResult {
+	now := Now()
+	return &ImportResult{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Status:   ImportStatusRunning,
+		Rejected: make([]ImportRowError, 0),
+	}
+}
+
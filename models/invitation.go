@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+)
+
+// InvitationStatus represents where an invitation is in its lifecycle
+type InvitationStatus string
+
+// Invitation status constants. Expired is never stored; it is reported
+// for pending invitations past their expiry.
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// Invitation asks someone, by email address, to join an organization. It
+// is accepted through a secret token sent to that address; only a hash of
+// the token is kept.
+type Invitation struct {
+	BaseEntity                  // Embedded struct
+	OrgID      OrgID            `json:"org_id"`
+	Email      string           `json:"email"`
+	Role       MemberRole       `json:"role"`
+	InvitedBy  UserID           `json:"invited_by"`
+	Status     InvitationStatus `json:"status"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	AcceptedBy UserID           `json:"accepted_by,omitempty"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	RevokedBy  UserID           `json:"revoked_by,omitempty"`
+	RevokedAt  *time.Time       `json:"revoked_at,omitempty"`
+	TokenHash  string           `json:"-"`
+	Token      string           `json:"token,omitempty"` // Only set in the response that issues it
+}
+
+// =====================================
+// Value Receiver Methods on Invitation
+// =====================================
+
+// IsValid checks if the status is a known invitation status (value receiver)
+func (s InvitationStatus) IsValid() bool {
+	switch s {
+	case InvitationPending, InvitationAccepted, InvitationRevoked, InvitationExpired:
+		return true
+	}
+	return false
+}
+
+// IsExpired checks if a pending invitation has passed its expiry (value receiver)
+func (i Invitation) IsExpired(now time.Time) bool {
+	return i.Status == InvitationPending && !now.Before(i.ExpiresAt)
+}
+
+// CurrentStatus returns the status, reporting expired pending invitations
+// as expired (value receiver)
+func (i Invitation) CurrentStatus(now time.Time) InvitationStatus {
+	if i.IsExpired(now) {
+		return InvitationExpired
+	}
+	return i.Status
+}
+
+// =====================================
+// Constructor Functions for Invitation
+// =====================================
+
+// NewInvitation creates a new pending Invitation
+func NewInvitation(id string, orgID OrgID, email string, role MemberRole, invitedBy UserID, expiresAt time.Time) *Invitation {
+	now := Now()
+	return &Invitation{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:     orgID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: invitedBy,
+		Status:    InvitationPending,
+		ExpiresAt: expiresAt,
+	}
+}
+
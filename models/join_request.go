@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// JoinRequestStatus represents where a join request is in its review
+type JoinRequestStatus string
+
+// Join request status constants
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestDenied   JoinRequestStatus = "denied"
+)
+
+// JoinRequest is a user's request to become a member of a public organization.
+// Owners and admins of the organization approve or deny it.
+type JoinRequest struct {
+	BaseEntity                   // Embedded struct
+	OrgID      OrgID             `json:"org_id"`
+	UserID     UserID            `json:"user_id"`
+	Message    string            `json:"message,omitempty"`
+	Status     JoinRequestStatus `json:"status"`
+	DecidedBy  UserID            `json:"decided_by,omitempty"`
+	DecidedAt  *time.Time        `json:"decided_at,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+}
+
+// =====================================
+// Value Receiver Methods on JoinRequest
+// =====================================
+
+// IsValid checks if the status is a known join request status (value receiver)
+func (s JoinRequestStatus) IsValid() bool {
+	switch s {
+	case JoinRequestPending, JoinRequestApproved, JoinRequestDenied:
+		return true
+	}
+	return false
+}
+
+// IsPending checks if the request still awaits a decision (value receiver)
+func (r JoinRequest) IsPending() bool {
+	return r.Status == JoinRequestPending
+}
+
+// =====================================
+// Constructor Functions for JoinRequest
+// =====================================
+
+// NewJoinRequest creates a new pending JoinRequest
+func NewJoinRequest(id string, orgID OrgID, userID UserID, message string) *JoinRequest {
+	now := Now()
+	return &JoinRequest{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:   orgID,
+		UserID:  userID,
+		Message: message,
+		Status:  JoinRequestPending,
+	}
+}
+
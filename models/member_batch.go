@@ -0,0 +1,48 @@
+package models
+
+// MemberOperationKind is what a batch membership operation does
+type MemberOperationKind string
+
+// Member operation kinds
+const (
+	MemberOperationAdd        MemberOperationKind = "add"
+	MemberOperationRemove     MemberOperationKind = "remove"
+	MemberOperationChangeRole MemberOperationKind = "change_role"
+)
+
+// MemberOperation is one item of a batch membership request
+type MemberOperation struct {
+	Op     MemberOperationKind `json:"op"`
+	UserID UserID              `json:"user_id"`
+	Role   MemberRole          `json:"role,omitempty"` // Required for change_role; add defaults to member
+}
+
+// MemberOperationResult reports the outcome of one batch item. Status is
+// the HTTP status the matching single-member request would have returned.
+type MemberOperationResult struct {
+	Index      int                 `json:"index"`
+	Op         MemberOperationKind `json:"op"`
+	UserID     UserID              `json:"user_id"`
+	Status     int                 `json:"status"`
+	Error      string              `json:"error,omitempty"`
+	Membership *Membership         `json:"membership,omitempty"`
+}
+
+// =====================================
+// Value Receiver Methods on MemberOperation
+// =====================================
+
+// IsValid checks if the kind is a known operation (value receiver)
+func (k MemberOperationKind) IsValid() bool {
+	switch k {
+	case MemberOperationAdd, MemberOperationRemove, MemberOperationChangeRole:
+		return true
+	}
+	return false
+}
+
+// Succeeded checks if the operation was applied (value receiver)
+func (r MemberOperationResult) Succeeded() bool {
+	return r.Error == ""
+}
+
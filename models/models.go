@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -28,6 +30,7 @@ type BaseEntity struct {
 	ID        string    `json:"id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"`
 }
 
 // Timestamps is another embeddable struct for audit fields
@@ -35,6 +38,11 @@ type Timestamps struct {
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// IsDeleted checks if the entity has been soft-deleted (value receiver)
+func (t Timestamps) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
 // User represents a user in the system
 // Demonstrates struct composition through embedding
 type User struct {
@@ -45,6 +53,8 @@ type User struct {
 	Email      string     `json:"email"`
 	Role       string     `json:"role"`
 	Active     bool       `json:"active"`
+	Locale     string     `json:"locale,omitempty"`   // Preferred locale; overrides the org default
+	Timezone   string     `json:"timezone,omitempty"` // Preferred IANA time zone; overrides the org default
 }
 
 // Profile represents user profile information
@@ -57,11 +67,23 @@ type Profile struct {
 	Website    string `json:"website"`
 }
 
-// APIResponse is a generic response wrapper
+// UserRoleAdmin is the user role of administrators
+const UserRoleAdmin = "admin"
+
+// APIResponse is the envelope every JSON response is wrapped in
 type APIResponse struct {
-	Code    ResponseCode `json:"code"`
-	Message string       `json:"message"`
-	Data    interface{}  `json:"data,omitempty"`
+	Code      ResponseCode  `json:"code"`
+	Message   string        `json:"message"`
+	Data      interface{}   `json:"data,omitempty"`
+	Meta      *ResponseMeta `json:"meta,omitempty"`       // Set when Data is a list
+	ErrorCode ErrorCode     `json:"error_code,omitempty"` // Set on error responses
+	Errors    []FieldError  `json:"errors,omitempty"`     // Per-field validation errors
+}
+
+// ResponseMeta describes the list carried in an APIResponse
+type ResponseMeta struct {
+	Count      int    `json:"count"`                 // Items in Data
+	NextCursor string `json:"next_cursor,omitempty"` // Set when cursor pagination has another page
 }
 
 // =====================================
@@ -79,6 +101,12 @@ func (u User) IsActive() bool {
 	return u.Active
 }
 
+// IsAdmin checks if the user holds the administrator role, which the admin
+// API requires (value receiver)
+func (u User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
 // GetAge returns how long the user has existed (value receiver)
 func (u User) GetAge() time.Duration {
 	return time.Since(u.CreatedAt)
@@ -97,26 +125,38 @@ func (u User) String() string {
 // Pointer receivers can modify the original struct
 func (u *User) UpdateEmail(email string) {
 	u.Email = email
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
 }
 
 // UpdateName updates the user's name (pointer receiver)
 func (u *User) UpdateName(firstName, lastName string) {
 	u.FirstName = firstName
 	u.LastName = lastName
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
 }
 
 // SetRole sets the user's role (pointer receiver)
 func (u *User) SetRole(role string) {
 	u.Role = role
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
+}
+
+// SetLocale sets the user's preferred locale (pointer receiver)
+func (u *User) SetLocale(locale string) {
+	u.Locale = locale
+	u.UpdatedAt = Now()
+}
+
+// SetTimezone sets the user's preferred time zone (pointer receiver)
+func (u *User) SetTimezone(timezone string) {
+	u.Timezone = timezone
+	u.UpdatedAt = Now()
 }
 
 // Deactivate marks the user as inactive (pointer receiver)
 func (u *User) Deactivate() {
 	u.Active = false
-	now := time.Now()
+	now := Now()
 	u.DeletedAt = &now
 	u.UpdatedAt = now
 }
@@ -125,7 +165,7 @@ func (u *User) Deactivate() {
 func (u *User) Activate() {
 	u.Active = true
 	u.DeletedAt = nil
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
 }
 
 // Serialize converts user to JSON (pointer receiver - implements Serializer)
@@ -149,6 +189,11 @@ func (u *User) Validate() error {
 	if u.FirstName == "" {
 		return errors.New("first name is required")
 	}
+	if u.Timezone != "" {
+		if _, err := time.LoadLocation(u.Timezone); err != nil {
+			return errors.New("unknown timezone")
+		}
+	}
 	return nil
 }
 
@@ -158,7 +203,7 @@ func (u *User) Validate() error {
 
 // NewUser creates a new User with initialized BaseEntity
 func NewUser(id, firstName, lastName, email string) *User {
-	now := time.Now()
+	now := Now()
 	return &User{
 		BaseEntity: BaseEntity{
 			ID:        id,
@@ -174,7 +219,7 @@ func NewUser(id, firstName, lastName, email string) *User {
 
 // NewProfile creates a new Profile for a user
 func NewProfile(id string, userID UserID) *Profile {
-	now := time.Now()
+	now := Now()
 	return &Profile{
 		BaseEntity: BaseEntity{
 			ID:        id,
@@ -199,13 +244,23 @@ func (b BaseEntity) Age() time.Duration {
 	return time.Since(b.CreatedAt)
 }
 
+// GetVersion returns the entity version (value receiver)
+func (b BaseEntity) GetVersion() int64 {
+	return b.Version
+}
+
 // =====================================
 // Pointer Receiver Methods on BaseEntity
 // =====================================
 
 // Touch updates the UpdatedAt timestamp (pointer receiver)
 func (b *BaseEntity) Touch() {
-	b.UpdatedAt = time.Now()
+	b.UpdatedAt = Now()
+}
+
+// IncrementVersion bumps the optimistic concurrency version (pointer receiver)
+func (b *BaseEntity) IncrementVersion() {
+	b.Version++
 }
 
 // =====================================
@@ -222,6 +277,19 @@ func (p Profile) HasWebsite() bool {
 	return p.Website != ""
 }
 
+// Validate checks that the profile links are absolute http(s) URLs; the
+// avatar may also be a path on this server, as stored by local avatar
+// uploads (value receiver)
+func (p Profile) Validate() error {
+	if p.Website != "" && !isHTTPURL(p.Website) {
+		return errors.New("website must be an absolute http(s) URL")
+	}
+	if p.AvatarURL != "" && !isHTTPURL(p.AvatarURL) && !isLocalPath(p.AvatarURL) {
+		return errors.New("avatar_url must be an absolute http(s) URL or a path on this server")
+	}
+	return nil
+}
+
 // =====================================
 // Pointer Receiver Methods on Profile
 // =====================================
@@ -229,13 +297,19 @@ func (p Profile) HasWebsite() bool {
 // SetBio updates the profile bio (pointer receiver)
 func (p *Profile) SetBio(bio string) {
 	p.Bio = bio
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
 }
 
 // SetAvatarURL updates the avatar URL (pointer receiver)
 func (p *Profile) SetAvatarURL(url string) {
 	p.AvatarURL = url
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
+}
+
+// SetWebsite updates the profile website (pointer receiver)
+func (p *Profile) SetWebsite(website string) {
+	p.Website = website
+	p.UpdatedAt = Now()
 }
 
 // =====================================
@@ -273,6 +347,21 @@ type Organization struct {
 	Size        OrgSize     `json:"size"`
 	Active      bool        `json:"active"`
 	OwnerID     UserID      `json:"owner_id"` // Using type alias
+	Slug        string      `json:"slug,omitempty"`
+	Public      bool        `json:"public_profile"` // Opt-in public read-only profile
+	Verified    bool        `json:"verified"`       // Set by administrators only
+	Support     OrgSupport  `json:"support"`        // Set by administrators only
+}
+
+// PublicOrgProfile is the curated subset of an organization served without authentication
+type PublicOrgProfile struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Industry    string `json:"industry"`
+	Website     string `json:"website,omitempty"`
+	Verified    bool   `json:"verified"`
 }
 
 // OrgSize represents organization size category
@@ -336,6 +425,24 @@ func (o Organization) FullAddress() string {
 	)
 }
 
+// HasPublicProfile checks if the organization opted in to a public profile (value receiver)
+func (o Organization) HasPublicProfile() bool {
+	return o.Public && o.Slug != ""
+}
+
+// PublicProfile returns the fields safe to publish (value receiver)
+func (o Organization) PublicProfile() PublicOrgProfile {
+	return PublicOrgProfile{
+		ID:          o.ID,
+		Slug:        o.Slug,
+		Name:        o.Name,
+		Description: o.Description,
+		Industry:    o.Industry,
+		Website:     o.ContactInfo.Website,
+		Verified:    o.Verified,
+	}
+}
+
 // String implements Stringer interface (value receiver)
 func (o Organization) String() string {
 	return fmt.Sprintf("Organization{ID: %s, Name: %s, Industry: %s}", o.ID, o.Name, o.Industry)
@@ -348,43 +455,67 @@ func (o Organization) String() string {
 // UpdateName updates the organization name (pointer receiver)
 func (o *Organization) UpdateName(name string) {
 	o.Name = name
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // UpdateDescription updates the description (pointer receiver)
 func (o *Organization) UpdateDescription(desc string) {
 	o.Description = desc
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // SetIndustry sets the industry (pointer receiver)
 func (o *Organization) SetIndustry(industry string) {
 	o.Industry = industry
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // SetSize sets the organization size (pointer receiver)
 func (o *Organization) SetSize(size OrgSize) {
 	o.Size = size
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // UpdateAddress updates the address (pointer receiver)
 func (o *Organization) UpdateAddress(addr Address) {
 	o.Address = addr
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // UpdateContact updates contact info (pointer receiver)
 func (o *Organization) UpdateContact(contact ContactInfo) {
 	o.ContactInfo = contact
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
+}
+
+// SetSlug sets the URL slug used by the public profile (pointer receiver)
+func (o *Organization) SetSlug(slug string) {
+	o.Slug = slug
+	o.UpdatedAt = Now()
+}
+
+// SetPublicProfile opts the organization in or out of a public profile (pointer receiver)
+func (o *Organization) SetPublicProfile(enabled bool) {
+	o.Public = enabled
+	o.UpdatedAt = Now()
+}
+
+// SetVerified grants or revokes the verified badge (pointer receiver)
+func (o *Organization) SetVerified(verified bool) {
+	o.Verified = verified
+	o.UpdatedAt = Now()
+}
+
+// SetSupport replaces the support tier and escalation settings (pointer receiver)
+func (o *Organization) SetSupport(support OrgSupport) {
+	o.Support = support
+	o.UpdatedAt = Now()
 }
 
 // Deactivate marks organization as inactive (pointer receiver)
 func (o *Organization) Deactivate() {
 	o.Active = false
-	now := time.Now()
+	now := Now()
 	o.DeletedAt = &now
 	o.UpdatedAt = now
 }
@@ -393,7 +524,7 @@ func (o *Organization) Deactivate() {
 func (o *Organization) Activate() {
 	o.Active = true
 	o.DeletedAt = nil
-	o.UpdatedAt = time.Now()
+	o.UpdatedAt = Now()
 }
 
 // Serialize converts organization to JSON (pointer receiver)
@@ -417,16 +548,38 @@ func (o *Organization) Validate() error {
 	if o.OwnerID == "" {
 		return errors.New("owner ID is required")
 	}
+	if o.Slug != "" && !IsValidSlug(o.Slug) {
+		return errors.New("slug must be lowercase letters, digits, and hyphens")
+	}
+	if o.Public && o.Slug == "" {
+		return errors.New("a public profile requires a slug")
+	}
+	if err := o.Support.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// IsValidSlug checks if s is a lowercase, hyphen-separated URL slug (standalone function)
+func IsValidSlug(s string) bool {
+	if s == "" || len(s) > 64 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
 // =====================================
 // Constructor Functions for Organization
 // =====================================
 
 // NewOrganization creates a new Organization with initialized fields
 func NewOrganization(id, name, ownerID string) *Organization {
-	now := time.Now()
+	now := Now()
 	return &Organization{
 		BaseEntity: BaseEntity{
 			ID:        id,
@@ -442,7 +595,7 @@ func NewOrganization(id, name, ownerID string) *Organization {
 
 // NewMembership creates a new Membership
 func NewMembership(id string, userID UserID, orgID OrgID, role MemberRole) *Membership {
-	now := time.Now()
+	now := Now()
 	return &Membership{
 		BaseEntity: BaseEntity{
 			ID:        id,
@@ -460,17 +613,25 @@ func NewMembership(id string, userID UserID, orgID OrgID, role MemberRole) *Memb
 // Value Receiver Methods on Membership
 // =====================================
 
-// IsOwner checks if membership is owner role (value receiver)
+// IsOwner checks if membership is owner role (value receiver).
+//
+// Deprecated: check permissions with policy.Allows, which also covers
+// custom roles.
 func (m Membership) IsOwner() bool {
 	return m.Role == MemberRoleOwner
 }
 
-// IsAdmin checks if membership has admin privileges (value receiver)
+// IsAdmin checks if membership has admin privileges (value receiver).
+//
+// Deprecated: check permissions with policy.Allows, which also covers
+// custom roles.
 func (m Membership) IsAdmin() bool {
 	return m.Role == MemberRoleOwner || m.Role == MemberRoleAdmin
 }
 
-// CanManageMembers checks if member can manage other members (value receiver)
+// CanManageMembers checks if member can manage other members (value receiver).
+//
+// Deprecated: use policy.Allows(m.Role, policy.ActionManageMembers).
 func (m Membership) CanManageMembers() bool {
 	return m.IsAdmin()
 }
@@ -482,14 +643,14 @@ func (m Membership) CanManageMembers() bool {
 // ChangeRole changes the membership role (pointer receiver)
 func (m *Membership) ChangeRole(role MemberRole) {
 	m.Role = role
-	m.UpdatedAt = time.Now()
+	m.UpdatedAt = Now()
 }
 
 // Promote promotes member to admin (pointer receiver)
 func (m *Membership) Promote() {
 	if m.Role == MemberRoleMember || m.Role == MemberRoleGuest {
 		m.Role = MemberRoleAdmin
-		m.UpdatedAt = time.Now()
+		m.UpdatedAt = Now()
 	}
 }
 
@@ -497,6 +658,23 @@ func (m *Membership) Promote() {
 func (m *Membership) Demote() {
 	if m.Role == MemberRoleAdmin {
 		m.Role = MemberRoleMember
-		m.UpdatedAt = time.Now()
+		m.UpdatedAt = Now()
 	}
 }
+
+// =====================================
+// Helper Functions
+// =====================================
+
+// isHTTPURL reports whether link is an absolute http(s) URL (standalone function)
+func isHTTPURL(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// isLocalPath reports whether link is a root-relative path, excluding
+// protocol-relative URLs (standalone function)
+func isLocalPath(link string) bool {
+	return strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "//")
+}
+
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// NotificationChannel is a way of reaching a user
+type NotificationChannel string
+
+// Notification channel constants
+const (
+	ChannelEmail NotificationChannel = "email"
+	ChannelPush  NotificationChannel = "push"
+)
+
+// NotificationPreferences records which channels a user wants notifications on
+type NotificationPreferences struct {
+	UserID    UserID     `json:"user_id"`
+	Email     bool       `json:"email"`
+	Push      bool       `json:"push"`
+	DeviceIDs []string   `json:"device_ids,omitempty"` // Push targets; required when Push is enabled
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // Nil until the user saves preferences
+}
+
+// NotificationTemplate is the Go text/template source of one notification
+// event's subject and body
+type NotificationTemplate struct {
+	Event     string     `json:"event"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Builtin   bool       `json:"builtin"`              // Shipped with the server; resetting restores the default
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // Nil until an admin edits the template
+}
+
+// NotificationData is what a notification template can reference:
+// {{.User.FirstName}}, {{.Org.Name}}, {{.Vars.request_id}} and so on
+type NotificationData struct {
+	Locale string            `json:"locale"`
+	User   *User             `json:"user,omitempty"` // Recipient
+	Org    *Organization     `json:"org,omitempty"`
+	Vars   map[string]string `json:"vars,omitempty"` // Event-specific values
+}
+
+// RenderedNotification is a template rendered for one recipient
+type RenderedNotification struct {
+	Event   string `json:"event"`
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// =====================================
+// Value Receiver Methods on NotificationPreferences
+// =====================================
+
+// Enabled reports whether the user wants notifications on a channel (value receiver)
+func (p NotificationPreferences) Enabled(channel NotificationChannel) bool {
+	switch channel {
+	case ChannelEmail:
+		return p.Email
+	case ChannelPush:
+		return p.Push && len(p.DeviceIDs) > 0
+	}
+	return false
+}
+
+// Channels lists the enabled channels in delivery order (value receiver)
+func (p NotificationPreferences) Channels() []NotificationChannel {
+	channels := make([]NotificationChannel, 0, 2)
+	for _, channel := range []NotificationChannel{ChannelEmail, ChannelPush} {
+		if p.Enabled(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// Validate checks that push has somewhere to go and device IDs are not blank (value receiver)
+func (p NotificationPreferences) Validate() error {
+	for _, deviceID := range p.DeviceIDs {
+		if strings.TrimSpace(deviceID) == "" {
+			return errors.New("device_ids must not contain empty values")
+		}
+	}
+	if p.Push && len(p.DeviceIDs) == 0 {
+		return errors.New("push requires at least one device_id")
+	}
+	return nil
+}
+
+// =====================================
+// Constructor Functions for NotificationPreferences
+// =====================================
+
+// DefaultNotificationPreferences returns the preferences of a user who has
+// not chosen any: email only (standalone function)
+func DefaultNotificationPreferences(userID UserID) NotificationPreferences {
+	return NotificationPreferences{
+		UserID: userID,
+		Email:  true,
+	}
+}
+
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+// OrgDeletionStatus represents where an organization deletion job is
+type OrgDeletionStatus string
+
+// Organization deletion status constants, shared by jobs and their steps
+const (
+	OrgDeletionQueued    OrgDeletionStatus = "queued"
+	OrgDeletionRunning   OrgDeletionStatus = "running"
+	OrgDeletionCompleted OrgDeletionStatus = "completed"
+	OrgDeletionFailed    OrgDeletionStatus = "failed"
+)
+
+// OrgDeletionStep is one kind of organization data a deletion job removes
+type OrgDeletionStep struct {
+	Name    string            `json:"name"`
+	Status  OrgDeletionStatus `json:"status"`
+	Deleted int               `json:"deleted"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// OrgDeletionJob deletes an organization and everything scoped to it in
+// the background, one step at a time
+type OrgDeletionJob struct {
+	BaseEntity                    // Embedded struct
+	OrgID       OrgID             `json:"org_id"`
+	RequestedBy UserID            `json:"requested_by,omitempty"`
+	Tier        SupportTier       `json:"tier,omitempty"` // Support tier of the organization; priority jobs run first
+	Status      OrgDeletionStatus `json:"status"`
+	Steps       []OrgDeletionStep `json:"steps"`
+	StartedAt   *time.Time        `json:"started_at,omitempty"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// =====================================
+// Value Receiver Methods on OrgDeletionJob
+// =====================================
+
+// IsDone checks if the job has completed or failed (value receiver)
+func (j OrgDeletionJob) IsDone() bool {
+	return j.Status == OrgDeletionCompleted || j.Status == OrgDeletionFailed
+}
+
+// =====================================
+// Constructor Functions for OrgDeletionJob
+// =====================================
+
+// NewOrgDeletionJob creates a queued OrgDeletionJob with a queued step per name
+func NewOrgDeletionJob(id string, orgID OrgID, requestedBy UserID, steps []string) *OrgDeletionJob {
+	now := Now()
+	job := &OrgDeletionJob{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:       orgID,
+		RequestedBy: requestedBy,
+		Status:      OrgDeletionQueued,
+		Steps:       make([]OrgDeletionStep, len(steps)),
+	}
+	for i, name := range steps {
+		job.Steps[i] = OrgDeletionStep{Name: name, Status: OrgDeletionQueued}
+	}
+	return job
+}
+
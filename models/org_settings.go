@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// OrgBranding holds an organization's visual identity
+type OrgBranding struct {
+	LogoURL        string `json:"logo_url"`
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+}
+
+// OrgSecurity holds an organization's security configuration
+type OrgSecurity struct {
+	RequireMFA            bool     `json:"require_mfa"`
+	SessionTimeoutMinutes int      `json:"session_timeout_minutes"`
+	AllowedEmailDomains   []string `json:"allowed_email_domains"`
+}
+
+// OrgProjectSettings holds an organization's project policies
+type OrgProjectSettings struct {
+	AutoArchiveOptOut bool `json:"auto_archive_opt_out"` // Keep stale projects out of the auto-archive job
+}
+
+// OrgSettings groups the configurable settings of an organization
+type OrgSettings struct {
+	Timezone string             `json:"timezone"`
+	Locale   string             `json:"locale"`
+	Branding OrgBranding        `json:"branding"`
+	Security OrgSecurity        `json:"security"`
+	Projects OrgProjectSettings `json:"projects"`
+}
+
+// SettingsRevision is an immutable snapshot of OrgSettings. Every change,
+// including a rollback, appends a new revision.
+type SettingsRevision struct {
+	Revision   int         `json:"revision"`
+	OrgID      OrgID       `json:"org_id"`
+	Settings   OrgSettings `json:"settings"`
+	ChangedBy  UserID      `json:"changed_by"`
+	Reason     string      `json:"reason,omitempty"`
+	RollbackOf int         `json:"rollback_of,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// =====================================
+// Value Receiver Methods on OrgSettings
+// =====================================
+
+// Clone returns a deep copy so revisions never share slices (value receiver)
+func (s OrgSettings) Clone() OrgSettings {
+	clone := s
+	if s.Security.AllowedEmailDomains != nil {
+		clone.Security.AllowedEmailDomains = append([]string{}, s.Security.AllowedEmailDomains...)
+	}
+	return clone
+}
+
+// Validate checks if settings are valid (value receiver)
+func (s OrgSettings) Validate() error {
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return errors.New("unknown timezone")
+		}
+	}
+	if s.Security.SessionTimeoutMinutes < 0 {
+		return errors.New("session timeout cannot be negative")
+	}
+	return nil
+}
+
+// IsRollback checks if the revision restored an earlier one (value receiver)
+func (r SettingsRevision) IsRollback() bool {
+	return r.RollbackOf > 0
+}
+
+// =====================================
+// Constructor Functions for OrgSettings
+// =====================================
+
+// DefaultOrgSettings returns the settings an organization starts with
+func DefaultOrgSettings() OrgSettings {
+	return OrgSettings{
+		Timezone: "UTC",
+		Locale:   "en-US",
+		Security: OrgSecurity{
+			SessionTimeoutMinutes: 60,
+		},
+	}
+}
+
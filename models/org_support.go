@@ -0,0 +1,46 @@
+package models
+
+import "errors"
+
+// SupportTier is the support level an organization is entitled to
+type SupportTier string
+
+// Support tier constants
+const (
+	SupportTierStandard SupportTier = "standard"
+	SupportTierPriority SupportTier = "priority"
+)
+
+// OrgSupport holds an organization's support tier and where to escalate
+// incidents that affect it
+type OrgSupport struct {
+	Tier              SupportTier `json:"tier,omitempty" validate:"oneof=standard priority"`
+	EscalationWebhook string      `json:"escalation_webhook,omitempty" validate:"max=2048"`
+	EscalationContact string      `json:"escalation_contact,omitempty" validate:"max=254"`
+}
+
+// =====================================
+// Value Receiver Methods on OrgSupport
+// =====================================
+
+// IsValid checks if the tier is a known support tier (value receiver)
+func (t SupportTier) IsValid() bool {
+	return t == SupportTierStandard || t == SupportTierPriority
+}
+
+// IsPriority checks if the organization gets priority support (value receiver)
+func (s OrgSupport) IsPriority() bool {
+	return s.Tier == SupportTierPriority
+}
+
+// Validate checks if support settings are valid (value receiver)
+func (s OrgSupport) Validate() error {
+	if s.Tier != "" && !s.Tier.IsValid() {
+		return errors.New("unknown support tier")
+	}
+	if s.EscalationWebhook != "" && !isHTTPURL(s.EscalationWebhook) {
+		return errors.New("escalation webhook must be an absolute http(s) URL")
+	}
+	return nil
+}
+
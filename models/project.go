@@ -2,7 +2,6 @@ package models
 
 import (
 	"fmt"
-	"time"
 )
 
 // Type alias for Project
@@ -31,6 +30,15 @@ type Project struct {
 	OrgID       OrgID     `json:"org_id"`
 }
 
+// IsValid checks if the status is a known project status (value receiver)
+func (s ProjectStatus) IsValid() bool {
+	switch s {
+	case ProjectStatusActive, ProjectStatusArchived, ProjectStatusDraft:
+		return true
+	}
+	return false
+}
+
 // =====================================
 // Value Receiver Methods on Project
 // =====================================
@@ -62,25 +70,25 @@ func (p Project) String() string {
 // UpdateName updates the project name (pointer receiver)
 func (p *Project) UpdateName(name string) {
 	p.Name = name
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
 }
 
 // UpdateDescription updates the description (pointer receiver)
 func (p *Project) UpdateDescription(desc string) {
 	p.Description = desc
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
 }
 
 // SetStatus sets the project status (pointer receiver)
 func (p *Project) SetStatus(status ProjectStatus) {
 	p.Status = status
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
 }
 
 // Archive archives the project (pointer receiver)
 func (p *Project) Archive() {
 	p.Status = ProjectStatusArchived
-	now := time.Now()
+	now := Now()
 	p.DeletedAt = &now
 	p.UpdatedAt = now
 }
@@ -89,7 +97,7 @@ func (p *Project) Archive() {
 func (p *Project) Activate() {
 	p.Status = ProjectStatusActive
 	p.DeletedAt = nil
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = Now()
 }
 
 // =====================================
@@ -98,7 +106,7 @@ func (p *Project) Activate() {
 
 // NewProject creates a new Project with initialized fields
 func NewProject(id, name, ownerID, orgID string) *Project {
-	now := time.Now()
+	now := Now()
 	return &Project{
 		BaseEntity: BaseEntity{
 			ID:        id,
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectGrantRole is the access level of a guest on a single project
+type ProjectGrantRole string
+
+// Project grant role constants
+const (
+	ProjectGrantViewer ProjectGrantRole = "viewer"
+	ProjectGrantEditor ProjectGrantRole = "editor"
+)
+
+// ProjectGrant gives a user from another organization guest access to a
+// single project without membership in the project's organization. The
+// grant is tied to the guest's home organization and lapses if they leave it.
+type ProjectGrant struct {
+	BaseEntity                  // Embedded struct
+	ProjectID  ProjectID        `json:"project_id"`
+	UserID     UserID           `json:"user_id"`
+	HomeOrgID  OrgID            `json:"home_org_id"`
+	Role       ProjectGrantRole `json:"role"`
+	GrantedBy  UserID           `json:"granted_by"`
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty"`
+}
+
+// =====================================
+// Value Receiver Methods on ProjectGrant
+// =====================================
+
+// IsValid checks if the role is a known grant role (value receiver)
+func (r ProjectGrantRole) IsValid() bool {
+	return r == ProjectGrantViewer || r == ProjectGrantEditor
+}
+
+// IsExpired checks if the grant has expired at the given time (value receiver)
+func (g ProjectGrant) IsExpired(now time.Time) bool {
+	return g.ExpiresAt != nil && !now.Before(*g.ExpiresAt)
+}
+
+// CanEdit checks if the guest may modify the project (value receiver)
+func (g ProjectGrant) CanEdit() bool {
+	return g.Role == ProjectGrantEditor
+}
+
+// =====================================
+// Constructor Functions for ProjectGrant
+// =====================================
+
+// NewProjectGrant creates a new ProjectGrant
+func NewProjectGrant(id string, projectID ProjectID, userID UserID, homeOrgID OrgID, role ProjectGrantRole, grantedBy UserID) *ProjectGrant {
+	now := Now()
+	return &ProjectGrant{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ProjectID: projectID,
+		UserID:    userID,
+		HomeOrgID: homeOrgID,
+		Role:      role,
+		GrantedBy: grantedBy,
+	}
+}
+
@@ -0,0 +1,55 @@
+package models
+
+// ProjectMemberRole is the role of an organization member on a single project
+type ProjectMemberRole string
+
+// Project member role constants
+const (
+	ProjectMemberLead        ProjectMemberRole = "lead"
+	ProjectMemberContributor ProjectMemberRole = "contributor"
+	ProjectMemberViewer      ProjectMemberRole = "viewer"
+)
+
+// ProjectMember assigns a member of a project's organization to the
+// project. Assignments only hold while the user stays in that organization.
+type ProjectMember struct {
+	BaseEntity                   // Embedded struct
+	ProjectID  ProjectID         `json:"project_id"`
+	UserID     UserID            `json:"user_id"`
+	Role       ProjectMemberRole `json:"role"`
+	AssignedBy UserID            `json:"assigned_by"`
+}
+
+// =====================================
+// Value Receiver Methods on ProjectMember
+// =====================================
+
+// IsValid checks if the role is a known project member role (value receiver)
+func (r ProjectMemberRole) IsValid() bool {
+	switch r {
+	case ProjectMemberLead, ProjectMemberContributor, ProjectMemberViewer:
+		return true
+	}
+	return false
+}
+
+// =====================================
+// Constructor Functions for ProjectMember
+// =====================================
+
+// NewProjectMember creates a new ProjectMember
+func NewProjectMember(id string, projectID ProjectID, userID UserID, role ProjectMemberRole, assignedBy UserID) *ProjectMember {
+	now := Now()
+	return &ProjectMember{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ProjectID:  projectID,
+		UserID:     userID,
+		Role:       role,
+		AssignedBy: assignedBy,
+	}
+}
+
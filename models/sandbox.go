@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Sandbox is an isolated copy of one organization's data that integrators
+// can read and write without touching production records. Member names and
+// emails are replaced with placeholders when the copy is taken.
+type Sandbox struct {
+	BaseEntity             // Embedded struct
+	OrgID       OrgID      `json:"org_id"`
+	CreatedBy   UserID     `json:"created_by"`
+	ResetAt     *time.Time `json:"reset_at,omitempty"` // When the copy was last retaken
+	Resets      int        `json:"resets"`
+	Users       int        `json:"users"` // Records copied at creation or the last reset
+	Memberships int        `json:"memberships"`
+	Projects    int        `json:"projects"`
+}
+
+// =====================================
+// Constructor Functions for Sandbox
+// =====================================
+
+// NewSandbox creates a new Sandbox
+func NewSandbox(id string, orgID OrgID, createdBy UserID) *Sandbox {
+	now := Now()
+	return &Sandbox{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:     orgID,
+		CreatedBy: createdBy,
+	}
+}
+
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ScheduledChangeTarget is the kind of record a scheduled change updates
+type ScheduledChangeTarget string
+
+// Scheduled change target constants
+const (
+	ScheduledChangeUser         ScheduledChangeTarget = "user"         // Fields of PUT /users/{id}
+	ScheduledChangeOrganization ScheduledChangeTarget = "organization" // Fields of PUT /organizations/{id}
+	ScheduledChangeMembership   ScheduledChangeTarget = "membership"   // {"role": ...} of a member of OrgID
+)
+
+// ScheduledChangeStatus represents where a scheduled change is in its lifecycle
+type ScheduledChangeStatus string
+
+// Scheduled change status constants
+const (
+	ScheduledChangePending   ScheduledChangeStatus = "pending"
+	ScheduledChangeApplied   ScheduledChangeStatus = "applied"
+	ScheduledChangeCancelled ScheduledChangeStatus = "cancelled"
+	ScheduledChangeFailed    ScheduledChangeStatus = "failed"
+)
+
+// ScheduledChange is an update to a user, organization, or membership that
+// takes effect at a future time. Until then it is pending and can be
+// cancelled; the scheduler applies it once EffectiveAt has passed.
+type ScheduledChange struct {
+	BaseEntity                        // Embedded struct
+	Target      ScheduledChangeTarget `json:"target"`
+	TargetID    string                `json:"target_id"`        // User or organization ID; the member's user ID for memberships
+	OrgID       OrgID                 `json:"org_id,omitempty"` // Set for memberships
+	Changes     json.RawMessage       `json:"changes"`
+	EffectiveAt time.Time             `json:"effective_at"`
+	RequestedBy UserID                `json:"requested_by,omitempty"`
+	Status      ScheduledChangeStatus `json:"status"`
+	AppliedAt   *time.Time            `json:"applied_at,omitempty"`
+	CancelledBy UserID                `json:"cancelled_by,omitempty"`
+	CancelledAt *time.Time            `json:"cancelled_at,omitempty"`
+	Error       string                `json:"error,omitempty"` // Why a failed change could not be applied
+
+	TargetVersion int64 `json:"target_version,omitempty"` // User or organization version the change was made against; 0 when not pinned
+}
+
+// =====================================
+// Value Receiver Methods
+// =====================================
+
+// IsValid checks if the target is a known scheduled change target (value receiver)
+func (t ScheduledChangeTarget) IsValid() bool {
+	switch t {
+	case ScheduledChangeUser, ScheduledChangeOrganization, ScheduledChangeMembership:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if the status is a known scheduled change status (value receiver)
+func (s ScheduledChangeStatus) IsValid() bool {
+	switch s {
+	case ScheduledChangePending, ScheduledChangeApplied, ScheduledChangeCancelled, ScheduledChangeFailed:
+		return true
+	}
+	return false
+}
+
+// IsDue checks if a pending change should be applied at now (value receiver)
+func (c ScheduledChange) IsDue(now time.Time) bool {
+	return c.Status == ScheduledChangePending && !now.Before(c.EffectiveAt)
+}
+
+// =====================================
+// Constructor Functions for ScheduledChange
+// =====================================
+
+// NewScheduledChange creates a new pending ScheduledChange
+func NewScheduledChange(id string, target ScheduledChangeTarget, targetID string, orgID OrgID, changes json.RawMessage, effectiveAt time.Time, requestedBy UserID) *ScheduledChange {
+	now := Now()
+	return &ScheduledChange{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Target:      target,
+		TargetID:    targetID,
+		OrgID:       orgID,
+		Changes:     changes,
+		EffectiveAt: effectiveAt,
+		RequestedBy: requestedBy,
+		Status:      ScheduledChangePending,
+	}
+}
+
@@ -0,0 +1,25 @@
+package models
+
+// SearchDocumentType identifies the kind of entity a search hit refers to
+type SearchDocumentType string
+
+// Search document type constants
+const (
+	SearchTypeUser         SearchDocumentType = "user"
+	SearchTypeOrganization SearchDocumentType = "organization"
+	SearchTypeProject      SearchDocumentType = "project"
+)
+
+// SearchResult represents a single search hit
+type SearchResult struct {
+	Type  SearchDocumentType `json:"type"`
+	ID    string             `json:"id"`
+	Title string             `json:"title"`
+	Score int                `json:"score"`
+}
+
+// IsType checks if the result is of the given type (value receiver)
+func (r SearchResult) IsType(t SearchDocumentType) bool {
+	return r.Type == t
+}
+
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskStatus represents the progress of a task
+type TaskStatus string
+
+// Task status constants
+const (
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusDone       TaskStatus = "done"
+)
+
+// Task is a unit of work within a project
+type Task struct {
+	BaseEntity            // Embedded struct
+	ProjectID  ProjectID  `json:"project_id"`
+	Title      string     `json:"title"`
+	Status     TaskStatus `json:"status"`
+	AssigneeID UserID     `json:"assignee_id,omitempty"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+}
+
+// =====================================
+// Value Receiver Methods on Task
+// =====================================
+
+// IsValid checks if the status is a known task status (value receiver)
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+// IsOverdue checks if an unfinished task is past its due date (value receiver)
+func (t Task) IsOverdue(now time.Time) bool {
+	return t.DueDate != nil && t.Status != TaskStatusDone && now.After(*t.DueDate)
+}
+
+// =====================================
+// Constructor Functions for Task
+// =====================================
+
+// NewTask creates a new Task in the todo status
+func NewTask(id string, projectID ProjectID, title string) *Task {
+	now := Now()
+	return &Task{
+		BaseEntity: BaseEntity{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ProjectID: projectID,
+		Title:     title,
+		Status:    TaskStatusTodo,
+	}
+}
+
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// Period is a calendar period used for period-based calculations
+type Period string
+
+// Period constants
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// Now returns the current time in UTC. All stored timestamps use it so
+// records compare and serialize the same regardless of the server's zone
+// (standalone function).
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// LocationOrUTC loads an IANA time zone such as "Europe/Berlin", falling
+// back to UTC for an empty or unknown name (standalone function)
+func LocationOrUTC(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// =====================================
+// Value Receiver Methods on Period
+// =====================================
+
+// IsValid checks if the period is a known calendar period (value receiver)
+func (p Period) IsValid() bool {
+	return p == PeriodDay || p == PeriodWeek || p == PeriodMonth
+}
+
+// Start returns the start of the period containing t, as observed in loc.
+// Weeks start on Monday. The result is returned in UTC (value receiver).
+func (p Period) Start(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	switch p {
+	case PeriodWeek:
+		offset := (int(local.Weekday()) + 6) % 7 // days since Monday
+		start = start.AddDate(0, 0, -offset)
+	case PeriodMonth:
+		start = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+	}
+	return start.UTC()
+}
+
+// Bounds returns the half-open interval [start, end) of the period
+// containing t, as observed in loc. Calendar arithmetic keeps days that
+// are 23 or 25 hours long around DST changes correct (value receiver).
+func (p Period) Bounds(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	start := p.Start(t, loc)
+	local := start.In(loc)
+	var end time.Time
+	switch p {
+	case PeriodWeek:
+		end = local.AddDate(0, 0, 7)
+	case PeriodMonth:
+		end = local.AddDate(0, 1, 0)
+	default:
+		end = local.AddDate(0, 0, 1)
+	}
+	return start, end.UTC()
+}
+
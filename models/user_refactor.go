@@ -1,9 +1,5 @@
 package models
 
-import (
-	"time"
-)
-
 // =====================================
 // REFACTORING: User model changes
 // These changes break relationships with other files
@@ -30,7 +26,7 @@ type UserRefactored struct {
 // This breaks: handlers/handlers.go (line 141) which calls UpdateEmail()
 func (u *UserRefactored) UpdateEmailAddress(email string, verified bool) {
 	u.EmailAddress = email
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
 	// verified parameter added but not used - breaks existing callers
 }
 
@@ -92,6 +88,6 @@ func (u UserRefactored) GetEmail() string {
 // Old code uses user.Email = value, new code should use SetEmail()
 func (u *UserRefactored) SetEmail(email string) {
 	u.EmailAddress = email
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = Now()
 }
 
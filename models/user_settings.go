@@ -0,0 +1,54 @@
+package models
+
+// Theme is the color scheme a user prefers in clients
+type Theme string
+
+// Theme constants
+const (
+	ThemeSystem Theme = "system"
+	ThemeLight  Theme = "light"
+	ThemeDark   Theme = "dark"
+)
+
+// NotificationToggles switches notification channels on or off
+type NotificationToggles struct {
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// UserSettings gathers a user's preferences in one place. Locale and
+// time zone are stored on the User, notification toggles with the
+// notification preferences, and the theme by the preferences service.
+type UserSettings struct {
+	UserID        UserID              `json:"user_id"`
+	Locale        string              `json:"locale"`
+	Timezone      string              `json:"timezone"`
+	Theme         Theme               `json:"theme"`
+	Notifications NotificationToggles `json:"notifications"`
+}
+
+// UserSettingsUpdate changes some of a user's settings; nil fields are left
+// as they are, and an empty locale or time zone clears the preference
+type UserSettingsUpdate struct {
+	Locale        *string `json:"locale" validate:"max=35"`
+	Timezone      *string `json:"timezone" validate:"timezone"`
+	Theme         *Theme  `json:"theme" validate:"oneof=system light dark"`
+	Notifications *struct {
+		Email *bool `json:"email"`
+		Push  *bool `json:"push"`
+	} `json:"notifications"`
+}
+
+// =====================================
+// Value Receiver Methods on Theme
+// =====================================
+
+// IsValid checks if the theme is a known theme (value receiver)
+func (t Theme) IsValid() bool {
+	switch t {
+	case ThemeSystem, ThemeLight, ThemeDark:
+		return true
+	}
+	return false
+}
+
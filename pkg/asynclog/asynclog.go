@@ -0,0 +1,151 @@
+package asynclog
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Policy decides what Write does when the buffer is full
+type Policy string
+
+// Backpressure policies
+const (
+	// PolicyBlock makes writers wait for room, so nothing is lost
+	PolicyBlock Policy = "block"
+	// PolicyDrop discards the entry and counts it, so writers never wait
+	PolicyDrop Policy = "drop"
+)
+
+// DefaultBufferSize is how many entries are buffered when unconfigured
+const DefaultBufferSize = 4096
+
+// Config configures a Writer
+type Config struct {
+	BufferSize int    // Entries buffered before the policy applies
+	Policy     Policy // Defaults to PolicyBlock
+}
+
+// Stats reports buffer usage and losses
+type Stats struct {
+	Policy   Policy `json:"policy"`
+	Capacity int    `json:"capacity"`
+	Buffered int    `json:"buffered"`
+	Written  uint64 `json:"written"`
+	Dropped  uint64 `json:"dropped"`
+	Errors   uint64 `json:"errors"`
+}
+
+// Writer moves writes to a background goroutine through a bounded buffer.
+// Each Write is one entry; *log.Logger issues one Write per line. After
+// Shutdown, writes go straight to the underlying writer.
+type Writer struct {
+	out     io.Writer
+	policy  Policy
+	entries chan []byte
+	done    chan struct{}
+	closed  bool
+	mu      sync.RWMutex // Guards closed against sends on a closed channel
+	outMu   sync.Mutex   // Serializes writes to out after Shutdown
+
+	written atomic.Uint64
+	dropped atomic.Uint64
+	errors  atomic.Uint64
+}
+
+// New creates a Writer and starts its background goroutine
+func New(out io.Writer, config Config) *Writer {
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultBufferSize
+	}
+	if config.Policy != PolicyDrop {
+		config.Policy = PolicyBlock
+	}
+
+	w := &Writer{
+		out:     out,
+		policy:  config.Policy,
+		entries: make(chan []byte, config.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// =====================================
+// Pointer Receiver Methods on Writer
+// =====================================
+
+// Write queues a copy of p. With PolicyDrop a full buffer discards the
+// entry; the write still reports success so callers are never slowed or
+// failed by logging (pointer receiver).
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		w.outMu.Lock()
+		defer w.outMu.Unlock()
+		return w.out.Write(p)
+	}
+
+	entry := append([]byte(nil), p...)
+	if w.policy == PolicyDrop {
+		select {
+		case w.entries <- entry:
+		default:
+			w.dropped.Add(1)
+		}
+		return len(p), nil
+	}
+
+	w.entries <- entry
+	return len(p), nil
+}
+
+// Shutdown stops accepting buffered writes and waits until every queued
+// entry is written or ctx is done (pointer receiver - implements Shutdowner)
+func (w *Writer) Shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats reports buffer usage and losses (pointer receiver)
+func (w *Writer) Stats() Stats {
+	return Stats{
+		Policy:   w.policy,
+		Capacity: cap(w.entries),
+		Buffered: len(w.entries),
+		Written:  w.written.Load(),
+		Dropped:  w.dropped.Load(),
+		Errors:   w.errors.Load(),
+	}
+}
+
+// run writes queued entries until the buffer is closed and drained (pointer receiver)
+func (w *Writer) run() {
+	defer close(w.done)
+	for entry := range w.entries {
+		w.outMu.Lock()
+		_, err := w.out.Write(entry)
+		w.outMu.Unlock()
+		if err != nil {
+			w.errors.Add(1)
+			continue
+		}
+		w.written.Add(1)
+	}
+}
+
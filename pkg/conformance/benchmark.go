@@ -0,0 +1,96 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+)
+
+// benchmarkPopulation is how many entities the mixed benchmarks read and update
+const benchmarkPopulation = 1024
+
+// OrgRepositoryFactory returns a new, empty OrgRepository for each benchmark
+type OrgRepositoryFactory func() interfaces.OrgRepository
+
+// BenchmarkRepositoryWrites measures parallel Writes of new users, the
+// case a single store-wide lock serializes. Run it with -cpu=1,4,8 and
+// compare runs before and after a change to the store.
+func BenchmarkRepositoryWrites(b *testing.B, factory RepositoryFactory) {
+	repo := factory()
+	ctx := context.Background()
+	var next atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			id := fmt.Sprintf("user_%d", next.Add(1))
+			if err := repo.Write(ctx, models.NewUser(id, "Bench", "User", id+"@example.com")); err != nil {
+				b.Errorf("Write(%s): %v", id, err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkRepositoryMixed measures parallel traffic over existing users:
+// nine Reads for every read-modify-Write. Write errors are ignored since
+// concurrent updates of one user are expected to conflict.
+func BenchmarkRepositoryMixed(b *testing.B, factory RepositoryFactory) {
+	repo := factory()
+	ctx := context.Background()
+	for i := 0; i < benchmarkPopulation; i++ {
+		id := fmt.Sprintf("user_%d", i)
+		if err := repo.Write(ctx, models.NewUser(id, "Bench", "User", id+"@example.com")); err != nil {
+			b.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	var next atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := next.Add(1)
+			id := fmt.Sprintf("user_%d", n%benchmarkPopulation)
+			user, err := repo.Read(ctx, id)
+			if err != nil {
+				b.Errorf("Read(%s): %v", id, err)
+				return
+			}
+			if n%10 != 0 {
+				continue
+			}
+			updated := *user
+			updated.FirstName = "Updated"
+			_ = repo.Write(ctx, &updated)
+		}
+	})
+}
+
+// BenchmarkOrgRepositoryWrites measures parallel WriteOrgs of new
+// organizations, each with its own slug
+func BenchmarkOrgRepositoryWrites(b *testing.B, factory OrgRepositoryFactory) {
+	repo := factory()
+	ctx := context.Background()
+	var next atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			id := fmt.Sprintf("org_%d", next.Add(1))
+			org := models.NewOrganization(id, "Org "+id, "owner_a")
+			org.Slug = id
+			if err := repo.WriteOrg(ctx, org); err != nil {
+				b.Errorf("WriteOrg(%s): %v", id, err)
+				return
+			}
+		}
+	})
+}
+
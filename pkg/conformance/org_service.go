@@ -0,0 +1,178 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+)
+
+// OrgServiceFactory returns a new, empty OrgService for each test case
+type OrgServiceFactory func() interfaces.OrgService
+
+// orgServiceCase is a single behavioral check against an OrgService
+type orgServiceCase struct {
+	name string
+	run  func(t *testing.T, ctx context.Context, svc interfaces.OrgService)
+}
+
+// orgServiceCases describe the contract every interfaces.OrgService must honour
+var orgServiceCases = []orgServiceCase{
+	{
+		name: "write then read returns the organization",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			org, err := svc.ReadOrg(ctx, "org_a")
+			if err != nil {
+				t.Fatalf("ReadOrg after WriteOrg: %v", err)
+			}
+			if org.ID != "org_a" || org.OwnerID != "owner_a" {
+				t.Fatalf("ReadOrg returned %v, want org_a owned by owner_a", org)
+			}
+		},
+	},
+	{
+		name: "read of missing organization fails",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			if _, err := svc.ReadOrg(ctx, "missing"); err == nil {
+				t.Fatal("ReadOrg of missing organization returned no error")
+			}
+		},
+	},
+	{
+		name: "write without ID fails",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			if err := svc.WriteOrg(ctx, models.NewOrganization("", "No ID", "owner_a")); err == nil {
+				t.Fatal("WriteOrg of organization without ID returned no error")
+			}
+		},
+	},
+	{
+		name: "read by owner filters organizations",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			mustWriteOrg(t, ctx, svc, "org_b", "owner_b")
+			orgs, err := svc.ReadOrgsByOwner(ctx, "owner_a")
+			if err != nil {
+				t.Fatalf("ReadOrgsByOwner: %v", err)
+			}
+			if len(orgs) != 1 || orgs[0].ID != "org_a" {
+				t.Fatalf("ReadOrgsByOwner returned %v, want only org_a", orgs)
+			}
+		},
+	},
+	{
+		name: "count and exists track stored organizations",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			mustWriteOrg(t, ctx, svc, "org_b", "owner_a")
+			if count, err := svc.CountOrgs(ctx); err != nil || count != 2 {
+				t.Fatalf("CountOrgs = %d, %v; want 2, nil", count, err)
+			}
+			if exists, err := svc.OrgExists(ctx, "org_a"); err != nil || !exists {
+				t.Fatalf("OrgExists(org_a) = %v, %v; want true, nil", exists, err)
+			}
+			if exists, err := svc.OrgExists(ctx, "missing"); err != nil || exists {
+				t.Fatalf("OrgExists(missing) = %v, %v; want false, nil", exists, err)
+			}
+		},
+	},
+	{
+		name: "adding a member requires an existing organization",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			membership := models.NewMembership("mem_a", "user_a", "missing", models.MemberRoleMember)
+			if err := svc.AddMember(ctx, membership); err == nil {
+				t.Fatal("AddMember to missing organization returned no error")
+			}
+		},
+	},
+	{
+		name: "duplicate membership is rejected",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			mustAddMember(t, ctx, svc, "user_a", "org_a", models.MemberRoleMember)
+			duplicate := models.NewMembership("mem_dup", "user_a", "org_a", models.MemberRoleAdmin)
+			if err := svc.AddMember(ctx, duplicate); err == nil {
+				t.Fatal("duplicate AddMember returned no error")
+			}
+		},
+	},
+	{
+		name: "members can be listed, updated, and removed",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			mustAddMember(t, ctx, svc, "user_a", "org_a", models.MemberRoleMember)
+			mustAddMember(t, ctx, svc, "user_b", "org_a", models.MemberRoleMember)
+
+			members, err := svc.GetMembers(ctx, "org_a")
+			if err != nil || len(members) != 2 {
+				t.Fatalf("GetMembers = %d members, %v; want 2, nil", len(members), err)
+			}
+
+			if err := svc.UpdateMemberRole(ctx, "user_a", "org_a", models.MemberRoleAdmin); err != nil {
+				t.Fatalf("UpdateMemberRole: %v", err)
+			}
+			membership, err := svc.GetMembership(ctx, "user_a", "org_a")
+			if err != nil || membership.Role != models.MemberRoleAdmin {
+				t.Fatalf("GetMembership after UpdateMemberRole = %v, %v; want admin role", membership, err)
+			}
+
+			if err := svc.RemoveMember(ctx, "user_b", "org_a"); err != nil {
+				t.Fatalf("RemoveMember: %v", err)
+			}
+			if _, err := svc.GetMembership(ctx, "user_b", "org_a"); err == nil {
+				t.Fatal("GetMembership after RemoveMember returned no error")
+			}
+			if err := svc.RemoveMember(ctx, "user_b", "org_a"); err == nil {
+				t.Fatal("second RemoveMember returned no error")
+			}
+		},
+	},
+	{
+		name: "deleting an organization removes its memberships",
+		run: func(t *testing.T, ctx context.Context, svc interfaces.OrgService) {
+			mustWriteOrg(t, ctx, svc, "org_a", "owner_a")
+			mustAddMember(t, ctx, svc, "user_a", "org_a", models.MemberRoleMember)
+			if err := svc.DeleteOrg(ctx, "org_a"); err != nil {
+				t.Fatalf("DeleteOrg: %v", err)
+			}
+			if _, err := svc.GetMembership(ctx, "user_a", "org_a"); err == nil {
+				t.Fatal("membership survived DeleteOrg")
+			}
+			if err := svc.DeleteOrg(ctx, "org_a"); err == nil {
+				t.Fatal("DeleteOrg of missing organization returned no error")
+			}
+		},
+	},
+}
+
+// RunOrgServiceSuite checks that an OrgService implementation honours the
+// behavioral contract, not just the method signatures
+func RunOrgServiceSuite(t *testing.T, factory OrgServiceFactory) {
+	t.Helper()
+	for _, tc := range orgServiceCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, context.Background(), factory())
+		})
+	}
+}
+
+// mustWriteOrg stores an organization or fails the test
+func mustWriteOrg(t *testing.T, ctx context.Context, svc interfaces.OrgService, id, ownerID string) {
+	t.Helper()
+	if err := svc.WriteOrg(ctx, models.NewOrganization(id, "Org "+id, ownerID)); err != nil {
+		t.Fatalf("WriteOrg(%s): %v", id, err)
+	}
+}
+
+// mustAddMember adds a membership or fails the test
+func mustAddMember(t *testing.T, ctx context.Context, svc interfaces.OrgService, userID, orgID string, role models.MemberRole) {
+	t.Helper()
+	membership := models.NewMembership("mem_"+userID+"_"+orgID, userID, orgID, role)
+	if err := svc.AddMember(ctx, membership); err != nil {
+		t.Fatalf("AddMember(%s, %s): %v", userID, orgID, err)
+	}
+}
+
@@ -0,0 +1,147 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+)
+
+// RepositoryFactory returns a new, empty Repository for each test case
+type RepositoryFactory func() interfaces.Repository
+
+// repositoryCase is a single behavioral check against a Repository
+type repositoryCase struct {
+	name string
+	run  func(t *testing.T, ctx context.Context, repo interfaces.Repository)
+}
+
+// repositoryCases describe the contract every interfaces.Repository must honour
+var repositoryCases = []repositoryCase{
+	{
+		name: "write then read returns the user",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			mustWriteUser(t, ctx, repo, "user_a")
+			user, err := repo.Read(ctx, "user_a")
+			if err != nil {
+				t.Fatalf("Read after Write: %v", err)
+			}
+			if user.ID != "user_a" || user.Email != "user_a@example.com" {
+				t.Fatalf("Read returned %v, want user_a", user)
+			}
+		},
+	},
+	{
+		name: "read of missing user fails",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			if _, err := repo.Read(ctx, "missing"); err == nil {
+				t.Fatal("Read of missing user returned no error")
+			}
+		},
+	},
+	{
+		name: "write without ID fails",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			if err := repo.Write(ctx, models.NewUser("", "No", "ID", "noid@example.com")); err == nil {
+				t.Fatal("Write of user without ID returned no error")
+			}
+		},
+	},
+	{
+		name: "read all returns every written user",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			mustWriteUser(t, ctx, repo, "user_a")
+			mustWriteUser(t, ctx, repo, "user_b")
+			users, err := repo.ReadAll(ctx)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			seen := make(map[string]bool)
+			for _, u := range users {
+				seen[u.ID] = true
+			}
+			if len(users) != 2 || !seen["user_a"] || !seen["user_b"] {
+				t.Fatalf("ReadAll returned %v, want user_a and user_b", users)
+			}
+		},
+	},
+	{
+		name: "count tracks writes and deletes",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			assertCount(t, ctx, repo, 0)
+			mustWriteUser(t, ctx, repo, "user_a")
+			mustWriteUser(t, ctx, repo, "user_b")
+			assertCount(t, ctx, repo, 2)
+			if err := repo.Delete(ctx, "user_a"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			assertCount(t, ctx, repo, 1)
+		},
+	},
+	{
+		name: "exists reflects stored users",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			mustWriteUser(t, ctx, repo, "user_a")
+			if exists, err := repo.Exists(ctx, "user_a"); err != nil || !exists {
+				t.Fatalf("Exists(user_a) = %v, %v; want true, nil", exists, err)
+			}
+			if exists, err := repo.Exists(ctx, "missing"); err != nil || exists {
+				t.Fatalf("Exists(missing) = %v, %v; want false, nil", exists, err)
+			}
+		},
+	},
+	{
+		name: "delete removes the user",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			mustWriteUser(t, ctx, repo, "user_a")
+			if err := repo.Delete(ctx, "user_a"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, err := repo.Read(ctx, "user_a"); err == nil {
+				t.Fatal("Read after Delete returned no error")
+			}
+		},
+	},
+	{
+		name: "delete of missing user fails",
+		run: func(t *testing.T, ctx context.Context, repo interfaces.Repository) {
+			if err := repo.Delete(ctx, "missing"); err == nil {
+				t.Fatal("Delete of missing user returned no error")
+			}
+		},
+	},
+}
+
+// RunRepositorySuite checks that a Repository implementation honours the
+// behavioral contract, not just the method signatures
+func RunRepositorySuite(t *testing.T, factory RepositoryFactory) {
+	t.Helper()
+	for _, tc := range repositoryCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, context.Background(), factory())
+		})
+	}
+}
+
+// mustWriteUser stores a user with a predictable email or fails the test
+func mustWriteUser(t *testing.T, ctx context.Context, repo interfaces.Repository, id string) {
+	t.Helper()
+	if err := repo.Write(ctx, models.NewUser(id, "Test", "User", id+"@example.com")); err != nil {
+		t.Fatalf("Write(%s): %v", id, err)
+	}
+}
+
+// assertCount fails the test if the repository count differs from want
+func assertCount(t *testing.T, ctx context.Context, repo interfaces.Repository, want int) {
+	t.Helper()
+	count, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if count != want {
+		t.Fatalf("Count = %d, want %d", count, want)
+	}
+}
+
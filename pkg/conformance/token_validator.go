@@ -0,0 +1,52 @@
+package conformance
+
+import (
+	"testing"
+)
+
+// TokenValidator mirrors auth.TokenValidator so validators can be checked
+// without importing the auth package. Any type passed to
+// RunTokenValidatorSuite must have exactly this method set, which catches
+// implementations whose Validate signature has drifted from the interface.
+type TokenValidator interface {
+	Validate(token string) bool
+}
+
+// TokenCases lists tokens a validator must accept and reject
+type TokenCases struct {
+	Valid   []string
+	Invalid []string
+}
+
+// tokenTest is a single expected validation outcome
+type tokenTest struct {
+	name  string
+	token string
+	want  bool
+}
+
+// RunTokenValidatorSuite checks that a TokenValidator accepts every valid
+// token and rejects every invalid one, including the empty token
+func RunTokenValidatorSuite(t *testing.T, validator TokenValidator, cases TokenCases) {
+	t.Helper()
+
+	tests := []tokenTest{
+		{name: "empty token is rejected", token: "", want: false},
+	}
+	for _, token := range cases.Valid {
+		tests = append(tests, tokenTest{name: "accepts " + token, token: token, want: true})
+	}
+	for _, token := range cases.Invalid {
+		tests = append(tests, tokenTest{name: "rejects " + token, token: token, want: false})
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validator.Validate(tt.token); got != tt.want {
+				t.Fatalf("Validate(%q) = %v, want %v", tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,168 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/test-repo-golang-support/interfaces"
+)
+
+// Provider constructs a component, resolving its dependencies from the container
+type Provider func(c *Container) (interface{}, error)
+
+// Container constructs components lazily in dependency order and tears them
+// down in reverse. It is meant to be wired and started from a single
+// goroutine at startup; resolved components may be shared freely afterwards.
+type Container struct {
+	names     []string
+	providers map[string]Provider
+	instances map[string]interface{}
+	resolving []string
+	order     []string
+}
+
+// New creates an empty Container
+func New() *Container {
+	return &Container{
+		names:     make([]string, 0),
+		providers: make(map[string]Provider),
+		instances: make(map[string]interface{}),
+		resolving: make([]string, 0),
+		order:     make([]string, 0),
+	}
+}
+
+// =====================================
+// Registration
+// =====================================
+
+// Provide registers the provider for a component (pointer receiver).
+// Registering the same name twice is a wiring bug and panics.
+func (c *Container) Provide(name string, provider Provider) {
+	if _, exists := c.providers[name]; exists {
+		panic(fmt.Sprintf("container: provider %q already registered", name))
+	}
+	c.names = append(c.names, name)
+	c.providers[name] = provider
+}
+
+// Override replaces the provider for a registered component (pointer receiver).
+// Tests use it to swap in fakes; it panics if the component is unknown or
+// has already been constructed.
+func (c *Container) Override(name string, provider Provider) {
+	if _, exists := c.providers[name]; !exists {
+		panic(fmt.Sprintf("container: cannot override unknown provider %q", name))
+	}
+	if _, built := c.instances[name]; built {
+		panic(fmt.Sprintf("container: cannot override %q after it was constructed", name))
+	}
+	c.providers[name] = provider
+}
+
+// =====================================
+// Resolution
+// =====================================
+
+// Resolve returns the component registered under name, constructing it and
+// its dependencies on first use (pointer receiver)
+func (c *Container) Resolve(name string) (interface{}, error) {
+	if instance, built := c.instances[name]; built {
+		return instance, nil
+	}
+
+	provider, exists := c.providers[name]
+	if !exists {
+		return nil, fmt.Errorf("container: no provider for %q", name)
+	}
+
+	for i, pending := range c.resolving {
+		if pending == name {
+			cycle := append(append([]string{}, c.resolving[i:]...), name)
+			return nil, fmt.Errorf("container: dependency cycle %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	c.resolving = append(c.resolving, name)
+	instance, err := provider(c)
+	c.resolving = c.resolving[:len(c.resolving)-1]
+	if err != nil {
+		return nil, fmt.Errorf("container: constructing %q: %w", name, err)
+	}
+
+	c.instances[name] = instance
+	c.order = append(c.order, name)
+	return instance, nil
+}
+
+// Get resolves a component and asserts its type (standalone function)
+func Get[T any](c *Container, name string) (T, error) {
+	var zero T
+	instance, err := c.Resolve(name)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("container: %q is %T, not %T", name, instance, zero)
+	}
+	return typed, nil
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Start constructs every registered component, then initializes those that
+// implement interfaces.Initializer in construction order (pointer receiver).
+// If one fails to initialize, the components before it are shut down in
+// reverse order so workers they started do not outlive the failed start.
+func (c *Container) Start(ctx context.Context) error {
+	for _, name := range c.names {
+		if _, err := c.Resolve(name); err != nil {
+			return err
+		}
+	}
+
+	for i, name := range c.order {
+		initializer, ok := c.instances[name].(interfaces.Initializer)
+		if !ok {
+			continue
+		}
+		if err := initializer.Initialize(ctx); err != nil {
+			err = fmt.Errorf("container: initializing %q: %w", name, err)
+			return errors.Join(err, c.shutdown(ctx, c.order[:i]))
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts down components that implement interfaces.Shutdowner in
+// reverse construction order, so nothing is stopped before its dependents
+// (pointer receiver). Every component is attempted; errors are joined.
+func (c *Container) Shutdown(ctx context.Context) error {
+	return c.shutdown(ctx, c.order)
+}
+
+// shutdown shuts down the named components in reverse order (pointer receiver)
+func (c *Container) shutdown(ctx context.Context, names []string) error {
+	errs := make([]error, 0)
+	for i := len(names) - 1; i >= 0; i-- {
+		name := names[i]
+		shutdowner, ok := c.instances[name].(interfaces.Shutdowner)
+		if !ok {
+			continue
+		}
+		if err := shutdowner.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("container: shutting down %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Order returns component names in construction order (pointer receiver)
+func (c *Container) Order() []string {
+	return append([]string{}, c.order...)
+}
+
@@ -0,0 +1,80 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// lifecycleComponent records its Initialize and Shutdown calls in a shared log
+type lifecycleComponent struct {
+	name    string
+	log     *[]string
+	initErr error
+}
+
+func (l *lifecycleComponent) Initialize(ctx context.Context) error {
+	*l.log = append(*l.log, "init "+l.name)
+	return l.initErr
+}
+
+func (l *lifecycleComponent) Shutdown(ctx context.Context) error {
+	*l.log = append(*l.log, "shutdown "+l.name)
+	return nil
+}
+
+func TestStartRollsBackOnInitializeFailure(t *testing.T) {
+	var calls []string
+	errBroken := errors.New("broken")
+
+	c := New()
+	for _, component := range []*lifecycleComponent{
+		{name: "a", log: &calls},
+		{name: "b", log: &calls},
+		{name: "c", log: &calls, initErr: errBroken},
+		{name: "d", log: &calls},
+	} {
+		component := component
+		c.Provide(component.name, func(c *Container) (interface{}, error) {
+			return component, nil
+		})
+	}
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, errBroken) {
+		t.Fatalf("Start() = %v, want %v", err, errBroken)
+	}
+
+	want := []string{"init a", "init b", "init c", "shutdown b", "shutdown a"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestStartAndShutdownOrder(t *testing.T) {
+	var calls []string
+
+	c := New()
+	c.Provide("server", func(c *Container) (interface{}, error) {
+		if _, err := c.Resolve("store"); err != nil {
+			return nil, err
+		}
+		return &lifecycleComponent{name: "server", log: &calls}, nil
+	})
+	c.Provide("store", func(c *Container) (interface{}, error) {
+		return &lifecycleComponent{name: "store", log: &calls}, nil
+	})
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	want := []string{"init store", "init server", "shutdown server", "shutdown store"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,84 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalid is returned for an address that is not a valid mailbox
+var ErrInvalid = errors.New("invalid email address")
+
+// Address limits from RFC 5321
+const (
+	MaxLength          = 254
+	maxLocalPartLength = 64
+	maxDomainLabel     = 63
+)
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// Normalize returns the form addresses are stored and compared in:
+// trimmed and lowercased (standalone function)
+func Normalize(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// Parse validates a bare RFC 5322 mailbox such as
+// "jane.doe+news@example.com" and returns it normalized. Display names,
+// comments and IP-literal domains are rejected, as are domains without a
+// dot (standalone function).
+func Parse(address string) (string, error) {
+	normalized := Normalize(address)
+	if normalized == "" {
+		return "", fmt.Errorf("%w: address is empty", ErrInvalid)
+	}
+	if len(normalized) > MaxLength {
+		return "", fmt.Errorf("%w: address is longer than %d characters", ErrInvalid, MaxLength)
+	}
+
+	parsed, err := mail.ParseAddress(normalized)
+	if err != nil || parsed.Name != "" || parsed.Address != normalized {
+		return "", fmt.Errorf("%w: %q is not a bare mailbox", ErrInvalid, address)
+	}
+
+	at := strings.LastIndex(normalized, "@")
+	if at > maxLocalPartLength {
+		return "", fmt.Errorf("%w: local part is longer than %d characters", ErrInvalid, maxLocalPartLength)
+	}
+	if !validDomain(normalized[at+1:]) {
+		return "", fmt.Errorf("%w: %q is not a valid domain", ErrInvalid, normalized[at+1:])
+	}
+	return normalized, nil
+}
+
+// Valid checks an address; see Parse (standalone function)
+func Valid(address string) bool {
+	_, err := Parse(address)
+	return err == nil
+}
+
+// validDomain checks a domain has at least two labels of letters, digits
+// and inner hyphens, and a top-level label that is not numeric
+// (standalone function)
+func validDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxDomainLabel || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+	return strings.IndexFunc(labels[len(labels)-1], func(r rune) bool { return !unicode.IsDigit(r) }) >= 0
+}
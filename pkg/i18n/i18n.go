@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale every lookup falls back to
+const DefaultLocale = "en"
+
+// Message keys for translated notification templates
+const (
+	MsgJoinRequested = "join_request.requested"
+	MsgJoinApproved  = "join_request.approved"
+	MsgJoinDenied    = "join_request.denied"
+	MsgOrgInvitation = "org_invitation.invited"
+)
+
+var (
+	catalog = map[string]map[string]string{
+		MsgJoinRequested: {
+			"en": "%s asked to join %s",
+			"es": "%s ha solicitado unirse a %s",
+			"de": "%s möchte %s beitreten",
+		},
+		MsgJoinApproved: {
+			"en": "Your request to join %s was approved",
+			"es": "Tu solicitud para unirte a %s fue aprobada",
+			"de": "Ihre Anfrage, %s beizutreten, wurde angenommen",
+		},
+		MsgJoinDenied: {
+			"en": "Your request to join %s was denied",
+			"es": "Tu solicitud para unirte a %s fue rechazada",
+			"de": "Ihre Anfrage, %s beizutreten, wurde abgelehnt",
+		},
+		MsgOrgInvitation: {
+			"en": "%s invited you to join %s",
+			"es": "%s te ha invitado a unirte a %s",
+			"de": "%s hat Sie eingeladen, %s beizutreten",
+		},
+	}
+	catalogMu sync.RWMutex
+)
+
+// Normalize lowercases a locale tag and converts "de_AT" to "de-at" (standalone function)
+func Normalize(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// Negotiate returns the first non-empty candidate, normalized, or
+// DefaultLocale when every candidate is empty. Callers list candidates
+// from most to least specific, e.g. user preference then org default
+// (standalone function).
+func Negotiate(candidates ...string) string {
+	for _, candidate := range candidates {
+		if locale := Normalize(candidate); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Pick selects the translation for a locale such as "de-AT" from a map keyed
+// by lowercase locale, falling back to the base language, then DefaultLocale
+// (standalone function)
+func Pick(translations map[string]string, locale string) (string, bool) {
+	locale = Normalize(locale)
+	if text, ok := translations[locale]; ok {
+		return text, true
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if text, ok := translations[base]; ok {
+			return text, true
+		}
+	}
+	text, ok := translations[DefaultLocale]
+	return text, ok
+}
+
+// Register adds or replaces the translations of a message key (standalone function)
+func Register(key string, translations map[string]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	merged := make(map[string]string, len(translations))
+	for locale, text := range catalog[key] {
+		merged[locale] = text
+	}
+	for locale, text := range translations {
+		merged[Normalize(locale)] = text
+	}
+	catalog[key] = merged
+}
+
+// T formats the message for a key in the given locale. Unknown keys are
+// returned as-is so a missing translation is visible rather than silent
+// (standalone function).
+func T(locale, key string, args ...interface{}) string {
+	catalogMu.RLock()
+	template, ok := Pick(catalog[key], locale)
+	catalogMu.RUnlock()
+
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(template, args...)
+}
+
@@ -0,0 +1,235 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SupportedLocales are the languages API messages are translated into
+var SupportedLocales = []string{"en", "es", "de"}
+
+// Message keys for API response messages
+const (
+	MsgInvalidRequestBody = "api.invalid_request_body"
+	MsgRateLimitExceeded  = "api.rate_limit_exceeded"
+	MsgActorRequired      = "api.actor_required"
+	MsgPermissionDenied   = "api.permission_denied"
+	MsgContributeDenied   = "api.contribute_denied"
+	MsgValidationFailed   = "api.validation_failed"
+	MsgUnsupportedMedia   = "api.unsupported_media_type"
+	MsgBodyTooLarge       = "api.body_too_large"
+
+	MsgUserNotFound       = "api.user.not_found"
+	MsgUserModified       = "api.user.modified"
+	MsgUserNotDeleted     = "api.user.not_deleted"
+	MsgUserStoreFull      = "api.user.store_full"
+	MsgUserEmailInUse     = "api.user.email_in_use"
+	MsgUserIDRequired     = "api.user.id_required"
+	MsgUserCreated        = "api.user.created"
+	MsgUserUpdated        = "api.user.updated"
+	MsgUserDeleted        = "api.user.deleted"
+	MsgUserRestored       = "api.user.restored"
+	MsgUserRetrieved      = "api.user.retrieved"
+	MsgUsersRetrieved     = "api.user.list_retrieved"
+	MsgUserCreateFailed   = "api.user.create_failed"
+	MsgUserUpdateFailed   = "api.user.update_failed"
+	MsgUserDeleteFailed   = "api.user.delete_failed"
+	MsgUserRestoreFailed  = "api.user.restore_failed"
+	MsgUsersFetchFailed   = "api.user.list_failed"
+	MsgUserOrgsRetrieved  = "api.user.orgs_retrieved"
+	MsgUserProjsRetrieved = "api.user.projects_retrieved"
+
+	MsgOrgNotFound             = "api.org.not_found"
+	MsgOrgModified             = "api.org.modified"
+	MsgOrgNotDeleted           = "api.org.not_deleted"
+	MsgOrgStoreFull            = "api.org.store_full"
+	MsgOrgSlugInUse            = "api.org.slug_in_use"
+	MsgOrgCreated              = "api.org.created"
+	MsgOrgUpdated              = "api.org.updated"
+	MsgOrgDeleted              = "api.org.deleted"
+	MsgOrgRestored             = "api.org.restored"
+	MsgOrgRetrieved            = "api.org.retrieved"
+	MsgOrgsRetrieved           = "api.org.list_retrieved"
+	MsgOrgCreateFailed         = "api.org.create_failed"
+	MsgOrgUpdateFailed         = "api.org.update_failed"
+	MsgOrgDeleteFailed         = "api.org.delete_failed"
+	MsgOrgRestoreFailed        = "api.org.restore_failed"
+	MsgOrgsFetchFailed         = "api.org.list_failed"
+	MsgOrgSupportUpdated       = "api.org.support_updated"
+	MsgOrgVerificationUpdated  = "api.org.verification_updated"
+	MsgOrgPermissionsRetrieved = "api.org.permissions_retrieved"
+
+	MsgMemberAdded         = "api.member.added"
+	MsgMemberRemoved       = "api.member.removed"
+	MsgMemberRoleUpdated   = "api.member.role_updated"
+	MsgMembersRetrieved    = "api.member.list_retrieved"
+	MsgMembersFetchFailed  = "api.member.list_failed"
+	MsgMembershipNotFound  = "api.member.not_found"
+	MsgMemberOpsProcessed  = "api.member.operations_processed"
+	MsgProjectNotFound     = "api.project.not_found"
+	MsgProjectModified     = "api.project.modified"
+	MsgProjectCreated      = "api.project.created"
+	MsgProjectUpdated      = "api.project.updated"
+	MsgProjectDeleted      = "api.project.deleted"
+	MsgProjectRetrieved    = "api.project.retrieved"
+	MsgProjectsRetrieved   = "api.project.list_retrieved"
+	MsgProjectUpdateFailed = "api.project.update_failed"
+	MsgProjectDeleteFailed = "api.project.delete_failed"
+	MsgProjectsFetchFailed = "api.project.list_failed"
+)
+
+// apiMessages are the translations of API response messages. The English
+// text must match what handlers send, since responses are localized by
+// looking their message up here.
+var apiMessages = map[string]map[string]string{
+	MsgInvalidRequestBody: {"en": "Invalid request body", "es": "Cuerpo de la solicitud no válido", "de": "Ungültiger Anfragetext"},
+	MsgRateLimitExceeded:  {"en": "Rate limit exceeded", "es": "Límite de solicitudes excedido", "de": "Anfragelimit überschritten"},
+	MsgActorRequired:      {"en": "X-Acting-User header is required", "es": "La cabecera X-Acting-User es obligatoria", "de": "Der Header X-Acting-User ist erforderlich"},
+	MsgValidationFailed:   {"en": "Validation failed", "es": "La validación ha fallado", "de": "Validierung fehlgeschlagen"},
+	MsgUnsupportedMedia:   {"en": "Content-Type must be application/json", "es": "El Content-Type debe ser application/json", "de": "Content-Type muss application/json sein"},
+	MsgBodyTooLarge:       {"en": "Request body is too large", "es": "El cuerpo de la solicitud es demasiado grande", "de": "Der Anfragetext ist zu groß"},
+	MsgPermissionDenied:   {"en": "User %s lacks the %s permission in organization %s", "es": "El usuario %s no tiene el permiso %s en la organización %s", "de": "Benutzer %s fehlt die Berechtigung %s in der Organisation %s"},
+	MsgContributeDenied:   {"en": "User %s may not contribute to project %s", "es": "El usuario %s no puede contribuir al proyecto %s", "de": "Benutzer %s darf nicht am Projekt %s mitwirken"},
+
+	MsgUserNotFound:       {"en": "User not found", "es": "Usuario no encontrado", "de": "Benutzer nicht gefunden"},
+	MsgUserModified:       {"en": "User has been modified", "es": "El usuario ha sido modificado", "de": "Der Benutzer wurde geändert"},
+	MsgUserNotDeleted:     {"en": "User is not deleted", "es": "El usuario no está eliminado", "de": "Der Benutzer ist nicht gelöscht"},
+	MsgUserStoreFull:      {"en": "User store is full", "es": "El almacén de usuarios está lleno", "de": "Der Benutzerspeicher ist voll"},
+	MsgUserEmailInUse:     {"en": "Email is already in use", "es": "El correo electrónico ya está en uso", "de": "Die E-Mail-Adresse wird bereits verwendet"},
+	MsgUserIDRequired:     {"en": "User ID is required", "es": "El ID de usuario es obligatorio", "de": "Die Benutzer-ID ist erforderlich"},
+	MsgUserCreated:        {"en": "User created successfully", "es": "Usuario creado correctamente", "de": "Benutzer erfolgreich erstellt"},
+	MsgUserUpdated:        {"en": "User updated successfully", "es": "Usuario actualizado correctamente", "de": "Benutzer erfolgreich aktualisiert"},
+	MsgUserDeleted:        {"en": "User deleted successfully", "es": "Usuario eliminado correctamente", "de": "Benutzer erfolgreich gelöscht"},
+	MsgUserRestored:       {"en": "User restored successfully", "es": "Usuario restaurado correctamente", "de": "Benutzer erfolgreich wiederhergestellt"},
+	MsgUserRetrieved:      {"en": "User retrieved successfully", "es": "Usuario obtenido correctamente", "de": "Benutzer erfolgreich abgerufen"},
+	MsgUsersRetrieved:     {"en": "Users retrieved successfully", "es": "Usuarios obtenidos correctamente", "de": "Benutzer erfolgreich abgerufen"},
+	MsgUserCreateFailed:   {"en": "Failed to create user", "es": "No se pudo crear el usuario", "de": "Benutzer konnte nicht erstellt werden"},
+	MsgUserUpdateFailed:   {"en": "Failed to update user", "es": "No se pudo actualizar el usuario", "de": "Benutzer konnte nicht aktualisiert werden"},
+	MsgUserDeleteFailed:   {"en": "Failed to delete user", "es": "No se pudo eliminar el usuario", "de": "Benutzer konnte nicht gelöscht werden"},
+	MsgUserRestoreFailed:  {"en": "Failed to restore user", "es": "No se pudo restaurar el usuario", "de": "Benutzer konnte nicht wiederhergestellt werden"},
+	MsgUsersFetchFailed:   {"en": "Failed to fetch users", "es": "No se pudieron obtener los usuarios", "de": "Benutzer konnten nicht abgerufen werden"},
+	MsgUserOrgsRetrieved:  {"en": "User organizations retrieved successfully", "es": "Organizaciones del usuario obtenidas correctamente", "de": "Organisationen des Benutzers erfolgreich abgerufen"},
+	MsgUserProjsRetrieved: {"en": "User projects retrieved successfully", "es": "Proyectos del usuario obtenidos correctamente", "de": "Projekte des Benutzers erfolgreich abgerufen"},
+
+	MsgOrgNotFound:             {"en": "Organization not found", "es": "Organización no encontrada", "de": "Organisation nicht gefunden"},
+	MsgOrgModified:             {"en": "Organization has been modified", "es": "La organización ha sido modificada", "de": "Die Organisation wurde geändert"},
+	MsgOrgNotDeleted:           {"en": "Organization is not deleted", "es": "La organización no está eliminada", "de": "Die Organisation ist nicht gelöscht"},
+	MsgOrgStoreFull:            {"en": "Organization store is full", "es": "El almacén de organizaciones está lleno", "de": "Der Organisationsspeicher ist voll"},
+	MsgOrgSlugInUse:            {"en": "Slug is already in use", "es": "El slug ya está en uso", "de": "Der Slug wird bereits verwendet"},
+	MsgOrgCreated:              {"en": "Organization created successfully", "es": "Organización creada correctamente", "de": "Organisation erfolgreich erstellt"},
+	MsgOrgUpdated:              {"en": "Organization updated successfully", "es": "Organización actualizada correctamente", "de": "Organisation erfolgreich aktualisiert"},
+	MsgOrgDeleted:              {"en": "Organization deleted successfully", "es": "Organización eliminada correctamente", "de": "Organisation erfolgreich gelöscht"},
+	MsgOrgRestored:             {"en": "Organization restored successfully", "es": "Organización restaurada correctamente", "de": "Organisation erfolgreich wiederhergestellt"},
+	MsgOrgRetrieved:            {"en": "Organization retrieved successfully", "es": "Organización obtenida correctamente", "de": "Organisation erfolgreich abgerufen"},
+	MsgOrgsRetrieved:           {"en": "Organizations retrieved successfully", "es": "Organizaciones obtenidas correctamente", "de": "Organisationen erfolgreich abgerufen"},
+	MsgOrgCreateFailed:         {"en": "Failed to create organization", "es": "No se pudo crear la organización", "de": "Organisation konnte nicht erstellt werden"},
+	MsgOrgUpdateFailed:         {"en": "Failed to update organization", "es": "No se pudo actualizar la organización", "de": "Organisation konnte nicht aktualisiert werden"},
+	MsgOrgDeleteFailed:         {"en": "Failed to delete organization", "es": "No se pudo eliminar la organización", "de": "Organisation konnte nicht gelöscht werden"},
+	MsgOrgRestoreFailed:        {"en": "Failed to restore organization", "es": "No se pudo restaurar la organización", "de": "Organisation konnte nicht wiederhergestellt werden"},
+	MsgOrgsFetchFailed:         {"en": "Failed to fetch organizations", "es": "No se pudieron obtener las organizaciones", "de": "Organisationen konnten nicht abgerufen werden"},
+	MsgOrgSupportUpdated:       {"en": "Organization support updated successfully", "es": "Soporte de la organización actualizado correctamente", "de": "Support der Organisation erfolgreich aktualisiert"},
+	MsgOrgVerificationUpdated:  {"en": "Organization verification updated successfully", "es": "Verificación de la organización actualizada correctamente", "de": "Verifizierung der Organisation erfolgreich aktualisiert"},
+	MsgOrgPermissionsRetrieved: {"en": "Permissions retrieved successfully", "es": "Permisos obtenidos correctamente", "de": "Berechtigungen erfolgreich abgerufen"},
+
+	MsgMemberAdded:        {"en": "Member added successfully", "es": "Miembro añadido correctamente", "de": "Mitglied erfolgreich hinzugefügt"},
+	MsgMemberRemoved:      {"en": "Member removed successfully", "es": "Miembro eliminado correctamente", "de": "Mitglied erfolgreich entfernt"},
+	MsgMemberRoleUpdated:  {"en": "Member role updated successfully", "es": "Rol del miembro actualizado correctamente", "de": "Rolle des Mitglieds erfolgreich aktualisiert"},
+	MsgMembersRetrieved:   {"en": "Members retrieved successfully", "es": "Miembros obtenidos correctamente", "de": "Mitglieder erfolgreich abgerufen"},
+	MsgMembersFetchFailed: {"en": "Failed to fetch members", "es": "No se pudieron obtener los miembros", "de": "Mitglieder konnten nicht abgerufen werden"},
+	MsgMembershipNotFound: {"en": "Membership not found", "es": "Membresía no encontrada", "de": "Mitgliedschaft nicht gefunden"},
+	MsgMemberOpsProcessed: {"en": "Member operations processed", "es": "Operaciones de miembros procesadas", "de": "Mitgliedsvorgänge verarbeitet"},
+
+	MsgProjectNotFound:     {"en": "Project not found", "es": "Proyecto no encontrado", "de": "Projekt nicht gefunden"},
+	MsgProjectModified:     {"en": "Project has been modified", "es": "El proyecto ha sido modificado", "de": "Das Projekt wurde geändert"},
+	MsgProjectCreated:      {"en": "Project created successfully", "es": "Proyecto creado correctamente", "de": "Projekt erfolgreich erstellt"},
+	MsgProjectUpdated:      {"en": "Project updated successfully", "es": "Proyecto actualizado correctamente", "de": "Projekt erfolgreich aktualisiert"},
+	MsgProjectDeleted:      {"en": "Project deleted successfully", "es": "Proyecto eliminado correctamente", "de": "Projekt erfolgreich gelöscht"},
+	MsgProjectRetrieved:    {"en": "Project retrieved successfully", "es": "Proyecto obtenido correctamente", "de": "Projekt erfolgreich abgerufen"},
+	MsgProjectsRetrieved:   {"en": "Projects retrieved successfully", "es": "Proyectos obtenidos correctamente", "de": "Projekte erfolgreich abgerufen"},
+	MsgProjectUpdateFailed: {"en": "Failed to update project", "es": "No se pudo actualizar el proyecto", "de": "Projekt konnte nicht aktualisiert werden"},
+	MsgProjectDeleteFailed: {"en": "Failed to delete project", "es": "No se pudo eliminar el proyecto", "de": "Projekt konnte nicht gelöscht werden"},
+	MsgProjectsFetchFailed: {"en": "Failed to fetch projects", "es": "No se pudieron obtener los proyectos", "de": "Projekte konnten nicht abgerufen werden"},
+}
+
+// messageKeys maps the English text of each API message to its key
+var messageKeys = make(map[string]string, len(apiMessages))
+
+func init() {
+	for key, translations := range apiMessages {
+		Register(key, translations)
+		messageKeys[translations[DefaultLocale]] = key
+	}
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// Localize translates an English API message into a locale. Messages
+// without a catalog entry, such as ones carrying error details, are
+// returned unchanged (standalone function).
+func Localize(locale, message string) string {
+	key, ok := messageKeys[message]
+	if !ok || Normalize(locale) == DefaultLocale {
+		return message
+	}
+	return T(locale, key)
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header from
+// most to least preferred, dropping "*" and tags with q=0, e.g.
+// "de-AT;q=0.8, es, *;q=0.1" gives ["es", "de-at"] (standalone function)
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = Normalize(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// Match returns the first supported locale among tags ordered by
+// preference, matching "de-at" to "de", or DefaultLocale when none is
+// supported (standalone function)
+func Match(tags []string) string {
+	for _, tag := range tags {
+		base, _, _ := strings.Cut(Normalize(tag), "-")
+		for _, supported := range SupportedLocales {
+			if base == supported {
+				return supported
+			}
+		}
+	}
+	return DefaultLocale
+}
+
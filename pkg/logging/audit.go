@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditCapacity bounds how many recent audit lines are kept in memory
+const auditCapacity = 1000
+
+// AuditEntry is one audit line kept for review, e.g. the line
+// "audit action=org.support org=org_1 tier=priority"
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Message string    `json:"message"` // The line without the logger's prefix
+}
+
+// auditLog keeps the most recent audit lines in a ring buffer
+type auditLog struct {
+	entries []AuditEntry
+	next    int // Index the next entry is written to once the buffer is full
+	mu      sync.Mutex
+}
+
+// =====================================
+// Pointer Receiver Methods on auditLog
+// =====================================
+
+// record keeps an audit line, replacing the oldest when full (pointer receiver)
+func (a *auditLog) record(line []byte) {
+	message := strings.TrimSpace(messageText(line))
+	entry := AuditEntry{
+		Time:    time.Now().UTC(),
+		Action:  auditAction(message),
+		Message: message,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.entries) < auditCapacity {
+		a.entries = append(a.entries, entry)
+		return
+	}
+	a.entries[a.next] = entry
+	a.next = (a.next + 1) % auditCapacity
+}
+
+// recent returns up to limit entries whose action starts with prefix,
+// newest first (pointer receiver)
+func (a *auditLog) recent(prefix string, limit int) []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entries := make([]AuditEntry, 0)
+	for i := len(a.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := a.entries[(a.next+i)%len(a.entries)]
+		if strings.HasPrefix(entry.Action, prefix) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// =====================================
+// Pointer Receiver Methods on Controller
+// =====================================
+
+// Audit returns up to limit recent audit lines whose action starts with
+// prefix, newest first. Only the last 1000 lines are kept (pointer receiver).
+func (c *Controller) Audit(prefix string, limit int) []AuditEntry {
+	return c.audit.recent(prefix, limit)
+}
+
+// =====================================
+// Helper Functions
+// =====================================
+
+// auditAction returns the action=... field of an audit message (standalone function)
+func auditAction(message string) string {
+	for _, field := range strings.Fields(message) {
+		if action, found := strings.CutPrefix(field, "action="); found {
+			return action
+		}
+	}
+	return ""
+}
+
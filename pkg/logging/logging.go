@@ -0,0 +1,275 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is the severity of a log line
+type Level int
+
+// Log levels, from most to least verbose
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelOff
+)
+
+var levelNames = [...]string{"debug", "info", "warn", "error", "off"}
+
+// ParseLevel converts a name such as "warn" to a Level (standalone function)
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+	for i, levelName := range levelNames {
+		if name == levelName {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// String returns the level's name (value receiver)
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelOff {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// MarshalText encodes the level by name (value receiver)
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level name (pointer receiver)
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// ClassifyLine infers the level of a log line from the first word of its
+// message: "Error", "Failed" and "Panic" lines are errors, "Warning" and
+// "Ignoring" lines are warnings, and everything else is info (standalone function).
+func ClassifyLine(line []byte) Level {
+	switch messageWord(line) {
+	case "Error", "Failed", "Panic":
+		return LevelError
+	case "Warning", "Ignoring":
+		return LevelWarn
+	}
+	return LevelInfo
+}
+
+// Config is the initial logging configuration
+type Config struct {
+	Level    Level            // Global level
+	Packages map[string]Level // Per-package overrides keyed by package name, e.g. "handlers"
+	Sampling []SampleRule     // Request log sampling, first matching rule wins
+}
+
+// LevelSettings is a snapshot of the global and per-package levels
+type LevelSettings struct {
+	Global     Level            `json:"global"`
+	Packages   map[string]Level `json:"packages"`
+	Suppressed uint64           `json:"suppressed"` // Lines filtered out since startup
+}
+
+// Controller filters log lines by level and samples request logs. It is an
+// io.Writer meant to be the output of a *log.Logger; the package a line
+// belongs to is taken from the code that called the logger.
+type Controller struct {
+	out      io.Writer
+	global   Level
+	packages map[string]Level
+	sampler  *sampler
+	mu       sync.RWMutex
+
+	callers    sync.Map // Program counter -> package name
+	suppressed atomic.Uint64
+	audit      auditLog // Recent audit lines, kept for review
+}
+
+// NewController creates a Controller writing accepted lines to out
+func NewController(out io.Writer, config Config) *Controller {
+	packages := make(map[string]Level, len(config.Packages))
+	for name, level := range config.Packages {
+		packages[name] = level
+	}
+	return &Controller{
+		out:      out,
+		global:   config.Level,
+		packages: packages,
+		sampler:  newSampler(config.Sampling),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on Controller
+// =====================================
+
+// Write passes p on when its level is enabled for the calling package.
+// Audit lines are never filtered and are kept for Audit, and a filtered
+// line still reports success (pointer receiver).
+func (c *Controller) Write(p []byte) (int, error) {
+	if messageWord(p) == "audit" {
+		c.audit.record(p)
+		return c.out.Write(p)
+	}
+	level := ClassifyLine(p)
+
+	c.mu.RLock()
+	overridden := len(c.packages) > 0
+	threshold := c.global
+	c.mu.RUnlock()
+
+	if overridden {
+		threshold = c.threshold(c.callerPackage())
+	}
+	if level < threshold {
+		c.suppressed.Add(1)
+		return len(p), nil
+	}
+	return c.out.Write(p)
+}
+
+// Enabled reports whether lines of a level from a package are written (pointer receiver)
+func (c *Controller) Enabled(pkg string, level Level) bool {
+	return level >= c.threshold(pkg)
+}
+
+// Levels returns the current global and per-package levels (pointer receiver)
+func (c *Controller) Levels() LevelSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	packages := make(map[string]Level, len(c.packages))
+	for name, level := range c.packages {
+		packages[name] = level
+	}
+	return LevelSettings{
+		Global:     c.global,
+		Packages:   packages,
+		Suppressed: c.suppressed.Load(),
+	}
+}
+
+// SetGlobalLevel changes the level used by packages without an override (pointer receiver)
+func (c *Controller) SetGlobalLevel(level Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.global = level
+}
+
+// SetPackageLevel overrides the level of one package (pointer receiver)
+func (c *Controller) SetPackageLevel(pkg string, level Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packages[pkg] = level
+}
+
+// ResetPackageLevel removes a package override so the global level applies (pointer receiver)
+func (c *Controller) ResetPackageLevel(pkg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.packages, pkg)
+}
+
+// SampleRequest reports whether a request to a route, given as its mux
+// path template, should be logged (pointer receiver)
+func (c *Controller) SampleRequest(route string) bool {
+	return c.sampler.sample(route)
+}
+
+// Sampling returns the configured request sampling rules and how many
+// requests each has skipped (pointer receiver)
+func (c *Controller) Sampling() []SampleStats {
+	return c.sampler.stats()
+}
+
+// threshold returns the level in effect for a package (pointer receiver)
+func (c *Controller) threshold(pkg string) Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if level, ok := c.packages[pkg]; ok {
+		return level
+	}
+	return c.global
+}
+
+// callerPackage names the package of the first caller outside the log
+// package and this one. Results are cached per call site (pointer receiver).
+func (c *Controller) callerPackage() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+
+	for _, pc := range pcs[:n] {
+		if cached, ok := c.callers.Load(pc); ok {
+			if pkg := cached.(string); pkg != "" {
+				return pkg
+			}
+			continue
+		}
+
+		pkg := packageName(runtime.FuncForPC(pc - 1))
+		if pkg == "log" || pkg == "logging" {
+			c.callers.Store(pc, "")
+			continue
+		}
+		c.callers.Store(pc, pkg)
+		return pkg
+	}
+	return ""
+}
+
+// =====================================
+// Helper Functions
+// =====================================
+
+// messageWord returns the first word of a line's message without a
+// trailing colon. The message starts after the "file.go:123: " header
+// written by log.Lshortfile when present (standalone function).
+func messageWord(line []byte) string {
+	word, _, _ := strings.Cut(messageText(line), " ")
+	return strings.TrimSuffix(word, ":")
+}
+
+// messageText returns a line's message after the "file.go:123: " header
+// written by log.Lshortfile, or the whole line without one (standalone function)
+func messageText(line []byte) string {
+	message := string(line)
+	if i := strings.Index(message, ".go:"); i >= 0 {
+		if j := strings.Index(message[i:], ": "); j >= 0 {
+			message = message[i+j+2:]
+		}
+	}
+	return message
+}
+
+// packageName returns the last element of a function's import path, e.g.
+// "handlers" for ".../handlers.(*Handler).CreateUser" (standalone function)
+func packageName(fn *runtime.Func) string {
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	pkg, _, _ := strings.Cut(name, ".")
+	return pkg
+}
+
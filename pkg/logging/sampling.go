@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// SampleRule logs one in Every requests to matching routes. Route is a mux
+// path template such as "/api/v1/users/{id}", or a prefix ending in "*".
+// Every 0 silences the route; 1 logs every request.
+type SampleRule struct {
+	Route string `json:"route"`
+	Every uint64 `json:"every"`
+}
+
+// SampleStats reports how a sampling rule has applied since startup
+type SampleStats struct {
+	SampleRule
+	Seen   uint64 `json:"seen"`
+	Logged uint64 `json:"logged"`
+}
+
+// ParseSampleRules parses "route=every" pairs separated by commas, e.g.
+// "/health=0,/api/v1/users*=100" (standalone function)
+func ParseSampleRules(value string) ([]SampleRule, error) {
+	var rules []SampleRule
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		route, every, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(route) == "" {
+			return nil, fmt.Errorf("invalid sampling rule %q", pair)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(every), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampling rate in %q", pair)
+		}
+		rules = append(rules, SampleRule{Route: strings.TrimSpace(route), Every: n})
+	}
+	return rules, nil
+}
+
+// =====================================
+// Value Receiver Methods on SampleRule
+// =====================================
+
+// Matches reports whether the rule applies to a route template (value receiver)
+func (r SampleRule) Matches(route string) bool {
+	if prefix, ok := strings.CutSuffix(r.Route, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return r.Route == route
+}
+
+// sampler applies sampling rules with a counter per rule. Rules are fixed
+// at startup, so counting needs no lock.
+type sampler struct {
+	rules  []SampleRule
+	seen   []atomic.Uint64
+	logged []atomic.Uint64
+}
+
+// newSampler creates a sampler for the rules
+func newSampler(rules []SampleRule) *sampler {
+	return &sampler{
+		rules:  append([]SampleRule(nil), rules...),
+		seen:   make([]atomic.Uint64, len(rules)),
+		logged: make([]atomic.Uint64, len(rules)),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on sampler
+// =====================================
+
+// sample reports whether to log this request; routes without a rule are
+// always logged (pointer receiver)
+func (s *sampler) sample(route string) bool {
+	for i, rule := range s.rules {
+		if !rule.Matches(route) {
+			continue
+		}
+		n := s.seen[i].Add(1)
+		if rule.Every == 0 || (n-1)%rule.Every != 0 {
+			return false
+		}
+		s.logged[i].Add(1)
+		return true
+	}
+	return true
+}
+
+// stats reports each rule with its counters (pointer receiver)
+func (s *sampler) stats() []SampleStats {
+	stats := make([]SampleStats, len(s.rules))
+	for i, rule := range s.rules {
+		stats[i] = SampleStats{
+			SampleRule: rule,
+			Seen:       s.seen[i].Load(),
+			Logged:     s.logged[i].Load(),
+		}
+	}
+	return stats
+}
+
@@ -0,0 +1,231 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default registry settings used when a Config field is zero
+const (
+	DefaultResolution = time.Minute
+	DefaultRetention  = 6 * time.Hour
+)
+
+// DefaultLatencyBounds are the upper bounds of the latency histogram
+// buckets. Requests slower than the last bound fall into an overflow bucket.
+var DefaultLatencyBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Config controls how finely and for how long request metrics are kept
+type Config struct {
+	Resolution    time.Duration   // Width of each time bucket
+	Retention     time.Duration   // How far back windows can reach
+	LatencyBounds []time.Duration // Ascending histogram bucket upper bounds
+}
+
+// Counts are the requests observed for one or more routes over a window
+type Counts struct {
+	Requests uint64   `json:"requests"`
+	Errors   uint64   `json:"errors"`  // Server errors (status 5xx)
+	Latency  []uint64 `json:"latency"` // Per latency bound, then overflow
+}
+
+// RouteCounts are the counts for a single route
+type RouteCounts struct {
+	Route string `json:"route"`
+	Counts
+}
+
+// bucket holds the counts for one time slot
+type bucket struct {
+	slot int64
+	Counts
+}
+
+// series is a ring of time buckets for one route
+type series struct {
+	buckets []bucket
+}
+
+// Registry records request outcomes per route template in time buckets,
+// so callers can ask what happened to a set of routes over a recent window
+type Registry struct {
+	config Config
+	routes map[string]*series
+	mu     sync.Mutex
+}
+
+// New creates a Registry
+func New(config Config) *Registry {
+	if config.Resolution <= 0 {
+		config.Resolution = DefaultResolution
+	}
+	if config.Retention < config.Resolution {
+		config.Retention = DefaultRetention
+	}
+	if len(config.LatencyBounds) == 0 {
+		config.LatencyBounds = DefaultLatencyBounds
+	}
+	config.LatencyBounds = append([]time.Duration(nil), config.LatencyBounds...)
+	sort.Slice(config.LatencyBounds, func(i, j int) bool {
+		return config.LatencyBounds[i] < config.LatencyBounds[j]
+	})
+
+	return &Registry{
+		config: config,
+		routes: make(map[string]*series),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on Registry
+// =====================================
+
+// Config returns the registry settings (pointer receiver)
+func (r *Registry) Config() Config {
+	return r.config
+}
+
+// Observe records one request to a route (pointer receiver)
+func (r *Registry) Observe(route string, status int, duration time.Duration, at time.Time) {
+	slot := r.slot(at)
+	latency := sort.Search(len(r.config.LatencyBounds), func(i int) bool {
+		return duration <= r.config.LatencyBounds[i]
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, exists := r.routes[route]
+	if !exists {
+		s = &series{buckets: make([]bucket, r.slots())}
+		r.routes[route] = s
+	}
+
+	b := &s.buckets[slot%int64(len(s.buckets))]
+	if b.slot != slot || b.Latency == nil {
+		*b = bucket{slot: slot, Counts: Counts{Latency: make([]uint64, len(r.config.LatencyBounds)+1)}}
+	}
+	b.Requests++
+	if status >= 500 {
+		b.Errors++
+	}
+	b.Latency[latency]++
+}
+
+// Window sums the counts of every route accepted by match over the window
+// ending at now. Windows longer than the retention are clamped to it
+// (pointer receiver).
+func (r *Registry) Window(match func(route string) bool, window time.Duration, now time.Time) Counts {
+	total := Counts{Latency: make([]uint64, len(r.config.LatencyBounds)+1)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := r.slot(now)
+	first := r.firstSlot(window, last)
+	for route, s := range r.routes {
+		if match != nil && !match(route) {
+			continue
+		}
+		s.sum(&total, first, last)
+	}
+	return total
+}
+
+// Routes returns the counts of every route seen over the window ending at
+// now, busiest first (pointer receiver)
+func (r *Registry) Routes(window time.Duration, now time.Time) []RouteCounts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := r.slot(now)
+	first := r.firstSlot(window, last)
+	routes := make([]RouteCounts, 0, len(r.routes))
+	for route, s := range r.routes {
+		counts := RouteCounts{Route: route, Counts: Counts{Latency: make([]uint64, len(r.config.LatencyBounds)+1)}}
+		s.sum(&counts.Counts, first, last)
+		if counts.Requests > 0 {
+			routes = append(routes, counts)
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Requests != routes[j].Requests {
+			return routes[i].Requests > routes[j].Requests
+		}
+		return routes[i].Route < routes[j].Route
+	})
+	return routes
+}
+
+// slot returns the time bucket index of an instant (pointer receiver)
+func (r *Registry) slot(at time.Time) int64 {
+	return at.UnixNano() / int64(r.config.Resolution)
+}
+
+// slots returns the number of buckets kept per route (pointer receiver)
+func (r *Registry) slots() int {
+	return int(r.config.Retention / r.config.Resolution)
+}
+
+// firstSlot returns the oldest bucket a window ending in last covers (pointer receiver)
+func (r *Registry) firstSlot(window time.Duration, last int64) int64 {
+	n := int64(window / r.config.Resolution)
+	if n < 1 {
+		n = 1
+	}
+	if n > int64(r.slots()) {
+		n = int64(r.slots())
+	}
+	return last - n + 1
+}
+
+// =====================================
+// Pointer Receiver Methods on series
+// =====================================
+
+// sum adds the buckets between two slots, inclusive, to counts (pointer receiver)
+func (s *series) sum(counts *Counts, first, last int64) {
+	for _, b := range s.buckets {
+		if b.slot < first || b.slot > last || b.Latency == nil {
+			continue
+		}
+		counts.Requests += b.Requests
+		counts.Errors += b.Errors
+		for i, n := range b.Latency {
+			counts.Latency[i] += n
+		}
+	}
+}
+
+// =====================================
+// Value Receiver Methods on Counts
+// =====================================
+
+// Within returns how many requests finished within a latency threshold,
+// given the bounds the counts were recorded with. The threshold is rounded
+// down to a bucket bound, so requests are never counted as faster than
+// they were (value receiver).
+func (c Counts) Within(bounds []time.Duration, threshold time.Duration) uint64 {
+	var within uint64
+	for i, bound := range bounds {
+		if bound > threshold || i >= len(c.Latency) {
+			break
+		}
+		within += c.Latency[i]
+	}
+	return within
+}
@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/pkg/container"
+)
+
+// Plugin is an extension compiled into the server. Plugins register
+// themselves from an init function and opt into extension points by
+// implementing ComponentProvider and/or RouteProvider.
+type Plugin interface {
+	Name() string
+}
+
+// ComponentProvider is implemented by plugins that contribute components
+// (services, background workers, handlers) to the application container
+type ComponentProvider interface {
+	Plugin
+	Provide(c *container.Container)
+}
+
+// RouteProvider is implemented by plugins that contribute a route group.
+// The router is the /api/v1 subrouter; dependencies are resolved from c.
+type RouteProvider interface {
+	Plugin
+	Routes(router *mux.Router, c *container.Container) error
+}
+
+var (
+	registry   = make(map[string]Plugin)
+	registryMu sync.RWMutex
+)
+
+// Register makes a plugin available to the server (standalone function).
+// It is meant to be called from init and panics on a nil or duplicate
+// plugin, like database/sql.Register.
+func Register(p Plugin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if p == nil {
+		panic("plugin: Register plugin is nil")
+	}
+	name := p.Name()
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("plugin: Register called twice for plugin %q", name))
+	}
+	registry[name] = p
+}
+
+// Plugins returns the registered plugins sorted by name, so wiring order
+// does not depend on package initialization order (standalone function)
+func Plugins() []Plugin {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	plugins := make([]Plugin, 0, len(registry))
+	for _, p := range registry {
+		plugins = append(plugins, p)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name() < plugins[j].Name()
+	})
+	return plugins
+}
+
+// ProvideAll lets every ComponentProvider plugin register its components
+// (standalone function)
+func ProvideAll(c *container.Container) {
+	for _, p := range Plugins() {
+		if provider, ok := p.(ComponentProvider); ok {
+			provider.Provide(c)
+		}
+	}
+}
+
+// MountAll mounts the route group of every RouteProvider plugin
+// (standalone function)
+func MountAll(router *mux.Router, c *container.Container) error {
+	for _, p := range Plugins() {
+		provider, ok := p.(RouteProvider)
+		if !ok {
+			continue
+		}
+		if err := provider.Routes(router, c); err != nil {
+			return fmt.Errorf("plugin %q: %w", p.Name(), err)
+		}
+	}
+	return nil
+}
+
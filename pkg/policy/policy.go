@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"github.com/test-repo-golang-support/models"
+)
+
+// Action is something a member may be allowed to do in an organization
+type Action string
+
+// Organization actions
+const (
+	ActionManageMembers  Action = "manage_members"  // Add, remove, and change the role of members; invitations and join requests
+	ActionManageProjects Action = "manage_projects" // Update, delete, and transfer projects; guest access and project members
+	ActionEditOrg        Action = "edit_org"        // Update the organization and its settings
+	ActionDeleteOrg      Action = "delete_org"      // Delete or restore the organization
+	ActionViewBilling    Action = "view_billing"    // See plan and billing details
+)
+
+// Actions lists every action in the order permissions are reported
+var Actions = []Action{
+	ActionManageMembers,
+	ActionManageProjects,
+	ActionEditOrg,
+	ActionDeleteOrg,
+	ActionViewBilling,
+}
+
+// matrix maps each member role to the actions it allows. Roles not listed,
+// including custom roles, allow nothing.
+var matrix = map[models.MemberRole][]Action{
+	models.MemberRoleOwner: {ActionManageMembers, ActionManageProjects, ActionEditOrg, ActionDeleteOrg, ActionViewBilling},
+	models.MemberRoleAdmin: {ActionManageMembers, ActionManageProjects, ActionEditOrg, ActionViewBilling},
+}
+
+// Allows reports whether a role may perform an action (standalone function)
+func Allows(role models.MemberRole, action Action) bool {
+	for _, allowed := range matrix[role] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns every action a role allows, in Actions order
+// (standalone function)
+func Permissions(role models.MemberRole) []Action {
+	permissions := make([]Action, 0, len(Actions))
+	for _, action := range Actions {
+		if Allows(role, action) {
+			permissions = append(permissions, action)
+		}
+	}
+	return permissions
+}
+
+// Grants returns a role's answer for every action, e.g. for gating UI
+// controls (standalone function)
+func Grants(role models.MemberRole) map[Action]bool {
+	grants := make(map[Action]bool, len(Actions))
+	for _, action := range Actions {
+		grants[action] = Allows(role, action)
+	}
+	return grants
+}
+
+// Matrix returns the actions each built-in role allows (standalone function)
+func Matrix() map[models.MemberRole][]Action {
+	roles := []models.MemberRole{models.MemberRoleOwner, models.MemberRoleAdmin, models.MemberRoleMember, models.MemberRoleGuest}
+	m := make(map[models.MemberRole][]Action, len(roles))
+	for _, role := range roles {
+		m[role] = Permissions(role)
+	}
+	return m
+}
+
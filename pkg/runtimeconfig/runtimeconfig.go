@@ -0,0 +1,177 @@
+package runtimeconfig
+
+import (
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sources of an effective runtime setting
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	SourceCgroup  = "cgroup"
+)
+
+// DefaultMemoryLimitRatio is the share of a container memory limit used as
+// the soft GOMEMLIMIT, leaving headroom for non-heap memory
+const DefaultMemoryLimitRatio = 0.9
+
+// Config holds the tuning knobs applied at startup
+type Config struct {
+	MemoryLimitRatio float64 // Share of the cgroup memory limit to use as GOMEMLIMIT
+}
+
+// Settings describes the effective Go runtime settings and where they came from
+type Settings struct {
+	GoVersion         string    `json:"go_version"`
+	NumCPU            int       `json:"num_cpu"`
+	GOMAXPROCS        int       `json:"gomaxprocs"`
+	GOMAXPROCSSource  string    `json:"gomaxprocs_source"`
+	CPUQuota          float64   `json:"cpu_quota,omitempty"`
+	GOGC              int       `json:"gogc"` // -1 means the collector is off
+	GOGCSource        string    `json:"gogc_source"`
+	MemoryLimit       int64     `json:"memory_limit_bytes,omitempty"` // 0 means no limit
+	MemoryLimitSource string    `json:"memory_limit_source"`
+	CgroupMemory      int64     `json:"cgroup_memory_bytes,omitempty"`
+	AppliedAt         time.Time `json:"applied_at"`
+}
+
+// cgroup files, read from the container's own cgroup namespace
+const (
+	cgroupV2CPU    = "/sys/fs/cgroup/cpu.max"
+	cgroupV2Memory = "/sys/fs/cgroup/memory.max"
+	cgroupV1Quota  = "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	cgroupV1Period = "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+	cgroupV1Memory = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+)
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// Apply sizes GOMAXPROCS to the container CPU quota and GOMEMLIMIT to a
+// share of the container memory limit. GOMAXPROCS, GOGC and GOMEMLIMIT set
+// in the environment always win, since the runtime has already applied
+// them. The effective settings are logged and returned (standalone function).
+func Apply(config Config, logger *log.Logger) Settings {
+	settings := Settings{
+		GoVersion:         runtime.Version(),
+		NumCPU:            runtime.NumCPU(),
+		GOMAXPROCSSource:  SourceDefault,
+		GOGCSource:        SourceDefault,
+		MemoryLimitSource: SourceDefault,
+	}
+
+	quota := cpuQuota()
+	settings.CPUQuota = quota
+	if os.Getenv("GOMAXPROCS") != "" {
+		settings.GOMAXPROCSSource = SourceEnv
+	} else if quota > 0 {
+		procs := int(math.Floor(quota))
+		if procs < 1 {
+			procs = 1
+		}
+		if procs < runtime.NumCPU() {
+			runtime.GOMAXPROCS(procs)
+			settings.GOMAXPROCSSource = SourceCgroup
+		}
+	}
+
+	settings.GOGC = gcPercent()
+	if os.Getenv("GOGC") != "" {
+		settings.GOGCSource = SourceEnv
+	}
+
+	settings.CgroupMemory = memoryLimit()
+	ratio := config.MemoryLimitRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = DefaultMemoryLimitRatio
+	}
+	if os.Getenv("GOMEMLIMIT") != "" {
+		settings.MemoryLimitSource = SourceEnv
+	} else if settings.CgroupMemory > 0 {
+		debug.SetMemoryLimit(int64(float64(settings.CgroupMemory) * ratio))
+		settings.MemoryLimitSource = SourceCgroup
+	}
+
+	settings.Refresh()
+	settings.AppliedAt = time.Now().UTC()
+
+	logger.Printf("Runtime: %s GOMAXPROCS=%d (%s, %d CPUs, quota %.2f) GOGC=%d (%s) GOMEMLIMIT=%d (%s)",
+		settings.GoVersion, settings.GOMAXPROCS, settings.GOMAXPROCSSource, settings.NumCPU, settings.CPUQuota,
+		settings.GOGC, settings.GOGCSource, settings.MemoryLimit, settings.MemoryLimitSource)
+	return settings
+}
+
+// cpuQuota returns the cgroup CPU limit in cores, or 0 when unlimited (standalone function)
+func cpuQuota() float64 {
+	if fields := strings.Fields(readFile(cgroupV2CPU)); len(fields) == 2 && fields[0] != "max" {
+		quota, err1 := strconv.ParseFloat(fields[0], 64)
+		period, err2 := strconv.ParseFloat(fields[1], 64)
+		if err1 == nil && err2 == nil && period > 0 {
+			return quota / period
+		}
+	}
+
+	quota, err1 := strconv.ParseFloat(readFile(cgroupV1Quota), 64)
+	period, err2 := strconv.ParseFloat(readFile(cgroupV1Period), 64)
+	if err1 == nil && err2 == nil && quota > 0 && period > 0 {
+		return quota / period
+	}
+	return 0
+}
+
+// memoryLimit returns the cgroup memory limit in bytes, or 0 when unlimited (standalone function)
+func memoryLimit() int64 {
+	for _, name := range []string{cgroupV2Memory, cgroupV1Memory} {
+		limit, err := strconv.ParseInt(readFile(name), 10, 64)
+		// cgroup v1 reports "unlimited" as a huge page-aligned number
+		if err == nil && limit > 0 && limit < 1<<62 {
+			return limit
+		}
+	}
+	return 0
+}
+
+// gcPercent returns the GOGC the runtime started with. The runtime has no
+// getter, and probing with debug.SetGCPercent would briefly change it (standalone function).
+func gcPercent() int {
+	value := os.Getenv("GOGC")
+	if value == "off" {
+		return -1
+	}
+	if percent, err := strconv.Atoi(value); err == nil {
+		return percent
+	}
+	return 100
+}
+
+// readFile returns the trimmed contents of a file, or "" if unreadable (standalone function)
+func readFile(name string) string {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// =====================================
+// Pointer Receiver Methods on Settings
+// =====================================
+
+// Refresh re-reads GOMAXPROCS and GOMEMLIMIT from the runtime, which may
+// have been changed since startup (pointer receiver)
+func (s *Settings) Refresh() {
+	s.GOMAXPROCS = runtime.GOMAXPROCS(0)
+	s.MemoryLimit = debug.SetMemoryLimit(-1)
+	if s.MemoryLimit == math.MaxInt64 {
+		s.MemoryLimit = 0
+	}
+}
+
@@ -0,0 +1,265 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Config controls startup seeding
+type Config struct {
+	File     string // Fixture file; empty seeds the built-in fixtures
+	Disabled bool   // Skip seeding entirely, e.g. in production
+}
+
+// Fixtures is the content of a fixture file. Organization owners get an
+// owner membership without listing one.
+type Fixtures struct {
+	Users         []UserFixture       `json:"users"`
+	Organizations []OrgFixture        `json:"organizations"`
+	Memberships   []MembershipFixture `json:"memberships"`
+	Accounts      []json.RawMessage   `json:"accounts,omitempty"` // Rejected by Validate; there is no account store yet
+}
+
+// UserFixture describes one seeded user
+type UserFixture struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+// OrgFixture describes one seeded organization
+type OrgFixture struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Industry string `json:"industry"`
+	OwnerID  string `json:"owner_id"`
+}
+
+// MembershipFixture describes one seeded membership
+type MembershipFixture struct {
+	UserID string `json:"user_id"`
+	OrgID  string `json:"org_id"`
+	Role   string `json:"role"`
+}
+
+// Summary counts the records a seed wrote
+type Summary struct {
+	Users         int
+	Organizations int
+	Memberships   int
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// Default returns the built-in fixtures seeded when no file is configured (standalone function)
+func Default() *Fixtures {
+	return &Fixtures{
+		Users: []UserFixture{
+			{ID: "user_1", FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", Role: "admin"},
+			{ID: "user_2", FirstName: "Jane", LastName: "Smith", Email: "jane.smith@example.com", Role: "user"},
+			{ID: "user_3", FirstName: "Bob", LastName: "Wilson", Email: "bob.wilson@example.com", Role: "user"},
+		},
+		Organizations: []OrgFixture{
+			{ID: "org_1", Name: "Acme Corp", Industry: "Technology", OwnerID: "user_1"},
+			{ID: "org_2", Name: "Global Industries", Industry: "Manufacturing", OwnerID: "user_2"},
+		},
+	}
+}
+
+// Load reads fixtures from a .json, .yaml or .yml file. Unknown keys are
+// errors so typos do not silently drop records (standalone function).
+func Load(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+	case ".yaml", ".yml":
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported fixture file type %q", ext)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var fixtures Fixtures
+	if err := decoder.Decode(&fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &fixtures, nil
+}
+
+// Run seeds the configured fixtures into the user and organization stores
+// (standalone function)
+func Run(ctx context.Context, cfg Config, users *services.UserService, orgs *services.OrganizationService) (Summary, error) {
+	if cfg.Disabled {
+		return Summary{}, nil
+	}
+
+	fixtures := Default()
+	if cfg.File != "" {
+		loaded, err := Load(cfg.File)
+		if err != nil {
+			return Summary{}, err
+		}
+		fixtures = loaded
+	}
+	if err := fixtures.Validate(); err != nil {
+		return Summary{}, err
+	}
+	return fixtures.Apply(ctx, users, orgs)
+}
+
+// validRole reports whether a fixture names a known membership role (standalone function)
+func validRole(role string) bool {
+	switch models.MemberRole(role) {
+	case models.MemberRoleOwner, models.MemberRoleAdmin, models.MemberRoleMember, models.MemberRoleGuest:
+		return true
+	}
+	return false
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Validate checks required fields and that every reference points at a
+// fixture in the same file; it reports all problems at once (pointer receiver)
+func (f *Fixtures) Validate() error {
+	var errs []error
+	if len(f.Accounts) > 0 {
+		errs = append(errs, errors.New("accounts: not supported, the service has no account store"))
+	}
+
+	userIDs := make(map[string]bool)
+	emails := make(map[string]bool)
+	for i, u := range f.Users {
+		switch {
+		case u.ID == "":
+			errs = append(errs, fmt.Errorf("users[%d]: id is required", i))
+		case userIDs[u.ID]:
+			errs = append(errs, fmt.Errorf("users[%d]: duplicate id %q", i, u.ID))
+		}
+		userIDs[u.ID] = true
+
+		email := strings.ToLower(u.Email)
+		switch {
+		case !services.ValidateEmail(u.Email):
+			errs = append(errs, fmt.Errorf("users[%d]: invalid email %q", i, u.Email))
+		case emails[email]:
+			errs = append(errs, fmt.Errorf("users[%d]: duplicate email %q", i, u.Email))
+		}
+		emails[email] = true
+	}
+
+	orgIDs := make(map[string]bool)
+	for i, o := range f.Organizations {
+		switch {
+		case o.ID == "":
+			errs = append(errs, fmt.Errorf("organizations[%d]: id is required", i))
+		case orgIDs[o.ID]:
+			errs = append(errs, fmt.Errorf("organizations[%d]: duplicate id %q", i, o.ID))
+		}
+		orgIDs[o.ID] = true
+
+		if o.Name == "" {
+			errs = append(errs, fmt.Errorf("organizations[%d]: name is required", i))
+		}
+		if !userIDs[o.OwnerID] {
+			errs = append(errs, fmt.Errorf("organizations[%d]: owner %q is not a seeded user", i, o.OwnerID))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range f.Memberships {
+		if !userIDs[m.UserID] {
+			errs = append(errs, fmt.Errorf("memberships[%d]: user %q is not a seeded user", i, m.UserID))
+		}
+		if !orgIDs[m.OrgID] {
+			errs = append(errs, fmt.Errorf("memberships[%d]: organization %q is not a seeded organization", i, m.OrgID))
+		}
+		if !validRole(m.Role) {
+			errs = append(errs, fmt.Errorf("memberships[%d]: invalid role %q", i, m.Role))
+		}
+
+		key := m.UserID + "|" + m.OrgID
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("memberships[%d]: duplicate membership of %q in %q", i, m.UserID, m.OrgID))
+		}
+		seen[key] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid fixtures: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Apply writes validated fixtures to the stores (pointer receiver)
+func (f *Fixtures) Apply(ctx context.Context, users *services.UserService, orgs *services.OrganizationService) (Summary, error) {
+	seedUsers := make([]*models.User, 0, len(f.Users))
+	for _, u := range f.Users {
+		user := services.CreateUser(u.ID, u.FirstName, u.LastName, u.Email)
+		if u.Role != "" {
+			user.SetRole(u.Role)
+		}
+		seedUsers = append(seedUsers, user)
+	}
+	if err := errors.Join(users.WriteMany(ctx, seedUsers)...); err != nil {
+		return Summary{}, fmt.Errorf("failed to seed users: %w", err)
+	}
+
+	seedOrgs := make([]*models.Organization, 0, len(f.Organizations))
+	listed := make(map[string]bool)
+	for _, m := range f.Memberships {
+		listed[m.UserID+"|"+m.OrgID] = true
+	}
+	memberships := make([]*models.Membership, 0, len(f.Organizations)+len(f.Memberships))
+	for _, o := range f.Organizations {
+		org := services.CreateOrganization(o.ID, o.Name, o.OwnerID)
+		if o.Industry != "" {
+			org.SetIndustry(o.Industry)
+		}
+		seedOrgs = append(seedOrgs, org)
+
+		// Add owner as member unless the file lists the membership itself
+		if !listed[o.OwnerID+"|"+o.ID] {
+			memberships = append(memberships, services.CreateMembership(o.OwnerID, o.ID, models.MemberRoleOwner))
+		}
+	}
+	if err := errors.Join(orgs.WriteOrgs(ctx, seedOrgs)...); err != nil {
+		return Summary{}, fmt.Errorf("failed to seed organizations: %w", err)
+	}
+
+	for _, m := range f.Memberships {
+		memberships = append(memberships, services.CreateMembership(m.UserID, m.OrgID, models.MemberRole(m.Role)))
+	}
+	if err := errors.Join(orgs.AddMembers(ctx, memberships)...); err != nil {
+		return Summary{}, fmt.Errorf("failed to seed memberships: %w", err)
+	}
+
+	return Summary{
+		Users:         len(seedUsers),
+		Organizations: len(seedOrgs),
+		Memberships:   len(memberships),
+	}, nil
+}
+
@@ -0,0 +1,147 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlToJSON converts the YAML subset fixture files use into JSON: top-level
+// keys holding lists of flat mappings with scalar values, e.g.
+//
+//	users:
+//	  - id: user_1
+//	    email: john.doe@example.com # comments are allowed
+//	memberships: []
+//
+// Anchors, flow mappings, multi-line strings and nesting below list items
+// are not supported (standalone function).
+func yamlToJSON(data []byte) ([]byte, error) {
+	doc := make(map[string][]map[string]string)
+	var (
+		section string
+		item    map[string]string
+		indent  int // Column of keys in the current item
+	)
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := stripYAMLComment(strings.TrimRight(raw, " \t\r"))
+		if strings.TrimSpace(line) == "" || line == "---" {
+			continue
+		}
+		if strings.Contains(line, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed", n+1)
+		}
+		col := len(line) - len(strings.TrimLeft(line, " "))
+		text := line[col:]
+
+		switch {
+		case strings.HasPrefix(text, "- ") || text == "-":
+			if section == "" {
+				return nil, fmt.Errorf("line %d: list item outside a top-level key", n+1)
+			}
+			item = make(map[string]string)
+			doc[section] = append(doc[section], item)
+			rest := strings.TrimLeft(text[1:], " ")
+			indent = col + len(text) - len(rest)
+			if rest == "" {
+				indent = -1 // Set by the item's first key
+				continue
+			}
+			if err := addYAMLPair(item, rest); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+
+		case col == 0:
+			key, value, err := splitYAMLPair(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			if _, exists := doc[key]; exists {
+				return nil, fmt.Errorf("line %d: duplicate key %q", n+1, key)
+			}
+			if value != "" && value != "[]" {
+				return nil, fmt.Errorf("line %d: %q must be a list", n+1, key)
+			}
+			section, item = key, nil
+			doc[section] = []map[string]string{}
+
+		case section == "":
+			return nil, fmt.Errorf("line %d: indented content outside a top-level key", n+1)
+
+		case item != nil && (indent == -1 || col == indent):
+			indent = col
+			if err := addYAMLPair(item, text); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected indentation", n+1)
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// addYAMLPair parses a key: value line into a list item (standalone function)
+func addYAMLPair(item map[string]string, text string) error {
+	key, value, err := splitYAMLPair(text)
+	if err != nil {
+		return err
+	}
+	if _, exists := item[key]; exists {
+		return fmt.Errorf("duplicate key %q", key)
+	}
+	if value, err = unquoteYAML(value); err != nil {
+		return err
+	}
+	item[key] = value
+	return nil
+}
+
+// splitYAMLPair splits a key: value line (standalone function)
+func splitYAMLPair(text string) (string, string, error) {
+	key, value, found := strings.Cut(text, ":")
+	if !found || strings.TrimSpace(key) == "" {
+		return "", "", fmt.Errorf("expected key: value, got %q", text)
+	}
+	if value != "" && value[0] != ' ' {
+		return "", "", fmt.Errorf("expected a space after %q:", key)
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), nil
+}
+
+// unquoteYAML strips single or double quotes from a scalar (standalone function)
+func unquoteYAML(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+	switch {
+	case value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+	return value, nil
+}
+
+// stripYAMLComment drops a # comment that is not inside quotes (standalone function)
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return strings.TrimRight(line[:i], " ")
+		}
+	}
+	return line
+}
+
@@ -0,0 +1,153 @@
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Layer is the part of the stack a span measures
+type Layer string
+
+// Layers recorded by spans. Handler time is whatever a request spends
+// outside service and storage spans.
+const (
+	LayerHandler Layer = "handler"
+	LayerService Layer = "service"
+	LayerStorage Layer = "storage"
+)
+
+// Span is one timed operation within a request
+type Span struct {
+	Layer    Layer         `json:"layer"`
+	Name     string        `json:"name"`
+	Offset   time.Duration `json:"offset"`   // Since the recorder started
+	Duration time.Duration `json:"duration"` // Including nested spans
+	Self     time.Duration `json:"self"`     // Excluding nested spans
+	Depth    int           `json:"depth"`
+}
+
+// Breakdown is a request's time split by layer, counting each span's self time
+type Breakdown struct {
+	Total   time.Duration `json:"total"`
+	Handler time.Duration `json:"handler"`
+	Service time.Duration `json:"service"`
+	Storage time.Duration `json:"storage"`
+}
+
+// frame is an open span
+type frame struct {
+	span     *Span
+	start    time.Time
+	children time.Duration
+}
+
+// Recorder collects the spans of one request. Spans nest in the order they
+// start and stop; it is safe for concurrent use, but spans started in
+// parallel goroutines are attributed to whichever span was open at the time.
+type Recorder struct {
+	start time.Time
+	spans []*Span
+	open  []*frame
+	mu    sync.Mutex
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context carrying a new Recorder (standalone function)
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{start: time.Now()}
+	return context.WithValue(ctx, recorderKey{}, recorder), recorder
+}
+
+// FromContext returns the Recorder of a context, if any (standalone function)
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return recorder
+}
+
+// Track starts a span and returns the function that ends it, for use as
+// defer timing.Track(ctx, timing.LayerService, "users.Read")(). Without a
+// Recorder in ctx it does nothing (standalone function).
+func Track(ctx context.Context, layer Layer, name string) func() {
+	recorder := FromContext(ctx)
+	if recorder == nil {
+		return func() {}
+	}
+	return recorder.begin(layer, name)
+}
+
+// =====================================
+// Pointer Receiver Methods on Recorder
+// =====================================
+
+// Spans returns the finished spans in start order (pointer receiver)
+func (r *Recorder) Spans() []Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]Span, len(r.spans))
+	for i, span := range r.spans {
+		spans[i] = *span
+	}
+	return spans
+}
+
+// Breakdown splits the time since the recorder started by layer (pointer receiver)
+func (r *Recorder) Breakdown() Breakdown {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breakdown := Breakdown{Total: time.Since(r.start)}
+	for _, span := range r.spans {
+		switch span.Layer {
+		case LayerService:
+			breakdown.Service += span.Self
+		case LayerStorage:
+			breakdown.Storage += span.Self
+		default:
+			breakdown.Handler += span.Self
+		}
+		if span.Depth == 0 {
+			breakdown.Handler -= span.Duration
+		}
+	}
+	breakdown.Handler += breakdown.Total
+	return breakdown
+}
+
+// begin opens a span nested in the innermost open one (pointer receiver)
+func (r *Recorder) begin(layer Layer, name string) func() {
+	r.mu.Lock()
+	now := time.Now()
+	f := &frame{
+		span:  &Span{Layer: layer, Name: name, Offset: now.Sub(r.start), Depth: len(r.open)},
+		start: now,
+	}
+	r.spans = append(r.spans, f.span)
+	r.open = append(r.open, f)
+	r.mu.Unlock()
+
+	return func() { r.end(f) }
+}
+
+// end closes a span and charges its duration to the span it was nested in (pointer receiver)
+func (r *Recorder) end(f *frame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f.span.Duration = time.Since(f.start)
+	f.span.Self = f.span.Duration - f.children
+
+	for i := len(r.open) - 1; i >= 0; i-- {
+		if r.open[i] != f {
+			continue
+		}
+		r.open = append(r.open[:i], r.open[i+1:]...)
+		if i > 0 {
+			r.open[i-1].children += f.span.Duration
+		}
+		return
+	}
+}
+
@@ -0,0 +1,324 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/email"
+)
+
+// Tag is the struct tag holding a field's rules, e.g.
+//
+//	Name  string `json:"name" validate:"required,max=100"`
+//	Role  string `json:"role" validate:"oneof=owner admin member"`
+//	Email string `json:"email" validate:"required,email"`
+//
+// Rules other than required only check fields that are set, so optional
+// fields are validated when present. Nested structs and slices of structs
+// are validated too, with field paths like "operations[2].user_id".
+const Tag = "validate"
+
+// Rule codes reported in models.FieldError.Code
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleOneOf    = "oneof"
+	RuleEmail    = "email"
+	RuleTimezone = "timezone"
+)
+
+// ErrInvalidTag is returned for a validate tag naming an unknown rule or
+// giving a rule a parameter or field kind it cannot use
+var ErrInvalidTag = errors.New("invalid validate tag")
+
+// check reports whether a set value satisfies a rule with its parameter,
+// and the message when it does not; it fails with ErrInvalidTag when the
+// rule does not apply to the value's kind
+type check func(value reflect.Value, param string) (bool, string, error)
+
+// rule is one parsed entry of a validate tag
+type rule struct {
+	name  string
+	param string
+}
+
+// checks holds the rules other than required
+var checks = map[string]check{
+	RuleMin:      checkMin,
+	RuleMax:      checkMax,
+	RuleOneOf:    checkOneOf,
+	RuleEmail:    checkEmail,
+	RuleTimezone: checkTimezone,
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// Struct validates a struct, or a pointer to one, against its validate
+// tags and returns the failures in field order; no failures means it is
+// valid. A malformed tag fails with ErrInvalidTag, as it is a programming
+// error rather than bad input; CheckTag finds those before they are
+// deployed (standalone function).
+func Struct(v interface{}) ([]models.FieldError, error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var errs []models.FieldError
+	if err := validateStruct(value, "", &errs); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// CheckTag parses a validate tag and checks that every rule is known and
+// has a usable parameter (standalone function)
+func CheckTag(tag string) error {
+	_, err := parseTag(tag)
+	return err
+}
+
+// validateStruct appends the failures of a struct's fields (standalone function)
+func validateStruct(value reflect.Value, prefix string, errs *[]models.FieldError) error {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if field.Anonymous {
+			if embedded := reflect.Indirect(fieldValue); embedded.Kind() == reflect.Struct {
+				if err := validateStruct(embedded, prefix, errs); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		path := prefix + name
+		failure, failed, err := validateField(fieldValue, field.Tag.Get(Tag))
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", typ, field.Name, err)
+		}
+		if failed {
+			failure.Field = path
+			*errs = append(*errs, failure)
+			continue
+		}
+		if err := validateNested(fieldValue, path, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateField applies a field's rules, stopping at the first failure (standalone function)
+func validateField(value reflect.Value, tag string) (models.FieldError, bool, error) {
+	rules, err := parseTag(tag)
+	if err != nil {
+		return models.FieldError{}, false, err
+	}
+	set := !isBlank(value)
+	for _, r := range rules {
+		if r.name == RuleRequired {
+			if !set {
+				return models.FieldError{Code: RuleRequired, Message: "is required"}, true, nil
+			}
+			continue
+		}
+		if !set {
+			continue
+		}
+		ok, message, err := checks[r.name](reflect.Indirect(value), r.param)
+		if err != nil {
+			return models.FieldError{}, false, err
+		}
+		if !ok {
+			return models.FieldError{Code: r.name, Message: message}, true, nil
+		}
+	}
+	return models.FieldError{}, false, nil
+}
+
+// parseTag splits a validate tag into rules, rejecting unknown rules and
+// bounds that are not numbers (standalone function)
+func parseTag(tag string) ([]rule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	rules := make([]rule, 0, strings.Count(tag, ",")+1)
+	for _, entry := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if _, known := checks[name]; !known && name != RuleRequired {
+			return nil, fmt.Errorf("%w: unknown rule %q", ErrInvalidTag, name)
+		}
+		if name == RuleMin || name == RuleMax {
+			if _, err := parseBound(name, param); err != nil {
+				return nil, err
+			}
+		}
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules, nil
+}
+
+// validateNested descends into struct and slice-of-struct fields (standalone function)
+func validateNested(value reflect.Value, path string, errs *[]models.FieldError) error {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != reflect.TypeOf(time.Time{}) {
+			return validateStruct(value, path+".", errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if item := reflect.Indirect(value.Index(i)); item.Kind() == reflect.Struct {
+				if err := validateStruct(item, fmt.Sprintf("%s[%d].", path, i), errs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldName returns the JSON name of a field, or "" for fields that are
+// not decoded (standalone function)
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// isBlank reports whether a value is unset: zero, nil, empty, or a string
+// of only whitespace (standalone function)
+func isBlank(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+// =====================================
+// Rules
+// =====================================
+
+// checkMin checks a string's length, a collection's size or a number's
+// value against a lower bound (standalone function)
+func checkMin(value reflect.Value, param string) (bool, string, error) {
+	bound, err := parseBound(RuleMin, param)
+	if err != nil {
+		return false, "", err
+	}
+	size, unit, err := measure(value)
+	if err != nil {
+		return false, "", err
+	}
+	if size < bound {
+		return false, fmt.Sprintf("must be at least %s%s", param, unit), nil
+	}
+	return true, "", nil
+}
+
+// checkMax checks a string's length, a collection's size or a number's
+// value against an upper bound (standalone function)
+func checkMax(value reflect.Value, param string) (bool, string, error) {
+	bound, err := parseBound(RuleMax, param)
+	if err != nil {
+		return false, "", err
+	}
+	size, unit, err := measure(value)
+	if err != nil {
+		return false, "", err
+	}
+	if size > bound {
+		return false, fmt.Sprintf("must be at most %s%s", param, unit), nil
+	}
+	return true, "", nil
+}
+
+// checkOneOf checks a string against space-separated allowed values (standalone function)
+func checkOneOf(value reflect.Value, param string) (bool, string, error) {
+	if value.Kind() != reflect.String {
+		return false, "", fmt.Errorf("%w: oneof does not apply to %s", ErrInvalidTag, value.Kind())
+	}
+	allowed := strings.Fields(param)
+	for _, candidate := range allowed {
+		if value.String() == candidate {
+			return true, "", nil
+		}
+	}
+	return false, "must be one of: " + strings.Join(allowed, ", "), nil
+}
+
+// checkEmail checks an email address (standalone function)
+func checkEmail(value reflect.Value, param string) (bool, string, error) {
+	if value.Kind() != reflect.String {
+		return false, "", fmt.Errorf("%w: email does not apply to %s", ErrInvalidTag, value.Kind())
+	}
+	if !email.Valid(value.String()) {
+		return false, "must be a valid email address", nil
+	}
+	return true, "", nil
+}
+
+// checkTimezone checks an IANA time zone name (standalone function)
+func checkTimezone(value reflect.Value, param string) (bool, string, error) {
+	if value.Kind() != reflect.String {
+		return false, "", fmt.Errorf("%w: timezone does not apply to %s", ErrInvalidTag, value.Kind())
+	}
+	if _, err := time.LoadLocation(value.String()); err != nil {
+		return false, "must be an IANA time zone such as Europe/Berlin", nil
+	}
+	return true, "", nil
+}
+
+// measure returns what min and max compare and the unit to report (standalone function)
+func measure(value reflect.Value) (float64, string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters", nil
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), " items", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), "", nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", nil
+	}
+	return 0, "", fmt.Errorf("%w: min and max do not apply to %s", ErrInvalidTag, value.Kind())
+}
+
+// parseBound parses a min or max parameter (standalone function)
+func parseBound(name, param string) (float64, error) {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s parameter %q", ErrInvalidTag, name, param)
+	}
+	return bound, nil
+}
+
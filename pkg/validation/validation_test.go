@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStructRejectsInvalidTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"unknown rule", &struct {
+			Name string `validate:"required,shout"`
+		}{Name: "x"}},
+		{"bound not a number", &struct {
+			Name string `validate:"max=ten"`
+		}{Name: "x"}},
+		{"min on a bool", &struct {
+			Admin bool `validate:"min=1"`
+		}{Admin: true}},
+		{"email on an int", &struct {
+			Count int `validate:"email"`
+		}{Count: 3}},
+		{"nested", &struct {
+			Items []struct {
+				Role string `validate:"oneof"`
+				Kind string `validate:"kind=a"`
+			}
+		}{Items: []struct {
+			Role string `validate:"oneof"`
+			Kind string `validate:"kind=a"`
+		}{{Role: "a"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Struct(tt.input); !errors.Is(err, ErrInvalidTag) {
+				t.Errorf("Struct() error = %v, want %v", err, ErrInvalidTag)
+			}
+		})
+	}
+}
+
+func TestCheckTag(t *testing.T) {
+	tests := []struct {
+		tag   string
+		valid bool
+	}{
+		{"", true},
+		{"required,max=100", true},
+		{"oneof=owner admin member", true},
+		{"required,email", true},
+		{"min=1.5", true},
+		{"required,maxx=3", false},
+		{"min=", false},
+		{"timezone,unique", false},
+	}
+
+	for _, tt := range tests {
+		if err := CheckTag(tt.tag); (err == nil) != tt.valid {
+			t.Errorf("CheckTag(%q) = %v, want valid=%v", tt.tag, err, tt.valid)
+		}
+	}
+}
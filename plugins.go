@@ -0,0 +1,8 @@
+package main
+
+// Plugins are compiled in by blank-importing their packages here. Each
+// plugin registers itself with plugin.Register from an init function, so
+// forks can add extensions by touching only this file:
+//
+//	import _ "example.com/fork/billing"
+
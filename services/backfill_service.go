@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// Default backfill settings used when a BackfillConfig field is zero
+const (
+	DefaultBackfillBatchSize     = 100
+	DefaultBackfillBatchInterval = 100 * time.Millisecond
+)
+
+// ErrBackfillState is returned when a backfill cannot make the requested transition
+var ErrBackfillState = errors.New("invalid backfill state")
+
+// Backfill is a long-running data migration processed in batches. Each
+// batch resumes after the cursor returned by the previous one (empty on
+// the first call), so a run can be paused and resumed at any batch boundary.
+type Backfill interface {
+	Name() string
+	Total(ctx context.Context) (int, error)
+	Batch(ctx context.Context, cursor string, limit int) (BackfillBatch, error)
+}
+
+// BackfillBatch is the outcome of processing one batch
+type BackfillBatch struct {
+	Cursor    string // checkpoint to resume after
+	Processed int
+	Failed    int
+	Done      bool
+}
+
+// BackfillConfig controls batch size and pacing. BatchInterval is the pause
+// between batches, which rate-limits the load a backfill puts on live traffic.
+type BackfillConfig struct {
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+// BackfillState is the lifecycle state of a backfill run
+type BackfillState string
+
+// Backfill state constants
+const (
+	BackfillIdle      BackfillState = "idle"
+	BackfillRunning   BackfillState = "running"
+	BackfillPaused    BackfillState = "paused"
+	BackfillCompleted BackfillState = "completed"
+	BackfillFailed    BackfillState = "failed"
+)
+
+// BackfillProgress reports how far a backfill has got. Checkpoint is the
+// cursor the next batch starts after.
+type BackfillProgress struct {
+	Name           string        `json:"name"`
+	State          BackfillState `json:"state"`
+	Checkpoint     string        `json:"checkpoint,omitempty"`
+	Total          int           `json:"total"`
+	Processed      int           `json:"processed"`
+	Failed         int           `json:"failed"`
+	Batches        int           `json:"batches"`
+	PercentDone    float64       `json:"percent_done"`
+	ItemsPerSecond float64       `json:"items_per_second"`
+	LastError      string        `json:"last_error,omitempty"`
+	StartedAt      *time.Time    `json:"started_at,omitempty"`
+	FinishedAt     *time.Time    `json:"finished_at,omitempty"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	running        time.Duration // time spent in earlier runs, excluding pauses
+	resumedAt      time.Time     // start of the current run
+}
+
+// backfillRun tracks the goroutine processing a backfill
+type backfillRun struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// BackfillOrchestrator runs registered backfills in the background, one
+// batch at a time, with pause/resume from the last checkpoint. It
+// implements interfaces.Shutdowner and pauses running backfills on shutdown.
+type BackfillOrchestrator struct {
+	backfills map[string]Backfill
+	progress  map[string]*BackfillProgress
+	runs      map[string]*backfillRun
+	config    BackfillConfig
+	logger    *log.Logger
+	mu        sync.Mutex
+}
+
+// NewBackfillOrchestrator creates a new BackfillOrchestrator instance
+func NewBackfillOrchestrator(config BackfillConfig, logger *log.Logger) *BackfillOrchestrator {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBackfillBatchSize
+	}
+	if config.BatchInterval <= 0 {
+		config.BatchInterval = DefaultBackfillBatchInterval
+	}
+	return &BackfillOrchestrator{
+		backfills: make(map[string]Backfill),
+		progress:  make(map[string]*BackfillProgress),
+		runs:      make(map[string]*backfillRun),
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Register adds a backfill; it panics on a duplicate name (pointer receiver)
+func (o *BackfillOrchestrator) Register(backfill Backfill) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	name := backfill.Name()
+	if _, exists := o.backfills[name]; exists {
+		panic(fmt.Sprintf("backfill %q registered twice", name))
+	}
+	o.backfills[name] = backfill
+	o.progress[name] = &BackfillProgress{Name: name, State: BackfillIdle, UpdatedAt: models.Now()}
+}
+
+// Start runs a backfill from the beginning. A paused backfill must be
+// resumed or restarted explicitly with restart (pointer receiver).
+func (o *BackfillOrchestrator) Start(ctx context.Context, name string, restart bool) (*BackfillProgress, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	backfill, progress, err := o.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	switch progress.State {
+	case BackfillRunning:
+		return nil, fmt.Errorf("%w: %s is already running", ErrBackfillState, name)
+	case BackfillPaused:
+		if !restart {
+			return nil, fmt.Errorf("%w: %s is paused; resume it or restart it", ErrBackfillState, name)
+		}
+	}
+
+	total, err := backfill.Total(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := models.Now()
+	*progress = BackfillProgress{
+		Name:      name,
+		State:     BackfillRunning,
+		Total:     total,
+		StartedAt: &now,
+		UpdatedAt: now,
+		resumedAt: now,
+	}
+	o.launch(backfill, "")
+	return o.snapshot(progress), nil
+}
+
+// Pause stops a running backfill after its current batch; the checkpoint
+// is kept for Resume (pointer receiver)
+func (o *BackfillOrchestrator) Pause(ctx context.Context, name string) (*BackfillProgress, error) {
+	o.mu.Lock()
+	_, progress, err := o.lookup(name)
+	if err != nil {
+		o.mu.Unlock()
+		return nil, err
+	}
+	run := o.runs[name]
+	if progress.State != BackfillRunning || run == nil {
+		o.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s is not running", ErrBackfillState, name)
+	}
+	delete(o.runs, name) // a second Pause must not close stop again
+	o.mu.Unlock()
+
+	close(run.stop)
+	select {
+	case <-run.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.snapshot(progress), nil
+}
+
+// Resume continues a paused or failed backfill from its checkpoint (pointer receiver)
+func (o *BackfillOrchestrator) Resume(ctx context.Context, name string) (*BackfillProgress, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	backfill, progress, err := o.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if progress.State != BackfillPaused && progress.State != BackfillFailed {
+		return nil, fmt.Errorf("%w: %s is %s", ErrBackfillState, name, progress.State)
+	}
+
+	progress.State = BackfillRunning
+	progress.LastError = ""
+	progress.UpdatedAt = models.Now()
+	progress.resumedAt = progress.UpdatedAt
+	o.launch(backfill, progress.Checkpoint)
+	return o.snapshot(progress), nil
+}
+
+// Progress returns a snapshot of one backfill's progress (pointer receiver)
+func (o *BackfillOrchestrator) Progress(name string) (*BackfillProgress, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, progress, err := o.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return o.snapshot(progress), nil
+}
+
+// List returns the progress of every registered backfill, sorted by name (pointer receiver)
+func (o *BackfillOrchestrator) List() []*BackfillProgress {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	list := make([]*BackfillProgress, 0, len(o.progress))
+	for _, progress := range o.progress {
+		list = append(list, o.snapshot(progress))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Shutdown pauses every running backfill so it can be resumed later (pointer receiver)
+func (o *BackfillOrchestrator) Shutdown(ctx context.Context) error {
+	o.mu.Lock()
+	names := make([]string, 0, len(o.runs))
+	for name := range o.runs {
+		names = append(names, name)
+	}
+	o.mu.Unlock()
+
+	var errs []error
+	for _, name := range names {
+		if _, err := o.Pause(ctx, name); err != nil && !errors.Is(err, ErrBackfillState) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookup finds a backfill and its progress; callers hold o.mu (pointer receiver)
+func (o *BackfillOrchestrator) lookup(name string) (Backfill, *BackfillProgress, error) {
+	backfill, exists := o.backfills[name]
+	if !exists {
+		return nil, nil, errors.New("backfill not found")
+	}
+	return backfill, o.progress[name], nil
+}
+
+// launch starts the batch loop from a cursor; callers hold o.mu (pointer receiver)
+func (o *BackfillOrchestrator) launch(backfill Backfill, cursor string) {
+	run := &backfillRun{stop: make(chan struct{}), done: make(chan struct{})}
+	o.runs[backfill.Name()] = run
+	go o.run(backfill, cursor, run)
+}
+
+// run processes batches until the backfill is done, fails, or is paused (pointer receiver)
+func (o *BackfillOrchestrator) run(backfill Backfill, cursor string, run *backfillRun) {
+	name := backfill.Name()
+	defer close(run.done)
+	defer func() {
+		o.mu.Lock()
+		if o.runs[name] == run {
+			delete(o.runs, name)
+		}
+		o.mu.Unlock()
+	}()
+
+	ctx := context.Background()
+	pause := time.NewTimer(0)
+	defer pause.Stop()
+
+	for {
+		select {
+		case <-run.stop:
+			o.finish(name, BackfillPaused, nil)
+			return
+		case <-pause.C:
+		}
+
+		batch, err := backfill.Batch(ctx, cursor, o.config.BatchSize)
+		if err != nil {
+			o.finish(name, BackfillFailed, err)
+			return
+		}
+		cursor = batch.Cursor
+		o.record(name, batch)
+
+		if batch.Done {
+			o.finish(name, BackfillCompleted, nil)
+			return
+		}
+		pause.Reset(o.config.BatchInterval)
+	}
+}
+
+// record folds a batch into the progress and moves the checkpoint (pointer receiver)
+func (o *BackfillOrchestrator) record(name string, batch BackfillBatch) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	progress := o.progress[name]
+	progress.Checkpoint = batch.Cursor
+	progress.Processed += batch.Processed
+	progress.Failed += batch.Failed
+	progress.Batches++
+	progress.UpdatedAt = models.Now()
+}
+
+// finish records the end of a run (pointer receiver)
+func (o *BackfillOrchestrator) finish(name string, state BackfillState, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	progress := o.progress[name]
+	progress.State = state
+	progress.UpdatedAt = models.Now()
+	progress.running += progress.UpdatedAt.Sub(progress.resumedAt)
+	if err != nil {
+		progress.LastError = err.Error()
+	}
+	if state == BackfillCompleted {
+		now := progress.UpdatedAt
+		progress.FinishedAt = &now
+	}
+
+	o.logger.Printf("event=backfill.%s name=%s processed=%d failed=%d checkpoint=%q",
+		state, name, progress.Processed, progress.Failed, progress.Checkpoint)
+}
+
+// snapshot copies progress and derives rate metrics; callers hold o.mu (pointer receiver)
+func (o *BackfillOrchestrator) snapshot(progress *BackfillProgress) *BackfillProgress {
+	copied := *progress
+	if copied.Total > 0 {
+		copied.PercentDone = float64(copied.Processed+copied.Failed) * 100 / float64(copied.Total)
+		if copied.PercentDone > 100 {
+			copied.PercentDone = 100
+		}
+	}
+	elapsed := copied.running
+	if copied.State == BackfillRunning {
+		elapsed += models.Now().Sub(copied.resumedAt)
+	}
+	if elapsed > 0 {
+		copied.ItemsPerSecond = float64(copied.Processed+copied.Failed) / elapsed.Seconds()
+	}
+	return &copied
+}
+
@@ -0,0 +1,22 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/pkg/conformance"
+	"github.com/test-repo-golang-support/services"
+)
+
+func TestUserServiceConformance(t *testing.T) {
+	conformance.RunRepositorySuite(t, func() interfaces.Repository {
+		return services.NewUserService()
+	})
+}
+
+func TestOrganizationServiceConformance(t *testing.T) {
+	conformance.RunOrgServiceSuite(t, func() interfaces.OrgService {
+		return services.NewOrganizationService()
+	})
+}
+
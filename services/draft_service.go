@@ -0,0 +1,425 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// ErrDraftNotFound is returned for an unknown draft
+var ErrDraftNotFound = errors.New("draft not found")
+
+// ErrDraftInvalid is returned when a draft's fields fail validation at publish time
+var ErrDraftInvalid = errors.New("draft is invalid")
+
+// ErrDraftConflict is returned when publishing would duplicate an existing record
+var ErrDraftConflict = errors.New("draft conflicts with an existing record")
+
+// userDraftFields are the fields of a user draft, as accepted by POST /users
+type userDraftFields struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Locale    string `json:"locale"`
+	Timezone  string `json:"timezone"`
+}
+
+// orgDraftFields are the fields of an organization draft, as accepted by POST /organizations
+type orgDraftFields struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Industry    string             `json:"industry"`
+	OwnerID     string             `json:"owner_id"`
+	Size        models.OrgSize     `json:"size"`
+	Slug        string             `json:"slug"`
+	Address     models.Address     `json:"address"`
+	Contact     models.ContactInfo `json:"contact"`
+}
+
+// projectDraftFields are the fields of a project draft, as accepted by POST /projects
+type projectDraftFields struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	OwnerID     string               `json:"owner_id"`
+	OrgID       string               `json:"org_id"`
+	Status      models.ProjectStatus `json:"status"`
+}
+
+// DraftService holds drafts of users, organizations, and projects until
+// they are published. Drafts are checked only for shape while edited;
+// publishing runs the full validation and uniqueness checks and creates
+// the record.
+type DraftService struct {
+	idSource // Hands out IDs for created entities
+	drafts   map[string]*models.Draft
+	users    *UserService
+	orgs     *OrganizationService
+	projects *ProjectService
+	mu       sync.RWMutex
+}
+
+// NewDraftService creates a new DraftService instance
+func NewDraftService(users *UserService, orgs *OrganizationService, projects *ProjectService) *DraftService {
+	return &DraftService{
+		drafts:   make(map[string]*models.Draft),
+		users:    users,
+		orgs:     orgs,
+		projects: projects,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// CreateDraft stores a new draft; fields may be empty or partial (pointer receiver)
+func (s *DraftService) CreateDraft(ctx context.Context, kind models.DraftKind, createdBy string, fields json.RawMessage) (*models.Draft, error) {
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("unknown draft kind %q", kind)
+	}
+	fields, err := checkDraftFields(kind, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := models.NewDraft(s.NewID(IDPrefixDraft), kind, createdBy, fields)
+	draft.IncrementVersion()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drafts[draft.ID] = draft
+	return copyDraft(draft), nil
+}
+
+// ReadDraft retrieves a draft (pointer receiver)
+func (s *DraftService) ReadDraft(ctx context.Context, id string) (*models.Draft, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	draft, exists := s.drafts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrDraftNotFound, id)
+	}
+	return copyDraft(draft), nil
+}
+
+// ListDrafts lists drafts, oldest first, optionally filtered by kind and
+// creator (pointer receiver)
+func (s *DraftService) ListDrafts(ctx context.Context, kind models.DraftKind, createdBy string) ([]models.Draft, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	drafts := make([]models.Draft, 0)
+	for _, draft := range s.drafts {
+		if (kind != "" && draft.Kind != kind) || (createdBy != "" && draft.CreatedBy != createdBy) {
+			continue
+		}
+		drafts = append(drafts, *copyDraft(draft))
+	}
+
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].CreatedAt.Before(drafts[j].CreatedAt)
+	})
+	return drafts, nil
+}
+
+// UpdateDraft replaces a draft's fields, or with merge set overlays the
+// given top-level fields onto the existing ones, a null removing a field.
+// A non-zero version must be the stored one or the update fails with
+// ErrVersionConflict (pointer receiver).
+func (s *DraftService) UpdateDraft(ctx context.Context, id string, fields json.RawMessage, merge bool, version int64) (*models.Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.drafts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrDraftNotFound, id)
+	}
+	if version != 0 && existing.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	if merge {
+		merged, err := mergeDraftFields(existing.Fields, fields)
+		if err != nil {
+			return nil, err
+		}
+		fields = merged
+	}
+	fields, err := checkDraftFields(existing.Kind, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := copyDraft(existing)
+	draft.Fields = fields
+	draft.Touch()
+	draft.IncrementVersion()
+	s.drafts[id] = draft
+	return copyDraft(draft), nil
+}
+
+// DeleteDraft discards a draft (pointer receiver)
+func (s *DraftService) DeleteDraft(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.drafts[id]; !exists {
+		return fmt.Errorf("%w: %s", ErrDraftNotFound, id)
+	}
+	delete(s.drafts, id)
+	return nil
+}
+
+// PublishDraft validates a draft as its create endpoint would, checks it
+// does not duplicate an existing record, creates the record, and removes
+// the draft. Publishes are serialized, so two drafts cannot both claim the
+// same email, name, or slug; on any failure the draft is left unchanged.
+// A non-zero version must be the stored one, so an edit made since the
+// caller read the draft fails the publish with ErrVersionConflict
+// (pointer receiver).
+func (s *DraftService) PublishDraft(ctx context.Context, id string, version int64) (*models.DraftPublication, error) {
+	defer timing.Track(ctx, timing.LayerService, "drafts.PublishDraft")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft, exists := s.drafts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrDraftNotFound, id)
+	}
+	if version != 0 && draft.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	var resourceID string
+	var resource interface{}
+	var err error
+	switch draft.Kind {
+	case models.DraftKindUser:
+		resourceID, resource, err = s.publishUser(ctx, draft)
+	case models.DraftKindOrganization:
+		resourceID, resource, err = s.publishOrg(ctx, draft)
+	case models.DraftKindProject:
+		resourceID, resource, err = s.publishProject(ctx, draft)
+	default:
+		err = fmt.Errorf("%w: unknown kind %q", ErrDraftInvalid, draft.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.drafts, id)
+	return &models.DraftPublication{
+		DraftID:     id,
+		Kind:        draft.Kind,
+		ResourceID:  resourceID,
+		Resource:    resource,
+		PublishedAt: models.Now(),
+	}, nil
+}
+
+// publishUser creates the user a draft describes; callers hold s.mu (pointer receiver)
+func (s *DraftService) publishUser(ctx context.Context, draft *models.Draft) (string, interface{}, error) {
+	var fields userDraftFields
+	if err := decodeDraftFields(draft.Fields, &fields); err != nil {
+		return "", nil, err
+	}
+	if !ValidateEmail(fields.Email) {
+		return "", nil, fmt.Errorf("%w: invalid email format", ErrDraftInvalid)
+	}
+
+	user := CreateUser(s.NewID(IDPrefixUser), fields.FirstName, fields.LastName, fields.Email)
+	user.SetRole(fields.Role)
+	if fields.Locale != "" {
+		user.SetLocale(i18n.Normalize(fields.Locale))
+	}
+	if fields.Timezone != "" {
+		user.SetTimezone(fields.Timezone)
+	}
+	if err := user.Validate(); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrDraftInvalid, err)
+	}
+	if _, err := s.users.FindByEmail(ctx, user.Email); err == nil {
+		return "", nil, fmt.Errorf("%w: email %s is already registered", ErrDraftConflict, user.Email)
+	}
+
+	if err := s.users.Write(ctx, user); err != nil {
+		if errors.Is(err, ErrEmailTaken) {
+			return "", nil, fmt.Errorf("%w: %v", ErrDraftConflict, err)
+		}
+		return "", nil, err
+	}
+	return user.ID, user, nil
+}
+
+// publishOrg creates the organization a draft describes and makes its
+// owner a member; callers hold s.mu (pointer receiver)
+func (s *DraftService) publishOrg(ctx context.Context, draft *models.Draft) (string, interface{}, error) {
+	var fields orgDraftFields
+	if err := decodeDraftFields(draft.Fields, &fields); err != nil {
+		return "", nil, err
+	}
+
+	if fields.Name == "" {
+		return "", nil, fmt.Errorf("%w: organization name is required", ErrDraftInvalid)
+	}
+	if fields.OwnerID == "" {
+		return "", nil, fmt.Errorf("%w: owner ID is required", ErrDraftInvalid)
+	}
+
+	org := CreateOrganization(s.NewID(IDPrefixOrg), fields.Name, fields.OwnerID)
+	org.UpdateDescription(fields.Description)
+	org.SetIndustry(fields.Industry)
+	if fields.Size != "" {
+		org.SetSize(fields.Size)
+	}
+	if fields.Slug != "" {
+		org.SetSlug(fields.Slug)
+	}
+	if fields.Address.City != "" {
+		org.UpdateAddress(fields.Address)
+	}
+	if fields.Contact.Email != "" {
+		org.UpdateContact(fields.Contact)
+	}
+	if err := org.Validate(); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrDraftInvalid, err)
+	}
+	if exists, _ := s.users.Exists(ctx, org.OwnerID); !exists {
+		return "", nil, fmt.Errorf("%w: owner %s not found", ErrDraftInvalid, org.OwnerID)
+	}
+	if _, err := s.orgs.FindOrgByName(ctx, org.Name); err == nil {
+		return "", nil, fmt.Errorf("%w: organization name %q is taken", ErrDraftConflict, org.Name)
+	}
+
+	if err := s.orgs.WriteOrg(ctx, org); err != nil {
+		switch {
+		case errors.Is(err, ErrSlugTaken):
+			return "", nil, fmt.Errorf("%w: %v", ErrDraftConflict, err)
+		case errors.Is(err, ErrInvalidEnumValue):
+			return "", nil, fmt.Errorf("%w: %v", ErrDraftInvalid, err)
+		}
+		return "", nil, err
+	}
+
+	membership := CreateMembership(org.OwnerID, org.ID, models.MemberRoleOwner)
+	if err := s.orgs.AddMember(ctx, membership); err != nil {
+		return "", nil, fmt.Errorf("failed to create owner membership: %w", err)
+	}
+	return org.ID, org, nil
+}
+
+// publishProject creates the project a draft describes; callers hold s.mu (pointer receiver)
+func (s *DraftService) publishProject(ctx context.Context, draft *models.Draft) (string, interface{}, error) {
+	var fields projectDraftFields
+	if err := decodeDraftFields(draft.Fields, &fields); err != nil {
+		return "", nil, err
+	}
+	if fields.Name == "" {
+		return "", nil, fmt.Errorf("%w: project name is required", ErrDraftInvalid)
+	}
+	if fields.OwnerID == "" || fields.OrgID == "" {
+		return "", nil, fmt.Errorf("%w: owner ID and organization ID are required", ErrDraftInvalid)
+	}
+	if fields.Status != "" && !fields.Status.IsValid() {
+		return "", nil, fmt.Errorf("%w: invalid project status", ErrDraftInvalid)
+	}
+	if exists, _ := s.orgs.OrgExists(ctx, fields.OrgID); !exists {
+		return "", nil, fmt.Errorf("%w: organization %s not found", ErrDraftInvalid, fields.OrgID)
+	}
+	existing, err := s.projects.ReadProjectsByOrg(ctx, fields.OrgID)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other.Name, fields.Name) {
+			return "", nil, fmt.Errorf("%w: project name %q is taken in %s", ErrDraftConflict, fields.Name, fields.OrgID)
+		}
+	}
+
+	project := CreateProject(s.NewID(IDPrefixProject), fields.Name, fields.OwnerID, fields.OrgID)
+	project.UpdateDescription(fields.Description)
+	if fields.Status != "" {
+		project.SetStatus(fields.Status)
+	}
+	if err := s.projects.WriteProject(ctx, project); err != nil {
+		return "", nil, err
+	}
+	return project.ID, project, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// checkDraftFields checks that fields are a JSON object whose known fields
+// have the right types for the kind, and normalizes empty fields to {}
+// (standalone function)
+func checkDraftFields(kind models.DraftKind, fields json.RawMessage) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(fields)) == 0 || bytes.Equal(bytes.TrimSpace(fields), []byte("null")) {
+		return json.RawMessage("{}"), nil
+	}
+
+	var target interface{}
+	switch kind {
+	case models.DraftKindUser:
+		target = &userDraftFields{}
+	case models.DraftKindOrganization:
+		target = &orgDraftFields{}
+	case models.DraftKindProject:
+		target = &projectDraftFields{}
+	}
+	if err := json.Unmarshal(fields, target); err != nil {
+		return nil, fmt.Errorf("fields do not match a %s: %v", kind, err)
+	}
+	return append(json.RawMessage(nil), fields...), nil
+}
+
+// mergeDraftFields overlays top-level fields onto a draft's fields; a null
+// value removes the field (standalone function)
+func mergeDraftFields(current, patch json.RawMessage) (json.RawMessage, error) {
+	merged := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(current, &merged); err != nil {
+		return nil, err
+	}
+
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, errors.New("fields must be a JSON object")
+	}
+	for name, value := range changes {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			delete(merged, name)
+			continue
+		}
+		merged[name] = value
+	}
+	return json.Marshal(merged)
+}
+
+// decodeDraftFields decodes a draft's fields for publishing (standalone function)
+func decodeDraftFields(fields json.RawMessage, target interface{}) error {
+	if err := json.Unmarshal(fields, target); err != nil {
+		return fmt.Errorf("%w: %v", ErrDraftInvalid, err)
+	}
+	return nil
+}
+
+// copyDraft copies a draft so callers cannot modify the stored one (standalone function)
+func copyDraft(draft *models.Draft) *models.Draft {
+	c := *draft
+	c.Fields = append(json.RawMessage(nil), draft.Fields...)
+	return &c
+}
+
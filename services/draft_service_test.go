@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// newTestDrafts returns a DraftService over fresh stores and a user draft
+// for ada@example.com
+func newTestDrafts(t *testing.T) (*DraftService, *UserService, *models.Draft) {
+	t.Helper()
+	users := NewUserService()
+	orgs := NewOrganizationService()
+	drafts := NewDraftService(users, orgs, NewProjectService(orgs))
+
+	draft, err := drafts.CreateDraft(context.Background(), models.DraftKindUser, "user_admin",
+		json.RawMessage(`{"first_name":"Ada","last_name":"Lovelace","email":"ada@example.com"}`))
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	return drafts, users, draft
+}
+
+// usersWithEmail counts the stored users with an email address
+func usersWithEmail(t *testing.T, users *UserService, email string) int {
+	t.Helper()
+	all, err := users.ReadAll(context.Background())
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	count := 0
+	for _, user := range all {
+		if user.Email == email {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDraftVersionChecks(t *testing.T) {
+	rename := json.RawMessage(`{"first_name":"Augusta"}`)
+	tests := []struct {
+		name    string
+		run     func(s *DraftService, draft *models.Draft) error
+		wantErr error
+	}{
+		{"update without a version", func(s *DraftService, draft *models.Draft) error {
+			_, err := s.UpdateDraft(context.Background(), draft.ID, rename, true, 0)
+			return err
+		}, nil},
+		{"update at the current version", func(s *DraftService, draft *models.Draft) error {
+			_, err := s.UpdateDraft(context.Background(), draft.ID, rename, true, draft.Version)
+			return err
+		}, nil},
+		{"update at a stale version", func(s *DraftService, draft *models.Draft) error {
+			if _, err := s.UpdateDraft(context.Background(), draft.ID, rename, true, draft.Version); err != nil {
+				return err
+			}
+			_, err := s.UpdateDraft(context.Background(), draft.ID, json.RawMessage(`{"last_name":"King"}`), true, draft.Version)
+			return err
+		}, ErrVersionConflict},
+		{"publish at the current version", func(s *DraftService, draft *models.Draft) error {
+			_, err := s.PublishDraft(context.Background(), draft.ID, draft.Version)
+			return err
+		}, nil},
+		{"publish at a stale version", func(s *DraftService, draft *models.Draft) error {
+			if _, err := s.UpdateDraft(context.Background(), draft.ID, rename, true, 0); err != nil {
+				return err
+			}
+			_, err := s.PublishDraft(context.Background(), draft.ID, draft.Version)
+			return err
+		}, ErrVersionConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, users, draft := newTestDrafts(t)
+			err := tt.run(s, draft)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+
+			// A refused write leaves the draft in place and publishes nothing
+			if _, err := s.ReadDraft(context.Background(), draft.ID); err != nil {
+				t.Errorf("draft gone after a refused write: %v", err)
+			}
+			if n := usersWithEmail(t, users, "ada@example.com"); n != 0 {
+				t.Errorf("%d users published by a refused write", n)
+			}
+		})
+	}
+}
+
+func TestPublishDraftRacesEdit(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		ctx := context.Background()
+		s, users, draft := newTestDrafts(t)
+
+		var wg sync.WaitGroup
+		var publishErr, updateErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, publishErr = s.PublishDraft(ctx, draft.ID, draft.Version)
+		}()
+		go func() {
+			defer wg.Done()
+			_, updateErr = s.UpdateDraft(ctx, draft.ID, json.RawMessage(`{"email":"augusta@example.com"}`), true, draft.Version)
+		}()
+		wg.Wait()
+
+		switch {
+		case publishErr == nil && errors.Is(updateErr, ErrDraftNotFound):
+			// Published first; the edit found no draft to change
+			if n := usersWithEmail(t, users, "ada@example.com"); n != 1 {
+				t.Fatalf("published users with the draft's email = %d, want 1", n)
+			}
+		case updateErr == nil && errors.Is(publishErr, ErrVersionConflict):
+			// Edited first; the publish of the version read before it is refused
+			current, err := s.ReadDraft(ctx, draft.ID)
+			if err != nil {
+				t.Fatalf("ReadDraft: %v", err)
+			}
+			if current.Version == draft.Version {
+				t.Fatalf("edited draft kept version %d", current.Version)
+			}
+			if n := usersWithEmail(t, users, "ada@example.com"); n != 0 {
+				t.Fatalf("stale version published %d users", n)
+			}
+		default:
+			t.Fatalf("publish err = %v, update err = %v; want exactly one to win", publishErr, updateErr)
+		}
+	}
+}
+
+func TestPublishDraftRacesDirectCreate(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		ctx := context.Background()
+		s, users, draft := newTestDrafts(t)
+
+		var wg sync.WaitGroup
+		var publishErr, writeErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, publishErr = s.PublishDraft(ctx, draft.ID, 0)
+		}()
+		go func() {
+			defer wg.Done()
+			writeErr = users.Write(ctx, CreateUser("user_direct", "Ada", "Direct", "ADA@example.com"))
+		}()
+		wg.Wait()
+
+		if (publishErr == nil) == (writeErr == nil) {
+			t.Fatalf("publish err = %v, write err = %v; want exactly one to win", publishErr, writeErr)
+		}
+		if publishErr != nil && !errors.Is(publishErr, ErrDraftConflict) {
+			t.Fatalf("losing publish: err = %v, want ErrDraftConflict", publishErr)
+		}
+		if writeErr != nil && !errors.Is(writeErr, ErrEmailTaken) {
+			t.Fatalf("losing write: err = %v, want ErrEmailTaken", writeErr)
+		}
+		if n := usersWithEmail(t, users, "ada@example.com"); n != 1 {
+			t.Fatalf("users with the draft's email = %d, want 1", n)
+		}
+		if _, err := s.ReadDraft(ctx, draft.ID); (err == nil) != (publishErr != nil) {
+			t.Fatalf("draft kept = %v after publish err %v", err == nil, publishErr)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/test-repo-golang-support/pkg/email"
+)
+
+// ErrInvalidEmail is returned for an address that is not a valid mailbox
+var ErrInvalidEmail = email.ErrInvalid
+
+// ErrEmailUndeliverable is returned when the domain of an address does not accept mail
+var ErrEmailUndeliverable = errors.New("email domain does not accept mail")
+
+// DefaultMXLookupTimeout bounds MX lookups when EmailConfig.Timeout is unset
+const DefaultMXLookupTimeout = 3 * time.Second
+
+// EmailConfig controls how strictly addresses are checked. Syntax is
+// always checked; the MX lookup needs network access and is off by default.
+type EmailConfig struct {
+	CheckMX bool          // Require the domain to accept mail before storing an address
+	Timeout time.Duration // Bound on each lookup; 0 uses DefaultMXLookupTimeout
+}
+
+// EmailVerifier validates addresses and, when configured, checks that
+// their domain accepts mail
+type EmailVerifier struct {
+	config   EmailConfig
+	lookupMX func(ctx context.Context, host string) ([]*net.MX, error)
+	lookupIP func(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// NewEmailVerifier creates a new EmailVerifier instance
+func NewEmailVerifier(config EmailConfig) *EmailVerifier {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultMXLookupTimeout
+	}
+	return &EmailVerifier{
+		config:   config,
+		lookupMX: net.DefaultResolver.LookupMX,
+		lookupIP: net.DefaultResolver.LookupIP,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Verify returns the normalized form of an address after checking its
+// syntax and, with CheckMX, its domain. A domain accepts mail when it
+// publishes MX records other than a null MX, or has none but resolves to
+// an address (RFC 5321 implicit MX). Lookups that fail for reasons other
+// than the domain not existing, such as timeouts, let the address
+// through, so a DNS outage does not block sign-ups (pointer receiver).
+func (v *EmailVerifier) Verify(ctx context.Context, email string) (string, error) {
+	normalized, err := ParseEmail(email)
+	if err != nil || !v.config.CheckMX {
+		return normalized, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.config.Timeout)
+	defer cancel()
+
+	domain := normalized[strings.LastIndex(normalized, "@")+1:]
+	records, err := v.lookupMX(ctx, domain)
+	switch {
+	case err == nil && len(records) == 1 && records[0].Host == ".":
+		return "", fmt.Errorf("%w: %s publishes a null MX record", ErrEmailUndeliverable, domain)
+	case err == nil && len(records) > 0:
+		return normalized, nil
+	case err != nil && !isNotFound(err):
+		return normalized, nil
+	}
+
+	if _, err := v.lookupIP(ctx, "ip", domain); err != nil && isNotFound(err) {
+		return "", fmt.Errorf("%w: %s has no mail servers", ErrEmailUndeliverable, domain)
+	}
+	return normalized, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// NormalizeEmail returns the form addresses are stored and compared in:
+// trimmed and lowercased (standalone function)
+func NormalizeEmail(address string) string {
+	return email.Normalize(address)
+}
+
+// ParseEmail validates a bare mailbox and returns it normalized; see
+// email.Parse (standalone function)
+func ParseEmail(address string) (string, error) {
+	return email.Parse(address)
+}
+
+// ValidateEmail validates an email address; see email.Parse (standalone function)
+func ValidateEmail(address string) bool {
+	return email.Valid(address)
+}
+
+// isNotFound reports whether a lookup failed because the name does not exist (standalone function)
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
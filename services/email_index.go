@@ -0,0 +1,57 @@
+package services
+
+// emailIndex maps the normalized email of every live user to their ID so
+// lookups and uniqueness checks do not scan the store. Soft-deleted users
+// are left out, freeing their address until they are restored. It has no
+// lock of its own; callers hold the owning store's lock.
+type emailIndex struct {
+	ids    map[string]string // Email -> user ID
+	emails map[string]string // User ID -> indexed email
+}
+
+// newEmailIndex creates an empty index (standalone function)
+func newEmailIndex() *emailIndex {
+	return &emailIndex{
+		ids:    make(map[string]string),
+		emails: make(map[string]string),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on emailIndex
+// =====================================
+
+// lookup returns the ID of the live user with an email (pointer receiver)
+func (x *emailIndex) lookup(email string) (string, bool) {
+	id, exists := x.ids[email]
+	return id, exists
+}
+
+// check returns ErrEmailTaken if another user holds email; an empty
+// email is never taken (pointer receiver)
+func (x *emailIndex) check(id, email string) error {
+	if owner, taken := x.ids[email]; email != "" && taken && owner != id {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+// set indexes a user under email, replacing their previous entry; an
+// empty email only removes it. Callers check first (pointer receiver).
+func (x *emailIndex) set(id, email string) {
+	x.remove(id)
+	if email == "" {
+		return
+	}
+	x.ids[email] = id
+	x.emails[id] = email
+}
+
+// remove drops a user's entry, if any (pointer receiver)
+func (x *emailIndex) remove(id string) {
+	if email, exists := x.emails[id]; exists {
+		delete(x.ids, email)
+		delete(x.emails, id)
+	}
+}
+
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// ErrInvalidEnumValue is returned when a value is not allowed by its enumeration
+var ErrInvalidEnumValue = errors.New("invalid enum value")
+
+// EnumService is the registry of managed enumerations
+type EnumService struct {
+	enums map[models.EnumKind]*models.EnumDefinition
+	mu    sync.RWMutex
+}
+
+// NewEnumService creates a new EnumService seeded with the built-in enumerations
+func NewEnumService() *EnumService {
+	enums := make(map[models.EnumKind]*models.EnumDefinition)
+	for _, def := range models.BuiltinEnums() {
+		def := def
+		enums[def.Kind] = &def
+	}
+	return &EnumService{
+		enums: enums,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on EnumService
+// =====================================
+
+// ListEnums returns every enumeration sorted by kind (pointer receiver)
+func (s *EnumService) ListEnums(ctx context.Context) []models.EnumDefinition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	defs := make([]models.EnumDefinition, 0, len(s.enums))
+	for _, def := range s.enums {
+		defs = append(defs, def.Clone())
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Kind < defs[j].Kind
+	})
+	return defs
+}
+
+// GetEnum returns a single enumeration (pointer receiver)
+func (s *EnumService) GetEnum(ctx context.Context, kind models.EnumKind) (*models.EnumDefinition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	def, exists := s.enums[kind]
+	if !exists {
+		return nil, errors.New("enum not found")
+	}
+	clone := def.Clone()
+	return &clone, nil
+}
+
+// Validate checks that value is allowed by the enumeration (pointer receiver)
+func (s *EnumService) Validate(kind models.EnumKind, value string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	def, exists := s.enums[kind]
+	if !exists || !def.Has(value) {
+		return fmt.Errorf("%w: %s %q", ErrInvalidEnumValue, kind, value)
+	}
+	return nil
+}
+
+// AddValue adds a value to an extensible enumeration (pointer receiver)
+func (s *EnumService) AddValue(ctx context.Context, kind models.EnumKind, value models.EnumValue) error {
+	value.Value = strings.TrimSpace(value.Value)
+	if value.Value == "" {
+		return errors.New("enum value is required")
+	}
+	value.Builtin = false
+
+	labels := make(map[string]string, len(value.Labels))
+	for locale, label := range value.Labels {
+		labels[strings.ToLower(locale)] = label
+	}
+	value.Labels = labels
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	def, exists := s.enums[kind]
+	if !exists {
+		return errors.New("enum not found")
+	}
+	if !def.Extensible {
+		return errors.New("enum is not extensible")
+	}
+	if def.Has(value.Value) {
+		return errors.New("enum value already exists")
+	}
+
+	def.Values = append(def.Values, value)
+	return nil
+}
+
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// Escalation defaults
+const (
+	DefaultEscalationCooldown = 5 * time.Minute
+	escalationTimeout         = 5 * time.Second
+)
+
+// Incident describes a failed request that affected an organization
+type Incident struct {
+	OrgID      string    `json:"org_id"`
+	Tier       string    `json:"tier"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EscalationService alerts priority-support organizations' escalation
+// webhooks when server errors affect them. Escalations for the same
+// organization are rate limited by a cooldown so an outage sends one
+// alert rather than one per failed request.
+type EscalationService struct {
+	orgs     *OrganizationService
+	client   *http.Client
+	cooldown time.Duration
+	logger   *log.Logger
+	last     map[string]time.Time // org ID -> last escalation
+	mu       sync.Mutex
+}
+
+// NewEscalationService creates a new EscalationService instance
+func NewEscalationService(orgs *OrganizationService, logger *log.Logger) *EscalationService {
+	return &EscalationService{
+		orgs:     orgs,
+		client:   &http.Client{Timeout: escalationTimeout},
+		cooldown: DefaultEscalationCooldown,
+		logger:   logger,
+		last:     make(map[string]time.Time),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Escalate reports an incident for a priority-support organization and
+// returns whether an escalation was raised. Standard-tier organizations,
+// unknown organizations and incidents within the cooldown are ignored.
+// The webhook is called in the background (pointer receiver).
+func (s *EscalationService) Escalate(ctx context.Context, incident Incident) bool {
+	org, err := s.orgs.ReadOrg(ctx, incident.OrgID)
+	if err != nil || !org.Support.IsPriority() {
+		return false
+	}
+
+	s.mu.Lock()
+	if last, ok := s.last[org.ID]; ok && incident.OccurredAt.Sub(last) < s.cooldown {
+		s.mu.Unlock()
+		return false
+	}
+	s.last[org.ID] = incident.OccurredAt
+	s.mu.Unlock()
+
+	incident.Tier = string(org.Support.Tier)
+	s.logger.Printf("event=support.escalation org=%s tier=%s status=%d method=%s path=%s contact=%q",
+		org.ID, incident.Tier, incident.Status, incident.Method, incident.Path, org.Support.EscalationContact)
+
+	if org.Support.EscalationWebhook != "" {
+		go s.deliver(org.Support, incident)
+	}
+	return true
+}
+
+// deliver posts an incident to the escalation webhook (pointer receiver)
+func (s *EscalationService) deliver(support models.OrgSupport, incident Incident) {
+	body, err := json.Marshal(incident)
+	if err != nil {
+		s.logger.Printf("escalation webhook org=%s failed: %v", incident.OrgID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), escalationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, support.EscalationWebhook, bytes.NewReader(body))
+	if err != nil {
+		s.logger.Printf("escalation webhook org=%s failed: %v", incident.OrgID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Printf("escalation webhook org=%s failed: %v", incident.OrgID, err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		s.logger.Printf("escalation webhook org=%s failed: %s", incident.OrgID, resp.Status)
+	}
+}
+
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ID prefixes, one per kind of entity. IDs look like "user_01J9Z3W8K7...".
+const (
+	IDPrefixUser            = "user"
+	IDPrefixProfile         = "profile"
+	IDPrefixOrg             = "org"
+	IDPrefixMembership      = "mem"
+	IDPrefixProject         = "proj"
+	IDPrefixGrant           = "grant"
+	IDPrefixProjectMember   = "pmem"
+	IDPrefixTask            = "task"
+	IDPrefixInvitation      = "invite"
+	IDPrefixJoinRequest     = "joinreq"
+	IDPrefixDraft           = "draft"
+	IDPrefixImport          = "imj"
+	IDPrefixImpersonation   = "imp"
+	IDPrefixSandbox         = "sbx"
+	IDPrefixScheduledChange = "change"
+	IDPrefixOrgDeletion     = "orgdel"
+	IDPrefixAvatar          = "avatar"
+)
+
+// IDGenerator hands out entity IDs. Implementations must be safe for
+// concurrent use and never return the same ID twice.
+type IDGenerator interface {
+	NewID(prefix string) string
+}
+
+// crockford is the Crockford base32 alphabet ULIDs are written in
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator generates prefixed ULIDs: a millisecond timestamp followed
+// by 80 random bits, so IDs sort by creation time. IDs generated within
+// the same millisecond increment the random part instead of drawing a new
+// one, keeping them unique and ordered under concurrent creation.
+type ULIDGenerator struct {
+	now     func() time.Time
+	lastMs  uint64
+	entropy [10]byte
+	mu      sync.Mutex
+}
+
+// NewULIDGenerator creates a new ULIDGenerator
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+// defaultIDGenerator is used by services until SetIDGenerator is called
+// and by the standalone constructors such as CreateMembership
+var defaultIDGenerator = NewULIDGenerator()
+
+// DefaultIDGenerator returns the generator services use by default (standalone function)
+func DefaultIDGenerator() IDGenerator {
+	return defaultIDGenerator
+}
+
+// =====================================
+// Pointer Receiver Methods on ULIDGenerator
+// =====================================
+
+// NewID returns prefix, an underscore and a new ULID (pointer receiver)
+func (g *ULIDGenerator) NewID(prefix string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMs || !g.increment() {
+		if ms <= g.lastMs {
+			ms = g.lastMs + 1 // The random part overflowed; borrow the next millisecond
+		}
+		if _, err := rand.Read(g.entropy[:]); err != nil {
+			panic(fmt.Sprintf("ids: failed to read random bytes: %v", err))
+		}
+		g.lastMs = ms
+	}
+	return prefix + "_" + encodeULID(g.lastMs, g.entropy)
+}
+
+// increment adds one to the random part and reports whether it did not
+// overflow; callers hold g.mu (pointer receiver)
+func (g *ULIDGenerator) increment() bool {
+	for i := len(g.entropy) - 1; i >= 0; i-- {
+		g.entropy[i]++
+		if g.entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// =====================================
+// Pointer Receiver Methods on idSource
+// =====================================
+
+// idSource is embedded in services that create entities and hands out
+// their IDs from the injected IDGenerator
+type idSource struct {
+	ids IDGenerator
+}
+
+// SetIDGenerator replaces the generator new IDs are drawn from; call it
+// before the service is used (pointer receiver)
+func (s *idSource) SetIDGenerator(ids IDGenerator) {
+	s.ids = ids
+}
+
+// NewID returns a new ID with the given prefix (pointer receiver)
+func (s *idSource) NewID(prefix string) string {
+	if s.ids == nil {
+		return defaultIDGenerator.NewID(prefix)
+	}
+	return s.ids.NewID(prefix)
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// encodeULID writes a 48-bit timestamp and 80 random bits as 26 Crockford
+// base32 characters (standalone function)
+func encodeULID(ms uint64, entropy [10]byte) string {
+	var raw [16]byte
+	for i := 0; i < 6; i++ {
+		raw[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(raw[6:], entropy[:])
+
+	// 26 characters hold 130 bits; the first character carries the top 3
+	var out [26]byte
+	for i := range out {
+		bit := i*5 - 2
+		value := 0
+		for j := 0; j < 5; j++ {
+			value <<= 1
+			if position := bit + j; position >= 0 && raw[position/8]&(0x80>>(position%8)) != 0 {
+				value |= 1
+			}
+		}
+		out[i] = crockford[value]
+	}
+	return string(out[:])
+}
+
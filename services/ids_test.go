@@ -0,0 +1,47 @@
+package services
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestIDPrefixesUnique parses the IDPrefix constants so a new entity kind
+// cannot reuse another kind's prefix
+func TestIDPrefixesUnique(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "ids.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parsing ids.go: %v", err)
+	}
+
+	seen := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if !strings.HasPrefix(name.Name, "IDPrefix") {
+					continue
+				}
+				prefix, err := strconv.Unquote(value.Values[i].(*ast.BasicLit).Value)
+				if err != nil {
+					t.Fatalf("%s: %v", name.Name, err)
+				}
+				if other, exists := seen[prefix]; exists {
+					t.Errorf("%s and %s share the prefix %q", other, name.Name, prefix)
+				}
+				seen[prefix] = name.Name
+			}
+		}
+	}
+	if len(seen) == 0 {
+		t.Fatal("no IDPrefix constants found in ids.go")
+	}
+}
+
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// Impersonation lifetimes
+const (
+	DefaultImpersonationTTL = 15 * time.Minute
+	MaxImpersonationTTL     = time.Hour
+)
+
+// ErrImpersonationNotFound is returned for an unknown impersonation
+var ErrImpersonationNotFound = errors.New("impersonation not found")
+
+// ErrImpersonationInvalid is returned for a token that is unknown, expired or ended
+var ErrImpersonationInvalid = errors.New("impersonation token is invalid or expired")
+
+// ErrImpersonationNotAllowed is returned when the target may not be impersonated
+var ErrImpersonationNotAllowed = errors.New("impersonation not allowed")
+
+// ImpersonationService issues short-lived tokens that let administrators
+// act as a user for support. Administrators cannot impersonate each other,
+// so an impersonation never carries admin rights.
+type ImpersonationService struct {
+	idSource       // Hands out IDs for created entities
+	impersonations map[string]*models.Impersonation
+	byToken        map[string]string // Token hash -> impersonation ID
+	users          *UserService
+	mu             sync.Mutex
+}
+
+// NewImpersonationService creates a new ImpersonationService instance
+func NewImpersonationService(users *UserService) *ImpersonationService {
+	return &ImpersonationService{
+		impersonations: make(map[string]*models.Impersonation),
+		byToken:        make(map[string]string),
+		users:          users,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Start issues an impersonation of a user by an administrator. A ttl of 0
+// uses DefaultImpersonationTTL; longer than MaxImpersonationTTL is
+// rejected. The token is only returned here (pointer receiver).
+func (s *ImpersonationService) Start(ctx context.Context, adminID, userID, reason string, ttl time.Duration) (*models.Impersonation, error) {
+	defer timing.Track(ctx, timing.LayerService, "impersonations.Start")()
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("reason is required")
+	}
+	if ttl == 0 {
+		ttl = DefaultImpersonationTTL
+	}
+	if ttl < 0 || ttl > MaxImpersonationTTL {
+		return nil, fmt.Errorf("ttl must be between 0 and %s", MaxImpersonationTTL)
+	}
+	if adminID == userID {
+		return nil, fmt.Errorf("%w: administrators cannot impersonate themselves", ErrImpersonationNotAllowed)
+	}
+
+	user, err := s.users.Read(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user %s", ErrImpersonationNotFound, userID)
+	}
+	if user.IsAdmin() {
+		return nil, fmt.Errorf("%w: %s is an administrator", ErrImpersonationNotAllowed, userID)
+	}
+
+	token, hash, err := newImpersonationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	impersonation := models.NewImpersonation(s.NewID(IDPrefixImpersonation), adminID, userID, strings.TrimSpace(reason), models.Now().Add(ttl))
+	impersonation.TokenHash = hash
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeExpired(models.Now())
+	s.impersonations[impersonation.ID] = impersonation
+	s.byToken[hash] = impersonation.ID
+
+	issued := *impersonation
+	issued.Token = token
+	return &issued, nil
+}
+
+// Resolve returns the active impersonation a token belongs to (pointer receiver)
+func (s *ImpersonationService) Resolve(ctx context.Context, token string) (*models.Impersonation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, exists := s.byToken[hashImpersonationToken(token)]
+	if !exists {
+		return nil, ErrImpersonationInvalid
+	}
+	impersonation := s.impersonations[id]
+	if !impersonation.IsActive(models.Now()) {
+		return nil, ErrImpersonationInvalid
+	}
+	copied := *impersonation
+	return &copied, nil
+}
+
+// End stops an impersonation before it expires (pointer receiver)
+func (s *ImpersonationService) End(ctx context.Context, id string) (*models.Impersonation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.impersonations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrImpersonationNotFound, id)
+	}
+
+	impersonation := *existing
+	if impersonation.EndedAt == nil {
+		now := models.Now()
+		impersonation.EndedAt = &now
+		impersonation.UpdatedAt = now
+		s.impersonations[id] = &impersonation
+	}
+	copied := impersonation
+	return &copied, nil
+}
+
+// EndUserImpersonations ends every active impersonation of a user and
+// returns how many were ended (pointer receiver)
+func (s *ImpersonationService) EndUserImpersonations(ctx context.Context, userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := models.Now()
+	ended := 0
+	for id, existing := range s.impersonations {
+		if existing.UserID != userID || !existing.IsActive(now) {
+			continue
+		}
+		impersonation := *existing
+		impersonation.EndedAt = &now
+		impersonation.UpdatedAt = now
+		s.impersonations[id] = &impersonation
+		ended++
+	}
+	return ended
+}
+
+// ListActive lists impersonations that have not ended or expired, oldest
+// first (pointer receiver)
+func (s *ImpersonationService) ListActive(ctx context.Context) []models.Impersonation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := models.Now()
+	s.purgeExpired(now)
+
+	active := make([]models.Impersonation, 0, len(s.impersonations))
+	for _, impersonation := range s.impersonations {
+		if impersonation.IsActive(now) {
+			active = append(active, *impersonation)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].CreatedAt.Before(active[j].CreatedAt)
+	})
+	return active
+}
+
+// purgeExpired drops impersonations that can no longer be used; callers
+// hold s.mu (pointer receiver)
+func (s *ImpersonationService) purgeExpired(now time.Time) {
+	for hash, id := range s.byToken {
+		if !s.impersonations[id].IsActive(now) {
+			delete(s.impersonations, id)
+			delete(s.byToken, hash)
+		}
+	}
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// newImpersonationToken generates a secret token and its stored hash (standalone function)
+func newImpersonationToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashImpersonationToken(token), nil
+}
+
+// hashImpersonationToken returns the stored form of a token (standalone function)
+func hashImpersonationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
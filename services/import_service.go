@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// Recognised CSV columns for user imports
+const (
+	importColFirstName = "first_name"
+	importColLastName  = "last_name"
+	importColEmail     = "email"
+	importColRole      = "role"
+	importColOrgID     = "org_id"
+	importColOrgRole   = "org_role"
+)
+
+// importBatchSize is how many rows are validated before their users and
+// memberships are written in one batch
+const importBatchSize = 500
+
+// ImportService imports users and organization memberships from CSV
+type ImportService struct {
+	idSource // Hands out IDs for created entities
+	users    *UserService
+	orgs     *OrganizationService
+	results  map[string]*models.ImportResult
+	mu       sync.RWMutex
+}
+
+// NewImportService creates a new ImportService instance
+func NewImportService(users *UserService, orgs *OrganizationService) *ImportService {
+	return &ImportService{
+		users:   users,
+		orgs:    orgs,
+		results: make(map[string]*models.ImportResult),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on ImportService
+// =====================================
+
+// ImportUsersCSV streams a CSV document row by row, creating users and
+// memberships. Rows that fail validation are recorded on the result
+// instead of aborting the whole import (pointer receiver).
+func (s *ImportService) ImportUsersCSV(ctx context.Context, r io.Reader) (*models.ImportResult, error) {
+	defer timing.Track(ctx, timing.LayerService, "import.ImportUsersCSV")()
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{importColFirstName, importColEmail} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column: %s", required)
+		}
+	}
+
+	result := models.NewImportResult(s.NewID(IDPrefixImport))
+	result.Header = header
+	s.saveResult(result)
+
+	// Rows read before a failure are still written, as they were accepted
+	batch := newImportBatch()
+	defer s.flush(ctx, result, batch)
+
+	row := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+
+		if err != nil {
+			if errors.Is(err, csv.ErrFieldCount) {
+				result.RowsProcessed++
+				result.Reject(row, "", "wrong number of fields", record)
+				continue
+			}
+			result.Fail()
+			return result, fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			result.Fail()
+			return result, err
+		}
+
+		result.RowsProcessed++
+		s.stageRow(ctx, result, batch, row, record, columns)
+		if len(batch.rows) >= importBatchSize {
+			s.flush(ctx, result, batch)
+		}
+	}
+
+	s.flush(ctx, result, batch)
+	result.Complete()
+	return result, nil
+}
+
+// importBatch holds validated rows until their users and memberships are written
+type importBatch struct {
+	rows     []stagedRow
+	newUsers []*models.User
+	byEmail  map[string]*models.User // Users created earlier in the batch
+}
+
+// stagedRow is a validated row waiting to be written
+type stagedRow struct {
+	row     int
+	record  []string
+	email   string
+	user    *models.User
+	orgID   string
+	orgRole models.MemberRole
+}
+
+// newImportBatch creates an empty batch (standalone function)
+func newImportBatch() *importBatch {
+	return &importBatch{byEmail: make(map[string]*models.User)}
+}
+
+// flush writes the batch's new users and memberships in one call each and
+// records the outcome of every row (pointer receiver)
+func (s *ImportService) flush(ctx context.Context, result *models.ImportResult, batch *importBatch) {
+	if len(batch.rows) == 0 {
+		return
+	}
+
+	failed := make(map[*models.User]error)
+	for i, err := range s.users.WriteMany(ctx, batch.newUsers) {
+		if err != nil {
+			failed[batch.newUsers[i]] = err
+			continue
+		}
+		result.UsersCreated++
+	}
+
+	var memberships []*models.Membership
+	var owners []stagedRow
+	for _, staged := range batch.rows {
+		if err, ok := failed[staged.user]; ok {
+			result.Reject(staged.row, staged.email, err.Error(), staged.record)
+			continue
+		}
+		if staged.orgID == "" {
+			continue
+		}
+		memberships = append(memberships, CreateMembership(staged.user.ID, staged.orgID, staged.orgRole))
+		owners = append(owners, staged)
+	}
+
+	for i, err := range s.orgs.AddMembers(ctx, memberships) {
+		if err != nil {
+			result.Reject(owners[i].row, owners[i].email, err.Error(), owners[i].record)
+			continue
+		}
+		result.MembershipsCreated++
+	}
+
+	// Write failures are found after later rows were validated; keep the report in row order
+	sort.SliceStable(result.Rejected, func(i, j int) bool {
+		return result.Rejected[i].Row < result.Rejected[j].Row
+	})
+	*batch = *newImportBatch()
+}
+
+// stageRow validates a single row and adds its user and optional
+// membership to the batch (pointer receiver)
+func (s *ImportService) stageRow(ctx context.Context, result *models.ImportResult, batch *importBatch, row int, record []string, columns map[string]int) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	email, err := s.users.VerifyEmail(ctx, field(importColEmail))
+	if err != nil {
+		reason := "invalid email format"
+		if errors.Is(err, ErrEmailUndeliverable) {
+			reason = "email domain does not accept mail"
+		}
+		result.Reject(row, NormalizeEmail(field(importColEmail)), reason, record)
+		return
+	}
+
+	firstName := field(importColFirstName)
+	if firstName == "" {
+		result.Reject(row, email, "first name is required", record)
+		return
+	}
+
+	orgID := field(importColOrgID)
+	orgRole := models.MemberRole(field(importColOrgRole))
+	if orgID != "" {
+		if exists, _ := s.orgs.OrgExists(ctx, orgID); !exists {
+			result.Reject(row, email, "organization not found", record)
+			return
+		}
+		if orgRole == "" {
+			orgRole = models.MemberRoleMember
+		}
+	}
+
+	// Reuse existing users, including ones created earlier in this import,
+	// so memberships can be imported for them
+	user, staged := batch.byEmail[email]
+	if !staged {
+		var err error
+		user, err = s.users.FindByEmail(ctx, email)
+		if err != nil {
+			user = CreateUser(s.NewID(IDPrefixUser), firstName, field(importColLastName), email)
+			if role := field(importColRole); role != "" {
+				user.SetRole(role)
+			}
+			batch.newUsers = append(batch.newUsers, user)
+			batch.byEmail[email] = user
+		}
+	}
+
+	batch.rows = append(batch.rows, stagedRow{
+		row:     row,
+		record:  record,
+		email:   email,
+		user:    user,
+		orgID:   orgID,
+		orgRole: orgRole,
+	})
+}
+
+// GetResult retrieves an import result by ID (pointer receiver)
+func (s *ImportService) GetResult(ctx context.Context, id string) (*models.ImportResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result, exists := s.results[id]
+	if !exists {
+		return nil, errors.New("import not found")
+	}
+	return result, nil
+}
+
+// saveResult stores an import result (pointer receiver)
+func (s *ImportService) saveResult(result *models.ImportResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[result.ID] = result
+}
+
+// =====================================
+// Standalone Functions for Imports
+// =====================================
+
+// WriteImportErrorReport writes the rejected rows of an import as CSV,
+// preserving the original columns and appending row number and reason
+func WriteImportErrorReport(w io.Writer, result *models.ImportResult) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string{"row"}, result.Header...)
+	header = append(header, "error")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, rejected := range result.Rejected {
+		line := append([]string{fmt.Sprintf("%d", rejected.Row)}, rejected.Record...)
+		line = append(line, rejected.Reason)
+		if err := writer.Write(line); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
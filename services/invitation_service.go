@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/policy"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// Invitation lifetimes
+const (
+	DefaultInvitationTTL = 7 * 24 * time.Hour
+	MaxInvitationTTL     = 30 * 24 * time.Hour
+)
+
+// ErrInvitationNotFound is returned for an unknown invitation or token
+var ErrInvitationNotFound = errors.New("invitation not found")
+
+// ErrInvitationNotAllowed is returned when the inviter cannot manage the organization
+var ErrInvitationNotAllowed = errors.New("invitation not allowed")
+
+// ErrInvitationExists is returned when the invitee is already a member or
+// already has a pending invitation
+var ErrInvitationExists = errors.New("invitation already exists")
+
+// ErrInvitationClosed is returned when accepting, revoking, or renewing an
+// invitation that was accepted, revoked, or has expired
+var ErrInvitationClosed = errors.New("invitation is no longer open")
+
+// ErrInviteeNotRegistered is returned when no user has the invited email address
+var ErrInviteeNotRegistered = errors.New("invitee not registered")
+
+// InvitationService invites people to organizations by email. Accepting
+// an invitation's token turns it into a membership.
+type InvitationService struct {
+	idSource    // Hands out IDs for created entities
+	invitations map[string]*models.Invitation
+	byToken     map[string]string // Token hash -> invitation ID
+	orgs        *OrganizationService
+	users       *UserService
+	email       interfaces.EmailNotifier     // Optional; invitations are not sent without one
+	templates   *NotificationTemplateService // Optional; plain translated text without one
+	mu          sync.RWMutex
+}
+
+// NewInvitationService creates a new InvitationService instance
+func NewInvitationService(orgs *OrganizationService, users *UserService, email interfaces.EmailNotifier) *InvitationService {
+	return &InvitationService{
+		invitations: make(map[string]*models.Invitation),
+		byToken:     make(map[string]string),
+		orgs:        orgs,
+		users:       users,
+		email:       email,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// SetTemplates attaches the templates invitation emails are rendered with (pointer receiver)
+func (s *InvitationService) SetTemplates(templates *NotificationTemplateService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates = templates
+}
+
+// Invite creates a pending invitation and emails its token to the invitee.
+// The inviter needs the manage_members permission, and owners cannot be
+// invited. A zero ttl means DefaultInvitationTTL. The returned invitation
+// carries the token; it cannot be read again (pointer receiver).
+func (s *InvitationService) Invite(ctx context.Context, orgID, email string, role models.MemberRole, invitedBy string, ttl time.Duration) (*models.Invitation, error) {
+	defer timing.Track(ctx, timing.LayerService, "invitations.Invite")()
+
+	email, err := s.users.VerifyEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("a valid email is required: %w", err)
+	}
+	if role == "" {
+		role = models.MemberRoleMember
+	}
+	if role == models.MemberRoleOwner {
+		return nil, errors.New("owners cannot be invited")
+	}
+	if err := s.orgs.ValidateMemberRole(role); err != nil {
+		return nil, err
+	}
+	if ttl == 0 {
+		ttl = DefaultInvitationTTL
+	}
+	if ttl < 0 || ttl > MaxInvitationTTL {
+		return nil, fmt.Errorf("expiry must be between 0 and %v", MaxInvitationTTL)
+	}
+
+	org, err := s.orgs.ReadOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkInviter(ctx, orgID, invitedBy); err != nil {
+		return nil, err
+	}
+	if user, err := s.users.FindByEmail(ctx, email); err == nil {
+		if _, err := s.orgs.GetMembership(ctx, user.ID, orgID); err == nil {
+			return nil, fmt.Errorf("%w: %s is already a member of %s", ErrInvitationExists, email, orgID)
+		}
+	}
+
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	now := models.Now()
+	for _, existing := range s.invitations {
+		if existing.OrgID == orgID && strings.EqualFold(existing.Email, email) && existing.CurrentStatus(now) == models.InvitationPending {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("%w: %s already has a pending invitation", ErrInvitationExists, email)
+		}
+	}
+	invitation := models.NewInvitation(s.NewID(IDPrefixInvitation), orgID, email, role, invitedBy, now.Add(ttl))
+	invitation.TokenHash = hash
+	s.invitations[invitation.ID] = invitation
+	s.byToken[hash] = invitation.ID
+	s.mu.Unlock()
+
+	s.send(ctx, invitation, org, token)
+
+	issued := *invitation
+	issued.Token = token
+	return &issued, nil
+}
+
+// ListInvitations lists an organization's invitations, newest first,
+// optionally filtered by status (pointer receiver)
+func (s *InvitationService) ListInvitations(ctx context.Context, orgID string, status models.InvitationStatus) ([]models.Invitation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := models.Now()
+	invitations := make([]models.Invitation, 0)
+	for _, invitation := range s.invitations {
+		current := *invitation
+		current.Status = invitation.CurrentStatus(now)
+		if current.OrgID != orgID || (status != "" && current.Status != status) {
+			continue
+		}
+		invitations = append(invitations, current)
+	}
+
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+	})
+	return invitations, nil
+}
+
+// RevokeInvitation cancels a pending invitation so its token no longer
+// works (pointer receiver)
+func (s *InvitationService) RevokeInvitation(ctx context.Context, orgID, invitationID, revokedBy string) (*models.Invitation, error) {
+	if err := s.checkInviter(ctx, orgID, revokedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invitation, err := s.openInvitation(orgID, invitationID, models.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	now := models.Now()
+	revoked := *invitation
+	revoked.Status = models.InvitationRevoked
+	revoked.RevokedBy = revokedBy
+	revoked.RevokedAt = &now
+	revoked.UpdatedAt = now
+	s.invitations[invitationID] = &revoked
+	delete(s.byToken, invitation.TokenHash)
+	return &revoked, nil
+}
+
+// RemoveOrgInvitations removes every invitation to an organization, along
+// with its token, and returns how many were removed (pointer receiver)
+func (s *InvitationService) RemoveOrgInvitations(ctx context.Context, orgID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, invitation := range s.invitations {
+		if invitation.OrgID == orgID {
+			delete(s.invitations, id)
+			removed++
+		}
+	}
+	for hash, id := range s.byToken {
+		if _, exists := s.invitations[id]; !exists {
+			delete(s.byToken, hash)
+		}
+	}
+	return removed, nil
+}
+
+// RenewInvitation replaces the token of a pending or expired invitation,
+// extends its expiry, and emails the new token. The old token stops
+// working (pointer receiver).
+func (s *InvitationService) RenewInvitation(ctx context.Context, orgID, invitationID, renewedBy string, ttl time.Duration) (*models.Invitation, error) {
+	if ttl == 0 {
+		ttl = DefaultInvitationTTL
+	}
+	if ttl < 0 || ttl > MaxInvitationTTL {
+		return nil, fmt.Errorf("expiry must be between 0 and %v", MaxInvitationTTL)
+	}
+	org, err := s.orgs.ReadOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkInviter(ctx, orgID, renewedBy); err != nil {
+		return nil, err
+	}
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	invitation, exists := s.invitations[invitationID]
+	if !exists || invitation.OrgID != orgID {
+		s.mu.Unlock()
+		return nil, ErrInvitationNotFound
+	}
+	if invitation.Status != models.InvitationPending {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: invitation %s", ErrInvitationClosed, invitation.Status)
+	}
+
+	now := models.Now()
+	renewed := *invitation
+	renewed.ExpiresAt = now.Add(ttl)
+	renewed.TokenHash = hash
+	renewed.UpdatedAt = now
+	renewed.IncrementVersion()
+	s.invitations[invitationID] = &renewed
+	delete(s.byToken, invitation.TokenHash)
+	s.byToken[hash] = invitationID
+	s.mu.Unlock()
+
+	s.send(ctx, &renewed, org, token)
+
+	issued := renewed
+	issued.Token = token
+	return &issued, nil
+}
+
+// AcceptInvitation turns the invitation a token belongs to into a
+// membership for the user registered with the invited email address
+// (pointer receiver)
+func (s *InvitationService) AcceptInvitation(ctx context.Context, token string) (*models.Invitation, *models.Membership, error) {
+	defer timing.Track(ctx, timing.LayerService, "invitations.AcceptInvitation")()
+
+	// Hold the lock throughout so a token cannot be accepted twice
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invitationID, exists := s.byToken[hashInvitationToken(token)]
+	if !exists {
+		return nil, nil, ErrInvitationNotFound
+	}
+	invitation, err := s.openInvitation("", invitationID, models.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.users.FindByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInviteeNotRegistered, invitation.Email)
+	}
+	membership := CreateMembership(user.ID, invitation.OrgID, invitation.Role)
+	if err := s.orgs.AddMember(ctx, membership); err != nil {
+		if _, memberErr := s.orgs.GetMembership(ctx, user.ID, invitation.OrgID); memberErr == nil {
+			return nil, nil, fmt.Errorf("%w: %s is already a member of %s", ErrInvitationExists, user.ID, invitation.OrgID)
+		}
+		return nil, nil, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	now := models.Now()
+	accepted := *invitation
+	accepted.Status = models.InvitationAccepted
+	accepted.AcceptedBy = user.ID
+	accepted.AcceptedAt = &now
+	accepted.UpdatedAt = now
+	s.invitations[invitationID] = &accepted
+	delete(s.byToken, invitation.TokenHash)
+	return &accepted, membership, nil
+}
+
+// openInvitation returns a pending, unexpired invitation; an empty orgID
+// matches any organization. Callers hold s.mu (pointer receiver).
+func (s *InvitationService) openInvitation(orgID, invitationID string, now time.Time) (*models.Invitation, error) {
+	invitation, exists := s.invitations[invitationID]
+	if !exists || (orgID != "" && invitation.OrgID != orgID) {
+		return nil, ErrInvitationNotFound
+	}
+	if status := invitation.CurrentStatus(now); status != models.InvitationPending {
+		return nil, fmt.Errorf("%w: invitation %s", ErrInvitationClosed, status)
+	}
+	return invitation, nil
+}
+
+// checkInviter verifies that a user may manage the organization's
+// invitations, which requires the manage_members permission (pointer receiver)
+func (s *InvitationService) checkInviter(ctx context.Context, orgID, userID string) error {
+	membership, err := s.orgs.GetMembership(ctx, userID, orgID)
+	if err != nil || !policy.Allows(membership.Role, policy.ActionManageMembers) {
+		return fmt.Errorf("%w: %s may not manage members of %s", ErrInvitationNotAllowed, userID, orgID)
+	}
+	return nil
+}
+
+// send emails the invitation token in the background, in the invitee's
+// locale when they already have an account (pointer receiver)
+func (s *InvitationService) send(ctx context.Context, invitation *models.Invitation, org *models.Organization, token string) {
+	s.mu.RLock()
+	templates := s.templates
+	s.mu.RUnlock()
+	if s.email == nil {
+		return
+	}
+
+	data := models.NotificationData{
+		Locale: i18n.DefaultLocale,
+		Org:    org,
+		Vars: map[string]string{
+			"inviter":     invitation.InvitedBy,
+			"role":        string(invitation.Role),
+			"accept_path": "/api/v1/invitations/" + token + "/accept",
+			"expires_at":  invitation.ExpiresAt.Format(time.RFC3339),
+		},
+	}
+	if user, err := s.users.FindByEmail(ctx, invitation.Email); err == nil {
+		data.User = user
+		data.Locale = i18n.Negotiate(user.Locale)
+	}
+
+	subject := i18n.T(data.Locale, i18n.MsgOrgInvitation, invitation.InvitedBy, org.Name)
+	body := subject + "\n\n" + data.Vars["accept_path"]
+	if templates != nil {
+		if rendered, err := templates.Render(ctx, EventOrgInvitation, data); err == nil {
+			subject, body = rendered.Subject, rendered.Body
+		}
+	}
+
+	go s.email.SendEmail(context.WithoutCancel(ctx), invitation.Email, subject, body)
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// newInvitationToken returns a random URL-safe token and its hash (standalone function)
+func newInvitationToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashInvitationToken(token), nil
+}
+
+// hashInvitationToken returns the stored form of a token (standalone function)
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// newTestInvitations stores org_a, administered by user_admin, and the
+// registered users user_new and user_member; user_member is a member of
+// org_a
+func newTestInvitations(t *testing.T) (*InvitationService, *OrganizationService) {
+	t.Helper()
+	ctx := context.Background()
+	users := NewUserService()
+	orgs := NewOrganizationService()
+	for _, id := range []string{"user_admin", "user_new", "user_member"} {
+		if err := users.Write(ctx, CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	writeTestOrg(t, orgs, "org_a")
+	for _, m := range []struct {
+		user string
+		role models.MemberRole
+	}{
+		{"user_admin", models.MemberRoleAdmin},
+		{"user_member", models.MemberRoleMember},
+	} {
+		if err := orgs.AddMember(ctx, CreateMembership(m.user, "org_a", m.role)); err != nil {
+			t.Fatalf("AddMember(%s): %v", m.user, err)
+		}
+	}
+	return NewInvitationService(orgs, users, NewLogNotifier(log.New(io.Discard, "", 0))), orgs
+}
+
+// expireInvitation moves an invitation's expiry into the past
+func expireInvitation(s *InvitationService, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired := *s.invitations[id]
+	expired.ExpiresAt = models.Now().Add(-time.Minute)
+	s.invitations[id] = &expired
+}
+
+// memberRole returns a user's role in an organization, or "" for non-members
+func memberRole(orgs *OrganizationService, userID, orgID string) models.MemberRole {
+	membership, err := orgs.GetMembership(context.Background(), userID, orgID)
+	if err != nil {
+		return ""
+	}
+	return membership.Role
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string // Returns the token to accept
+		wantErr error
+	}{
+		{"open invitation", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			return invitation.Token
+		}, nil},
+		{"unknown token", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			return "not-a-token"
+		}, ErrInvitationNotFound},
+		{"expired", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			expireInvitation(s, invitation.ID)
+			return invitation.Token
+		}, ErrInvitationClosed},
+		{"already accepted", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			if _, _, err := s.AcceptInvitation(context.Background(), invitation.Token); err != nil {
+				t.Fatalf("first AcceptInvitation: %v", err)
+			}
+			return invitation.Token
+		}, ErrInvitationNotFound},
+		{"revoked", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			if _, err := s.RevokeInvitation(context.Background(), "org_a", invitation.ID, "user_admin"); err != nil {
+				t.Fatalf("RevokeInvitation: %v", err)
+			}
+			return invitation.Token
+		}, ErrInvitationNotFound},
+		{"joined another way since", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			if err := orgs.AddMember(context.Background(), CreateMembership("user_new", "org_a", models.MemberRoleMember)); err != nil {
+				t.Fatalf("AddMember: %v", err)
+			}
+			return invitation.Token
+		}, ErrInvitationExists},
+		{"renewed token replaces the expired one", func(t *testing.T, s *InvitationService, orgs *OrganizationService, invitation *models.Invitation) string {
+			expireInvitation(s, invitation.ID)
+			renewed, err := s.RenewInvitation(context.Background(), "org_a", invitation.ID, "user_admin", 0)
+			if err != nil {
+				t.Fatalf("RenewInvitation: %v", err)
+			}
+			if _, _, err := s.AcceptInvitation(context.Background(), invitation.Token); !errors.Is(err, ErrInvitationNotFound) {
+				t.Fatalf("accepting the replaced token: err = %v, want ErrInvitationNotFound", err)
+			}
+			return renewed.Token
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, orgs := newTestInvitations(t)
+			invitation, err := s.Invite(ctx, "org_a", "user_new@example.com", models.MemberRoleAdmin, "user_admin", 0)
+			if err != nil {
+				t.Fatalf("Invite: %v", err)
+			}
+			token := tt.prepare(t, s, orgs, invitation)
+			before := memberRole(orgs, "user_new", "org_a")
+
+			accepted, membership, err := s.AcceptInvitation(ctx, token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AcceptInvitation: err = %v, want %v", err, tt.wantErr)
+				}
+				// A refused token never changes the invitee's membership
+				if after := memberRole(orgs, "user_new", "org_a"); after != before {
+					t.Errorf("membership role went from %q to %q on a refused token", before, after)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AcceptInvitation: %v", err)
+			}
+			if accepted.Status != models.InvitationAccepted || accepted.AcceptedBy != "user_new" {
+				t.Errorf("invitation = %s by %q, want accepted by user_new", accepted.Status, accepted.AcceptedBy)
+			}
+			if membership.UserID != "user_new" || membership.Role != models.MemberRoleAdmin {
+				t.Errorf("membership = %s as %s, want user_new as admin", membership.UserID, membership.Role)
+			}
+		})
+	}
+}
+
+func TestAcceptInvitationIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	s, orgs := newTestInvitations(t)
+	invitation, err := s.Invite(ctx, "org_a", "user_new@example.com", models.MemberRoleMember, "user_admin", 0)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+
+	const attempts = 8
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		go func() {
+			_, _, err := s.AcceptInvitation(ctx, invitation.Token)
+			errs <- err
+		}()
+	}
+	accepted := 0
+	for i := 0; i < attempts; i++ {
+		if err := <-errs; err == nil {
+			accepted++
+		} else if !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("concurrent AcceptInvitation: err = %v, want ErrInvitationNotFound", err)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("token accepted %d times, want once", accepted)
+	}
+	if _, err := orgs.GetMembership(ctx, "user_new", "org_a"); err != nil {
+		t.Errorf("invitee is not a member after accepting: %v", err)
+	}
+
+	listed, err := s.ListInvitations(ctx, "org_a", models.InvitationAccepted)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("accepted invitations = %d (err %v), want 1", len(listed), err)
+	}
+}
+
+func TestInviteExistingMember(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestInvitations(t)
+
+	if _, err := s.Invite(ctx, "org_a", "USER_MEMBER@example.com", models.MemberRoleAdmin, "user_admin", 0); !errors.Is(err, ErrInvitationExists) {
+		t.Fatalf("inviting a member: err = %v, want ErrInvitationExists", err)
+	}
+	if _, err := s.Invite(ctx, "org_a", "user_new@example.com", models.MemberRoleMember, "user_member", 0); !errors.Is(err, ErrInvitationNotAllowed) {
+		t.Fatalf("invited by a plain member: err = %v, want ErrInvitationNotAllowed", err)
+	}
+}
+
+func TestExpiredInvitationStatus(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestInvitations(t)
+	invitation, err := s.Invite(ctx, "org_a", "user_new@example.com", models.MemberRoleMember, "user_admin", time.Hour)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	expireInvitation(s, invitation.ID)
+
+	for _, status := range []models.InvitationStatus{models.InvitationExpired, models.InvitationPending} {
+		listed, err := s.ListInvitations(ctx, "org_a", status)
+		if err != nil {
+			t.Fatalf("ListInvitations(%s): %v", status, err)
+		}
+		want := 0
+		if status == models.InvitationExpired {
+			want = 1
+		}
+		if len(listed) != want {
+			t.Errorf("%s invitations = %d, want %d", status, len(listed), want)
+		}
+	}
+	if _, err := s.RevokeInvitation(ctx, "org_a", invitation.ID, "user_admin"); !errors.Is(err, ErrInvitationClosed) {
+		t.Errorf("revoking an expired invitation: err = %v, want ErrInvitationClosed", err)
+	}
+
+	// An expired invitation does not block a new one
+	if _, err := s.Invite(ctx, "org_a", "user_new@example.com", models.MemberRoleMember, "user_admin", 0); err != nil {
+		t.Errorf("re-inviting after expiry: %v", err)
+	}
+}
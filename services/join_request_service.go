@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/policy"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// ErrJoinNotAllowed is returned when an organization does not accept join requests
+var ErrJoinNotAllowed = errors.New("join not allowed")
+
+// ErrDecisionNotAllowed is returned when the reviewer cannot manage the organization
+var ErrDecisionNotAllowed = errors.New("decision not allowed")
+
+// JoinRequestService handles membership requests for public organizations
+type JoinRequestService struct {
+	idSource // Hands out IDs for created entities
+	requests map[string]*models.JoinRequest
+	orgs     *OrganizationService
+	notifier interfaces.Notifier
+	locales  *LocaleService // Optional; notifications are sent in English without one
+	mu       sync.RWMutex
+}
+
+// NewJoinRequestService creates a new JoinRequestService instance
+func NewJoinRequestService(orgs *OrganizationService, notifier interfaces.Notifier) *JoinRequestService {
+	return &JoinRequestService{
+		requests: make(map[string]*models.JoinRequest),
+		orgs:     orgs,
+		notifier: notifier,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// SetLocales attaches the resolver used to localize notifications for
+// each recipient (pointer receiver)
+func (s *JoinRequestService) SetLocales(locales *LocaleService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locales = locales
+}
+
+// RequestToJoin files a pending join request and notifies the organization's
+// owners and admins. Only organizations listed in the public directory accept
+// requests, and a user may have at most one pending request per organization
+// (pointer receiver).
+func (s *JoinRequestService) RequestToJoin(ctx context.Context, orgID, userID, message string) (*models.JoinRequest, error) {
+	defer timing.Track(ctx, timing.LayerService, "join_requests.RequestToJoin")()
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+	org, err := s.orgs.ReadOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.HasPublicProfile() {
+		return nil, fmt.Errorf("%w: %s is not listed in the public directory", ErrJoinNotAllowed, orgID)
+	}
+	if _, err := s.orgs.GetMembership(ctx, userID, orgID); err == nil {
+		return nil, fmt.Errorf("%w: %s is already a member of %s", ErrJoinNotAllowed, userID, orgID)
+	}
+
+	s.mu.Lock()
+	for _, existing := range s.requests {
+		if existing.OrgID == orgID && existing.UserID == userID && existing.IsPending() {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("%w: %s already has a pending request", ErrJoinNotAllowed, userID)
+		}
+	}
+	request := models.NewJoinRequest(s.NewID(IDPrefixJoinRequest), orgID, userID, message)
+	s.requests[request.ID] = request
+	s.mu.Unlock()
+
+	members, _ := s.orgs.GetMembers(ctx, orgID)
+	for _, member := range members {
+		if policy.Allows(member.Role, policy.ActionManageMembers) {
+			locale := s.localeFor(ctx, member.UserID, orgID)
+			s.notify(ctx, member.UserID, EventJoinRequested, models.NotificationData{
+				Locale: locale,
+				Org:    org,
+				Vars:   map[string]string{"request_id": request.ID, "requester": userID, "message": message},
+			}, i18n.T(locale, i18n.MsgJoinRequested, userID, org.Name))
+		}
+	}
+	return request, nil
+}
+
+// ReadJoinRequest retrieves a join request of an organization (pointer receiver)
+func (s *JoinRequestService) ReadJoinRequest(ctx context.Context, orgID, requestID string) (*models.JoinRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	request, exists := s.requests[requestID]
+	if !exists || request.OrgID != orgID {
+		return nil, errors.New("join request not found")
+	}
+	return request, nil
+}
+
+// RemoveOrgJoinRequests removes every join request to an organization and
+// returns how many were removed (pointer receiver)
+func (s *JoinRequestService) RemoveOrgJoinRequests(ctx context.Context, orgID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, request := range s.requests {
+		if request.OrgID == orgID {
+			delete(s.requests, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// RemoveUserJoinRequests removes every join request made by a user and
+// returns how many were removed (pointer receiver)
+func (s *JoinRequestService) RemoveUserJoinRequests(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, request := range s.requests {
+		if request.UserID == userID {
+			delete(s.requests, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// ListJoinRequests lists an organization's join requests, oldest first,
+// optionally filtered by status (pointer receiver)
+func (s *JoinRequestService) ListJoinRequests(ctx context.Context, orgID string, status models.JoinRequestStatus) ([]*models.JoinRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := make([]*models.JoinRequest, 0)
+	for _, request := range s.requests {
+		if request.OrgID != orgID || (status != "" && request.Status != status) {
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// DecideJoinRequest approves or denies a pending request and notifies the
+// requester. The reviewer needs the manage_members permission.
+// Approval adds the requester as a member (pointer receiver).
+func (s *JoinRequestService) DecideJoinRequest(ctx context.Context, orgID, requestID, decidedBy string, approve bool, reason string) (*models.JoinRequest, error) {
+	defer timing.Track(ctx, timing.LayerService, "join_requests.DecideJoinRequest")()
+	membership, err := s.orgs.GetMembership(ctx, decidedBy, orgID)
+	if err != nil || !policy.Allows(membership.Role, policy.ActionManageMembers) {
+		return nil, fmt.Errorf("%w: %s may not manage members of %s", ErrDecisionNotAllowed, decidedBy, orgID)
+	}
+
+	s.mu.Lock()
+	request, exists := s.requests[requestID]
+	if !exists || request.OrgID != orgID {
+		s.mu.Unlock()
+		return nil, errors.New("join request not found")
+	}
+	if !request.IsPending() {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("join request already %s", request.Status)
+	}
+
+	if approve {
+		if err := s.orgs.AddMember(ctx, CreateMembership(request.UserID, orgID, models.MemberRoleMember)); err != nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("failed to add member: %w", err)
+		}
+	}
+
+	now := models.Now()
+	decided := *request
+	decided.Status = models.JoinRequestDenied
+	if approve {
+		decided.Status = models.JoinRequestApproved
+	}
+	decided.DecidedBy = decidedBy
+	decided.DecidedAt = &now
+	decided.Reason = reason
+	decided.UpdatedAt = now
+	s.requests[requestID] = &decided
+	s.mu.Unlock()
+
+	org, err := s.orgs.ReadOrg(ctx, orgID)
+	if err != nil {
+		org = &models.Organization{Name: orgID}
+		org.ID = orgID
+	}
+	event := EventJoinDenied
+	if approve {
+		event = EventJoinApproved
+	}
+	locale := s.localeFor(ctx, decided.UserID, orgID)
+	s.notify(ctx, decided.UserID, event, models.NotificationData{
+		Locale: locale,
+		Org:    org,
+		Vars:   map[string]string{"request_id": decided.ID, "reason": reason},
+	}, i18n.T(locale, event, org.Name))
+	return &decided, nil
+}
+
+// localeFor picks the notification locale for a recipient (pointer receiver)
+func (s *JoinRequestService) localeFor(ctx context.Context, userID, orgID string) string {
+	s.mu.RLock()
+	locales := s.locales
+	s.mu.RUnlock()
+
+	if locales == nil {
+		return i18n.DefaultLocale
+	}
+	return locales.LocaleFor(ctx, userID, orgID)
+}
+
+// notify sends a join request event to one user, if a notifier is
+// configured. Notifiers that render templates get the event and its data;
+// others get the translated message naming the recipient (pointer receiver).
+func (s *JoinRequestService) notify(ctx context.Context, userID, event string, data models.NotificationData, message string) {
+	if s.notifier == nil {
+		return
+	}
+	if events, ok := s.notifier.(interfaces.EventNotifier); ok {
+		go events.NotifyEvent(context.WithoutCancel(ctx), userID, event, data)
+		return
+	}
+	s.notifier.NotifyAsync(ctx, fmt.Sprintf("to=%s join_request=%s %s", userID, data.Vars["request_id"], message))
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
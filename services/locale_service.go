@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+)
+
+// LocaleService resolves which locale and time zone to use for a user
+// within an organization: the user's own preference wins over the org default
+type LocaleService struct {
+	users    *UserService
+	settings *OrgSettingsService
+}
+
+// NewLocaleService creates a new LocaleService instance
+func NewLocaleService(users *UserService, settings *OrgSettingsService) *LocaleService {
+	return &LocaleService{
+		users:    users,
+		settings: settings,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Preferred returns the user's preferred locale, else the organization's
+// default locale, else "" so callers can fall back further (pointer receiver)
+func (s *LocaleService) Preferred(ctx context.Context, userID, orgID string) string {
+	if userID != "" {
+		if user, err := s.users.Read(ctx, userID); err == nil && user.Locale != "" {
+			return i18n.Normalize(user.Locale)
+		}
+	}
+	if orgID != "" {
+		if current, err := s.settings.GetSettings(ctx, orgID); err == nil {
+			return i18n.Normalize(current.Settings.Locale)
+		}
+	}
+	return ""
+}
+
+// LocaleFor returns the locale to address a user in, defaulting to
+// i18n.DefaultLocale when neither user nor organization set one (pointer receiver)
+func (s *LocaleService) LocaleFor(ctx context.Context, userID, orgID string) string {
+	return i18n.Negotiate(s.Preferred(ctx, userID, orgID))
+}
+
+// LocationFor returns the time zone period boundaries are computed in for
+// a user within an organization: the user's time zone, else the
+// organization's, else UTC. Either ID may be empty, and soft-deleted
+// records still resolve (pointer receiver).
+func (s *LocaleService) LocationFor(ctx context.Context, userID, orgID string) *time.Location {
+	if userID != "" {
+		if user, err := s.users.ReadIncludingDeleted(ctx, userID); err == nil && user.Timezone != "" {
+			return models.LocationOrUTC(user.Timezone)
+		}
+	}
+	if orgID != "" {
+		return s.settings.Location(orgID)
+	}
+	return time.UTC
+}
+
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+)
+
+// ErrTemplateNotFound is returned for an event without a notification template
+var ErrTemplateNotFound = errors.New("notification template not found")
+
+// Notification events with built-in templates. They share their names with
+// the i18n message keys the default templates translate.
+const (
+	EventJoinRequested = i18n.MsgJoinRequested
+	EventJoinApproved  = i18n.MsgJoinApproved
+	EventJoinDenied    = i18n.MsgJoinDenied
+	EventOrgInvitation = i18n.MsgOrgInvitation
+)
+
+// defaultNotificationTemplates are the built-in templates. {{t .Locale key args...}}
+// looks up a translated message.
+var defaultNotificationTemplates = []models.NotificationTemplate{
+	{
+		Event:   EventJoinRequested,
+		Subject: `{{t .Locale "join_request.requested" .Vars.requester .Org.Name}}`,
+		Body: `{{t .Locale "join_request.requested" .Vars.requester .Org.Name}}
+{{- with .Vars.message}}
+
+"{{.}}"{{end}}
+
+/organizations/{{.Org.ID}}/join-requests/{{.Vars.request_id}}`,
+	},
+	{
+		Event:   EventJoinApproved,
+		Subject: `{{t .Locale "join_request.approved" .Org.Name}}`,
+		Body:    `{{t .Locale "join_request.approved" .Org.Name}}`,
+	},
+	{
+		Event:   EventJoinDenied,
+		Subject: `{{t .Locale "join_request.denied" .Org.Name}}`,
+		Body: `{{t .Locale "join_request.denied" .Org.Name}}
+{{- with .Vars.reason}}
+
+{{.}}{{end}}`,
+	},
+	{
+		Event:   EventOrgInvitation,
+		Subject: `{{t .Locale "org_invitation.invited" .Vars.inviter .Org.Name}}`,
+		Body: `{{t .Locale "org_invitation.invited" .Vars.inviter .Org.Name}} ({{.Vars.role}})
+
+{{.Vars.accept_path}}
+
+{{.Vars.expires_at}}`,
+	},
+}
+
+// templateFuncs are the functions available to notification templates
+var templateFuncs = template.FuncMap{
+	"t":     i18n.T,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// compiledTemplate is a notification template with its parsed subject and body
+type compiledTemplate struct {
+	def     models.NotificationTemplate
+	subject *template.Template
+	body    *template.Template
+}
+
+// NotificationTemplateService stores and renders per-event notification
+// templates written in Go text/template syntax
+type NotificationTemplateService struct {
+	templates map[string]*compiledTemplate
+	mu        sync.RWMutex
+}
+
+// NewNotificationTemplateService creates a new NotificationTemplateService
+// with the built-in templates
+func NewNotificationTemplateService() *NotificationTemplateService {
+	s := &NotificationTemplateService{templates: make(map[string]*compiledTemplate)}
+	for _, def := range defaultNotificationTemplates {
+		def.Builtin = true
+		compiled, err := compileTemplate(def)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in notification template %s: %v", def.Event, err))
+		}
+		s.templates[def.Event] = compiled
+	}
+	return s
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// ListTemplates returns every template ordered by event (pointer receiver)
+func (s *NotificationTemplateService) ListTemplates(ctx context.Context) []models.NotificationTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	templates := make([]models.NotificationTemplate, 0, len(s.templates))
+	for _, compiled := range s.templates {
+		templates = append(templates, compiled.def)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Event < templates[j].Event
+	})
+	return templates
+}
+
+// GetTemplate returns the template of an event (pointer receiver)
+func (s *NotificationTemplateService) GetTemplate(ctx context.Context, event string) (models.NotificationTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	compiled, exists := s.templates[event]
+	if !exists {
+		return models.NotificationTemplate{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, event)
+	}
+	return compiled.def, nil
+}
+
+// SetTemplate creates or replaces the template of an event. The template
+// must parse and render against sample data before it is stored
+// (pointer receiver).
+func (s *NotificationTemplateService) SetTemplate(ctx context.Context, def models.NotificationTemplate) (models.NotificationTemplate, error) {
+	compiled, err := compileTemplate(def)
+	if err != nil {
+		return models.NotificationTemplate{}, err
+	}
+	if _, err := compiled.render(SampleNotificationData(i18n.DefaultLocale)); err != nil {
+		return models.NotificationTemplate{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := models.Now()
+	_, compiled.def.Builtin = builtinTemplate(def.Event)
+	compiled.def.UpdatedAt = &now
+	s.templates[def.Event] = compiled
+	return compiled.def, nil
+}
+
+// ResetTemplate restores a built-in template to its default or removes a
+// custom one (pointer receiver)
+func (s *NotificationTemplateService) ResetTemplate(ctx context.Context, event string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.templates[event]; !exists {
+		return fmt.Errorf("%w: %s", ErrTemplateNotFound, event)
+	}
+
+	def, builtin := builtinTemplate(event)
+	if !builtin {
+		delete(s.templates, event)
+		return nil
+	}
+	compiled, err := compileTemplate(def)
+	if err != nil {
+		return err
+	}
+	s.templates[event] = compiled
+	return nil
+}
+
+// Render renders the template of an event for one recipient (pointer receiver)
+func (s *NotificationTemplateService) Render(ctx context.Context, event string, data models.NotificationData) (models.RenderedNotification, error) {
+	s.mu.RLock()
+	compiled, exists := s.templates[event]
+	s.mu.RUnlock()
+
+	if !exists {
+		return models.RenderedNotification{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, event)
+	}
+	return compiled.render(data)
+}
+
+// Preview renders a template without storing it (pointer receiver)
+func (s *NotificationTemplateService) Preview(ctx context.Context, def models.NotificationTemplate, data models.NotificationData) (models.RenderedNotification, error) {
+	compiled, err := compileTemplate(def)
+	if err != nil {
+		return models.RenderedNotification{}, err
+	}
+	return compiled.render(data)
+}
+
+// render executes the subject and body templates (pointer receiver)
+func (c *compiledTemplate) render(data models.NotificationData) (models.RenderedNotification, error) {
+	if data.Locale == "" {
+		data.Locale = i18n.DefaultLocale
+	}
+
+	var subject, body strings.Builder
+	if err := c.subject.Execute(&subject, data); err != nil {
+		return models.RenderedNotification{}, fmt.Errorf("render subject: %w", err)
+	}
+	if err := c.body.Execute(&body, data); err != nil {
+		return models.RenderedNotification{}, fmt.Errorf("render body: %w", err)
+	}
+
+	return models.RenderedNotification{
+		Event:   c.def.Event,
+		Locale:  data.Locale,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()),
+	}, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// SampleNotificationData returns placeholder data for previews and for
+// checking templates before they are stored (standalone function)
+func SampleNotificationData(locale string) models.NotificationData {
+	user := models.NewUser("user_sample", "Ada", "Lovelace", "ada@example.com")
+	org := CreateOrganization("org_sample", "Example Org", user.ID)
+	org.SetSlug("example-org")
+
+	return models.NotificationData{
+		Locale: locale,
+		User:   user,
+		Org:    org,
+		Vars: map[string]string{
+			"request_id":  "join_sample",
+			"requester":   user.ID,
+			"message":     "I'd like to help out.",
+			"reason":      "",
+			"inviter":     user.ID,
+			"role":        string(models.MemberRoleMember),
+			"accept_path": "/api/v1/invitations/sample/accept",
+			"expires_at":  "2030-01-01T00:00:00Z",
+		},
+	}
+}
+
+// compileTemplate parses a template's subject and body (standalone function)
+func compileTemplate(def models.NotificationTemplate) (*compiledTemplate, error) {
+	if strings.TrimSpace(def.Event) == "" {
+		return nil, errors.New("event is required")
+	}
+	if strings.TrimSpace(def.Subject) == "" {
+		return nil, errors.New("subject is required")
+	}
+
+	subject, err := template.New(def.Event + ".subject").Funcs(templateFuncs).Option("missingkey=zero").Parse(def.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject: %w", err)
+	}
+	body, err := template.New(def.Event + ".body").Funcs(templateFuncs).Option("missingkey=zero").Parse(def.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body: %w", err)
+	}
+	return &compiledTemplate{def: def, subject: subject, body: body}, nil
+}
+
+// builtinTemplate returns the default template of an event, if it has one
+// (standalone function)
+func builtinTemplate(event string) (models.NotificationTemplate, bool) {
+	for _, def := range defaultNotificationTemplates {
+		if def.Event == event {
+			def.Builtin = true
+			return def, true
+		}
+	}
+	return models.NotificationTemplate{}, false
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+)
+
+// ErrNotificationUserNotFound is returned when preferences or a notification
+// reference a missing user
+var ErrNotificationUserNotFound = errors.New("notification user not found")
+
+// LogNotifier delivers notifications by writing them to the application log.
+// It stands in for a real channel (email, push) until one is configured.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier instance
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// =====================================
+// Pointer Receiver Methods - Notifier Implementation
+// =====================================
+
+// Notify writes the message to the log (pointer receiver)
+func (n *LogNotifier) Notify(ctx context.Context, message string) error {
+	n.logger.Printf("notify %s", message)
+	return nil
+}
+
+// NotifyAsync writes the message to the log without blocking the caller (pointer receiver)
+func (n *LogNotifier) NotifyAsync(ctx context.Context, message string) error {
+	go n.Notify(ctx, message)
+	return nil
+}
+
+// SendEmail writes the email to the log (pointer receiver)
+func (n *LogNotifier) SendEmail(ctx context.Context, to, subject, body string) error {
+	n.logger.Printf("notify channel=email to=%s subject=%q body=%q", to, subject, body)
+	return nil
+}
+
+// SendPush writes the push notification to the log (pointer receiver)
+func (n *LogNotifier) SendPush(ctx context.Context, deviceID, title, body string) error {
+	n.logger.Printf("notify channel=push device=%s title=%q body=%q", deviceID, title, body)
+	return nil
+}
+
+// MultiChannelNotifier fans notifications out to email and push according
+// to each user's stored preferences. Users without preferences get email.
+type MultiChannelNotifier struct {
+	email       interfaces.EmailNotifier
+	push        interfaces.PushNotifier
+	users       *UserService
+	templates   *NotificationTemplateService // Optional; required by NotifyEvent
+	preferences map[string]models.NotificationPreferences
+	mu          sync.RWMutex
+}
+
+// NewMultiChannelNotifier creates a new MultiChannelNotifier instance
+func NewMultiChannelNotifier(email interfaces.EmailNotifier, push interfaces.PushNotifier, users *UserService) *MultiChannelNotifier {
+	return &MultiChannelNotifier{
+		email:       email,
+		push:        push,
+		users:       users,
+		preferences: make(map[string]models.NotificationPreferences),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods - Preferences
+// =====================================
+
+// SetTemplates attaches the templates NotifyEvent renders (pointer receiver)
+func (n *MultiChannelNotifier) SetTemplates(templates *NotificationTemplateService) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.templates = templates
+}
+
+// GetPreferences returns a user's notification preferences, or the
+// defaults if they have not set any (pointer receiver)
+func (n *MultiChannelNotifier) GetPreferences(ctx context.Context, userID string) (models.NotificationPreferences, error) {
+	if _, err := n.users.Read(ctx, userID); err != nil {
+		return models.NotificationPreferences{}, fmt.Errorf("%w: %s", ErrNotificationUserNotFound, userID)
+	}
+	return n.preferencesFor(userID), nil
+}
+
+// SetPreferences validates and stores a user's notification preferences (pointer receiver)
+func (n *MultiChannelNotifier) SetPreferences(ctx context.Context, prefs models.NotificationPreferences) (models.NotificationPreferences, error) {
+	if _, err := n.users.Read(ctx, prefs.UserID); err != nil {
+		return models.NotificationPreferences{}, fmt.Errorf("%w: %s", ErrNotificationUserNotFound, prefs.UserID)
+	}
+	if err := prefs.Validate(); err != nil {
+		return models.NotificationPreferences{}, err
+	}
+
+	now := models.Now()
+	prefs.DeviceIDs = append([]string(nil), prefs.DeviceIDs...)
+	prefs.UpdatedAt = &now
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.preferences[prefs.UserID] = prefs
+	return prefs, nil
+}
+
+// RemovePreferences drops a user's stored preferences and returns how many
+// were removed (pointer receiver)
+func (n *MultiChannelNotifier) RemovePreferences(ctx context.Context, userID string) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.preferences[userID]; !ok {
+		return 0, nil
+	}
+	delete(n.preferences, userID)
+	return 1, nil
+}
+
+// preferencesFor returns the stored preferences or the defaults (pointer receiver)
+func (n *MultiChannelNotifier) preferencesFor(userID string) models.NotificationPreferences {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if prefs, ok := n.preferences[userID]; ok {
+		return prefs
+	}
+	return models.DefaultNotificationPreferences(userID)
+}
+
+// =====================================
+// Pointer Receiver Methods - Notifier Implementation
+// =====================================
+
+// NotifyUser sends a notification on every channel the user has enabled
+// (pointer receiver - implements UserNotifier)
+func (n *MultiChannelNotifier) NotifyUser(ctx context.Context, userID, subject, body string) error {
+	user, err := n.users.Read(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNotificationUserNotFound, userID)
+	}
+	return n.deliver(ctx, user, subject, body)
+}
+
+// NotifyEvent renders the event's template for the user and sends it on
+// every channel the user has enabled. data.User is filled in with the
+// recipient (pointer receiver - implements EventNotifier).
+func (n *MultiChannelNotifier) NotifyEvent(ctx context.Context, userID, event string, data models.NotificationData) error {
+	n.mu.RLock()
+	templates := n.templates
+	n.mu.RUnlock()
+	if templates == nil {
+		return fmt.Errorf("%w: %s", ErrTemplateNotFound, event)
+	}
+
+	user, err := n.users.Read(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNotificationUserNotFound, userID)
+	}
+	data.User = user
+
+	rendered, err := templates.Render(ctx, event, data)
+	if err != nil {
+		return err
+	}
+	return n.deliver(ctx, user, rendered.Subject, rendered.Body)
+}
+
+// deliver sends to each enabled channel. Delivery continues past a failing
+// channel; the errors are joined (pointer receiver).
+func (n *MultiChannelNotifier) deliver(ctx context.Context, user *models.User, subject, body string) error {
+	prefs := n.preferencesFor(user.ID)
+
+	var errs []error
+	for _, channel := range prefs.Channels() {
+		switch channel {
+		case models.ChannelEmail:
+			if user.Email != "" {
+				errs = append(errs, n.SendEmail(ctx, user.Email, subject, body))
+			}
+		case models.ChannelPush:
+			for _, deviceID := range prefs.DeviceIDs {
+				errs = append(errs, n.SendPush(ctx, deviceID, subject, body))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Notify sends a message that has no single recipient through both
+// channels (pointer receiver)
+func (n *MultiChannelNotifier) Notify(ctx context.Context, message string) error {
+	return errors.Join(n.email.Notify(ctx, message), n.push.Notify(ctx, message))
+}
+
+// NotifyAsync sends the message without blocking the caller (pointer receiver)
+func (n *MultiChannelNotifier) NotifyAsync(ctx context.Context, message string) error {
+	go n.Notify(context.WithoutCancel(ctx), message)
+	return nil
+}
+
+// SendEmail sends an email through the email channel (pointer receiver)
+func (n *MultiChannelNotifier) SendEmail(ctx context.Context, to, subject, body string) error {
+	return n.email.SendEmail(ctx, to, subject, body)
+}
+
+// SendPush sends a push notification through the push channel (pointer receiver)
+func (n *MultiChannelNotifier) SendPush(ctx context.Context, deviceID, title, body string) error {
+	return n.push.SendPush(ctx, deviceID, title, body)
+}
+
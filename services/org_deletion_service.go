@@ -0,0 +1,471 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// orgDeletionQueueSize bounds how many deletion jobs may wait to run
+const orgDeletionQueueSize = 100
+
+// ErrOrgDeletionNotFound is returned for an unknown deletion job
+var ErrOrgDeletionNotFound = errors.New("organization deletion not found")
+
+// ErrOrgDeletionOrgNotFound is returned when the organization to delete does not exist
+var ErrOrgDeletionOrgNotFound = errors.New("organization to delete not found")
+
+// ErrOrgDeletionInProgress is returned when an organization already has a queued or running deletion
+var ErrOrgDeletionInProgress = errors.New("organization deletion already in progress")
+
+// ErrOrgDeletionQueueFull is returned when too many deletions are waiting to run
+var ErrOrgDeletionQueueFull = errors.New("organization deletion queue is full")
+
+// OrgDeletionStores are the stores holding organization-scoped data that a
+// cascading delete clears
+type OrgDeletionStores struct {
+	Projects     *ProjectService
+	Tasks        *TaskService
+	Invitations  *InvitationService
+	JoinRequests *JoinRequestService
+	Settings     *OrgSettingsService
+	Changes      *ChangeScheduler
+	Sandboxes    *SandboxService
+}
+
+// orgDeletionStep removes one kind of organization data and reports how
+// many records it removed
+type orgDeletionStep struct {
+	name   string
+	remove func(ctx context.Context, orgID string) (int, error)
+}
+
+// orgDeletionQueue holds queued job IDs: priority-support organizations'
+// jobs ahead of the rest, first in, first out within a tier. Callers hold
+// the service's lock.
+type orgDeletionQueue struct {
+	priority []string
+	standard []string
+}
+
+// OrgDeletionService deletes organizations together with everything
+// scoped to them. Enqueue soft-deletes the organization right away and
+// queues a job; as a background worker between Initialize and Shutdown,
+// the service then runs each job's steps in order, finishing with the
+// organization and its memberships. Queued jobs of priority-support
+// organizations run before standard ones. Delete runs the same job inline. A
+// failed job leaves the organization soft-deleted and may be started
+// again; steps are safe to repeat. Plain soft-deletes and restores also
+// go through the service so neither races a running job.
+type OrgDeletionService struct {
+	idSource // Hands out IDs for created entities
+	jobs     map[string]*models.OrgDeletionJob
+	byOrg    map[string]string // Org ID -> latest job ID
+	orgs     *OrganizationService
+	stores   OrgDeletionStores
+	steps    []orgDeletionStep
+	logger   *log.Logger
+	queue    orgDeletionQueue
+	wake     chan struct{} // Signalled when a job is queued
+	stop     chan struct{}
+	done     chan struct{}
+	mu       sync.Mutex
+}
+
+// NewOrgDeletionService creates a new OrgDeletionService instance
+func NewOrgDeletionService(orgs *OrganizationService, stores OrgDeletionStores, logger *log.Logger) *OrgDeletionService {
+	s := &OrgDeletionService{
+		jobs:   make(map[string]*models.OrgDeletionJob),
+		byOrg:  make(map[string]string),
+		orgs:   orgs,
+		stores: stores,
+		logger: logger,
+		wake:   make(chan struct{}, 1),
+	}
+	s.steps = []orgDeletionStep{
+		{name: "sandboxes", remove: stores.Sandboxes.RemoveOrgSandbox},
+		{name: "scheduled_changes", remove: stores.Changes.RemoveOrgChanges},
+		{name: "join_requests", remove: stores.JoinRequests.RemoveOrgJoinRequests},
+		{name: "invitations", remove: stores.Invitations.RemoveOrgInvitations},
+		{name: "projects", remove: s.removeProjects},
+		{name: "settings", remove: stores.Settings.RemoveOrgSettings},
+		{name: "organization", remove: s.removeOrg},
+	}
+	return s
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Initialize starts the background loop (pointer receiver)
+func (s *OrgDeletionService) Initialize(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return errors.New("organization deletion worker already running")
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(s.stop, s.done)
+	return nil
+}
+
+// Shutdown stops the loop and waits for a running job to finish; queued
+// jobs stay queued (pointer receiver)
+func (s *OrgDeletionService) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run processes queued jobs, highest tier first, until stopped (pointer receiver)
+func (s *OrgDeletionService) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-s.wake:
+		}
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			s.mu.Lock()
+			id, ok := s.queue.pop()
+			s.mu.Unlock()
+			if !ok {
+				break
+			}
+			s.process(context.Background(), id)
+		}
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Enqueue soft-deletes an organization and queues the job that removes it
+// and its data (pointer receiver)
+func (s *OrgDeletionService) Enqueue(ctx context.Context, orgID, requestedBy string) (*models.OrgDeletionJob, error) {
+	job, err := s.start(ctx, orgID, requestedBy, true)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Printf("event=org.deletion_queued job=%s org=%s actor=%s", job.ID, orgID, requestedBy)
+	return job, nil
+}
+
+// Delete soft-deletes an organization and removes it and its data before
+// returning, for callers that already run in the background such as the
+// retention janitor. It returns the finished job and fails if any step
+// did (pointer receiver).
+func (s *OrgDeletionService) Delete(ctx context.Context, orgID, requestedBy string) (*models.OrgDeletionJob, error) {
+	job, err := s.start(ctx, orgID, requestedBy, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.process(ctx, job.ID)
+	job, err = s.ReadJob(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == models.OrgDeletionFailed {
+		return job, fmt.Errorf("organization deletion %s failed: %s", job.ID, job.Error)
+	}
+	return job, nil
+}
+
+// SoftDelete marks an organization deleted without touching its data, so
+// it can still be restored; the retention janitor later hands it to
+// Delete. org is the organization as the caller read it, and a stale
+// version fails with ErrVersionConflict (pointer receiver).
+func (s *OrgDeletionService) SoftDelete(ctx context.Context, org *models.Organization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkIdle(org.ID); err != nil {
+		return err
+	}
+	deleted := *org
+	deleted.Deactivate()
+	return s.orgs.WriteOrg(ctx, &deleted)
+}
+
+// Restore brings back a soft-deleted organization unless a deletion job
+// is removing it, and returns the restored organization (pointer receiver)
+func (s *OrgDeletionService) Restore(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkIdle(org.ID); err != nil {
+		return nil, err
+	}
+	restored := *org
+	restored.Activate()
+	if err := s.orgs.WriteOrg(ctx, &restored); err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+// ReadJob retrieves a deletion job (pointer receiver)
+func (s *OrgDeletionService) ReadJob(ctx context.Context, id string) (*models.OrgDeletionJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrOrgDeletionNotFound, id)
+	}
+	return copyOrgDeletionJob(job), nil
+}
+
+// ReadOrgJob retrieves the latest deletion job of an organization (pointer receiver)
+func (s *OrgDeletionService) ReadOrgJob(ctx context.Context, orgID string) (*models.OrgDeletionJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, exists := s.byOrg[orgID]
+	if !exists {
+		return nil, fmt.Errorf("%w: organization %s", ErrOrgDeletionNotFound, orgID)
+	}
+	return copyOrgDeletionJob(s.jobs[id]), nil
+}
+
+// ListJobs lists every deletion job, including finished ones (pointer receiver)
+func (s *OrgDeletionService) ListJobs(ctx context.Context) []models.OrgDeletionJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]models.OrgDeletionJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *copyOrgDeletionJob(job))
+	}
+	return jobs
+}
+
+// start soft-deletes an organization and records a pending job for it,
+// queueing the job when queued is set. The queue is checked before the
+// organization is touched, so a full queue leaves it as it was (pointer
+// receiver).
+func (s *OrgDeletionService) start(ctx context.Context, orgID, requestedBy string, queued bool) (*models.OrgDeletionJob, error) {
+	org, err := s.orgs.ReadOrgIncludingDeleted(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrOrgDeletionOrgNotFound, orgID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkIdle(orgID); err != nil {
+		return nil, err
+	}
+	if queued && s.queue.len() >= orgDeletionQueueSize {
+		return nil, ErrOrgDeletionQueueFull
+	}
+
+	if !org.IsDeleted() {
+		deleted := *org
+		deleted.Deactivate()
+		if err := s.orgs.WriteOrg(ctx, &deleted); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, len(s.steps))
+	for i, step := range s.steps {
+		names[i] = step.name
+	}
+	job := models.NewOrgDeletionJob(s.NewID(IDPrefixOrgDeletion), orgID, requestedBy, names)
+	job.Tier = org.Support.Tier
+	s.jobs[job.ID] = job
+	s.byOrg[orgID] = job.ID
+	if queued {
+		s.queue.push(job.ID, job.Tier)
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return copyOrgDeletionJob(job), nil
+}
+
+// checkIdle fails with ErrOrgDeletionInProgress while an organization has
+// a pending or running job; callers hold s.mu (pointer receiver)
+func (s *OrgDeletionService) checkIdle(orgID string) error {
+	if id, exists := s.byOrg[orgID]; exists && !s.jobs[id].IsDone() {
+		return fmt.Errorf("%w: %s", ErrOrgDeletionInProgress, id)
+	}
+	return nil
+}
+
+// process runs a job's steps in order, stopping at the first failure
+// (pointer receiver)
+func (s *OrgDeletionService) process(ctx context.Context, id string) {
+	s.update(id, func(job *models.OrgDeletionJob) {
+		now := models.Now()
+		job.Status = models.OrgDeletionRunning
+		job.StartedAt = &now
+	})
+
+	s.mu.Lock()
+	orgID := s.jobs[id].OrgID
+	s.mu.Unlock()
+
+	for i, step := range s.steps {
+		s.update(id, func(job *models.OrgDeletionJob) {
+			job.Steps[i].Status = models.OrgDeletionRunning
+		})
+
+		deleted, err := step.remove(ctx, orgID)
+		s.update(id, func(job *models.OrgDeletionJob) {
+			job.Steps[i].Deleted = deleted
+			job.Steps[i].Status = models.OrgDeletionCompleted
+			if err != nil {
+				job.Steps[i].Status = models.OrgDeletionFailed
+				job.Steps[i].Error = err.Error()
+			}
+		})
+
+		if err != nil {
+			s.finish(id, fmt.Errorf("%s: %w", step.name, err))
+			return
+		}
+	}
+	s.finish(id, nil)
+}
+
+// finish marks a job completed or failed (pointer receiver)
+func (s *OrgDeletionService) finish(id string, err error) {
+	s.update(id, func(job *models.OrgDeletionJob) {
+		now := models.Now()
+		job.CompletedAt = &now
+		job.Status = models.OrgDeletionCompleted
+		if err != nil {
+			job.Status = models.OrgDeletionFailed
+			job.Error = err.Error()
+		}
+	})
+
+	job, _ := s.ReadJob(context.Background(), id)
+	if err != nil {
+		s.logger.Printf("event=org.deletion_failed job=%s org=%s error=%q", id, job.OrgID, err)
+		return
+	}
+	s.logger.Printf("event=org.deletion_completed job=%s org=%s duration=%s", id, job.OrgID, job.CompletedAt.Sub(*job.StartedAt))
+}
+
+// update applies a change to a stored job (pointer receiver)
+func (s *OrgDeletionService) update(id string, change func(job *models.OrgDeletionJob)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[id]
+	change(job)
+	job.UpdatedAt = models.Now()
+}
+
+// removeProjects deletes an organization's projects, archived ones
+// included, together with their tasks (pointer receiver)
+func (s *OrgDeletionService) removeProjects(ctx context.Context, orgID string) (int, error) {
+	projects, err := s.stores.Projects.ReadAllProjectsIncludingDeleted(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, project := range projects {
+		if project.OrgID != orgID {
+			continue
+		}
+		if _, err := s.stores.Tasks.RemoveProjectTasks(ctx, project.ID); err != nil {
+			return removed, err
+		}
+		if err := s.stores.Projects.DeleteProject(ctx, project.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// removeOrg hard-deletes the organization and its memberships (pointer receiver)
+func (s *OrgDeletionService) removeOrg(ctx context.Context, orgID string) (int, error) {
+	if _, err := s.orgs.ReadOrgIncludingDeleted(ctx, orgID); err != nil {
+		return 0, nil
+	}
+	if err := s.orgs.DeleteOrg(ctx, orgID); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// copyOrgDeletionJob copies a job so callers never share its steps (standalone function)
+func copyOrgDeletionJob(job *models.OrgDeletionJob) *models.OrgDeletionJob {
+	copied := *job
+	copied.Steps = append([]models.OrgDeletionStep(nil), job.Steps...)
+	return &copied
+}
+
+// =====================================
+// Pointer Receiver Methods on orgDeletionQueue
+// =====================================
+
+// push queues a job behind the others of its tier (pointer receiver)
+func (q *orgDeletionQueue) push(id string, tier models.SupportTier) {
+	if tier == models.SupportTierPriority {
+		q.priority = append(q.priority, id)
+		return
+	}
+	q.standard = append(q.standard, id)
+}
+
+// pop removes and returns the next job to run (pointer receiver)
+func (q *orgDeletionQueue) pop() (string, bool) {
+	for _, ids := range []*[]string{&q.priority, &q.standard} {
+		if len(*ids) > 0 {
+			id := (*ids)[0]
+			*ids = (*ids)[1:]
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// len returns how many jobs are queued (pointer receiver)
+func (q *orgDeletionQueue) len() int {
+	return len(q.priority) + len(q.standard)
+}
+
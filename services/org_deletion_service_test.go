@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// testDeletions is an OrgDeletionService over fresh stores; its worker is
+// not started, so queued jobs stay queued
+type testDeletions struct {
+	*OrgDeletionService
+	users    *UserService
+	orgs     *OrganizationService
+	projects *ProjectService
+	tasks    *TaskService
+}
+
+// newTestDeletions wires an OrgDeletionService the way the application does
+func newTestDeletions(t *testing.T) *testDeletions {
+	t.Helper()
+	logger := log.New(io.Discard, "", 0)
+	users := NewUserService()
+	orgs := NewOrganizationService()
+	projects := NewProjectService(orgs)
+	tasks := NewTaskService(projects)
+	projects.SetPurgeHook(func(ctx context.Context, id string) error {
+		_, err := tasks.RemoveProjectTasks(ctx, id)
+		return err
+	})
+	notifier := NewMultiChannelNotifier(NewLogNotifier(logger), NewLogNotifier(logger), users)
+
+	deletions := NewOrgDeletionService(orgs, OrgDeletionStores{
+		Projects:     projects,
+		Tasks:        tasks,
+		Invitations:  NewInvitationService(orgs, users, notifier),
+		JoinRequests: NewJoinRequestService(orgs, notifier),
+		Settings:     NewOrgSettingsService(orgs),
+		Changes:      NewChangeScheduler(users, orgs, SchedulerConfig{}, logger),
+		Sandboxes:    NewSandboxService(users, orgs, projects),
+	}, logger)
+	return &testDeletions{OrgDeletionService: deletions, users: users, orgs: orgs, projects: projects, tasks: tasks}
+}
+
+// writeTestOrg stores a live organization
+func writeTestOrg(t *testing.T, orgs *OrganizationService, id string) *models.Organization {
+	t.Helper()
+	org := models.NewOrganization(id, "Org "+id, "owner_"+id)
+	if err := orgs.WriteOrg(context.Background(), org); err != nil {
+		t.Fatalf("WriteOrg(%s): %v", id, err)
+	}
+	return org
+}
+
+func TestOrgDeletionEnqueueFullQueueLeavesOrgLive(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDeletions(t)
+
+	for i := 0; i < orgDeletionQueueSize; i++ {
+		id := fmt.Sprintf("org_%d", i)
+		writeTestOrg(t, d.orgs, id)
+		if _, err := d.Enqueue(ctx, id, "tester"); err != nil {
+			t.Fatalf("Enqueue(%s): %v", id, err)
+		}
+	}
+
+	writeTestOrg(t, d.orgs, "org_overflow")
+	if _, err := d.Enqueue(ctx, "org_overflow", "tester"); !errors.Is(err, ErrOrgDeletionQueueFull) {
+		t.Fatalf("Enqueue on a full queue: err = %v, want ErrOrgDeletionQueueFull", err)
+	}
+	if _, err := d.orgs.ReadOrg(ctx, "org_overflow"); err != nil {
+		t.Errorf("organization was soft-deleted although its job was refused: %v", err)
+	}
+	if _, err := d.ReadOrgJob(ctx, "org_overflow"); !errors.Is(err, ErrOrgDeletionNotFound) {
+		t.Errorf("ReadOrgJob: err = %v, want ErrOrgDeletionNotFound", err)
+	}
+}
+
+func TestOrgDeletionDeleteRemovesOrgAndProjects(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDeletions(t)
+
+	writeTestOrg(t, d.orgs, "org_a")
+	writeTestOrg(t, d.orgs, "org_b")
+	for _, project := range []*models.Project{
+		CreateProject("proj_a", "Alpha", "owner_org_a", "org_a"),
+		CreateProject("proj_b", "Beta", "owner_org_b", "org_b"),
+	} {
+		if err := d.projects.WriteProject(ctx, project); err != nil {
+			t.Fatalf("WriteProject(%s): %v", project.ID, err)
+		}
+	}
+
+	job, err := d.Delete(ctx, "org_a", "tester")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if job.Status != models.OrgDeletionCompleted {
+		t.Errorf("job status = %s, want %s", job.Status, models.OrgDeletionCompleted)
+	}
+	if _, err := d.orgs.ReadOrgIncludingDeleted(ctx, "org_a"); err == nil {
+		t.Error("org_a still stored after Delete")
+	}
+	if _, err := d.projects.ReadProjectIncludingDeleted(ctx, "proj_a"); err == nil {
+		t.Error("proj_a outlived its organization")
+	}
+	if _, err := d.projects.ReadProject(ctx, "proj_b"); err != nil {
+		t.Errorf("proj_b of another organization was removed: %v", err)
+	}
+}
+
+func TestOrgDeletionRestoreRefusedWhileJobPending(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDeletions(t)
+
+	writeTestOrg(t, d.orgs, "org_a")
+	if _, err := d.Enqueue(ctx, "org_a", "tester"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	org, err := d.orgs.ReadOrgIncludingDeleted(ctx, "org_a")
+	if err != nil {
+		t.Fatalf("ReadOrgIncludingDeleted: %v", err)
+	}
+	if _, err := d.Restore(ctx, org); !errors.Is(err, ErrOrgDeletionInProgress) {
+		t.Fatalf("Restore: err = %v, want ErrOrgDeletionInProgress", err)
+	}
+	if _, err := d.orgs.ReadOrg(ctx, "org_a"); err == nil {
+		t.Error("org_a is live although its deletion is queued")
+	}
+}
+
+func TestOrgDeletionRunsPriorityJobsFirst(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDeletions(t)
+
+	order := []string{"org_std_a", "org_pri_a", "org_std_b", "org_pri_b"}
+	for _, id := range order {
+		org := writeTestOrg(t, d.orgs, id)
+		if strings.HasPrefix(id, "org_pri") {
+			org.SetSupport(models.OrgSupport{Tier: models.SupportTierPriority})
+			if err := d.orgs.WriteOrg(ctx, org); err != nil {
+				t.Fatalf("WriteOrg(%s): %v", id, err)
+			}
+		}
+		if _, err := d.Enqueue(ctx, id, "tester"); err != nil {
+			t.Fatalf("Enqueue(%s): %v", id, err)
+		}
+	}
+
+	if err := d.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer d.Shutdown(ctx)
+
+	jobs := make([]*models.OrgDeletionJob, 0, len(order))
+	deadline := time.Now().Add(5 * time.Second)
+	for _, id := range order {
+		for {
+			job, err := d.ReadOrgJob(ctx, id)
+			if err != nil {
+				t.Fatalf("ReadOrgJob(%s): %v", id, err)
+			}
+			if job.IsDone() {
+				jobs = append(jobs, job)
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job for %s still %s", id, job.Status)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartedAt.Before(*jobs[j].StartedAt) })
+	got := make([]string, len(jobs))
+	for i, job := range jobs {
+		got[i] = job.OrgID
+	}
+	want := []string{"org_pri_a", "org_pri_b", "org_std_a", "org_std_b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("run order = %v, want %v", got, want)
+	}
+	if jobs[0].Tier != models.SupportTierPriority {
+		t.Errorf("job tier = %q, want %q", jobs[0].Tier, models.SupportTierPriority)
+	}
+}
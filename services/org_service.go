@@ -4,77 +4,145 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
-	"time"
 
+	"github.com/test-repo-golang-support/interfaces"
 	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
 )
 
+// ErrSlugTaken is returned when another organization already uses a slug
+var ErrSlugTaken = errors.New("slug already in use")
+
+// ErrMembershipNotFound is returned when a user is not a member of an organization
+var ErrMembershipNotFound = errors.New("membership not found")
+
+// ErrMembershipExists is returned when a user is already a member of an organization
+var ErrMembershipExists = errors.New("membership already exists")
+
 // OrganizationService handles organization-related operations
 type OrganizationService struct {
+	idSource    // Hands out IDs for created entities
 	orgs        map[string]*models.Organization
-	memberships map[string]*models.Membership // key: "userID:orgID"
+	memberships map[string]*models.Membership      // key: "userID:orgID"
+	indexer     interfaces.Indexable               // Optional search index kept in sync on writes
+	enums       *EnumService                       // Optional registry validating sizes and roles
+	revision    uint64                             // Bumped on every organization write or delete
+	meter       *storeMeter                        // Approximate memory accounting and limits
+	bySlug      *slugIndex                         // Organizations by public slug
+	snapshot    snapshotCache[models.Organization] // Copy-on-write view served to list reads
 	mu          sync.RWMutex
 }
 
+// OrgStats counts the organizations and memberships held by an
+// OrganizationService
+type OrgStats struct {
+	Total       int `json:"total"`
+	Deleted     int `json:"deleted"` // Soft-deleted organizations, included in Total
+	Memberships int `json:"memberships"`
+}
+
 // NewOrganizationService creates a new OrganizationService instance
 func NewOrganizationService() *OrganizationService {
 	return &OrganizationService{
 		orgs:        make(map[string]*models.Organization),
 		memberships: make(map[string]*models.Membership),
+		meter:       newStoreMeter("organizations"),
+		bySlug:      newSlugIndex(),
 	}
 }
 
 // =====================================
-// Value Receiver Methods on OrganizationService
+// Pointer Receiver Methods - OrgReader Implementation
 // =====================================
 
-// Count returns the number of organizations (value receiver)
-func (s OrganizationService) Count() int {
-	return len(s.orgs)
-}
-
-// HasOrgs checks if there are any organizations (value receiver)
-func (s OrganizationService) HasOrgs() bool {
-	return len(s.orgs) > 0
-}
-
-// IsEmpty checks if the service has no organizations (value receiver)
-func (s OrganizationService) IsEmpty() bool {
-	return len(s.orgs) == 0
+// ReadOrg retrieves an organization by ID, ignoring soft-deleted ones (pointer receiver)
+func (s *OrganizationService) ReadOrg(ctx context.Context, id string) (*models.Organization, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ReadOrg")()
+	return s.readOrg(id, false)
 }
 
-// MembershipCount returns total membership count (value receiver)
-func (s OrganizationService) MembershipCount() int {
-	return len(s.memberships)
+// ReadOrgIncludingDeleted retrieves an organization by ID even if soft-deleted (pointer receiver)
+func (s *OrganizationService) ReadOrgIncludingDeleted(ctx context.Context, id string) (*models.Organization, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ReadOrgIncludingDeleted")()
+	return s.readOrg(id, true)
 }
 
-// =====================================
-// Pointer Receiver Methods - OrgReader Implementation
-// =====================================
-
-// ReadOrg retrieves an organization by ID (pointer receiver)
-func (s *OrganizationService) ReadOrg(ctx context.Context, id string) (*models.Organization, error) {
+// readOrg looks up an organization, optionally including soft-deleted ones (pointer receiver)
+func (s *OrganizationService) readOrg(id string, includeDeleted bool) (*models.Organization, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	org, exists := s.orgs[id]
-	if !exists {
+	if !exists || (org.IsDeleted() && !includeDeleted) {
 		return nil, errors.New("organization not found")
 	}
 	return org, nil
 }
 
-// ReadAllOrgs retrieves all organizations (pointer receiver)
+// ReadAllOrgs retrieves all organizations that are not soft-deleted (pointer receiver)
 func (s *OrganizationService) ReadAllOrgs(ctx context.Context) (models.OrgList, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ReadAllOrgs")()
+	orgs, _ := s.readOrgsPage(false, PageRequest{})
+	return orgs, nil
+}
 
-	orgs := make(models.OrgList, 0, len(s.orgs))
+// ReadAllOrgsIncludingDeleted retrieves all organizations, including soft-deleted ones (pointer receiver)
+func (s *OrganizationService) ReadAllOrgsIncludingDeleted(ctx context.Context) (models.OrgList, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ReadAllOrgsIncludingDeleted")()
+	orgs, _ := s.readOrgsPage(true, PageRequest{})
+	return orgs, nil
+}
+
+// ReadOrgsPage retrieves one page of organizations ordered by ID,
+// optionally including soft-deleted ones, and the ID the next page starts
+// after, or "" on the last page (pointer receiver)
+func (s *OrganizationService) ReadOrgsPage(ctx context.Context, includeDeleted bool, page PageRequest) (models.OrgList, string, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ReadOrgsPage")()
+	orgs, next := s.readOrgsPage(includeDeleted, page)
+	return orgs, next, nil
+}
+
+// readOrgsPage copies a page of organizations, optionally including
+// soft-deleted ones, out of the cached snapshot, so no lock is held while
+// copying (pointer receiver)
+func (s *OrganizationService) readOrgsPage(includeDeleted bool, page PageRequest) (models.OrgList, string) {
+	snapshot := s.snapshot.load(&s.mu, s.buildSnapshot)
+	return pageAfter(snapshot.items, func(org models.Organization) string { return org.ID }, func(org models.Organization) bool {
+		return includeDeleted || !org.IsDeleted()
+	}, page)
+}
+
+// Snapshot returns an immutable snapshot of every organization, including
+// soft-deleted ones, ordered by ID. It is shared until the next write, so
+// repeated calls between writes cost nothing (pointer receiver).
+func (s *OrganizationService) Snapshot(ctx context.Context) *Snapshot[models.Organization] {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.Snapshot")()
+	return s.snapshot.load(&s.mu, s.buildSnapshot)
+}
+
+// buildSnapshot copies every organization into a new snapshot; callers
+// hold s.mu (pointer receiver)
+func (s *OrganizationService) buildSnapshot() *Snapshot[models.Organization] {
+	orgs := make([]models.Organization, 0, len(s.orgs))
 	for _, org := range s.orgs {
-		orgs = append(orgs, *org)
+		o := *org
+		o.DeletedAt = cloneTime(org.DeletedAt)
+		orgs = append(orgs, o)
 	}
-	return orgs, nil
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].ID < orgs[j].ID
+	})
+	return newSnapshot(models.Now(), orgs)
+}
+
+// IterateOrgs calls fn with a copy of each organization, including
+// soft-deleted ones, stopping at the first error returned by fn. It iterates
+// a snapshot, so fn may be slow without blocking writers (pointer receiver).
+func (s *OrganizationService) IterateOrgs(ctx context.Context, fn func(models.Organization) error) error {
+	return s.Snapshot(ctx).Each(ctx, fn)
 }
 
 // ReadOrgsByOwner retrieves organizations by owner ID (pointer receiver)
@@ -84,7 +152,7 @@ func (s *OrganizationService) ReadOrgsByOwner(ctx context.Context, ownerID strin
 
 	orgs := make(models.OrgList, 0)
 	for _, org := range s.orgs {
-		if org.OwnerID == ownerID {
+		if org.OwnerID == ownerID && !org.IsDeleted() {
 			orgs = append(orgs, *org)
 		}
 	}
@@ -97,25 +165,92 @@ func (s *OrganizationService) ReadOrgsByOwner(ctx context.Context, ownerID strin
 
 // WriteOrg creates or updates an organization (pointer receiver)
 func (s *OrganizationService) WriteOrg(ctx context.Context, org *models.Organization) error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.WriteOrg")()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeOrg(ctx, org)
+}
+
+// WriteOrgs creates or updates organizations under a single lock
+// acquisition. The returned slice holds the error for the organization at
+// the same index, or nil (pointer receiver - implements OrgBatchWriter).
+func (s *OrganizationService) WriteOrgs(ctx context.Context, orgs []*models.Organization) []error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.WriteOrgs")()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	errs := make([]error, len(orgs))
+	for i, org := range orgs {
+		errs[i] = s.writeOrg(ctx, org)
+	}
+	return errs
+}
+
+// writeOrg stores one organization; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) writeOrg(ctx context.Context, org *models.Organization) error {
 	if org.ID == "" {
 		return errors.New("organization ID is required")
 	}
+	if s.enums != nil && org.Size != "" {
+		if err := s.enums.Validate(models.EnumKindOrgSize, string(org.Size)); err != nil {
+			return err
+		}
+	}
+	if existing, exists := s.orgs[org.ID]; exists && existing.Version != org.Version {
+		return ErrVersionConflict
+	}
+	if err := s.bySlug.check(org.ID, org.Slug); err != nil {
+		return err
+	}
+
+	size := approxOrgBytes(org)
+	if err := s.meter.admit(org.ID, size, s.evictionCandidates, func(id string) {
+		s.evict(ctx, id)
+	}); err != nil {
+		return err
+	}
+
+	org.IncrementVersion()
 	s.orgs[org.ID] = org
+	s.meter.record(org.ID, size)
+	s.bySlug.set(org.ID, org.Slug)
+	s.revision++
+	s.snapshot.invalidate()
+
+	if s.indexer != nil {
+		if err := s.indexer.Index(ctx, org.ID, org); err != nil {
+			return fmt.Errorf("failed to index organization: %w", err)
+		}
+	}
 	return nil
 }
 
 // DeleteOrg removes an organization (pointer receiver)
 func (s *OrganizationService) DeleteOrg(ctx context.Context, id string) error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.DeleteOrg")()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if _, exists := s.orgs[id]; !exists {
 		return errors.New("organization not found")
 	}
+	s.removeOrg(id)
+
+	if s.indexer != nil {
+		if err := s.indexer.DeleteIndex(ctx, id); err != nil {
+			return fmt.Errorf("failed to remove organization from index: %w", err)
+		}
+	}
+	return nil
+}
+
+// removeOrg deletes an organization and its memberships; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) removeOrg(id string) {
 	delete(s.orgs, id)
+	s.meter.forget(id)
+	s.bySlug.remove(id)
+	s.revision++
+	s.snapshot.invalidate()
 
 	// Also remove all memberships for this org
 	for key, m := range s.memberships {
@@ -123,26 +258,105 @@ func (s *OrganizationService) DeleteOrg(ctx context.Context, id string) error {
 			delete(s.memberships, key)
 		}
 	}
-	return nil
+}
+
+// SetLimits caps the store; writes beyond the limits follow the policy (pointer receiver)
+func (s *OrganizationService) SetLimits(limits StoreLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meter.setLimits(limits)
+}
+
+// StoreStats reports the approximate memory use of the organization store (pointer receiver)
+func (s *OrganizationService) StoreStats() StoreStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.meter.stats()
+}
+
+// Stats counts the stored organizations, soft-deleted ones included, and
+// their memberships (pointer receiver)
+func (s *OrganizationService) Stats() OrgStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := OrgStats{Total: len(s.orgs), Memberships: len(s.memberships)}
+	for _, org := range s.orgs {
+		if org.IsDeleted() {
+			stats.Deleted++
+		}
+	}
+	return stats
+}
+
+// evictionCandidates lists soft-deleted organizations; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) evictionCandidates() []evictionCandidate {
+	var candidates []evictionCandidate
+	for id, org := range s.orgs {
+		if org.IsDeleted() {
+			candidates = append(candidates, evictionCandidate{id: id, deletedAt: *org.DeletedAt})
+		}
+	}
+	return candidates
+}
+
+// evict hard-deletes a soft-deleted organization to make room; callers
+// hold s.mu. Index errors are ignored since the organization is already
+// hidden from reads (pointer receiver).
+func (s *OrganizationService) evict(ctx context.Context, id string) {
+	s.removeOrg(id)
+	if s.indexer != nil {
+		_ = s.indexer.DeleteIndex(ctx, id)
+	}
+}
+
+// Revision returns a counter that changes whenever any organization is
+// written or deleted, letting caches derived from organizations detect
+// staleness cheaply (pointer receiver)
+func (s *OrganizationService) Revision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.revision
+}
+
+// SetIndexer attaches a search index updated on every WriteOrg and DeleteOrg (pointer receiver)
+func (s *OrganizationService) SetIndexer(indexer interfaces.Indexable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexer = indexer
+}
+
+// SetEnums attaches the enum registry used to validate organization sizes
+// and member roles; without one any value is accepted (pointer receiver)
+func (s *OrganizationService) SetEnums(enums *EnumService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enums = enums
 }
 
 // =====================================
 // Pointer Receiver Methods - OrgRepository Implementation
 // =====================================
 
-// CountOrgs returns total organization count (pointer receiver)
+// CountOrgs returns the count of non-deleted organizations (pointer receiver)
 func (s *OrganizationService) CountOrgs(ctx context.Context) (int, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.orgs), nil
+	count := 0
+	for _, org := range s.orgs {
+		if !org.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
 }
 
-// OrgExists checks if an organization exists (pointer receiver)
+// OrgExists checks if a non-deleted organization exists (pointer receiver)
 func (s *OrganizationService) OrgExists(ctx context.Context, id string) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	_, exists := s.orgs[id]
-	return exists, nil
+	org, exists := s.orgs[id]
+	return exists && !org.IsDeleted(), nil
 }
 
 // =====================================
@@ -156,39 +370,99 @@ func membershipKey(userID, orgID string) string {
 
 // AddMember adds a member to an organization (pointer receiver)
 func (s *OrganizationService) AddMember(ctx context.Context, membership *models.Membership) error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.AddMember")()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addMember(membership)
+}
+
+// AddMembers adds memberships under a single lock acquisition. The returned
+// slice holds the error for the membership at the same index, or nil
+// (pointer receiver - implements OrgBatchWriter).
+func (s *OrganizationService) AddMembers(ctx context.Context, memberships []*models.Membership) []error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.AddMembers")()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	errs := make([]error, len(memberships))
+	for i, membership := range memberships {
+		errs[i] = s.addMember(membership)
+	}
+	return errs
+}
+
+// addMember stores one membership; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) addMember(membership *models.Membership) error {
 	// Verify organization exists
-	if _, exists := s.orgs[membership.OrgID]; !exists {
+	if org, exists := s.orgs[membership.OrgID]; !exists || org.IsDeleted() {
 		return errors.New("organization not found")
 	}
 
+	if s.enums != nil {
+		if err := s.enums.Validate(models.EnumKindMemberRole, string(membership.Role)); err != nil {
+			return err
+		}
+	}
+
 	key := membershipKey(membership.UserID, membership.OrgID)
 	if _, exists := s.memberships[key]; exists {
-		return errors.New("membership already exists")
+		return ErrMembershipExists
 	}
 
 	s.memberships[key] = membership
 	return nil
 }
 
+// ValidateMemberRole checks a role against the member role enumeration (pointer receiver)
+func (s *OrganizationService) ValidateMemberRole(role models.MemberRole) error {
+	s.mu.RLock()
+	enums := s.enums
+	s.mu.RUnlock()
+
+	if enums == nil {
+		return nil
+	}
+	return enums.Validate(models.EnumKindMemberRole, string(role))
+}
+
 // RemoveMember removes a member from an organization (pointer receiver)
 func (s *OrganizationService) RemoveMember(ctx context.Context, userID, orgID string) error {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.RemoveMember")()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.removeMember(userID, orgID)
+}
 
+// removeMember deletes one membership; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) removeMember(userID, orgID string) error {
 	key := membershipKey(userID, orgID)
 	if _, exists := s.memberships[key]; !exists {
-		return errors.New("membership not found")
+		return ErrMembershipNotFound
 	}
 
 	delete(s.memberships, key)
 	return nil
 }
 
+// RemoveUserMemberships removes every membership held by a user and
+// returns how many were removed (pointer receiver)
+func (s *OrganizationService) RemoveUserMemberships(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for key, membership := range s.memberships {
+		if membership.UserID == userID {
+			delete(s.memberships, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
 // GetMembers retrieves all members of an organization (pointer receiver)
 func (s *OrganizationService) GetMembers(ctx context.Context, orgID string) ([]*models.Membership, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.GetMembers")()
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -203,13 +477,14 @@ func (s *OrganizationService) GetMembers(ctx context.Context, orgID string) ([]*
 
 // GetMembership retrieves a specific membership (pointer receiver)
 func (s *OrganizationService) GetMembership(ctx context.Context, userID, orgID string) (*models.Membership, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.GetMembership")()
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	key := membershipKey(userID, orgID)
 	membership, exists := s.memberships[key]
 	if !exists {
-		return nil, errors.New("membership not found")
+		return nil, ErrMembershipNotFound
 	}
 	return membership, nil
 }
@@ -218,17 +493,61 @@ func (s *OrganizationService) GetMembership(ctx context.Context, userID, orgID s
 func (s *OrganizationService) UpdateMemberRole(ctx context.Context, userID, orgID string, role models.MemberRole) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.updateMemberRole(userID, orgID, role)
+}
 
+// updateMemberRole changes one member's role; callers hold s.mu (pointer receiver)
+func (s *OrganizationService) updateMemberRole(userID, orgID string, role models.MemberRole) error {
 	key := membershipKey(userID, orgID)
 	membership, exists := s.memberships[key]
 	if !exists {
-		return errors.New("membership not found")
+		return ErrMembershipNotFound
+	}
+
+	if s.enums != nil {
+		if err := s.enums.Validate(models.EnumKindMemberRole, string(role)); err != nil {
+			return err
+		}
 	}
 
 	membership.ChangeRole(role)
 	return nil
 }
 
+// ApplyMemberOperations adds, removes, and changes the role of members of
+// one organization under a single lock acquisition, in order. Each
+// operation stands alone: a failing one does not stop or undo the rest.
+// The returned slice holds the error for the operation at the same index,
+// or nil; added memberships are returned at their index (pointer receiver).
+func (s *OrganizationService) ApplyMemberOperations(ctx context.Context, orgID string, ops []models.MemberOperation) ([]*models.Membership, []error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.ApplyMemberOperations")()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	memberships := make([]*models.Membership, len(ops))
+	errs := make([]error, len(ops))
+	for i, op := range ops {
+		switch op.Op {
+		case models.MemberOperationAdd:
+			membership := CreateMembership(op.UserID, orgID, op.Role)
+			if errs[i] = s.addMember(membership); errs[i] == nil {
+				copied := *membership
+				memberships[i] = &copied
+			}
+		case models.MemberOperationRemove:
+			errs[i] = s.removeMember(op.UserID, orgID)
+		case models.MemberOperationChangeRole:
+			if errs[i] = s.updateMemberRole(op.UserID, orgID, op.Role); errs[i] == nil {
+				copied := *s.memberships[membershipKey(op.UserID, orgID)]
+				memberships[i] = &copied
+			}
+		default:
+			errs[i] = fmt.Errorf("unknown operation %q", op.Op)
+		}
+	}
+	return memberships, errs
+}
+
 // =====================================
 // Additional Pointer Receiver Methods
 // =====================================
@@ -239,13 +558,27 @@ func (s *OrganizationService) FindOrgByName(ctx context.Context, name string) (*
 	defer s.mu.RUnlock()
 
 	for _, org := range s.orgs {
-		if org.Name == name {
+		if org.Name == name && !org.IsDeleted() {
 			return org, nil
 		}
 	}
 	return nil, errors.New("organization not found")
 }
 
+// FindOrgBySlug finds a non-deleted organization by its public slug
+// through the slug index (pointer receiver)
+func (s *OrganizationService) FindOrgBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.FindOrgBySlug")()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.bySlug.lookup(slug)
+	if org := s.orgs[id]; exists && org != nil && !org.IsDeleted() {
+		return org, nil
+	}
+	return nil, errors.New("organization not found")
+}
+
 // FindOrgsByIndustry finds organizations by industry (pointer receiver)
 func (s *OrganizationService) FindOrgsByIndustry(ctx context.Context, industry string) (models.OrgList, error) {
 	s.mu.RLock()
@@ -253,10 +586,38 @@ func (s *OrganizationService) FindOrgsByIndustry(ctx context.Context, industry s
 
 	orgs := make(models.OrgList, 0)
 	for _, org := range s.orgs {
-		if org.Industry == industry {
+		if org.Industry == industry && !org.IsDeleted() {
+			orgs = append(orgs, *org)
+		}
+	}
+	return orgs, nil
+}
+
+// FindPublicOrgs lists organizations with a public profile whose name,
+// description, or industry contains the query, sorted by name. An empty
+// query matches every public organization (pointer receiver).
+func (s *OrganizationService) FindPublicOrgs(ctx context.Context, query string) (models.OrgList, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "orgs.FindPublicOrgs")()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	orgs := make(models.OrgList, 0)
+	for _, org := range s.orgs {
+		if org.IsDeleted() || !org.HasPublicProfile() {
+			continue
+		}
+		if query == "" ||
+			strings.Contains(strings.ToLower(org.Name), query) ||
+			strings.Contains(strings.ToLower(org.Description), query) ||
+			strings.Contains(strings.ToLower(org.Industry), query) {
 			orgs = append(orgs, *org)
 		}
 	}
+
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Name < orgs[j].Name
+	})
 	return orgs, nil
 }
 
@@ -274,13 +635,30 @@ func (s *OrganizationService) GetUserOrganizations(ctx context.Context, userID s
 
 	orgs := make(models.OrgList, 0, len(orgIDs))
 	for orgID := range orgIDs {
-		if org, exists := s.orgs[orgID]; exists {
+		if org, exists := s.orgs[orgID]; exists && !org.IsDeleted() {
 			orgs = append(orgs, *org)
 		}
 	}
 	return orgs, nil
 }
 
+// SupportTierOf returns the best support tier among the live organizations
+// a user belongs to, standard when none is priority (pointer receiver)
+func (s *OrganizationService) SupportTierOf(ctx context.Context, userID string) models.SupportTier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, m := range s.memberships {
+		if m.UserID != userID {
+			continue
+		}
+		if org, exists := s.orgs[m.OrgID]; exists && !org.IsDeleted() && org.Support.IsPriority() {
+			return models.SupportTierPriority
+		}
+	}
+	return models.SupportTierStandard
+}
+
 // =====================================
 // Standalone Functions for Organization
 // =====================================
@@ -290,18 +668,8 @@ func CreateOrganization(id, name, ownerID string) *models.Organization {
 	return models.NewOrganization(id, name, ownerID)
 }
 
-// GenerateOrgID generates a unique organization ID (standalone function)
-func GenerateOrgID() string {
-	return fmt.Sprintf("org_%d", time.Now().UnixNano())
-}
-
-// GenerateMembershipID generates a unique membership ID (standalone function)
-func GenerateMembershipID() string {
-	return fmt.Sprintf("mem_%d", time.Now().UnixNano())
-}
-
 // CreateMembership is a standalone function that creates a new membership
 func CreateMembership(userID, orgID string, role models.MemberRole) *models.Membership {
-	return models.NewMembership(GenerateMembershipID(), userID, orgID, role)
+	return models.NewMembership(defaultIDGenerator.NewID(IDPrefixMembership), userID, orgID, role)
 }
 
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOrganizationServiceSlugIndex(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrganizationService()
+
+	acme := CreateOrganization("org_a", "Acme", "user_a")
+	acme.SetSlug("acme")
+	if err := service.WriteOrg(ctx, acme); err != nil {
+		t.Fatalf("WriteOrg(org_a): %v", err)
+	}
+
+	other := CreateOrganization("org_b", "Other", "user_b")
+	other.SetSlug("acme")
+	if err := service.WriteOrg(ctx, other); !errors.Is(err, ErrSlugTaken) {
+		t.Fatalf("WriteOrg(org_b) with a taken slug = %v, want %v", err, ErrSlugTaken)
+	}
+
+	// Renaming the slug frees the old one
+	renamed := *acme
+	renamed.SetSlug("acme-corp")
+	if err := service.WriteOrg(ctx, &renamed); err != nil {
+		t.Fatalf("WriteOrg(org_a renamed): %v", err)
+	}
+	if _, err := service.FindOrgBySlug(ctx, "acme"); err == nil {
+		t.Error("FindOrgBySlug(acme) found an organization after the slug changed")
+	}
+	if err := service.WriteOrg(ctx, other); err != nil {
+		t.Fatalf("WriteOrg(org_b) after the slug was freed: %v", err)
+	}
+	if found, err := service.FindOrgBySlug(ctx, "acme"); err != nil || found.ID != "org_b" {
+		t.Errorf("FindOrgBySlug(acme) = %v, %v; want org_b", found, err)
+	}
+
+	// Deleting an organization frees its slug
+	if err := service.DeleteOrg(ctx, "org_a"); err != nil {
+		t.Fatalf("DeleteOrg(org_a): %v", err)
+	}
+	third := CreateOrganization("org_c", "Third", "user_c")
+	third.SetSlug("acme-corp")
+	if err := service.WriteOrg(ctx, third); err != nil {
+		t.Errorf("WriteOrg(org_c) with a deleted organization's slug: %v", err)
+	}
+}
+
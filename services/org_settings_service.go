@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// OrgSettingsService stores organization settings as a revision history
+type OrgSettingsService struct {
+	orgs      *OrganizationService
+	revisions map[string][]*models.SettingsRevision
+	mu        sync.RWMutex
+}
+
+// NewOrgSettingsService creates a new OrgSettingsService instance
+func NewOrgSettingsService(orgs *OrganizationService) *OrgSettingsService {
+	return &OrgSettingsService{
+		orgs:      orgs,
+		revisions: make(map[string][]*models.SettingsRevision),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on OrgSettingsService
+// =====================================
+
+// GetSettings returns the current settings revision. Organizations that
+// were never configured get revision 0 with the default settings
+// (pointer receiver).
+func (s *OrgSettingsService) GetSettings(ctx context.Context, orgID string) (*models.SettingsRevision, error) {
+	if _, err := s.orgs.ReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.revisions[orgID]
+	if len(history) == 0 {
+		return &models.SettingsRevision{
+			OrgID:    orgID,
+			Settings: models.DefaultOrgSettings(),
+		}, nil
+	}
+	return history[len(history)-1], nil
+}
+
+// Location returns the organization's configured time zone, or UTC when it
+// has none. Unlike GetSettings it also answers for soft-deleted
+// organizations, so period calculations on deleted records still use the
+// organization's calendar (pointer receiver).
+func (s *OrgSettingsService) Location(orgID string) *time.Location {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.revisions[orgID]
+	if len(history) == 0 {
+		return models.LocationOrUTC(models.DefaultOrgSettings().Timezone)
+	}
+	return models.LocationOrUTC(history[len(history)-1].Settings.Timezone)
+}
+
+// UpdateSettings validates and stores settings as a new revision (pointer receiver)
+func (s *OrgSettingsService) UpdateSettings(ctx context.Context, orgID string, settings models.OrgSettings, changedBy, reason string) (*models.SettingsRevision, error) {
+	defer timing.Track(ctx, timing.LayerService, "org_settings.UpdateSettings")()
+	if _, err := s.orgs.ReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendRevision(orgID, settings, changedBy, reason, 0), nil
+}
+
+// RemoveOrgSettings removes an organization's settings history and returns
+// how many revisions were removed (pointer receiver)
+func (s *OrgSettingsService) RemoveOrgSettings(ctx context.Context, orgID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := len(s.revisions[orgID])
+	delete(s.revisions, orgID)
+	return removed, nil
+}
+
+// History returns every settings revision, oldest first (pointer receiver)
+func (s *OrgSettingsService) History(ctx context.Context, orgID string) ([]*models.SettingsRevision, error) {
+	if _, err := s.orgs.ReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := make([]*models.SettingsRevision, len(s.revisions[orgID]))
+	copy(history, s.revisions[orgID])
+	return history, nil
+}
+
+// Rollback restores the settings of an earlier revision by appending a new
+// revision with the same settings, so the history itself is never
+// rewritten (pointer receiver)
+func (s *OrgSettingsService) Rollback(ctx context.Context, orgID string, revision int, changedBy string) (*models.SettingsRevision, error) {
+	defer timing.Track(ctx, timing.LayerService, "org_settings.Rollback")()
+	if _, err := s.orgs.ReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := s.revisions[orgID]
+	if revision < 1 || revision > len(history) {
+		return nil, errors.New("revision not found")
+	}
+	if revision == len(history) {
+		return nil, errors.New("revision is already current")
+	}
+
+	target := history[revision-1]
+	reason := fmt.Sprintf("rollback to revision %d", revision)
+	return s.appendRevision(orgID, target.Settings, changedBy, reason, revision), nil
+}
+
+// appendRevision records a new revision; callers must hold the write lock
+// (pointer receiver)
+func (s *OrgSettingsService) appendRevision(orgID string, settings models.OrgSettings, changedBy, reason string, rollbackOf int) *models.SettingsRevision {
+	revision := &models.SettingsRevision{
+		Revision:   len(s.revisions[orgID]) + 1,
+		OrgID:      orgID,
+		Settings:   settings.Clone(),
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		RollbackOf: rollbackOf,
+		CreatedAt:  models.Now(),
+	}
+	s.revisions[orgID] = append(s.revisions[orgID], revision)
+	return revision
+}
+
@@ -0,0 +1,33 @@
+package services
+
+import "sort"
+
+// PageRequest asks a list read for one page of results ordered by ID
+type PageRequest struct {
+	After string // ID the page starts after; empty for the first page
+	Limit int    // Items per page; 0 returns every item
+}
+
+// pageAfter returns up to page.Limit of the items after page.After, and the
+// ID of the last one returned when more follow. items must be ordered by
+// ID; keep drops items that are not listed (standalone function).
+func pageAfter[T any](items []T, id func(T) string, keep func(T) bool, page PageRequest) ([]T, string) {
+	start := 0
+	if page.After != "" {
+		start = sort.Search(len(items), func(i int) bool {
+			return id(items[i]) > page.After
+		})
+	}
+
+	paged := make([]T, 0)
+	for i := start; i < len(items); i++ {
+		if !keep(items[i]) {
+			continue
+		}
+		if page.Limit > 0 && len(paged) == page.Limit {
+			return paged, id(paged[len(paged)-1])
+		}
+		paged = append(paged, items[i])
+	}
+	return paged, ""
+}
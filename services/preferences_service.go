@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+)
+
+// ErrSettingsUserNotFound is returned when settings reference a missing user
+var ErrSettingsUserNotFound = errors.New("settings user not found")
+
+// PreferencesService reads and updates a user's settings. Locale and time
+// zone live on the User, so the locale service and everything else reading
+// them keeps working; notification toggles are the notifier's preferences.
+// Only the theme is stored here.
+type PreferencesService struct {
+	users    *UserService
+	notifier *MultiChannelNotifier
+	themes   map[string]models.Theme
+	mu       sync.RWMutex
+}
+
+// NewPreferencesService creates a new PreferencesService instance
+func NewPreferencesService(users *UserService, notifier *MultiChannelNotifier) *PreferencesService {
+	return &PreferencesService{
+		users:    users,
+		notifier: notifier,
+		themes:   make(map[string]models.Theme),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// GetSettings returns a user's settings (pointer receiver)
+func (s *PreferencesService) GetSettings(ctx context.Context, userID string) (*models.UserSettings, error) {
+	user, err := s.users.Read(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSettingsUserNotFound, userID)
+	}
+	prefs, err := s.notifier.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSettingsUserNotFound, userID)
+	}
+	return s.settings(user, prefs), nil
+}
+
+// UpdateSettings applies the non-nil fields of an update. Everything is
+// validated before anything is stored; a concurrent change to the user
+// fails with ErrVersionConflict (pointer receiver).
+func (s *PreferencesService) UpdateSettings(ctx context.Context, userID string, update models.UserSettingsUpdate) (*models.UserSettings, error) {
+	user, err := s.users.Read(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSettingsUserNotFound, userID)
+	}
+	prefs, err := s.notifier.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSettingsUserNotFound, userID)
+	}
+
+	// Validate and stage every change
+	updated := *user
+	userChanged := false
+	if update.Locale != nil && i18n.Normalize(*update.Locale) != user.Locale {
+		updated.SetLocale(i18n.Normalize(*update.Locale))
+		userChanged = true
+	}
+	if update.Timezone != nil && *update.Timezone != user.Timezone {
+		if *update.Timezone != "" {
+			if _, err := time.LoadLocation(*update.Timezone); err != nil {
+				return nil, errors.New("unknown timezone")
+			}
+		}
+		updated.SetTimezone(*update.Timezone)
+		userChanged = true
+	}
+	if update.Theme != nil && !update.Theme.IsValid() {
+		return nil, fmt.Errorf("unknown theme %q", *update.Theme)
+	}
+	prefsChanged := false
+	if toggles := update.Notifications; toggles != nil {
+		if toggles.Email != nil && *toggles.Email != prefs.Email {
+			prefs.Email = *toggles.Email
+			prefsChanged = true
+		}
+		if toggles.Push != nil && *toggles.Push != prefs.Push {
+			prefs.Push = *toggles.Push
+			prefsChanged = true
+		}
+		if err := prefs.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store them
+	if userChanged {
+		if err := s.users.Write(ctx, &updated); err != nil {
+			return nil, err
+		}
+	}
+	if prefsChanged {
+		if prefs, err = s.notifier.SetPreferences(ctx, prefs); err != nil {
+			return nil, err
+		}
+	}
+	if update.Theme != nil {
+		s.mu.Lock()
+		s.themes[userID] = *update.Theme
+		s.mu.Unlock()
+	}
+
+	return s.settings(&updated, prefs), nil
+}
+
+// RemoveUserSettings drops a user's stored theme and notification
+// preferences and returns how many records were removed (pointer receiver)
+func (s *PreferencesService) RemoveUserSettings(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	removed := 0
+	if _, exists := s.themes[userID]; exists {
+		delete(s.themes, userID)
+		removed++
+	}
+	s.mu.Unlock()
+
+	prefs, err := s.notifier.RemovePreferences(ctx, userID)
+	return removed + prefs, err
+}
+
+// settings assembles a user's settings (pointer receiver)
+func (s *PreferencesService) settings(user *models.User, prefs models.NotificationPreferences) *models.UserSettings {
+	s.mu.RLock()
+	theme, exists := s.themes[user.ID]
+	s.mu.RUnlock()
+	if !exists {
+		theme = models.ThemeSystem
+	}
+
+	return &models.UserSettings{
+		UserID:   user.ID,
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+		Theme:    theme,
+		Notifications: models.NotificationToggles{
+			Email: prefs.Email,
+			Push:  prefs.Push,
+		},
+	}
+}
+
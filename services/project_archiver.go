@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// DefaultProjectArchiveInterval is how often the archiver runs when
+// ProjectArchiveConfig.Interval is zero
+const DefaultProjectArchiveInterval = time.Hour
+
+// maxProjectArchiveEvents bounds how many archive events are kept
+const maxProjectArchiveEvents = 100
+
+// ErrProjectArchiveDisabled is returned when a run is requested without an idle period configured
+var ErrProjectArchiveDisabled = errors.New("project auto-archive is disabled")
+
+// ProjectArchiveConfig controls the auto-archive job. It is off unless
+// After is set.
+type ProjectArchiveConfig struct {
+	After    time.Duration // how long a project may go without updates before it is archived
+	Interval time.Duration // how often the archiver runs
+	DryRun   bool          // report what would be archived without archiving
+}
+
+// ProjectArchiveEvent records one project archived for inactivity
+type ProjectArchiveEvent struct {
+	ProjectID     models.ProjectID `json:"project_id"`
+	OrgID         models.OrgID     `json:"org_id"`
+	OwnerID       models.UserID    `json:"owner_id"`
+	LastUpdatedAt time.Time        `json:"last_updated_at"`
+	ArchivedAt    time.Time        `json:"archived_at"`
+	DryRun        bool             `json:"dry_run"`
+}
+
+// ProjectArchiveReport describes the outcome of a single archive run
+type ProjectArchiveReport struct {
+	StartedAt  time.Time             `json:"started_at"`
+	Cutoff     time.Time             `json:"cutoff"`
+	DryRun     bool                  `json:"dry_run"`
+	Archived   []ProjectArchiveEvent `json:"archived"`
+	OptedOut   int                   `json:"opted_out"`  // Stale projects left alone by their organization's settings
+	Conflicted int                   `json:"conflicted"` // Stale projects updated while the run was in progress
+	Duration   time.Duration         `json:"duration"`
+}
+
+// ProjectArchiver archives projects that have not been updated within the
+// configured period, unless their organization opted out in its settings.
+// Projects of priority-support organizations are handled first in each
+// run. It runs as a background worker between Initialize and Shutdown.
+type ProjectArchiver struct {
+	projects *ProjectService
+	orgs     *OrganizationService
+	settings *OrgSettingsService
+	config   ProjectArchiveConfig
+	logger   *log.Logger
+	events   []ProjectArchiveEvent // Most recent last
+	lastRun  *ProjectArchiveReport
+	stop     chan struct{}
+	done     chan struct{}
+	mu       sync.Mutex
+	runMu    sync.Mutex // serializes archive runs
+}
+
+// NewProjectArchiver creates a new ProjectArchiver instance
+func NewProjectArchiver(projects *ProjectService, orgs *OrganizationService, settings *OrgSettingsService, config ProjectArchiveConfig, logger *log.Logger) *ProjectArchiver {
+	if config.Interval <= 0 {
+		config.Interval = DefaultProjectArchiveInterval
+	}
+	return &ProjectArchiver{
+		projects: projects,
+		orgs:     orgs,
+		settings: settings,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Initialize starts the background archive loop when an idle period is
+// configured (pointer receiver)
+func (a *ProjectArchiver) Initialize(ctx context.Context) error {
+	if !a.Enabled() {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stop != nil {
+		return errors.New("project archiver already running")
+	}
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+
+	go a.run(a.stop, a.done)
+	return nil
+}
+
+// Shutdown stops the archive loop and waits for an in-flight run to finish
+// (pointer receiver)
+func (a *ProjectArchiver) Shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run archives on every tick until stopped (pointer receiver)
+func (a *ProjectArchiver) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if _, err := a.Archive(context.Background(), now.UTC()); err != nil {
+				a.logger.Printf("project auto-archive failed: %v", err)
+			}
+		}
+	}
+}
+
+// =====================================
+// Archiving
+// =====================================
+
+// Archive archives every active or draft project last updated before now
+// minus the idle period. Projects of organizations that opted out are
+// skipped, as are projects updated while the run is in progress. Each
+// archived project is logged as an event=project.auto_archived line and
+// kept in Events (pointer receiver).
+func (a *ProjectArchiver) Archive(ctx context.Context, now time.Time) (*ProjectArchiveReport, error) {
+	defer timing.Track(ctx, timing.LayerService, "projects.AutoArchive")()
+	if !a.Enabled() {
+		return nil, ErrProjectArchiveDisabled
+	}
+
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	report := &ProjectArchiveReport{
+		StartedAt: now,
+		Cutoff:    now.Add(-a.config.After),
+		DryRun:    a.config.DryRun,
+		Archived:  make([]ProjectArchiveEvent, 0),
+	}
+
+	err := a.archive(ctx, report)
+	report.Duration = time.Since(now)
+	a.record(report)
+
+	a.logger.Printf("project auto-archive dry_run=%t cutoff=%s archived=%d opted_out=%d conflicted=%d",
+		report.DryRun, report.Cutoff.Format(time.RFC3339), len(report.Archived), report.OptedOut, report.Conflicted)
+	return report, err
+}
+
+// archive performs the writes for a single run (pointer receiver)
+func (a *ProjectArchiver) archive(ctx context.Context, report *ProjectArchiveReport) error {
+	projects, err := a.projects.ReadAllProjects(ctx)
+	if err != nil {
+		return err
+	}
+	a.byTier(ctx, projects)
+
+	optedOut := make(map[string]bool)
+	for _, project := range projects {
+		if project.IsArchived() || !project.UpdatedAt.Before(report.Cutoff) {
+			continue
+		}
+
+		skip, checked := optedOut[project.OrgID]
+		if !checked {
+			revision, err := a.settings.GetSettings(ctx, project.OrgID)
+			skip = err != nil || revision.Settings.Projects.AutoArchiveOptOut
+			optedOut[project.OrgID] = skip
+		}
+		if skip {
+			report.OptedOut++
+			continue
+		}
+
+		event := ProjectArchiveEvent{
+			ProjectID:     project.ID,
+			OrgID:         project.OrgID,
+			OwnerID:       project.OwnerID,
+			LastUpdatedAt: project.UpdatedAt,
+			ArchivedAt:    report.StartedAt,
+			DryRun:        report.DryRun,
+		}
+		if !report.DryRun {
+			archived := project
+			archived.Archive()
+			if err := a.projects.WriteProject(ctx, &archived); err != nil {
+				if errors.Is(err, ErrVersionConflict) {
+					report.Conflicted++
+					continue
+				}
+				return err
+			}
+		}
+
+		a.logger.Printf("event=project.auto_archived project=%s org=%s owner=%s last_updated=%s dry_run=%t",
+			event.ProjectID, event.OrgID, event.OwnerID, event.LastUpdatedAt.Format(time.RFC3339), event.DryRun)
+		report.Archived = append(report.Archived, event)
+	}
+	return nil
+}
+
+// byTier orders projects of priority-support organizations first, keeping
+// the order within each tier (pointer receiver)
+func (a *ProjectArchiver) byTier(ctx context.Context, projects models.ProjectList) {
+	priority := make(map[string]bool)
+	for _, project := range projects {
+		if _, checked := priority[project.OrgID]; !checked {
+			org, err := a.orgs.ReadOrgIncludingDeleted(ctx, project.OrgID)
+			priority[project.OrgID] = err == nil && org.Support.IsPriority()
+		}
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		return priority[projects[i].OrgID] && !priority[projects[j].OrgID]
+	})
+}
+
+// record keeps a run's events and report (pointer receiver)
+func (a *ProjectArchiver) record(report *ProjectArchiveReport) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.events = append(a.events, report.Archived...)
+	if overflow := len(a.events) - maxProjectArchiveEvents; overflow > 0 {
+		a.events = append([]ProjectArchiveEvent(nil), a.events[overflow:]...)
+	}
+	a.lastRun = report
+}
+
+// Events returns the most recent archive events, oldest first (pointer receiver)
+func (a *ProjectArchiver) Events() []ProjectArchiveEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append(make([]ProjectArchiveEvent, 0, len(a.events)), a.events...)
+}
+
+// LastRun returns the report of the latest run, if any (pointer receiver)
+func (a *ProjectArchiver) LastRun() *ProjectArchiveReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastRun
+}
+
+// Enabled reports whether an idle period is configured (pointer receiver)
+func (a *ProjectArchiver) Enabled() bool {
+	return a.config.After > 0
+}
+
+// Config returns the effective auto-archive settings (pointer receiver)
+func (a *ProjectArchiver) Config() ProjectArchiveConfig {
+	return a.config
+}
+
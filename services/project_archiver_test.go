@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+func TestProjectArchiverHandlesPriorityOrgsFirst(t *testing.T) {
+	ctx := context.Background()
+	orgs := NewOrganizationService()
+	projects := NewProjectService(orgs)
+	archiver := NewProjectArchiver(projects, orgs, NewOrgSettingsService(orgs), ProjectArchiveConfig{After: time.Hour, DryRun: true}, log.New(io.Discard, "", 0))
+
+	writeTestOrg(t, orgs, "org_std")
+	priority := writeTestOrg(t, orgs, "org_pri")
+	priority.SetSupport(models.OrgSupport{Tier: models.SupportTierPriority})
+	if err := orgs.WriteOrg(ctx, priority); err != nil {
+		t.Fatalf("WriteOrg(org_pri): %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		for _, orgID := range []string{"org_std", "org_pri"} {
+			id := fmt.Sprintf("proj_%s_%d", orgID, i)
+			if err := projects.WriteProject(ctx, CreateProject(id, id, "owner_"+orgID, orgID)); err != nil {
+				t.Fatalf("WriteProject(%s): %v", id, err)
+			}
+		}
+	}
+
+	report, err := archiver.Archive(ctx, models.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if len(report.Archived) != 10 {
+		t.Fatalf("archived %d projects, want 10", len(report.Archived))
+	}
+	for i, event := range report.Archived {
+		want := "org_pri"
+		if i >= 5 {
+			want = "org_std"
+		}
+		if event.OrgID != want {
+			t.Errorf("event %d: org = %s, want %s", i, event.OrgID, want)
+		}
+	}
+}
@@ -0,0 +1,707 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/policy"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// ErrTransferNotAllowed is returned when the requester lacks rights in either organization
+var ErrTransferNotAllowed = errors.New("transfer not allowed")
+
+// ErrGrantNotAllowed is returned when the granter cannot manage the project's organization
+var ErrGrantNotAllowed = errors.New("grant not allowed")
+
+// ErrAssignmentNotAllowed is returned when the assigner cannot manage the project's organization
+var ErrAssignmentNotAllowed = errors.New("assignment not allowed")
+
+// ErrAssigneeNotInOrg is returned when the assignee is not a member of the project's organization
+var ErrAssigneeNotInOrg = errors.New("assignee is not a member of the project's organization")
+
+// ErrProjectMemberNotFound is returned for a user not assigned to a project
+var ErrProjectMemberNotFound = errors.New("project member not found")
+
+// ProjectService handles project-related operations
+type ProjectService struct {
+	idSource // Hands out IDs for created entities
+	projects map[string]*models.Project
+	grants   map[string]*models.ProjectGrant  // key: "projectID:userID"
+	members  map[string]*models.ProjectMember // key: "projectID:userID"
+	orgs     *OrganizationService
+	indexer  interfaces.Indexable                       // Optional search index kept in sync on writes
+	onPurge  func(ctx context.Context, id string) error // Optional cleanup for purged projects
+	mu       sync.RWMutex
+}
+
+// NewProjectService creates a new ProjectService instance
+func NewProjectService(orgs *OrganizationService) *ProjectService {
+	return &ProjectService{
+		projects: make(map[string]*models.Project),
+		grants:   make(map[string]*models.ProjectGrant),
+		members:  make(map[string]*models.ProjectMember),
+		orgs:     orgs,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods - ProjectReader Implementation
+// =====================================
+
+// ReadProject retrieves a project by ID, ignoring archived ones (pointer receiver)
+func (s *ProjectService) ReadProject(ctx context.Context, id string) (*models.Project, error) {
+	return s.readProject(id, false)
+}
+
+// ReadProjectIncludingDeleted retrieves a project by ID even if archived (pointer receiver)
+func (s *ProjectService) ReadProjectIncludingDeleted(ctx context.Context, id string) (*models.Project, error) {
+	return s.readProject(id, true)
+}
+
+// readProject looks up a project, optionally including archived ones (pointer receiver)
+func (s *ProjectService) readProject(id string, includeDeleted bool) (*models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project, exists := s.projects[id]
+	if !exists || (project.IsDeleted() && !includeDeleted) {
+		return nil, errors.New("project not found")
+	}
+	return project, nil
+}
+
+// ReadAllProjects retrieves all projects that are not archived (pointer receiver)
+func (s *ProjectService) ReadAllProjects(ctx context.Context) (models.ProjectList, error) {
+	projects, _ := s.readProjectsPage(false, PageRequest{})
+	return projects, nil
+}
+
+// ReadAllProjectsIncludingDeleted retrieves all projects, including archived ones (pointer receiver)
+func (s *ProjectService) ReadAllProjectsIncludingDeleted(ctx context.Context) (models.ProjectList, error) {
+	projects, _ := s.readProjectsPage(true, PageRequest{})
+	return projects, nil
+}
+
+// ReadProjectsPage retrieves one page of projects ordered by ID,
+// optionally including archived ones, and the ID the next page starts
+// after, or "" on the last page (pointer receiver)
+func (s *ProjectService) ReadProjectsPage(ctx context.Context, includeDeleted bool, page PageRequest) (models.ProjectList, string, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "projects.ReadProjectsPage")()
+	projects, next := s.readProjectsPage(includeDeleted, page)
+	return projects, next, nil
+}
+
+// readProjectsPage copies a page of projects, optionally including
+// archived ones, ordered by ID (pointer receiver)
+func (s *ProjectService) readProjectsPage(includeDeleted bool, page PageRequest) (models.ProjectList, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.projects))
+	for id, project := range s.projects {
+		if includeDeleted || !project.IsDeleted() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	ids, next := pageAfter(ids, func(id string) string { return id }, func(string) bool { return true }, page)
+
+	projects := make(models.ProjectList, 0, len(ids))
+	for _, id := range ids {
+		projects = append(projects, *s.projects[id])
+	}
+	return projects, next
+}
+
+// ReadProjectsByOrg retrieves the non-archived projects of an organization (pointer receiver)
+func (s *ProjectService) ReadProjectsByOrg(ctx context.Context, orgID string) (models.ProjectList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make(models.ProjectList, 0)
+	for _, project := range s.projects {
+		if project.OrgID == orgID && !project.IsDeleted() {
+			projects = append(projects, *project)
+		}
+	}
+	return projects, nil
+}
+
+// =====================================
+// Pointer Receiver Methods - ProjectWriter Implementation
+// =====================================
+
+// WriteProject creates or updates a project (pointer receiver)
+func (s *ProjectService) WriteProject(ctx context.Context, project *models.Project) error {
+	if project.ID == "" {
+		return errors.New("project ID is required")
+	}
+	if exists, _ := s.orgs.OrgExists(ctx, project.OrgID); !exists {
+		return errors.New("organization not found")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.projects[project.ID]; exists && existing.Version != project.Version {
+		return ErrVersionConflict
+	}
+	project.IncrementVersion()
+	s.projects[project.ID] = project
+
+	if s.indexer != nil {
+		if err := s.indexer.Index(ctx, project.ID, project); err != nil {
+			return fmt.Errorf("failed to index project: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteProject removes a project (pointer receiver)
+func (s *ProjectService) DeleteProject(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.projects[id]; !exists {
+		return errors.New("project not found")
+	}
+	delete(s.projects, id)
+
+	// Also remove all guest grants and assignments for this project
+	for key, grant := range s.grants {
+		if grant.ProjectID == id {
+			delete(s.grants, key)
+		}
+	}
+	for key, member := range s.members {
+		if member.ProjectID == id {
+			delete(s.members, key)
+		}
+	}
+
+	if s.indexer != nil {
+		if err := s.indexer.DeleteIndex(ctx, id); err != nil {
+			return fmt.Errorf("failed to remove project from index: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetPurgeHook registers fn to remove data tied to a project that
+// PurgeProject hard-deletes, such as its tasks; fn runs before the project
+// is removed and without the service lock (pointer receiver)
+func (s *ProjectService) SetPurgeHook(fn func(ctx context.Context, id string) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onPurge = fn
+}
+
+// PurgeProject hard-deletes a project together with the data removed by
+// the purge hook. A failed hook leaves the project in place, so the purge
+// can be retried (pointer receiver).
+func (s *ProjectService) PurgeProject(ctx context.Context, id string) error {
+	s.mu.RLock()
+	onPurge := s.onPurge
+	s.mu.RUnlock()
+
+	if onPurge != nil {
+		if err := onPurge(ctx, id); err != nil {
+			return err
+		}
+	}
+	return s.DeleteProject(ctx, id)
+}
+
+// SetIndexer attaches a search index updated on every WriteProject and DeleteProject (pointer receiver)
+func (s *ProjectService) SetIndexer(indexer interfaces.Indexable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexer = indexer
+}
+
+// =====================================
+// Pointer Receiver Methods - ProjectRepository Implementation
+// =====================================
+
+// CountProjects returns the count of non-archived projects (pointer receiver)
+func (s *ProjectService) CountProjects(ctx context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, project := range s.projects {
+		if !project.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ProjectExists checks if a non-archived project exists (pointer receiver)
+func (s *ProjectService) ProjectExists(ctx context.Context, id string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	project, exists := s.projects[id]
+	return exists && !project.IsDeleted(), nil
+}
+
+// =====================================
+// Project Transfer
+// =====================================
+
+// ProjectTransfer describes a completed move of a project between organizations
+type ProjectTransfer struct {
+	Project            *models.Project      `json:"project"`
+	FromOrgID          string               `json:"from_org_id"`
+	ToOrgID            string               `json:"to_org_id"`
+	RequestedBy        string               `json:"requested_by"`
+	AddedMemberships   []*models.Membership `json:"added_memberships"`
+	DroppedAssignments []string             `json:"dropped_assignments"` // Assigned users outside the target organization
+	DroppedGrants      []string             `json:"dropped_grants"`      // Guests who are members of the target organization
+	TransferredAt      time.Time            `json:"transferred_at"`
+}
+
+// TransferProject moves a project to another organization. The requester
+// needs the manage_projects permission in both organizations. If the
+// project owner is not yet a member of the target organization they are
+// added as a member first, so the project keeps an accessible owner. If
+// the project cannot be written, both steps are rolled back. Once the
+// project has moved, assignments of users outside the target organization
+// are dropped, as are guest grants of users who are members of it
+// (pointer receiver).
+func (s *ProjectService) TransferProject(ctx context.Context, projectID, targetOrgID, requestedBy string) (*ProjectTransfer, error) {
+	defer timing.Track(ctx, timing.LayerService, "projects.TransferProject")()
+	existing, err := s.ReadProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	sourceOrgID := existing.OrgID
+	if sourceOrgID == targetOrgID {
+		return nil, errors.New("project already belongs to the target organization")
+	}
+	if exists, _ := s.orgs.OrgExists(ctx, targetOrgID); !exists {
+		return nil, errors.New("target organization not found")
+	}
+
+	for _, orgID := range []string{sourceOrgID, targetOrgID} {
+		if !s.canManage(ctx, requestedBy, orgID) {
+			return nil, fmt.Errorf("%w: %s may not manage projects of %s", ErrTransferNotAllowed, requestedBy, orgID)
+		}
+	}
+
+	transfer := &ProjectTransfer{
+		FromOrgID:        sourceOrgID,
+		ToOrgID:          targetOrgID,
+		RequestedBy:      requestedBy,
+		AddedMemberships: make([]*models.Membership, 0),
+		TransferredAt:    models.Now(),
+	}
+
+	if _, err := s.orgs.GetMembership(ctx, existing.OwnerID, targetOrgID); err != nil {
+		membership := CreateMembership(existing.OwnerID, targetOrgID, models.MemberRoleMember)
+		if err := s.orgs.AddMember(ctx, membership); err != nil {
+			return nil, fmt.Errorf("failed to add project owner to target organization: %w", err)
+		}
+		transfer.AddedMemberships = append(transfer.AddedMemberships, membership)
+	}
+
+	updated := *existing
+	updated.OrgID = targetOrgID
+	updated.UpdatedAt = transfer.TransferredAt
+	if err := s.WriteProject(ctx, &updated); err != nil {
+		if rollbackErr := s.rollbackTransfer(ctx, existing, &updated, transfer); rollbackErr != nil {
+			return nil, fmt.Errorf("%w; rolling back the transfer also failed: %v", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	transfer.DroppedAssignments, transfer.DroppedGrants = s.remapAccess(ctx, projectID, targetOrgID)
+	transfer.Project = &updated
+	return transfer, nil
+}
+
+// rollbackTransfer undoes the steps of a transfer whose project write
+// failed: a project stored before indexing failed is moved back to its
+// organization, and memberships added for the owner are removed
+// (pointer receiver)
+func (s *ProjectService) rollbackTransfer(ctx context.Context, original, updated *models.Project, transfer *ProjectTransfer) error {
+	s.mu.Lock()
+	if s.projects[updated.ID] == updated {
+		reverted := *updated
+		reverted.OrgID = original.OrgID
+		reverted.UpdatedAt = original.UpdatedAt
+		reverted.IncrementVersion()
+		s.projects[updated.ID] = &reverted
+	}
+	s.mu.Unlock()
+
+	for _, membership := range transfer.AddedMemberships {
+		if err := s.orgs.RemoveMember(ctx, membership.UserID, membership.OrgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remapAccess drops a moved project's assignments of users outside its new
+// organization and the guest grants of users inside it, returning the
+// user IDs of each, sorted (pointer receiver)
+func (s *ProjectService) remapAccess(ctx context.Context, projectID, orgID string) ([]string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assignments := make([]string, 0)
+	for key, member := range s.members {
+		if member.ProjectID == projectID && !s.isMemberOf(ctx, member.UserID, orgID) {
+			delete(s.members, key)
+			assignments = append(assignments, member.UserID)
+		}
+	}
+	grants := make([]string, 0)
+	for key, grant := range s.grants {
+		if grant.ProjectID == projectID && s.isMemberOf(ctx, grant.UserID, orgID) {
+			delete(s.grants, key)
+			grants = append(grants, grant.UserID)
+		}
+	}
+
+	sort.Strings(assignments)
+	sort.Strings(grants)
+	return assignments, grants
+}
+
+// canManage checks if a user has the manage_projects permission in an organization (pointer receiver)
+func (s *ProjectService) canManage(ctx context.Context, userID, orgID string) bool {
+	membership, err := s.orgs.GetMembership(ctx, userID, orgID)
+	if err != nil {
+		return false
+	}
+	return policy.Allows(membership.Role, policy.ActionManageProjects)
+}
+
+// =====================================
+// Cross-Org Guest Access
+// =====================================
+
+// Project access sources reported by CheckAccess
+const (
+	ProjectAccessMembership = "membership"
+	ProjectAccessGuest      = "guest"
+)
+
+// ProjectAccess explains whether and how a user can reach a project
+type ProjectAccess struct {
+	Allowed bool   `json:"allowed"`
+	Via     string `json:"via,omitempty"`
+	Role    string `json:"role,omitempty"`
+}
+
+// grantKey generates a unique key for project-user grants
+func grantKey(projectID, userID string) string {
+	return fmt.Sprintf("%s:%s", projectID, userID)
+}
+
+// GrantGuestAccess gives a user from another organization access to a
+// single project. The granter needs the manage_projects permission in the
+// project's organization, and the guest must be a member of their home organization
+// but not of the project's (pointer receiver).
+func (s *ProjectService) GrantGuestAccess(ctx context.Context, grant *models.ProjectGrant) error {
+	if !grant.Role.IsValid() {
+		return errors.New("invalid grant role")
+	}
+
+	project, err := s.ReadProject(ctx, grant.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !s.canManage(ctx, grant.GrantedBy, project.OrgID) {
+		return fmt.Errorf("%w: %s may not manage projects of %s", ErrGrantNotAllowed, grant.GrantedBy, project.OrgID)
+	}
+	if _, err := s.orgs.GetMembership(ctx, grant.UserID, project.OrgID); err == nil {
+		return errors.New("user is already a member of the project's organization")
+	}
+	if !s.isMemberOf(ctx, grant.UserID, grant.HomeOrgID) {
+		return errors.New("user is not a member of the home organization")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := grantKey(grant.ProjectID, grant.UserID)
+	if _, exists := s.grants[key]; exists {
+		return errors.New("guest access already granted")
+	}
+	s.grants[key] = grant
+	return nil
+}
+
+// RevokeGuestAccess removes a user's guest access to a project (pointer receiver)
+func (s *ProjectService) RevokeGuestAccess(ctx context.Context, projectID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := grantKey(projectID, userID)
+	if _, exists := s.grants[key]; !exists {
+		return errors.New("grant not found")
+	}
+	delete(s.grants, key)
+	return nil
+}
+
+// ListGuests returns the guest grants of a project, including lapsed ones (pointer receiver)
+func (s *ProjectService) ListGuests(ctx context.Context, projectID string) ([]*models.ProjectGrant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	grants := make([]*models.ProjectGrant, 0)
+	for _, grant := range s.grants {
+		if grant.ProjectID == projectID {
+			grants = append(grants, grant)
+		}
+	}
+	return grants, nil
+}
+
+// CheckAccess reports whether a user can reach a project, either through
+// membership of the project's organization or an active guest grant
+// (pointer receiver)
+func (s *ProjectService) CheckAccess(ctx context.Context, userID, projectID string) (*ProjectAccess, error) {
+	defer timing.Track(ctx, timing.LayerService, "projects.CheckAccess")()
+	project, err := s.ReadProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.access(ctx, userID, project), nil
+}
+
+// RemoveUserAssignments removes a user from every project they are
+// assigned to and returns how many assignments were removed (pointer receiver)
+func (s *ProjectService) RemoveUserAssignments(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for key, member := range s.members {
+		if member.UserID == userID {
+			delete(s.members, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// RemoveUserGrants removes every guest grant held by a user and returns
+// how many were removed (pointer receiver)
+func (s *ProjectService) RemoveUserGrants(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for key, grant := range s.grants {
+		if grant.UserID == userID {
+			delete(s.grants, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// CanContribute reports whether a user may change a project's work, such
+// as its tasks: members of the project's organization may, and so may
+// guests granted editor access (pointer receiver)
+func (s *ProjectService) CanContribute(ctx context.Context, userID, projectID string) (bool, error) {
+	access, err := s.CheckAccess(ctx, userID, projectID)
+	if err != nil {
+		return false, err
+	}
+	if !access.Allowed {
+		return false, nil
+	}
+	return access.Via == ProjectAccessMembership || access.Role == string(models.ProjectGrantEditor), nil
+}
+
+// ReadProjectsVisibleTo retrieves the non-archived projects a user can
+// reach through membership or guest grants (pointer receiver)
+func (s *ProjectService) ReadProjectsVisibleTo(ctx context.Context, userID string) (models.ProjectList, error) {
+	defer timing.Track(ctx, timing.LayerService, "projects.ReadProjectsVisibleTo")()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make(models.ProjectList, 0)
+	for _, project := range s.projects {
+		if project.IsDeleted() {
+			continue
+		}
+		if s.access(ctx, userID, project).Allowed {
+			projects = append(projects, *project)
+		}
+	}
+	return projects, nil
+}
+
+// access resolves a user's access to a project; callers must hold the
+// read lock (pointer receiver)
+func (s *ProjectService) access(ctx context.Context, userID string, project *models.Project) *ProjectAccess {
+	if membership, err := s.orgs.GetMembership(ctx, userID, project.OrgID); err == nil {
+		return &ProjectAccess{Allowed: true, Via: ProjectAccessMembership, Role: string(membership.Role)}
+	}
+
+	grant, exists := s.grants[grantKey(project.ID, userID)]
+	if !exists || grant.IsExpired(models.Now()) || !s.isMemberOf(ctx, userID, grant.HomeOrgID) {
+		return &ProjectAccess{Allowed: false}
+	}
+	return &ProjectAccess{Allowed: true, Via: ProjectAccessGuest, Role: string(grant.Role)}
+}
+
+// isMemberOf checks if a user belongs to an organization that still exists (pointer receiver)
+func (s *ProjectService) isMemberOf(ctx context.Context, userID, orgID string) bool {
+	if exists, _ := s.orgs.OrgExists(ctx, orgID); !exists {
+		return false
+	}
+	_, err := s.orgs.GetMembership(ctx, userID, orgID)
+	return err == nil
+}
+
+// =====================================
+// Project Membership
+// =====================================
+
+// AssignMember assigns a member of the project's organization to the
+// project with a role. The assigner needs the manage_projects permission
+// there (pointer receiver).
+func (s *ProjectService) AssignMember(ctx context.Context, member *models.ProjectMember) error {
+	defer timing.Track(ctx, timing.LayerService, "projects.AssignMember")()
+	if !member.Role.IsValid() {
+		return errors.New("invalid project member role")
+	}
+
+	project, err := s.ReadProject(ctx, member.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !s.canManage(ctx, member.AssignedBy, project.OrgID) {
+		return fmt.Errorf("%w: %s may not manage projects of %s", ErrAssignmentNotAllowed, member.AssignedBy, project.OrgID)
+	}
+	if !s.isMemberOf(ctx, member.UserID, project.OrgID) {
+		return fmt.Errorf("%w: %s is not in %s", ErrAssigneeNotInOrg, member.UserID, project.OrgID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An assignment that lapsed when its user left the organization is replaced
+	key := grantKey(member.ProjectID, member.UserID)
+	if existing, exists := s.members[key]; exists && s.isMemberOf(ctx, existing.UserID, project.OrgID) {
+		return errors.New("user is already assigned to the project")
+	}
+	s.members[key] = member
+	return nil
+}
+
+// UpdateMemberRole changes an assigned user's role on a project
+// (pointer receiver)
+func (s *ProjectService) UpdateMemberRole(ctx context.Context, projectID, userID string, role models.ProjectMemberRole, updatedBy string) (*models.ProjectMember, error) {
+	if !role.IsValid() {
+		return nil, errors.New("invalid project member role")
+	}
+
+	project, err := s.ReadProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canManage(ctx, updatedBy, project.OrgID) {
+		return nil, fmt.Errorf("%w: %s may not manage projects of %s", ErrAssignmentNotAllowed, updatedBy, project.OrgID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.activeMember(ctx, project, userID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *existing
+	updated.Role = role
+	updated.UpdatedAt = models.Now()
+	updated.Version++
+	s.members[grantKey(projectID, userID)] = &updated
+
+	copied := updated
+	return &copied, nil
+}
+
+// UnassignMember removes a user from a project. Users may always remove
+// themselves; anyone else needs the manage_projects permission
+// (pointer receiver).
+func (s *ProjectService) UnassignMember(ctx context.Context, projectID, userID, removedBy string) error {
+	project, err := s.ReadProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if removedBy != userID && !s.canManage(ctx, removedBy, project.OrgID) {
+		return fmt.Errorf("%w: %s may not manage projects of %s", ErrAssignmentNotAllowed, removedBy, project.OrgID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := grantKey(projectID, userID)
+	if _, exists := s.members[key]; !exists {
+		return fmt.Errorf("%w: %s", ErrProjectMemberNotFound, userID)
+	}
+	delete(s.members, key)
+	return nil
+}
+
+// ListMembers returns a project's assignments in the order they were made,
+// leaving out those whose user has left the project's organization
+// (pointer receiver)
+func (s *ProjectService) ListMembers(ctx context.Context, projectID string) ([]models.ProjectMember, error) {
+	project, err := s.ReadProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := make([]models.ProjectMember, 0)
+	for _, member := range s.members {
+		if member.ProjectID == projectID && s.isMemberOf(ctx, member.UserID, project.OrgID) {
+			members = append(members, *member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members, nil
+}
+
+// activeMember looks up an assignment that has not lapsed; callers must
+// hold the lock (pointer receiver)
+func (s *ProjectService) activeMember(ctx context.Context, project *models.Project, userID string) (*models.ProjectMember, error) {
+	member, exists := s.members[grantKey(project.ID, userID)]
+	if !exists || !s.isMemberOf(ctx, userID, project.OrgID) {
+		return nil, fmt.Errorf("%w: %s", ErrProjectMemberNotFound, userID)
+	}
+	return member, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// CreateProject is a standalone function to create a project
+func CreateProject(id, name, ownerID, orgID string) *models.Project {
+	return models.NewProject(id, name, ownerID, orgID)
+}
+
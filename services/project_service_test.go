@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// failingIndexer fails every Index call
+type failingIndexer struct{}
+
+func (failingIndexer) Index(ctx context.Context, id string, data interface{}) error {
+	return errors.New("index unavailable")
+}
+func (failingIndexer) Reindex(ctx context.Context) error                { return nil }
+func (failingIndexer) DeleteIndex(ctx context.Context, id string) error { return nil }
+
+// newTestTransfer stores org_src and org_dst, both managed by user_admin,
+// and proj_a in org_src owned by user_lead, who is only in org_src
+func newTestTransfer(t *testing.T) (*ProjectService, *OrganizationService) {
+	t.Helper()
+	ctx := context.Background()
+	orgs := NewOrganizationService()
+	projects := NewProjectService(orgs)
+
+	for _, id := range []string{"org_src", "org_dst", "org_other"} {
+		writeTestOrg(t, orgs, id)
+	}
+	for _, m := range []struct {
+		user, org string
+		role      models.MemberRole
+	}{
+		{"user_admin", "org_src", models.MemberRoleAdmin},
+		{"user_admin", "org_dst", models.MemberRoleAdmin},
+		{"user_lead", "org_src", models.MemberRoleMember},
+		{"user_both", "org_src", models.MemberRoleMember},
+		{"user_both", "org_dst", models.MemberRoleMember},
+		{"user_src_only", "org_src", models.MemberRoleMember},
+		{"user_guest", "org_dst", models.MemberRoleMember},
+		{"user_outsider", "org_other", models.MemberRoleMember},
+	} {
+		if err := orgs.AddMember(ctx, CreateMembership(m.user, m.org, m.role)); err != nil {
+			t.Fatalf("AddMember(%s, %s): %v", m.user, m.org, err)
+		}
+	}
+
+	if err := projects.WriteProject(ctx, CreateProject("proj_a", "Alpha", "user_lead", "org_src")); err != nil {
+		t.Fatalf("WriteProject: %v", err)
+	}
+	return projects, orgs
+}
+
+func TestTransferProjectRemapsAccess(t *testing.T) {
+	ctx := context.Background()
+	projects, orgs := newTestTransfer(t)
+
+	for _, user := range []string{"user_both", "user_src_only"} {
+		if err := projects.AssignMember(ctx, models.NewProjectMember("pm_"+user, "proj_a", user, models.ProjectMemberContributor, "user_admin")); err != nil {
+			t.Fatalf("AssignMember(%s): %v", user, err)
+		}
+	}
+	for user, home := range map[string]string{"user_guest": "org_dst", "user_outsider": "org_other"} {
+		if err := projects.GrantGuestAccess(ctx, models.NewProjectGrant("grant_"+user, "proj_a", user, home, models.ProjectGrantViewer, "user_admin")); err != nil {
+			t.Fatalf("GrantGuestAccess(%s): %v", user, err)
+		}
+	}
+
+	transfer, err := projects.TransferProject(ctx, "proj_a", "org_dst", "user_admin")
+	if err != nil {
+		t.Fatalf("TransferProject: %v", err)
+	}
+	if transfer.Project.OrgID != "org_dst" {
+		t.Errorf("project org = %s, want org_dst", transfer.Project.OrgID)
+	}
+	if len(transfer.AddedMemberships) != 1 || transfer.AddedMemberships[0].UserID != "user_lead" {
+		t.Errorf("AddedMemberships = %+v, want the owner user_lead", transfer.AddedMemberships)
+	}
+	if _, err := orgs.GetMembership(ctx, "user_lead", "org_dst"); err != nil {
+		t.Errorf("owner is not a member of org_dst: %v", err)
+	}
+	if want := []string{"user_src_only"}; !reflect.DeepEqual(transfer.DroppedAssignments, want) {
+		t.Errorf("DroppedAssignments = %v, want %v", transfer.DroppedAssignments, want)
+	}
+	if want := []string{"user_guest"}; !reflect.DeepEqual(transfer.DroppedGrants, want) {
+		t.Errorf("DroppedGrants = %v, want %v", transfer.DroppedGrants, want)
+	}
+
+	members, err := projects.ListMembers(ctx, "proj_a")
+	if err != nil {
+		t.Fatalf("ListMembers: %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != "user_both" {
+		t.Errorf("ListMembers = %+v, want only user_both", members)
+	}
+	guests, err := projects.ListGuests(ctx, "proj_a")
+	if err != nil {
+		t.Fatalf("ListGuests: %v", err)
+	}
+	if len(guests) != 1 || guests[0].UserID != "user_outsider" {
+		t.Errorf("ListGuests = %+v, want only user_outsider", guests)
+	}
+}
+
+func TestTransferProjectRollsBackFailedWrite(t *testing.T) {
+	ctx := context.Background()
+	projects, orgs := newTestTransfer(t)
+	projects.SetIndexer(failingIndexer{})
+
+	if _, err := projects.TransferProject(ctx, "proj_a", "org_dst", "user_admin"); err == nil {
+		t.Fatal("TransferProject succeeded although the project could not be indexed")
+	}
+
+	project, err := projects.ReadProject(ctx, "proj_a")
+	if err != nil {
+		t.Fatalf("ReadProject: %v", err)
+	}
+	if project.OrgID != "org_src" {
+		t.Errorf("project org = %s after a failed transfer, want org_src", project.OrgID)
+	}
+	if _, err := orgs.GetMembership(ctx, "user_lead", "org_dst"); err == nil {
+		t.Error("owner membership added for the failed transfer was kept")
+	}
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// Default retention settings used when a RetentionConfig field is zero
+const (
+	DefaultRetentionWindow   = 30 * 24 * time.Hour
+	DefaultRetentionInterval = time.Hour
+)
+
+// RetentionConfig controls how long soft-deleted records are kept
+type RetentionConfig struct {
+	Window   time.Duration // how long a record stays soft-deleted before purge
+	Interval time.Duration // how often the janitor runs
+	DryRun   bool          // report what would be purged without deleting
+	SLAs     SLAConfig     // targets checked after every run
+}
+
+// RetentionReport describes the outcome of a single purge run
+type RetentionReport struct {
+	StartedAt      time.Time     `json:"started_at"`
+	Cutoff         time.Time     `json:"cutoff"`
+	DryRun         bool          `json:"dry_run"`
+	PurgedUsers    int           `json:"purged_users"`
+	PurgedOrgs     int           `json:"purged_orgs"`
+	PurgedProjects int           `json:"purged_projects"`
+	PurgedMembers  int           `json:"purged_memberships"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// RetentionStats accumulates purge counts across runs
+type RetentionStats struct {
+	Runs           int64            `json:"runs"`
+	Failures       int64            `json:"failures"`
+	PurgedUsers    int64            `json:"purged_users"`
+	PurgedOrgs     int64            `json:"purged_orgs"`
+	PurgedProjects int64            `json:"purged_projects"`
+	PurgedMembers  int64            `json:"purged_memberships"`
+	LastRun        *RetentionReport `json:"last_run,omitempty"`
+}
+
+// RetentionJanitor hard-deletes records whose DeletedAt is older than the
+// retention window. It runs as a background worker between Initialize and
+// Shutdown, and after each run evaluates the attached SLATracker.
+type RetentionJanitor struct {
+	users     *UserService
+	orgs      *OrganizationService
+	projects  *ProjectService     // Purges archived projects with their tasks
+	deletions *OrgDeletionService // Removes purged organizations with their data
+	config    RetentionConfig
+	locales   *LocaleService // Optional; cutoffs are computed in UTC without one
+	slas      *SLATracker    // Optional; evaluated after every scheduled run
+	logger    *log.Logger
+	stats     RetentionStats
+	stop      chan struct{}
+	done      chan struct{}
+	mu        sync.Mutex
+	runMu     sync.Mutex // serializes purge runs
+}
+
+// NewRetentionJanitor creates a new RetentionJanitor instance
+func NewRetentionJanitor(users *UserService, orgs *OrganizationService, projects *ProjectService, deletions *OrgDeletionService, config RetentionConfig, logger *log.Logger) *RetentionJanitor {
+	if config.Window <= 0 {
+		config.Window = DefaultRetentionWindow
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultRetentionInterval
+	}
+	return &RetentionJanitor{
+		users:     users,
+		orgs:      orgs,
+		projects:  projects,
+		deletions: deletions,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// SetLocales attaches the resolver used to find each record's time zone.
+// With one, a record is purged only once the whole local calendar day it
+// was deleted on has left the retention window (pointer receiver).
+func (j *RetentionJanitor) SetLocales(locales *LocaleService) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.locales = locales
+}
+
+// SetSLAs attaches the tracker whose breaches are checked after every
+// scheduled run (pointer receiver)
+func (j *RetentionJanitor) SetSLAs(slas *SLATracker) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.slas = slas
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Initialize starts the background purge loop (pointer receiver)
+func (j *RetentionJanitor) Initialize(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.stop != nil {
+		return errors.New("retention janitor already running")
+	}
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	go j.run(j.stop, j.done)
+	return nil
+}
+
+// Shutdown stops the purge loop and waits for an in-flight run to finish
+// (pointer receiver)
+func (j *RetentionJanitor) Shutdown(ctx context.Context) error {
+	j.mu.Lock()
+	stop, done := j.stop, j.done
+	j.stop, j.done = nil, nil
+	j.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run purges and checks SLAs on every tick until stopped (pointer receiver)
+func (j *RetentionJanitor) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if _, err := j.Purge(context.Background(), now.UTC()); err != nil {
+				j.logger.Printf("retention purge failed: %v", err)
+			}
+			j.evaluateSLAs(now.UTC())
+		}
+	}
+}
+
+// =====================================
+// Purging
+// =====================================
+
+// Purge hard-deletes users, projects and organizations soft-deleted
+// before now minus the retention window. With locales attached, the
+// cutoff is moved back to the start of that day in the record's time
+// zone. Purged users also lose their memberships, projects go through
+// ProjectService.PurgeProject so their tasks go too, and organizations go
+// through the OrgDeletionService cascade so none of their data is
+// orphaned. In
+// dry-run mode nothing is deleted but the report still counts what would
+// have been (pointer receiver).
+func (j *RetentionJanitor) Purge(ctx context.Context, now time.Time) (*RetentionReport, error) {
+	j.runMu.Lock()
+	defer j.runMu.Unlock()
+
+	report := &RetentionReport{
+		StartedAt: now,
+		Cutoff:    now.Add(-j.config.Window),
+		DryRun:    j.config.DryRun,
+	}
+
+	err := j.purge(ctx, report)
+	report.Duration = time.Since(now)
+	j.record(report, err)
+
+	j.logger.Printf("retention purge dry_run=%t cutoff=%s users=%d projects=%d orgs=%d memberships=%d",
+		report.DryRun, report.Cutoff.Format(time.RFC3339), report.PurgedUsers, report.PurgedProjects, report.PurgedOrgs, report.PurgedMembers)
+	return report, err
+}
+
+// purge performs the deletes for a single run (pointer receiver)
+func (j *RetentionJanitor) purge(ctx context.Context, report *RetentionReport) error {
+	users, err := j.users.ReadAllIncludingDeleted(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if !user.IsDeleted() || !user.DeletedAt.Before(j.cutoff(ctx, report.Cutoff, user.ID, "")) {
+			continue
+		}
+		report.PurgedUsers++
+		if report.DryRun {
+			continue
+		}
+		if err := j.users.Delete(ctx, user.ID); err != nil {
+			return err
+		}
+		removed, err := j.orgs.RemoveUserMemberships(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		report.PurgedMembers += removed
+	}
+
+	// Projects go before organizations so a dry run counts the archived
+	// projects of an organization that is also due
+	projects, err := j.projects.ReadAllProjectsIncludingDeleted(ctx)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if !project.IsDeleted() || !project.DeletedAt.Before(j.cutoff(ctx, report.Cutoff, "", project.OrgID)) {
+			continue
+		}
+		report.PurgedProjects++
+		if report.DryRun {
+			continue
+		}
+		if err := j.projects.PurgeProject(ctx, project.ID); err != nil {
+			return err
+		}
+	}
+
+	orgs, err := j.orgs.ReadAllOrgsIncludingDeleted(ctx)
+	if err != nil {
+		return err
+	}
+	for _, org := range orgs {
+		if !org.IsDeleted() || !org.DeletedAt.Before(j.cutoff(ctx, report.Cutoff, "", org.ID)) {
+			continue
+		}
+		if report.DryRun {
+			report.PurgedOrgs++
+			continue
+		}
+		if _, err := j.deletions.Delete(ctx, org.ID, "retention"); err != nil {
+			if errors.Is(err, ErrOrgDeletionInProgress) {
+				continue // A queued deletion will remove it
+			}
+			return err
+		}
+		report.PurgedOrgs++
+	}
+	return nil
+}
+
+// evaluateSLAs runs the attached SLATracker, if any (pointer receiver)
+func (j *RetentionJanitor) evaluateSLAs(now time.Time) {
+	j.mu.Lock()
+	slas := j.slas
+	j.mu.Unlock()
+
+	if slas == nil {
+		return
+	}
+	if _, err := slas.Evaluate(context.Background(), now); err != nil {
+		j.logger.Printf("sla evaluation failed: %v", err)
+	}
+}
+
+// cutoff aligns the retention cutoff to the start of its calendar day in
+// the time zone of the given user or organization (pointer receiver)
+func (j *RetentionJanitor) cutoff(ctx context.Context, cutoff time.Time, userID, orgID string) time.Time {
+	j.mu.Lock()
+	locales := j.locales
+	j.mu.Unlock()
+
+	if locales == nil {
+		return cutoff
+	}
+	return models.PeriodDay.Start(cutoff, locales.LocationFor(ctx, userID, orgID))
+}
+
+// record folds a run into the accumulated stats (pointer receiver)
+func (j *RetentionJanitor) record(report *RetentionReport, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.stats.Runs++
+	if err != nil {
+		j.stats.Failures++
+	}
+	if !report.DryRun {
+		j.stats.PurgedUsers += int64(report.PurgedUsers)
+		j.stats.PurgedOrgs += int64(report.PurgedOrgs)
+		j.stats.PurgedProjects += int64(report.PurgedProjects)
+		j.stats.PurgedMembers += int64(report.PurgedMembers)
+	}
+	j.stats.LastRun = report
+}
+
+// Stats returns a snapshot of the accumulated purge counts (pointer receiver)
+func (j *RetentionJanitor) Stats() RetentionStats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.stats
+}
+
+// Config returns the effective retention settings (pointer receiver)
+func (j *RetentionJanitor) Config() RetentionConfig {
+	return j.config
+}
+
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// writeTestProject stores a project of orgID, archived at deletedAt unless
+// it is zero, with one task
+func writeTestProject(t *testing.T, d *testDeletions, id, orgID string, deletedAt time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	project := CreateProject(id, "Project "+id, "owner_"+orgID, orgID)
+	if !deletedAt.IsZero() {
+		project.Archive()
+		project.DeletedAt = &deletedAt
+	}
+	if err := d.projects.WriteProject(ctx, project); err != nil {
+		t.Fatalf("WriteProject(%s): %v", id, err)
+	}
+	if err := d.tasks.WriteTask(ctx, models.NewTask("task_"+id, models.ProjectID(id), "Task of "+id)); err != nil {
+		t.Fatalf("WriteTask(%s): %v", id, err)
+	}
+}
+
+func TestRetentionPurgeProjects(t *testing.T) {
+	ctx := context.Background()
+	now := models.Now()
+	expired := now.Add(-DefaultRetentionWindow - time.Hour)
+
+	tests := []struct {
+		name   string
+		dryRun bool
+	}{
+		{"purge", false},
+		{"dry run", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDeletions(t)
+			janitor := NewRetentionJanitor(d.users, d.orgs, d.projects, d.OrgDeletionService, RetentionConfig{DryRun: tt.dryRun}, log.New(io.Discard, "", 0))
+
+			writeTestOrg(t, d.orgs, "org_a")
+			writeTestProject(t, d, "proj_expired", "org_a", expired)
+			writeTestProject(t, d, "proj_recent", "org_a", now.Add(-time.Hour))
+			writeTestProject(t, d, "proj_live", "org_a", time.Time{})
+
+			report, err := janitor.Purge(ctx, now)
+			if err != nil {
+				t.Fatalf("Purge: %v", err)
+			}
+			if report.PurgedProjects != 1 {
+				t.Errorf("PurgedProjects = %d, want 1", report.PurgedProjects)
+			}
+
+			_, err = d.projects.ReadProjectIncludingDeleted(ctx, "proj_expired")
+			if purged := err != nil; purged == tt.dryRun {
+				t.Errorf("proj_expired purged = %t, want %t", purged, !tt.dryRun)
+			}
+			tasks, _ := d.tasks.ListTasks(ctx, "proj_expired", TaskFilter{})
+			if kept := len(tasks) == 1; kept != tt.dryRun {
+				t.Errorf("proj_expired has %d tasks after the purge", len(tasks))
+			}
+
+			for _, id := range []string{"proj_recent", "proj_live"} {
+				if _, err := d.projects.ReadProjectIncludingDeleted(ctx, id); err != nil {
+					t.Errorf("%s was purged: %v", id, err)
+				}
+				if tasks, _ := d.tasks.ListTasks(ctx, id, TaskFilter{}); len(tasks) != 1 {
+					t.Errorf("%s has %d tasks, want 1", id, len(tasks))
+				}
+			}
+		})
+	}
+}
+
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/policy"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// maxSandboxes bounds how many sandboxes exist at once
+const maxSandboxes = 100
+
+// ErrSandboxNotFound is returned for an unknown sandbox
+var ErrSandboxNotFound = errors.New("sandbox not found")
+
+// ErrSandboxOrgNotFound is returned when the organization to copy does not exist
+var ErrSandboxOrgNotFound = errors.New("sandbox organization not found")
+
+// ErrSandboxExists is returned when an organization already has a sandbox
+var ErrSandboxExists = errors.New("organization already has a sandbox")
+
+// ErrSandboxNotAllowed is returned when the requester may not manage the organization's sandbox
+var ErrSandboxNotAllowed = errors.New("sandbox not allowed")
+
+// SandboxEnvironment is a sandbox together with the isolated stores that
+// hold its copy of the organization's data. A reset replaces the whole
+// environment, so holders of an old one keep a consistent view.
+type SandboxEnvironment struct {
+	Sandbox  *models.Sandbox
+	Users    *UserService
+	Orgs     *OrganizationService
+	Projects *ProjectService
+}
+
+// SandboxService creates per-organization sandboxes: copies of an
+// organization, its members, and its projects held in their own stores.
+// Members' names and emails are anonymized in the copy; IDs are kept so
+// integrators can line sandbox records up with production ones.
+type SandboxService struct {
+	idSource  // Hands out IDs for created entities
+	sandboxes map[string]*SandboxEnvironment
+	byOrg     map[string]string // Org ID -> sandbox ID
+	users     *UserService
+	orgs      *OrganizationService
+	projects  *ProjectService
+	enums     *EnumService // Optional; sandbox orgs validate enum values like production with it
+	mu        sync.RWMutex
+}
+
+// NewSandboxService creates a new SandboxService instance
+func NewSandboxService(users *UserService, orgs *OrganizationService, projects *ProjectService) *SandboxService {
+	return &SandboxService{
+		sandboxes: make(map[string]*SandboxEnvironment),
+		byOrg:     make(map[string]string),
+		users:     users,
+		orgs:      orgs,
+		projects:  projects,
+	}
+}
+
+// SetEnums attaches the enum registry sandbox organizations validate
+// against (pointer receiver)
+func (s *SandboxService) SetEnums(enums *EnumService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enums = enums
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// CreateSandbox copies an organization's data into a new sandbox. The
+// requester needs the edit_org permission (pointer receiver).
+func (s *SandboxService) CreateSandbox(ctx context.Context, orgID, createdBy string) (*models.Sandbox, error) {
+	defer timing.Track(ctx, timing.LayerService, "sandboxes.CreateSandbox")()
+
+	if _, err := s.orgs.ReadOrg(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSandboxOrgNotFound, orgID)
+	}
+	if err := s.checkRequester(ctx, orgID, createdBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, exists := s.byOrg[orgID]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrSandboxExists, id)
+	}
+	if len(s.sandboxes) >= maxSandboxes {
+		return nil, fmt.Errorf("sandbox limit of %d reached", maxSandboxes)
+	}
+
+	sandbox := models.NewSandbox(s.NewID(IDPrefixSandbox), orgID, createdBy)
+	env, err := s.populate(ctx, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	s.sandboxes[sandbox.ID] = env
+	s.byOrg[orgID] = sandbox.ID
+
+	copied := *env.Sandbox
+	return &copied, nil
+}
+
+// ResetSandbox discards everything written to a sandbox and copies the
+// organization's current data again (pointer receiver)
+func (s *SandboxService) ResetSandbox(ctx context.Context, orgID, requestedBy string) (*models.Sandbox, error) {
+	defer timing.Track(ctx, timing.LayerService, "sandboxes.ResetSandbox")()
+
+	if err := s.checkRequester(ctx, orgID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.forOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	sandbox := *existing.Sandbox
+	now := models.Now()
+	sandbox.ResetAt = &now
+	sandbox.Resets++
+	sandbox.UpdatedAt = now
+	env, err := s.populate(ctx, &sandbox)
+	if err != nil {
+		return nil, err
+	}
+	s.sandboxes[sandbox.ID] = env
+
+	copied := *env.Sandbox
+	return &copied, nil
+}
+
+// DeleteSandbox removes an organization's sandbox and its data (pointer receiver)
+func (s *SandboxService) DeleteSandbox(ctx context.Context, orgID, requestedBy string) error {
+	if err := s.checkRequester(ctx, orgID, requestedBy); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	env, err := s.forOrg(orgID)
+	if err != nil {
+		return err
+	}
+	delete(s.sandboxes, env.Sandbox.ID)
+	delete(s.byOrg, orgID)
+	return nil
+}
+
+// RemoveOrgSandbox removes an organization's sandbox without a permission
+// check, for cleanup after the organization is gone; it returns how many
+// sandboxes were removed (pointer receiver)
+func (s *SandboxService) RemoveOrgSandbox(ctx context.Context, orgID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, exists := s.byOrg[orgID]
+	if !exists {
+		return 0, nil
+	}
+	delete(s.sandboxes, id)
+	delete(s.byOrg, orgID)
+	return 1, nil
+}
+
+// ReadSandbox retrieves an organization's sandbox (pointer receiver)
+func (s *SandboxService) ReadSandbox(ctx context.Context, orgID string) (*models.Sandbox, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	env, err := s.forOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	copied := *env.Sandbox
+	return &copied, nil
+}
+
+// ListSandboxes lists every sandbox, oldest first (pointer receiver)
+func (s *SandboxService) ListSandboxes(ctx context.Context) ([]models.Sandbox, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sandboxes := make([]models.Sandbox, 0, len(s.sandboxes))
+	for _, env := range s.sandboxes {
+		sandboxes = append(sandboxes, *env.Sandbox)
+	}
+	sort.Slice(sandboxes, func(i, j int) bool {
+		return sandboxes[i].CreatedAt.Before(sandboxes[j].CreatedAt)
+	})
+	return sandboxes, nil
+}
+
+// Environment returns the stores requests to a sandbox are served from (pointer receiver)
+func (s *SandboxService) Environment(id string) (*SandboxEnvironment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	env, exists := s.sandboxes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSandboxNotFound, id)
+	}
+	return env, nil
+}
+
+// forOrg looks up an organization's sandbox; callers hold s.mu (pointer receiver)
+func (s *SandboxService) forOrg(orgID string) (*SandboxEnvironment, error) {
+	id, exists := s.byOrg[orgID]
+	if !exists {
+		return nil, fmt.Errorf("%w: organization %s has no sandbox", ErrSandboxNotFound, orgID)
+	}
+	return s.sandboxes[id], nil
+}
+
+// checkRequester verifies that a user may manage an organization's
+// sandbox (pointer receiver)
+func (s *SandboxService) checkRequester(ctx context.Context, orgID, userID string) error {
+	membership, err := s.orgs.GetMembership(ctx, userID, orgID)
+	if err != nil || !policy.Allows(membership.Role, policy.ActionEditOrg) {
+		return fmt.Errorf("%w: %s may not edit %s", ErrSandboxNotAllowed, userID, orgID)
+	}
+	return nil
+}
+
+// populate builds fresh stores holding a copy of the sandbox's
+// organization: the organization, its memberships, its projects, and the
+// users they refer to, anonymized; callers hold s.mu (pointer receiver)
+func (s *SandboxService) populate(ctx context.Context, sandbox *models.Sandbox) (*SandboxEnvironment, error) {
+	env := &SandboxEnvironment{
+		Sandbox: sandbox,
+		Users:   NewUserService(),
+		Orgs:    NewOrganizationService(),
+	}
+	env.Projects = NewProjectService(env.Orgs)
+	if s.enums != nil {
+		env.Orgs.SetEnums(s.enums)
+	}
+
+	org, err := s.orgs.ReadOrg(ctx, sandbox.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgs.GetMembers(ctx, sandbox.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projects.ReadProjectsByOrg(ctx, sandbox.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy every user the organization's records point at
+	userIDs := []string{org.OwnerID}
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	for _, project := range projects {
+		userIDs = append(userIDs, project.OwnerID)
+	}
+	copiedUsers := make(map[string]bool)
+	for _, id := range userIDs {
+		if copiedUsers[id] {
+			continue
+		}
+		user, err := s.users.Read(ctx, id)
+		if err != nil {
+			continue
+		}
+		anonymized := anonymizeUser(*user, len(copiedUsers)+1)
+		if err := env.Users.Write(ctx, &anonymized); err != nil {
+			return nil, fmt.Errorf("failed to copy user %s: %w", id, err)
+		}
+		copiedUsers[id] = true
+	}
+
+	orgCopy := *org
+	orgCopy.Version = 0
+	if err := env.Orgs.WriteOrg(ctx, &orgCopy); err != nil {
+		return nil, fmt.Errorf("failed to copy organization: %w", err)
+	}
+
+	memberships := make([]*models.Membership, 0, len(members))
+	for _, member := range members {
+		membership := *member
+		memberships = append(memberships, &membership)
+	}
+	for i, err := range env.Orgs.AddMembers(ctx, memberships) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy membership of %s: %w", memberships[i].UserID, err)
+		}
+	}
+
+	for _, project := range projects {
+		projectCopy := project
+		projectCopy.Version = 0
+		if err := env.Projects.WriteProject(ctx, &projectCopy); err != nil {
+			return nil, fmt.Errorf("failed to copy project %s: %w", project.ID, err)
+		}
+	}
+
+	sandbox.Users = len(copiedUsers)
+	sandbox.Memberships = len(memberships)
+	sandbox.Projects = len(projects)
+	return env, nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// anonymizeUser replaces a user's personal details with placeholders that
+// are stable within one copy (standalone function)
+func anonymizeUser(user models.User, n int) models.User {
+	user.FirstName = "Sandbox"
+	user.LastName = fmt.Sprintf("User %d", n)
+	user.Email = fmt.Sprintf("sandbox-user-%d@example.invalid", n)
+	user.Version = 0
+	return user
+}
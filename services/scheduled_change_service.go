@@ -0,0 +1,504 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/i18n"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// DefaultSchedulerInterval is how often due changes are applied when
+// SchedulerConfig.Interval is zero
+const DefaultSchedulerInterval = time.Minute
+
+// ErrScheduledChangeNotFound is returned for an unknown scheduled change
+var ErrScheduledChangeNotFound = errors.New("scheduled change not found")
+
+// ErrScheduledChangeInvalid is returned when a change cannot be scheduled
+var ErrScheduledChangeInvalid = errors.New("invalid scheduled change")
+
+// ErrScheduledChangeClosed is returned when cancelling a change that is no longer pending
+var ErrScheduledChangeClosed = errors.New("scheduled change is no longer pending")
+
+// SchedulerConfig controls how often the scheduler looks for due changes
+type SchedulerConfig struct {
+	Interval time.Duration
+}
+
+// ScheduledChangeFilter narrows ListChanges; zero fields match everything
+type ScheduledChangeFilter struct {
+	Target   models.ScheduledChangeTarget
+	TargetID string
+	OrgID    string
+	Status   models.ScheduledChangeStatus
+}
+
+// userChanges are the fields a scheduled user change may set, as accepted
+// by PUT /users/{id}; empty fields are left unchanged
+type userChanges struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Locale    string `json:"locale"`
+	Timezone  string `json:"timezone"`
+}
+
+// orgChanges are the fields a scheduled organization change may set, as
+// accepted by PUT /organizations/{id}; empty fields are left unchanged
+type orgChanges struct {
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	Industry      string         `json:"industry"`
+	Size          models.OrgSize `json:"size"`
+	Slug          string         `json:"slug"`
+	PublicProfile *bool          `json:"public_profile"`
+}
+
+// membershipChanges are the fields a scheduled membership change may set
+type membershipChanges struct {
+	Role models.MemberRole `json:"role"`
+}
+
+// ChangeScheduler stores updates scheduled to take effect later and, as a
+// background worker between Initialize and Shutdown, applies each once its
+// effective time has passed. A change is checked against the current
+// record when scheduled and again when applied; one that no longer
+// applies is marked failed with the reason.
+type ChangeScheduler struct {
+	idSource // Hands out IDs for created entities
+	changes  map[string]*models.ScheduledChange
+	users    *UserService
+	orgs     *OrganizationService
+	config   SchedulerConfig
+	logger   *log.Logger
+	stop     chan struct{}
+	done     chan struct{}
+	mu       sync.Mutex
+}
+
+// NewChangeScheduler creates a new ChangeScheduler instance
+func NewChangeScheduler(users *UserService, orgs *OrganizationService, config SchedulerConfig, logger *log.Logger) *ChangeScheduler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultSchedulerInterval
+	}
+	return &ChangeScheduler{
+		changes: make(map[string]*models.ScheduledChange),
+		users:   users,
+		orgs:    orgs,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Initialize starts the background loop (pointer receiver)
+func (s *ChangeScheduler) Initialize(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return errors.New("change scheduler already running")
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(s.stop, s.done)
+	return nil
+}
+
+// Shutdown stops the background loop (pointer receiver)
+func (s *ChangeScheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run applies due changes on every tick until stopped (pointer receiver)
+func (s *ChangeScheduler) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.ApplyDue(context.Background(), now.UTC())
+		}
+	}
+}
+
+// =====================================
+// Scheduling
+// =====================================
+
+// Schedule stores a change to take effect at effectiveAt, after checking
+// that the target exists and the change would apply to it now. A non-zero
+// targetVersion pins a user or organization change to that version: it
+// fails with ErrVersionConflict if the target has already moved on, and
+// is marked failed when applied if the target changed in the meantime
+// (pointer receiver).
+func (s *ChangeScheduler) Schedule(ctx context.Context, target models.ScheduledChangeTarget, targetID, orgID string, changes json.RawMessage, effectiveAt time.Time, targetVersion int64, requestedBy string) (*models.ScheduledChange, error) {
+	if !target.IsValid() {
+		return nil, fmt.Errorf("%w: target must be user, organization, or membership", ErrScheduledChangeInvalid)
+	}
+	if targetID == "" {
+		return nil, fmt.Errorf("%w: target_id is required", ErrScheduledChangeInvalid)
+	}
+	if target == models.ScheduledChangeMembership && orgID == "" {
+		return nil, fmt.Errorf("%w: org_id is required for membership changes", ErrScheduledChangeInvalid)
+	}
+	if target != models.ScheduledChangeMembership {
+		orgID = ""
+	}
+	if effectiveAt.IsZero() || !effectiveAt.After(models.Now()) {
+		return nil, fmt.Errorf("%w: effective_at must be in the future", ErrScheduledChangeInvalid)
+	}
+	if targetVersion != 0 && target == models.ScheduledChangeMembership {
+		return nil, fmt.Errorf("%w: memberships are not versioned", ErrScheduledChangeInvalid)
+	}
+
+	change := models.NewScheduledChange(s.NewID(IDPrefixScheduledChange), target, targetID, orgID, changes, effectiveAt.UTC(), requestedBy)
+	change.TargetVersion = targetVersion
+	if err := s.apply(ctx, change, true); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrScheduledChangeInvalid, err)
+	}
+	change.Changes = append(json.RawMessage(nil), bytes.TrimSpace(changes)...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes[change.ID] = change
+
+	s.logger.Printf("event=scheduled_change.created id=%s target=%s target_id=%s effective_at=%s requested_by=%s",
+		change.ID, change.Target, change.TargetID, change.EffectiveAt.Format(time.RFC3339), change.RequestedBy)
+	return copyScheduledChange(change), nil
+}
+
+// ReadChange retrieves a scheduled change (pointer receiver)
+func (s *ChangeScheduler) ReadChange(ctx context.Context, id string) (*models.ScheduledChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change, exists := s.changes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrScheduledChangeNotFound, id)
+	}
+	return copyScheduledChange(change), nil
+}
+
+// ListChanges lists scheduled changes matching a filter, soonest first
+// (pointer receiver)
+func (s *ChangeScheduler) ListChanges(ctx context.Context, filter ScheduledChangeFilter) ([]models.ScheduledChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := make([]models.ScheduledChange, 0)
+	for _, change := range s.changes {
+		if (filter.Target != "" && change.Target != filter.Target) ||
+			(filter.TargetID != "" && change.TargetID != filter.TargetID) ||
+			(filter.OrgID != "" && change.OrgID != filter.OrgID) ||
+			(filter.Status != "" && change.Status != filter.Status) {
+			continue
+		}
+		changes = append(changes, *copyScheduledChange(change))
+	}
+
+	sortScheduledChanges(changes)
+	return changes, nil
+}
+
+// CancelChange cancels a pending change so it never takes effect (pointer receiver)
+func (s *ChangeScheduler) CancelChange(ctx context.Context, id, cancelledBy string) (*models.ScheduledChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change, exists := s.changes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrScheduledChangeNotFound, id)
+	}
+	if change.Status != models.ScheduledChangePending {
+		return nil, fmt.Errorf("%w: change is %s", ErrScheduledChangeClosed, change.Status)
+	}
+
+	now := models.Now()
+	cancelled := copyScheduledChange(change)
+	cancelled.Status = models.ScheduledChangeCancelled
+	cancelled.CancelledBy = cancelledBy
+	cancelled.CancelledAt = &now
+	cancelled.UpdatedAt = now
+	s.changes[id] = cancelled
+
+	s.logger.Printf("event=scheduled_change.cancelled id=%s target=%s target_id=%s cancelled_by=%s",
+		cancelled.ID, cancelled.Target, cancelled.TargetID, cancelledBy)
+	return copyScheduledChange(cancelled), nil
+}
+
+// ApplyDue applies every pending change whose effective time has passed,
+// oldest effective time first, and returns them with their outcome. The
+// lock is held throughout, so a change cannot be cancelled while it is
+// being applied (pointer receiver).
+func (s *ChangeScheduler) ApplyDue(ctx context.Context, now time.Time) []models.ScheduledChange {
+	defer timing.Track(ctx, timing.LayerService, "scheduler.ApplyDue")()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]models.ScheduledChange, 0)
+	for _, change := range s.changes {
+		if change.IsDue(now) {
+			due = append(due, *change)
+		}
+	}
+	sortScheduledChanges(due)
+
+	for i := range due {
+		change := &due[i]
+		appliedAt := models.Now()
+		change.UpdatedAt = appliedAt
+		if err := s.apply(ctx, change, false); err != nil {
+			change.Status = models.ScheduledChangeFailed
+			change.Error = err.Error()
+			s.logger.Printf("event=scheduled_change.failed id=%s target=%s target_id=%s error=%q",
+				change.ID, change.Target, change.TargetID, change.Error)
+		} else {
+			change.Status = models.ScheduledChangeApplied
+			change.AppliedAt = &appliedAt
+			s.logger.Printf("event=scheduled_change.applied id=%s target=%s target_id=%s effective_at=%s",
+				change.ID, change.Target, change.TargetID, change.EffectiveAt.Format(time.RFC3339))
+		}
+		s.changes[change.ID] = copyScheduledChange(change)
+	}
+	return due
+}
+
+// RemoveOrgChanges removes every change scheduled against an organization
+// or its memberships and returns how many were removed (pointer receiver)
+func (s *ChangeScheduler) RemoveOrgChanges(ctx context.Context, orgID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, change := range s.changes {
+		if change.OrgID == orgID || (change.Target == models.ScheduledChangeOrganization && change.TargetID == orgID) {
+			delete(s.changes, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Config returns the scheduler settings (pointer receiver)
+func (s *ChangeScheduler) Config() SchedulerConfig {
+	return s.config
+}
+
+// =====================================
+// Applying Changes
+// =====================================
+
+// apply makes a change to its target, or with dryRun only checks that it
+// would succeed (pointer receiver)
+func (s *ChangeScheduler) apply(ctx context.Context, change *models.ScheduledChange, dryRun bool) error {
+	switch change.Target {
+	case models.ScheduledChangeUser:
+		return s.applyUser(ctx, change, dryRun)
+	case models.ScheduledChangeOrganization:
+		return s.applyOrg(ctx, change, dryRun)
+	case models.ScheduledChangeMembership:
+		return s.applyMembership(ctx, change, dryRun)
+	}
+	return fmt.Errorf("unknown target %q", change.Target)
+}
+
+// applyUser updates a user as PUT /users/{id} would (pointer receiver)
+func (s *ChangeScheduler) applyUser(ctx context.Context, change *models.ScheduledChange, dryRun bool) error {
+	var fields userChanges
+	if err := decodeChanges(change.Changes, &fields); err != nil {
+		return err
+	}
+	existing, err := s.users.Read(ctx, change.TargetID)
+	if err != nil {
+		return fmt.Errorf("user %s not found", change.TargetID)
+	}
+	if err := checkTargetVersion(change, existing.Version); err != nil {
+		return err
+	}
+
+	// Work on a copy so a rejected change leaves the stored user untouched
+	updated := *existing
+	user := &updated
+	if fields.FirstName != "" || fields.LastName != "" {
+		user.UpdateName(fields.FirstName, fields.LastName)
+	}
+	if fields.Email != "" {
+		email, err := s.users.VerifyEmail(ctx, fields.Email)
+		if err != nil {
+			return err
+		}
+		user.UpdateEmail(email)
+	}
+	if fields.Role != "" {
+		user.SetRole(fields.Role)
+	}
+	if fields.Locale != "" {
+		user.SetLocale(i18n.Normalize(fields.Locale))
+	}
+	if fields.Timezone != "" {
+		user.SetTimezone(fields.Timezone)
+	}
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return nil
+	}
+	return s.users.Write(ctx, user)
+}
+
+// applyOrg updates an organization as PUT /organizations/{id} would (pointer receiver)
+func (s *ChangeScheduler) applyOrg(ctx context.Context, change *models.ScheduledChange, dryRun bool) error {
+	var fields orgChanges
+	if err := decodeChanges(change.Changes, &fields); err != nil {
+		return err
+	}
+	existing, err := s.orgs.ReadOrg(ctx, change.TargetID)
+	if err != nil {
+		return fmt.Errorf("organization %s not found", change.TargetID)
+	}
+	if err := checkTargetVersion(change, existing.Version); err != nil {
+		return err
+	}
+
+	// Work on a copy so a rejected change leaves the stored organization untouched
+	updated := *existing
+	org := &updated
+	if fields.Name != "" {
+		org.UpdateName(fields.Name)
+	}
+	if fields.Description != "" {
+		org.UpdateDescription(fields.Description)
+	}
+	if fields.Industry != "" {
+		org.SetIndustry(fields.Industry)
+	}
+	if fields.Size != "" {
+		org.SetSize(fields.Size)
+	}
+	if fields.Slug != "" {
+		org.SetSlug(fields.Slug)
+	}
+	if fields.PublicProfile != nil {
+		org.SetPublicProfile(*fields.PublicProfile)
+	}
+	if err := org.Validate(); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return nil
+	}
+	return s.orgs.WriteOrg(ctx, org)
+}
+
+// applyMembership changes a member's role (pointer receiver)
+func (s *ChangeScheduler) applyMembership(ctx context.Context, change *models.ScheduledChange, dryRun bool) error {
+	var fields membershipChanges
+	if err := decodeChanges(change.Changes, &fields); err != nil {
+		return err
+	}
+	if fields.Role == "" {
+		return errors.New("role is required")
+	}
+	if err := s.orgs.ValidateMemberRole(fields.Role); err != nil {
+		return err
+	}
+	if _, err := s.orgs.GetMembership(ctx, change.TargetID, change.OrgID); err != nil {
+		return fmt.Errorf("user %s is not a member of %s", change.TargetID, change.OrgID)
+	}
+
+	if dryRun {
+		return nil
+	}
+	return s.orgs.UpdateMemberRole(ctx, change.TargetID, change.OrgID, fields.Role)
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// checkTargetVersion fails a change pinned to a version other than the
+// target's current one (standalone function)
+func checkTargetVersion(change *models.ScheduledChange, version int64) error {
+	if change.TargetVersion != 0 && change.TargetVersion != version {
+		return fmt.Errorf("%w: %s %s is at version %d, the change was made against %d",
+			ErrVersionConflict, change.Target, change.TargetID, version, change.TargetVersion)
+	}
+	return nil
+}
+
+// decodeChanges decodes a change's fields, rejecting empty changes and
+// unknown fields so a typo is caught when scheduling rather than silently
+// ignored when applied (standalone function)
+func decodeChanges(changes json.RawMessage, target interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(changes, &fields); err != nil || len(fields) == 0 {
+		return errors.New("changes must be a non-empty JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(changes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("invalid changes: %v", err)
+	}
+	return nil
+}
+
+// sortScheduledChanges orders changes by effective time, then creation (standalone function)
+func sortScheduledChanges(changes []models.ScheduledChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].EffectiveAt.Equal(changes[j].EffectiveAt) {
+			return changes[i].EffectiveAt.Before(changes[j].EffectiveAt)
+		}
+		return changes[i].CreatedAt.Before(changes[j].CreatedAt)
+	})
+}
+
+// copyScheduledChange copies a change so callers cannot modify the stored one (standalone function)
+func copyScheduledChange(change *models.ScheduledChange) *models.ScheduledChange {
+	c := *change
+	c.Changes = append(json.RawMessage(nil), change.Changes...)
+	return &c
+}
+
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// newTestScheduler returns a ChangeScheduler, whose worker is not started,
+// over a user store holding user_a
+func newTestScheduler(t *testing.T) (*ChangeScheduler, *UserService) {
+	t.Helper()
+	users := NewUserService()
+	if err := users.Write(context.Background(), CreateUser("user_a", "Ada", "Lovelace", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+	return NewChangeScheduler(users, NewOrganizationService(), SchedulerConfig{}, log.New(io.Discard, "", 0)), users
+}
+
+// editUser changes user_a's timezone the way PUT /users/{id} does
+func editUser(ctx context.Context, users *UserService) error {
+	user, err := users.Read(ctx, "user_a")
+	if err != nil {
+		return err
+	}
+	user.SetTimezone("Europe/Paris")
+	return users.Write(ctx, user)
+}
+
+func TestScheduledChangeTargetVersion(t *testing.T) {
+	rename := json.RawMessage(`{"first_name":"Augusta"}`)
+	tests := []struct {
+		name        string
+		pin         func(version int64) int64 // Version to schedule against, given user_a's
+		editBefore  bool                      // Edit user_a directly before the change is due
+		scheduleErr error
+		want        models.ScheduledChangeStatus
+	}{
+		{"unpinned", func(int64) int64 { return 0 }, false, nil, models.ScheduledChangeApplied},
+		{"unpinned, edited since", func(int64) int64 { return 0 }, true, nil, models.ScheduledChangeApplied},
+		{"pinned", func(v int64) int64 { return v }, false, nil, models.ScheduledChangeApplied},
+		{"pinned, edited since", func(v int64) int64 { return v }, true, nil, models.ScheduledChangeFailed},
+		{"pinned to another version", func(v int64) int64 { return v + 1 }, false, ErrVersionConflict, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, users := newTestScheduler(t)
+			user, _ := users.Read(ctx, "user_a")
+			effectiveAt := models.Now().Add(time.Hour)
+
+			change, err := s.Schedule(ctx, models.ScheduledChangeUser, "user_a", "", rename, effectiveAt, tt.pin(user.Version), "user_admin")
+			if !errors.Is(err, tt.scheduleErr) {
+				t.Fatalf("Schedule: err = %v, want %v", err, tt.scheduleErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.editBefore {
+				if err := editUser(ctx, users); err != nil {
+					t.Fatalf("direct edit: %v", err)
+				}
+			}
+
+			applied := s.ApplyDue(ctx, effectiveAt.Add(time.Second))
+			if len(applied) != 1 || applied[0].ID != change.ID {
+				t.Fatalf("ApplyDue = %+v, want only %s", applied, change.ID)
+			}
+			if applied[0].Status != tt.want {
+				t.Fatalf("status = %s (%s), want %s", applied[0].Status, applied[0].Error, tt.want)
+			}
+
+			stored, _ := users.Read(ctx, "user_a")
+			if tt.want == models.ScheduledChangeFailed {
+				if !strings.Contains(applied[0].Error, ErrVersionConflict.Error()) {
+					t.Errorf("error = %q, want a version conflict", applied[0].Error)
+				}
+				if stored.FirstName != "Ada" {
+					t.Errorf("failed change renamed the user to %q", stored.FirstName)
+				}
+				return
+			}
+			if stored.FirstName != "Augusta" {
+				t.Errorf("first name = %q, want Augusta", stored.FirstName)
+			}
+			if tt.editBefore && stored.Timezone != "Europe/Paris" {
+				t.Errorf("change lost the direct edit: timezone = %q", stored.Timezone)
+			}
+		})
+	}
+}
+
+func TestScheduleMembershipRejectsVersion(t *testing.T) {
+	s, _ := newTestScheduler(t)
+	_, err := s.Schedule(context.Background(), models.ScheduledChangeMembership, "user_a", "org_a",
+		json.RawMessage(`{"role":"admin"}`), models.Now().Add(time.Hour), 3, "user_admin")
+	if !errors.Is(err, ErrScheduledChangeInvalid) {
+		t.Fatalf("Schedule: err = %v, want ErrScheduledChangeInvalid", err)
+	}
+}
+
+func TestApplyDueRacesDirectEdit(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		ctx := context.Background()
+		s, users := newTestScheduler(t)
+		effectiveAt := models.Now().Add(time.Hour)
+		if _, err := s.Schedule(ctx, models.ScheduledChangeUser, "user_a", "", json.RawMessage(`{"first_name":"Augusta"}`), effectiveAt, 0, "user_admin"); err != nil {
+			t.Fatalf("Schedule: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		var applied []models.ScheduledChange
+		var editErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			applied = s.ApplyDue(ctx, effectiveAt.Add(time.Second))
+		}()
+		go func() {
+			defer wg.Done()
+			editErr = editUser(ctx, users)
+		}()
+		wg.Wait()
+
+		if len(applied) != 1 {
+			t.Fatalf("ApplyDue returned %d changes, want 1", len(applied))
+		}
+		change := applied[0]
+		if editErr != nil && !errors.Is(editErr, ErrVersionConflict) {
+			t.Fatalf("direct edit: err = %v, want nil or ErrVersionConflict", editErr)
+		}
+		if change.Status == models.ScheduledChangeFailed && !strings.Contains(change.Error, ErrVersionConflict.Error()) {
+			t.Fatalf("change failed with %q, want a version conflict", change.Error)
+		}
+
+		// Whatever won is stored; neither write silently undid the other
+		stored, _ := users.Read(ctx, "user_a")
+		if (change.Status == models.ScheduledChangeApplied) != (stored.FirstName == "Augusta") {
+			t.Fatalf("change %s but first name is %q", change.Status, stored.FirstName)
+		}
+		if (editErr == nil) != (stored.Timezone == "Europe/Paris") {
+			t.Fatalf("edit err %v but timezone is %q", editErr, stored.Timezone)
+		}
+	}
+}
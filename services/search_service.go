@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// searchFilterType is the filter key used to restrict results by document type
+const searchFilterType = "type"
+
+// searchDocument is an indexed entity
+type searchDocument struct {
+	docType models.SearchDocumentType
+	id      string
+	title   string
+	fields  map[string]string
+	tokens  []string
+	deleted bool // Soft-deleted entities are never indexed
+}
+
+// key returns the unique index key of the document (value receiver)
+func (d searchDocument) key() string {
+	return searchKey(d.docType, d.id)
+}
+
+// SearchService provides full-text search over users, organizations, and projects
+// Implements interfaces.SearchEngine (Searchable + Indexable) with an in-memory inverted index
+type SearchService struct {
+	users    *UserService
+	orgs     *OrganizationService
+	projects interfaces.ProjectReader // Optional, may be nil
+	docs     map[string]*searchDocument
+	index    map[string]map[string]struct{} // token -> document keys
+	mu       sync.RWMutex
+}
+
+// NewSearchService creates a new SearchService instance
+func NewSearchService(users *UserService, orgs *OrganizationService, projects interfaces.ProjectReader) *SearchService {
+	return &SearchService{
+		users:    users,
+		orgs:     orgs,
+		projects: projects,
+		docs:     make(map[string]*searchDocument),
+		index:    make(map[string]map[string]struct{}),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods - Searchable Implementation
+// =====================================
+
+// Search finds documents matching every term of the query (pointer receiver)
+func (s *SearchService) Search(ctx context.Context, query string) ([]interface{}, error) {
+	return s.SearchWithFilters(ctx, query, nil)
+}
+
+// SearchWithFilters finds documents matching the query and all filters.
+// The "type" filter accepts a string or []string of document types; any
+// other key is matched exactly against the document's fields (pointer receiver).
+func (s *SearchService) SearchWithFilters(ctx context.Context, query string, filters map[string]interface{}) ([]interface{}, error) {
+	defer timing.Track(ctx, timing.LayerService, "search.SearchWithFilters")()
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil, errors.New("search query is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scores := make(map[string]int)
+	for i, term := range terms {
+		matched := s.matchTerm(term)
+		if i == 0 {
+			for key, score := range matched {
+				scores[key] = score
+			}
+			continue
+		}
+		// Every term must match
+		for key := range scores {
+			if score, ok := matched[key]; ok {
+				scores[key] += score
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+
+	hits := make([]models.SearchResult, 0, len(scores))
+	for key, score := range scores {
+		doc := s.docs[key]
+		if !matchesFilters(doc, filters) {
+			continue
+		}
+		hits = append(hits, models.SearchResult{
+			Type:  doc.docType,
+			ID:    doc.id,
+			Title: doc.title,
+			Score: score,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Title < hits[j].Title
+	})
+
+	results := make([]interface{}, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, hit)
+	}
+	return results, nil
+}
+
+// matchTerm returns matching document keys with a score; exact token
+// matches score higher than prefix matches (pointer receiver)
+func (s *SearchService) matchTerm(term string) map[string]int {
+	matched := make(map[string]int)
+	for token, keys := range s.index {
+		score := 0
+		switch {
+		case token == term:
+			score = 2
+		case strings.HasPrefix(token, term):
+			score = 1
+		default:
+			continue
+		}
+		for key := range keys {
+			if score > matched[key] {
+				matched[key] = score
+			}
+		}
+	}
+	return matched
+}
+
+// =====================================
+// Pointer Receiver Methods - Indexable Implementation
+// =====================================
+
+// Index adds or replaces the document for a user, organization, or project (pointer receiver)
+func (s *SearchService) Index(ctx context.Context, id string, data interface{}) error {
+	var doc *searchDocument
+	switch v := data.(type) {
+	case *models.User:
+		doc = userDocument(v)
+	case models.User:
+		doc = userDocument(&v)
+	case *models.Organization:
+		doc = orgDocument(v)
+	case models.Organization:
+		doc = orgDocument(&v)
+	case *models.Project:
+		doc = projectDocument(v)
+	case models.Project:
+		doc = projectDocument(&v)
+	default:
+		return fmt.Errorf("unsupported search document type %T", data)
+	}
+	doc.id = id
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeDocument(doc.key())
+	if !doc.deleted {
+		s.addDocument(doc)
+	}
+	return nil
+}
+
+// DeleteIndex removes every document with the given ID (pointer receiver)
+func (s *SearchService) DeleteIndex(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, docType := range []models.SearchDocumentType{
+		models.SearchTypeUser,
+		models.SearchTypeOrganization,
+		models.SearchTypeProject,
+	} {
+		s.removeDocument(searchKey(docType, id))
+	}
+	return nil
+}
+
+// Reindex rebuilds the index from scratch using the backing services (pointer receiver)
+func (s *SearchService) Reindex(ctx context.Context) error {
+	defer timing.Track(ctx, timing.LayerService, "search.Reindex")()
+	docs := make([]*searchDocument, 0)
+
+	err := s.users.IterateUsers(ctx, func(u models.User) error {
+		docs = append(docs, userDocument(&u))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.orgs.IterateOrgs(ctx, func(o models.Organization) error {
+		docs = append(docs, orgDocument(&o))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.projects != nil {
+		projects, err := s.projects.ReadAllProjects(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range projects {
+			docs = append(docs, projectDocument(&projects[i]))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.docs = make(map[string]*searchDocument, len(docs))
+	s.index = make(map[string]map[string]struct{})
+	for _, doc := range docs {
+		if !doc.deleted {
+			s.addDocument(doc)
+		}
+	}
+	return nil
+}
+
+// addDocument adds a document to the index; callers must hold the write lock (pointer receiver)
+func (s *SearchService) addDocument(doc *searchDocument) {
+	key := doc.key()
+	s.docs[key] = doc
+	for _, token := range doc.tokens {
+		keys, exists := s.index[token]
+		if !exists {
+			keys = make(map[string]struct{})
+			s.index[token] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// removeDocument drops a document from the index; callers must hold the write lock (pointer receiver)
+func (s *SearchService) removeDocument(key string) {
+	doc, exists := s.docs[key]
+	if !exists {
+		return
+	}
+	for _, token := range doc.tokens {
+		keys := s.index[token]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(s.index, token)
+		}
+	}
+	delete(s.docs, key)
+}
+
+// DocumentCount returns the number of indexed documents (pointer receiver)
+func (s *SearchService) DocumentCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.docs)
+}
+
+// =====================================
+// Standalone Functions for Search
+// =====================================
+
+// searchKey builds the index key for a document
+func searchKey(docType models.SearchDocumentType, id string) string {
+	return fmt.Sprintf("%s:%s", docType, id)
+}
+
+// tokenize lowercases text and splits it into alphanumeric tokens
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// newSearchDocument builds a document, tokenizing its title and searchable text
+func newSearchDocument(docType models.SearchDocumentType, id, title string, fields map[string]string, text ...string) *searchDocument {
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	for _, t := range append([]string{title}, text...) {
+		for _, token := range tokenize(t) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return &searchDocument{
+		docType: docType,
+		id:      id,
+		title:   title,
+		fields:  fields,
+		tokens:  tokens,
+	}
+}
+
+// userDocument converts a user into a search document
+func userDocument(u *models.User) *searchDocument {
+	doc := newSearchDocument(models.SearchTypeUser, u.ID, u.FullName(),
+		map[string]string{"role": u.Role},
+		u.Email, u.Role)
+	doc.deleted = u.IsDeleted()
+	return doc
+}
+
+// orgDocument converts an organization into a search document
+func orgDocument(o *models.Organization) *searchDocument {
+	doc := newSearchDocument(models.SearchTypeOrganization, o.ID, o.DisplayName(),
+		map[string]string{"industry": o.Industry, "size": string(o.Size)},
+		o.Description, o.Industry, o.Address.City, o.Address.Country)
+	doc.deleted = o.IsDeleted()
+	return doc
+}
+
+// projectDocument converts a project into a search document
+func projectDocument(p *models.Project) *searchDocument {
+	doc := newSearchDocument(models.SearchTypeProject, p.ID, p.DisplayName(),
+		map[string]string{"status": string(p.Status), "org_id": p.OrgID},
+		p.Description)
+	doc.deleted = p.IsDeleted()
+	return doc
+}
+
+// matchesFilters checks a document against search filters
+func matchesFilters(doc *searchDocument, filters map[string]interface{}) bool {
+	for key, value := range filters {
+		if key == searchFilterType {
+			if !matchesTypeFilter(doc.docType, value) {
+				return false
+			}
+			continue
+		}
+		if doc.fields[key] != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTypeFilter checks a document type against a string or []string filter
+func matchesTypeFilter(docType models.SearchDocumentType, value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return string(docType) == v
+	case models.SearchDocumentType:
+		return docType == v
+	case []string:
+		for _, t := range v {
+			if string(docType) == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
@@ -4,87 +4,184 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
-	"time"
 
+	"github.com/test-repo-golang-support/interfaces"
 	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
 )
 
+// ErrVersionConflict is returned when a write carries a stale entity version
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrEmailTaken is returned when another user already uses an email address
+var ErrEmailTaken = errors.New("email already in use")
+
+// ErrProfileUserNotFound is returned when a profile references a missing user
+var ErrProfileUserNotFound = errors.New("profile user not found")
+
 // UserService handles user-related operations
 type UserService struct {
-	users map[string]*models.User
-	mu    sync.RWMutex
+	idSource // Hands out IDs for created entities
+	users    map[string]*models.User
+	indexer  interfaces.Indexable                 // Optional search index kept in sync on writes
+	meter    *storeMeter                          // Approximate memory accounting and limits
+	onEvict  func(ctx context.Context, id string) // Optional cleanup for users evicted by the limits
+	evicted  []string                             // Users evicted since the hook last ran
+	emails   *EmailVerifier                       // Checks addresses submitted through VerifyEmail
+	byEmail  *emailIndex                          // Live users by normalized email
+	snapshot snapshotCache[models.User]           // Copy-on-write view served to list reads
+	mu       sync.RWMutex
+}
+
+// UserStats counts the users held by a UserService
+type UserStats struct {
+	Total   int `json:"total"`
+	Deleted int `json:"deleted"` // Soft-deleted users, included in Total
 }
 
 // NewUserService creates a new UserService instance
 func NewUserService() *UserService {
 	return &UserService{
-		users: make(map[string]*models.User),
+		users:   make(map[string]*models.User),
+		meter:   newStoreMeter("users"),
+		emails:  NewEmailVerifier(EmailConfig{}),
+		byEmail: newEmailIndex(),
 	}
 }
 
 // =====================================
-// Value Receiver Methods on UserService
+// Pointer Receiver Methods on UserService
+// (Interface Implementation)
 // =====================================
 
-// Count returns the number of users (value receiver on service)
-func (s UserService) Count() int {
-	return len(s.users)
-}
-
-// HasUsers checks if there are any users (value receiver)
-func (s UserService) HasUsers() bool {
-	return len(s.users) > 0
+// Read retrieves a copy of a user by ID, ignoring soft-deleted users.
+// Edits to the copy reach the store only through Write, which checks the
+// version it carries (pointer receiver - implements Reader).
+func (s *UserService) Read(ctx context.Context, id string) (*models.User, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "users.Read")()
+	return s.read(id, false)
 }
 
-// IsEmpty checks if the service has no users (value receiver)
-func (s UserService) IsEmpty() bool {
-	return len(s.users) == 0
+// ReadIncludingDeleted retrieves a copy of a user by ID even if soft-deleted (pointer receiver)
+func (s *UserService) ReadIncludingDeleted(ctx context.Context, id string) (*models.User, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "users.ReadIncludingDeleted")()
+	return s.read(id, true)
 }
 
-// =====================================
-// Pointer Receiver Methods on UserService
-// (Interface Implementation)
-// =====================================
-
-// Read retrieves a user by ID (pointer receiver - implements Reader)
-func (s *UserService) Read(ctx context.Context, id string) (*models.User, error) {
+// read looks up a user, optionally including soft-deleted ones (pointer receiver)
+func (s *UserService) read(id string, includeDeleted bool) (*models.User, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	user, exists := s.users[id]
-	if !exists {
+	if !exists || (user.IsDeleted() && !includeDeleted) {
 		return nil, errors.New("user not found")
 	}
-	return user, nil
+	return copyUser(user), nil
 }
 
-// ReadAll retrieves all users (pointer receiver - implements Reader)
+// ReadAll retrieves all users that are not soft-deleted (pointer receiver - implements Reader)
 func (s *UserService) ReadAll(ctx context.Context) (models.UserList, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	defer timing.Track(ctx, timing.LayerStorage, "users.ReadAll")()
+	users, _ := s.readPage(false, PageRequest{})
+	return users, nil
+}
 
-	users := make(models.UserList, 0, len(s.users))
-	for _, user := range s.users {
-		users = append(users, *user)
-	}
+// ReadAllIncludingDeleted retrieves all users, including soft-deleted ones (pointer receiver)
+func (s *UserService) ReadAllIncludingDeleted(ctx context.Context) (models.UserList, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "users.ReadAllIncludingDeleted")()
+	users, _ := s.readPage(true, PageRequest{})
 	return users, nil
 }
 
-// Write creates or updates a user (pointer receiver - implements Writer)
+// ReadUsersPage retrieves one page of users ordered by ID, optionally
+// including soft-deleted ones, and the ID the next page starts after, or
+// "" on the last page (pointer receiver)
+func (s *UserService) ReadUsersPage(ctx context.Context, includeDeleted bool, page PageRequest) (models.UserList, string, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "users.ReadUsersPage")()
+	users, next := s.readPage(includeDeleted, page)
+	return users, next, nil
+}
+
+// readPage copies a page of users, optionally including soft-deleted ones,
+// out of the cached snapshot, so no lock is held while copying (pointer receiver)
+func (s *UserService) readPage(includeDeleted bool, page PageRequest) (models.UserList, string) {
+	snapshot := s.snapshot.load(&s.mu, s.buildSnapshot)
+	return pageAfter(snapshot.items, func(user models.User) string { return user.ID }, func(user models.User) bool {
+		return includeDeleted || !user.IsDeleted()
+	}, page)
+}
+
+// Write creates or updates a user. Email addresses are unique among users
+// that are not soft-deleted; a clash fails with ErrEmailTaken (pointer
+// receiver - implements Writer).
 func (s *UserService) Write(ctx context.Context, user *models.User) error {
+	defer timing.Track(ctx, timing.LayerStorage, "users.Write")()
+	defer s.runEvictionHook(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(ctx, user)
+}
+
+// WriteMany creates or updates users under a single lock acquisition. Each
+// user is validated as by Write; the returned slice holds the error for the
+// user at the same index, or nil (pointer receiver - implements BatchWriter).
+func (s *UserService) WriteMany(ctx context.Context, users []*models.User) []error {
+	defer timing.Track(ctx, timing.LayerStorage, "users.WriteMany")()
+	defer s.runEvictionHook(ctx)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	errs := make([]error, len(users))
+	for i, user := range users {
+		errs[i] = s.write(ctx, user)
+	}
+	return errs
+}
+
+// write stores one user; callers hold s.mu (pointer receiver)
+func (s *UserService) write(ctx context.Context, user *models.User) error {
 	if user.ID == "" {
 		return errors.New("user ID is required")
 	}
-	s.users[user.ID] = user
+	user.Email = NormalizeEmail(user.Email)
+	if existing, exists := s.users[user.ID]; exists && existing.Version != user.Version {
+		return ErrVersionConflict
+	}
+	indexed := user.Email
+	if user.IsDeleted() {
+		indexed = ""
+	}
+	if err := s.byEmail.check(user.ID, indexed); err != nil {
+		return err
+	}
+
+	size := approxUserBytes(user)
+	if err := s.meter.admit(user.ID, size, s.evictionCandidates, func(id string) {
+		s.evict(ctx, id)
+	}); err != nil {
+		return err
+	}
+
+	user.IncrementVersion()
+	s.users[user.ID] = copyUser(user)
+	s.meter.record(user.ID, size)
+	s.byEmail.set(user.ID, indexed)
+	s.snapshot.invalidate()
+
+	if s.indexer != nil {
+		if err := s.indexer.Index(ctx, user.ID, user); err != nil {
+			return fmt.Errorf("failed to index user: %w", err)
+		}
+	}
 	return nil
 }
 
 // Delete removes a user (pointer receiver - implements Writer)
 func (s *UserService) Delete(ctx context.Context, id string) error {
+	defer timing.Track(ctx, timing.LayerStorage, "users.Delete")()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -92,37 +189,176 @@ func (s *UserService) Delete(ctx context.Context, id string) error {
 		return errors.New("user not found")
 	}
 	delete(s.users, id)
+	s.meter.forget(id)
+	s.byEmail.remove(id)
+	s.snapshot.invalidate()
+
+	if s.indexer != nil {
+		if err := s.indexer.DeleteIndex(ctx, id); err != nil {
+			return fmt.Errorf("failed to remove user from index: %w", err)
+		}
+	}
 	return nil
 }
 
-// CountUsers returns total user count (pointer receiver - implements Repository)
-func (s *UserService) CountUsers(ctx context.Context) (int, error) {
+// Count returns the count of non-deleted users (pointer receiver - implements Repository)
+func (s *UserService) Count(ctx context.Context) (int, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.users), nil
+	count := 0
+	for _, user := range s.users {
+		if !user.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
 }
 
-// Exists checks if a user exists (pointer receiver - implements Repository)
+// Exists checks if a non-deleted user exists (pointer receiver - implements Repository)
 func (s *UserService) Exists(ctx context.Context, id string) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	_, exists := s.users[id]
-	return exists, nil
+	user, exists := s.users[id]
+	return exists && !user.IsDeleted(), nil
 }
 
-// FindByEmail finds a user by email (pointer receiver)
-func (s *UserService) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+// SetIndexer attaches a search index updated on every Write and Delete (pointer receiver)
+func (s *UserService) SetIndexer(indexer interfaces.Indexable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexer = indexer
+}
+
+// SetEmailVerifier replaces the syntax-only check used by VerifyEmail (pointer receiver)
+func (s *UserService) SetEmailVerifier(emails *EmailVerifier) {
+	s.emails = emails
+}
+
+// VerifyEmail checks an address submitted for a user and returns it
+// normalized; with MX checking configured it may query DNS, so call it
+// before taking any lock (pointer receiver)
+func (s *UserService) VerifyEmail(ctx context.Context, email string) (string, error) {
+	defer timing.Track(ctx, timing.LayerService, "users.VerifyEmail")()
+	return s.emails.Verify(ctx, email)
+}
+
+// SetLimits caps the store; writes beyond the limits follow the policy (pointer receiver)
+func (s *UserService) SetLimits(limits StoreLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meter.setLimits(limits)
+}
+
+// SetEvictionHook registers fn to clean up data tied to a user that the
+// limits evicted, such as memberships; fn runs once the write that evicted
+// the user has released the service lock (pointer receiver)
+func (s *UserService) SetEvictionHook(fn func(ctx context.Context, id string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onEvict = fn
+}
+
+// StoreStats reports the approximate memory use of the user store (pointer receiver)
+func (s *UserService) StoreStats() StoreStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.meter.stats()
+}
+
+// Stats counts the stored users, soft-deleted ones included (pointer receiver)
+func (s *UserService) Stats() UserStats {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	stats := UserStats{Total: len(s.users)}
 	for _, user := range s.users {
-		if user.Email == email {
-			return user, nil
+		if user.IsDeleted() {
+			stats.Deleted++
+		}
+	}
+	return stats
+}
+
+// evictionCandidates lists soft-deleted users; callers hold s.mu (pointer receiver)
+func (s *UserService) evictionCandidates() []evictionCandidate {
+	var candidates []evictionCandidate
+	for id, user := range s.users {
+		if user.IsDeleted() {
+			candidates = append(candidates, evictionCandidate{id: id, deletedAt: *user.DeletedAt})
 		}
 	}
+	return candidates
+}
+
+// evict hard-deletes a soft-deleted user to make room; callers hold s.mu.
+// Index errors are ignored since the user is already hidden from reads (pointer receiver).
+func (s *UserService) evict(ctx context.Context, id string) {
+	delete(s.users, id)
+	s.byEmail.remove(id)
+	s.snapshot.invalidate()
+	if s.indexer != nil {
+		_ = s.indexer.DeleteIndex(ctx, id)
+	}
+	if s.onEvict != nil {
+		s.evicted = append(s.evicted, id)
+	}
+}
+
+// runEvictionHook hands users evicted by earlier writes to the eviction
+// hook; callers must not hold s.mu (pointer receiver)
+func (s *UserService) runEvictionHook(ctx context.Context) {
+	s.mu.Lock()
+	evicted, hook := s.evicted, s.onEvict
+	s.evicted = nil
+	s.mu.Unlock()
+
+	for _, id := range evicted {
+		hook(ctx, id)
+	}
+}
+
+// FindByEmail finds a copy of a user by email, ignoring case and
+// surrounding whitespace, through the email index (pointer receiver)
+func (s *UserService) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	defer timing.Track(ctx, timing.LayerStorage, "users.FindByEmail")()
+	email = NormalizeEmail(email)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.byEmail.lookup(email)
+	if user := s.users[id]; exists && user != nil {
+		return copyUser(user), nil
+	}
 	return nil, errors.New("user not found")
 }
 
+// Snapshot returns an immutable snapshot of every user, including
+// soft-deleted ones, ordered by ID. It is shared until the next write,
+// so repeated calls between writes cost nothing (pointer receiver).
+func (s *UserService) Snapshot(ctx context.Context) *Snapshot[models.User] {
+	defer timing.Track(ctx, timing.LayerStorage, "users.Snapshot")()
+	return s.snapshot.load(&s.mu, s.buildSnapshot)
+}
+
+// buildSnapshot copies every user into a new snapshot; callers hold s.mu (pointer receiver)
+func (s *UserService) buildSnapshot() *Snapshot[models.User] {
+	users := make([]models.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, *copyUser(user))
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return newSnapshot(models.Now(), users)
+}
+
+// IterateUsers calls fn with a copy of each user, including soft-deleted
+// ones, stopping at the first error returned by fn. It iterates a snapshot,
+// so fn may be slow without blocking writers (pointer receiver).
+func (s *UserService) IterateUsers(ctx context.Context, fn func(models.User) error) error {
+	return s.Snapshot(ctx).Each(ctx, fn)
+}
+
 // FindByRole finds all users with a specific role (pointer receiver)
 func (s *UserService) FindByRole(ctx context.Context, role string) (models.UserList, error) {
 	s.mu.RLock()
@@ -130,8 +366,8 @@ func (s *UserService) FindByRole(ctx context.Context, role string) (models.UserL
 
 	users := make(models.UserList, 0)
 	for _, user := range s.users {
-		if user.Role == role {
-			users = append(users, *user)
+		if user.Role == role && !user.IsDeleted() {
+			users = append(users, *copyUser(user))
 		}
 	}
 	return users, nil
@@ -146,20 +382,11 @@ func CreateUser(id, firstName, lastName, email string) *models.User {
 	return models.NewUser(id, firstName, lastName, email)
 }
 
-// ValidateEmail validates an email format (standalone function)
-func ValidateEmail(email string) bool {
-	// Simple validation - just check for @ symbol
-	for _, char := range email {
-		if char == '@' {
-			return true
-		}
-	}
-	return false
-}
-
-// GenerateUserID generates a unique user ID (standalone function)
-func GenerateUserID() string {
-	return fmt.Sprintf("user_%d", time.Now().UnixNano())
+// copyUser copies a user so the copy shares no pointers with the store (standalone function)
+func copyUser(user *models.User) *models.User {
+	copied := *user
+	copied.DeletedAt = cloneTime(user.DeletedAt)
+	return &copied
 }
 
 // =====================================
@@ -168,31 +395,35 @@ func GenerateUserID() string {
 
 // ProfileService handles user profile operations
 type ProfileService struct {
+	idSource // Hands out IDs for created entities
 	profiles map[string]*models.Profile
+	users    *UserService // Every profile must reference an existing user
 	mu       sync.RWMutex
 }
 
+// ProfileStats counts the profiles held by a ProfileService
+type ProfileStats struct {
+	Total int `json:"total"`
+}
+
 // NewProfileService creates a new ProfileService instance
-func NewProfileService() *ProfileService {
+func NewProfileService(users *UserService) *ProfileService {
 	return &ProfileService{
 		profiles: make(map[string]*models.Profile),
+		users:    users,
 	}
 }
 
-// Value receiver methods on ProfileService
+// Pointer receiver methods on ProfileService
 
-// Count returns the number of profiles (value receiver)
-func (ps ProfileService) Count() int {
-	return len(ps.profiles)
-}
+// Stats counts the stored profiles (pointer receiver)
+func (ps *ProfileService) Stats() ProfileStats {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
 
-// HasProfiles checks if there are any profiles (value receiver)
-func (ps ProfileService) HasProfiles() bool {
-	return len(ps.profiles) > 0
+	return ProfileStats{Total: len(ps.profiles)}
 }
 
-// Pointer receiver methods on ProfileService
-
 // GetProfile retrieves a profile by ID (pointer receiver)
 func (ps *ProfileService) GetProfile(ctx context.Context, id string) (*models.Profile, error) {
 	ps.mu.RLock()
@@ -213,6 +444,18 @@ func (ps *ProfileService) SaveProfile(ctx context.Context, profile *models.Profi
 	if profile.ID == "" {
 		return errors.New("profile ID is required")
 	}
+	if exists, _ := ps.users.Exists(ctx, string(profile.UserID)); !exists {
+		return fmt.Errorf("%w: %s", ErrProfileUserNotFound, profile.UserID)
+	}
+	for _, other := range ps.profiles {
+		if other.ID != profile.ID && other.UserID == profile.UserID {
+			return errors.New("profile already exists for user")
+		}
+	}
+	if existing, exists := ps.profiles[profile.ID]; exists && existing.Version != profile.Version {
+		return ErrVersionConflict
+	}
+	profile.IncrementVersion()
 	ps.profiles[profile.ID] = profile
 	return nil
 }
@@ -230,6 +473,22 @@ func (ps *ProfileService) GetByUserID(ctx context.Context, userID models.UserID)
 	return nil, errors.New("profile not found for user")
 }
 
+// RemoveUserProfile removes a user's profile, if any, and returns how many
+// profiles were removed (pointer receiver)
+func (ps *ProfileService) RemoveUserProfile(ctx context.Context, userID string) (int, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	removed := 0
+	for id, profile := range ps.profiles {
+		if string(profile.UserID) == userID {
+			delete(ps.profiles, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
 // DeleteProfile removes a profile (pointer receiver)
 func (ps *ProfileService) DeleteProfile(ctx context.Context, id string) error {
 	ps.mu.Lock()
@@ -241,3 +500,4 @@ func (ps *ProfileService) DeleteProfile(ctx context.Context, id string) error {
 	delete(ps.profiles, id)
 	return nil
 }
+
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUserServiceReadReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+	if err := service.Write(ctx, CreateUser("user_a", "Ada", "One", "ada@example.com")); err != nil {
+		t.Fatalf("Write(user_a): %v", err)
+	}
+
+	// An edit to a read user is not stored until it is written
+	edited, err := service.Read(ctx, "user_a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	edited.FirstName = "Changed"
+	if stored, _ := service.Read(ctx, "user_a"); stored.FirstName != "Ada" {
+		t.Errorf("stored first name = %q after editing a read copy, want Ada", stored.FirstName)
+	}
+	if found, _ := service.FindByEmail(ctx, "ada@example.com"); found.FirstName != "Ada" {
+		t.Errorf("FindByEmail first name = %q after editing a read copy, want Ada", found.FirstName)
+	}
+
+	// Two writers holding the same version: the second one conflicts
+	first, _ := service.Read(ctx, "user_a")
+	second, _ := service.Read(ctx, "user_a")
+	first.FirstName = "First"
+	if err := service.Write(ctx, first); err != nil {
+		t.Fatalf("Write(first): %v", err)
+	}
+	second.FirstName = "Second"
+	if err := service.Write(ctx, second); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("Write(second) = %v, want %v", err, ErrVersionConflict)
+	}
+
+	// Editing the written pointer afterwards does not reach the store either
+	first.FirstName = "Later"
+	if stored, _ := service.Read(ctx, "user_a"); stored.FirstName != "First" {
+		t.Errorf("stored first name = %q, want First", stored.FirstName)
+	}
+}
+
+func TestReadUsersPageSkipsDeletedUsers(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+	for _, id := range []string{"user_a", "user_b", "user_c", "user_d"} {
+		if err := service.Write(ctx, CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	deleted, _ := service.Read(ctx, "user_b")
+	deleted.Deactivate()
+	if err := service.Write(ctx, deleted); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		includeDeleted bool
+		page           PageRequest
+		want           []string
+		next           string
+	}{
+		{"every user", false, PageRequest{}, []string{"user_a", "user_c", "user_d"}, ""},
+		{"first page", false, PageRequest{Limit: 2}, []string{"user_a", "user_c"}, "user_c"},
+		{"last page", false, PageRequest{After: "user_c", Limit: 2}, []string{"user_d"}, ""},
+		{"exact fit", false, PageRequest{After: "user_a", Limit: 2}, []string{"user_c", "user_d"}, ""},
+		{"including deleted", true, PageRequest{Limit: 2}, []string{"user_a", "user_b"}, "user_b"},
+		{"cursor between IDs", false, PageRequest{After: "user_bb", Limit: 5}, []string{"user_c", "user_d"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, next, err := service.ReadUsersPage(ctx, tt.includeDeleted, tt.page)
+			if err != nil {
+				t.Fatalf("ReadUsersPage: %v", err)
+			}
+			got := make([]string, 0, len(users))
+			for _, user := range users {
+				got = append(got, user.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) || next != tt.next {
+				t.Errorf("page = %v next %q, want %v next %q", got, next, tt.want, tt.next)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sitemapNamespace is the XML namespace required by the sitemaps protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapConfig controls how public profiles are advertised to crawlers
+type SitemapConfig struct {
+	BaseURL     string   // Absolute origin used in sitemap URLs; the request host when empty
+	DisallowAll bool     // Block all crawling, e.g. on staging deployments
+	Disallow    []string // Extra path prefixes to block in robots.txt
+}
+
+// Sitemap is a rendered sitemap.xml with the metadata needed for caching
+type Sitemap struct {
+	Body         []byte
+	Revision     uint64
+	LastModified time.Time
+}
+
+// sitemapURL is one <url> entry of a sitemap
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// sitemapURLSet is the root element of a sitemap
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// SitemapService builds sitemap.xml and robots.txt for public organization
+// profiles. The sitemap is cached and rebuilt only after organizations change.
+type SitemapService struct {
+	orgs    *OrganizationService
+	config  SitemapConfig
+	cached  *Sitemap
+	baseURL string // Base URL the cached sitemap was rendered for
+	mu      sync.Mutex
+}
+
+// NewSitemapService creates a new SitemapService instance
+func NewSitemapService(orgs *OrganizationService, config SitemapConfig) *SitemapService {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &SitemapService{
+		orgs:   orgs,
+		config: config,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// BaseURL returns the configured base URL, falling back to the given one (pointer receiver)
+func (s *SitemapService) BaseURL(fallback string) string {
+	if s.config.BaseURL != "" {
+		return s.config.BaseURL
+	}
+	return strings.TrimRight(fallback, "/")
+}
+
+// Sitemap returns the sitemap for the given base URL, rebuilding it if any
+// organization changed since it was last rendered (pointer receiver)
+func (s *SitemapService) Sitemap(ctx context.Context, baseURL string) (*Sitemap, error) {
+	revision := s.orgs.Revision()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && s.cached.Revision == revision && s.baseURL == baseURL {
+		return s.cached, nil
+	}
+
+	orgs, err := s.orgs.FindPublicOrgs(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	set := sitemapURLSet{
+		Xmlns: sitemapNamespace,
+		URLs:  []sitemapURL{{Loc: baseURL + "/public/organizations"}},
+	}
+	var lastModified time.Time
+	for _, org := range orgs {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     baseURL + "/public/organizations/" + url.PathEscape(org.Slug),
+			LastMod: org.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+		if org.UpdatedAt.After(lastModified) {
+			lastModified = org.UpdatedAt
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(set); err != nil {
+		return nil, fmt.Errorf("failed to render sitemap: %w", err)
+	}
+	buf.WriteString("\n")
+
+	s.cached = &Sitemap{
+		Body:         buf.Bytes(),
+		Revision:     revision,
+		LastModified: lastModified,
+	}
+	s.baseURL = baseURL
+	return s.cached, nil
+}
+
+// Robots renders robots.txt: public profiles are crawlable, the API and
+// embeds are not, and the sitemap location is advertised (pointer receiver)
+func (s *SitemapService) Robots(baseURL string) string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if s.config.DisallowAll {
+		b.WriteString("Disallow: /\n")
+		return b.String()
+	}
+
+	b.WriteString("Allow: /public/\n")
+	b.WriteString("Disallow: /api/\n")
+	b.WriteString("Disallow: /embed/\n")
+	for _, path := range s.config.Disallow {
+		if path = strings.TrimSpace(path); path != "" {
+			fmt.Fprintf(&b, "Disallow: %s\n", path)
+		}
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s/sitemap.xml\n", baseURL)
+	return b.String()
+}
+
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// SLA defaults
+const (
+	DefaultOrgDeletionSLA     = 15 * time.Minute
+	DefaultScheduledChangeSLA = 5 * time.Minute
+	maxSLABreaches            = 100
+)
+
+// SLA operations
+const (
+	SLAOrgDeletion     = "org_deletion"
+	SLAScheduledChange = "scheduled_change"
+)
+
+// SLA outcomes
+const (
+	SLAOutcomeMet      = "met"
+	SLAOutcomeBreached = "breached"
+	SLAOutcomePending  = "pending"
+)
+
+// SLAConfig sets how long each kind of asynchronous operation may take.
+// An organization deletion is measured from the request, a scheduled
+// change from its effective time. Zero fields use the defaults.
+type SLAConfig struct {
+	OrgDeletion     time.Duration
+	ScheduledChange time.Duration
+}
+
+// SLARecord is the target and actual completion time of one operation.
+// An operation that failed, or is still running past its target, is
+// breached.
+type SLARecord struct {
+	Operation   string     `json:"operation"`
+	ID          string     `json:"id"`
+	OrgID       string     `json:"org_id,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	TargetAt    time.Time  `json:"target_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Outcome     string     `json:"outcome"`
+}
+
+// SLACompliance summarizes one operation kind for one organization
+type SLACompliance struct {
+	OrgID      string  `json:"org_id"`
+	Operation  string  `json:"operation"`
+	Met        int     `json:"met"`
+	Breached   int     `json:"breached"`
+	Pending    int     `json:"pending"`
+	Compliance float64 `json:"compliance"` // % of settled operations that met their target
+}
+
+// SLABreach records when a breached operation was first noticed
+type SLABreach struct {
+	SLARecord
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// SLAReport is the compliance of every organization at one point in time
+type SLAReport struct {
+	EvaluatedAt time.Time       `json:"evaluated_at"`
+	Compliance  []SLACompliance `json:"compliance"`
+}
+
+// SLATracker compares organization deletion jobs and scheduled changes
+// with their SLA targets. It keeps no copy of the operations: records are
+// derived from the jobs and changes on every call. Evaluate, run by the
+// RetentionJanitor on each tick, emits an event the first time an
+// operation is seen breached.
+type SLATracker struct {
+	deletions *OrgDeletionService
+	changes   *ChangeScheduler
+	config    SLAConfig
+	logger    *log.Logger
+	reported  map[string]bool // operation/ID of breaches already emitted
+	breaches  []SLABreach
+	mu        sync.Mutex
+}
+
+// NewSLATracker creates a new SLATracker instance
+func NewSLATracker(deletions *OrgDeletionService, changes *ChangeScheduler, config SLAConfig, logger *log.Logger) *SLATracker {
+	if config.OrgDeletion <= 0 {
+		config.OrgDeletion = DefaultOrgDeletionSLA
+	}
+	if config.ScheduledChange <= 0 {
+		config.ScheduledChange = DefaultScheduledChangeSLA
+	}
+	return &SLATracker{
+		deletions: deletions,
+		changes:   changes,
+		config:    config,
+		logger:    logger,
+		reported:  make(map[string]bool),
+	}
+}
+
+// =====================================
+// Evaluation
+// =====================================
+
+// Evaluate computes compliance and emits an event for every breach not
+// reported before (pointer receiver)
+func (t *SLATracker) Evaluate(ctx context.Context, now time.Time) (*SLAReport, error) {
+	records, err := t.Records(ctx, "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, record := range records {
+		key := record.Operation + "/" + record.ID
+		if record.Outcome != SLAOutcomeBreached || t.reported[key] {
+			continue
+		}
+		t.reported[key] = true
+		t.record(SLABreach{SLARecord: record, DetectedAt: now})
+	}
+	return &SLAReport{EvaluatedAt: now, Compliance: compliance(records)}, nil
+}
+
+// Report computes compliance without emitting events; a non-empty orgID
+// limits it to that organization (pointer receiver)
+func (t *SLATracker) Report(ctx context.Context, orgID string, now time.Time) (*SLAReport, error) {
+	records, err := t.Records(ctx, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+	return &SLAReport{EvaluatedAt: now, Compliance: compliance(records)}, nil
+}
+
+// Records lists every tracked operation with its outcome, oldest first; a
+// non-empty orgID limits it to that organization (pointer receiver)
+func (t *SLATracker) Records(ctx context.Context, orgID string, now time.Time) ([]SLARecord, error) {
+	records := make([]SLARecord, 0)
+
+	for _, job := range t.deletions.ListJobs(ctx) {
+		if orgID != "" && job.OrgID != orgID {
+			continue
+		}
+		record := SLARecord{
+			Operation:   SLAOrgDeletion,
+			ID:          job.ID,
+			OrgID:       job.OrgID,
+			StartedAt:   job.CreatedAt,
+			TargetAt:    job.CreatedAt.Add(t.config.OrgDeletion),
+			CompletedAt: job.CompletedAt,
+		}
+		record.Outcome = slaOutcome(record, job.Status == models.OrgDeletionFailed, now)
+		records = append(records, record)
+	}
+
+	changes, err := t.changes.ListChanges(ctx, ScheduledChangeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		changeOrg := change.OrgID
+		if change.Target == models.ScheduledChangeOrganization {
+			changeOrg = change.TargetID
+		}
+		if change.Status == models.ScheduledChangeCancelled || (orgID != "" && changeOrg != orgID) {
+			continue
+		}
+		record := SLARecord{
+			Operation:   SLAScheduledChange,
+			ID:          change.ID,
+			OrgID:       changeOrg,
+			StartedAt:   change.EffectiveAt,
+			TargetAt:    change.EffectiveAt.Add(t.config.ScheduledChange),
+			CompletedAt: change.AppliedAt,
+		}
+		failed := change.Status == models.ScheduledChangeFailed
+		if failed {
+			updatedAt := change.UpdatedAt
+			record.CompletedAt = &updatedAt
+		}
+		record.Outcome = slaOutcome(record, failed, now)
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartedAt.Before(records[j].StartedAt)
+	})
+	return records, nil
+}
+
+// Breaches returns the most recent breaches, newest first (pointer receiver)
+func (t *SLATracker) Breaches() []SLABreach {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	breaches := make([]SLABreach, len(t.breaches))
+	for i, breach := range t.breaches {
+		breaches[len(t.breaches)-1-i] = breach
+	}
+	return breaches
+}
+
+// Config returns the effective SLA targets (pointer receiver)
+func (t *SLATracker) Config() SLAConfig {
+	return t.config
+}
+
+// record logs a breach event and keeps it in the recent history. Callers
+// hold t.mu (pointer receiver).
+func (t *SLATracker) record(breach SLABreach) {
+	t.logger.Printf("event=sla.breached operation=%s id=%s org=%s target_at=%s overdue=%s",
+		breach.Operation, breach.ID, breach.OrgID, breach.TargetAt.Format(time.RFC3339), breach.overdue().Round(time.Second))
+
+	t.breaches = append(t.breaches, breach)
+	if len(t.breaches) > maxSLABreaches {
+		t.breaches = t.breaches[len(t.breaches)-maxSLABreaches:]
+	}
+}
+
+// =====================================
+// Value Receiver Methods on SLABreach
+// =====================================
+
+// overdue returns how far past its target the operation finished, or had
+// run when the breach was detected (value receiver)
+func (b SLABreach) overdue() time.Duration {
+	end := b.DetectedAt
+	if b.CompletedAt != nil {
+		end = *b.CompletedAt
+	}
+	return end.Sub(b.TargetAt)
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// slaOutcome classifies a record at now; failed operations are breached
+// however fast they failed (standalone function)
+func slaOutcome(record SLARecord, failed bool, now time.Time) string {
+	switch {
+	case failed:
+		return SLAOutcomeBreached
+	case record.CompletedAt != nil && !record.CompletedAt.After(record.TargetAt):
+		return SLAOutcomeMet
+	case record.CompletedAt != nil || now.After(record.TargetAt):
+		return SLAOutcomeBreached
+	}
+	return SLAOutcomePending
+}
+
+// compliance groups records by organization and operation, sorted by both
+// (standalone function)
+func compliance(records []SLARecord) []SLACompliance {
+	groups := make(map[[2]string]*SLACompliance)
+	for _, record := range records {
+		key := [2]string{record.OrgID, record.Operation}
+		group, exists := groups[key]
+		if !exists {
+			group = &SLACompliance{OrgID: record.OrgID, Operation: record.Operation}
+			groups[key] = group
+		}
+		switch record.Outcome {
+		case SLAOutcomeMet:
+			group.Met++
+		case SLAOutcomeBreached:
+			group.Breached++
+		default:
+			group.Pending++
+		}
+	}
+
+	result := make([]SLACompliance, 0, len(groups))
+	for _, group := range groups {
+		group.Compliance = 100
+		if settled := group.Met + group.Breached; settled > 0 {
+			group.Compliance = float64(group.Met) / float64(settled) * 100
+		}
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OrgID != result[j].OrgID {
+			return result[i].OrgID < result[j].OrgID
+		}
+		return result[i].Operation < result[j].Operation
+	})
+	return result
+}
+
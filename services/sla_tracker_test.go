@@ -0,0 +1,78 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+func TestSLATrackerEvaluate(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDeletions(t)
+	var events bytes.Buffer
+	changes := d.stores.Changes
+	tracker := NewSLATracker(d.OrgDeletionService, changes, SLAConfig{OrgDeletion: time.Hour, ScheduledChange: time.Minute}, log.New(&events, "", 0))
+
+	writeTestOrg(t, d.orgs, "org_done")
+	writeTestOrg(t, d.orgs, "org_stuck")
+	if _, err := d.Delete(ctx, "org_done", "tester"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := d.Enqueue(ctx, "org_stuck", "tester"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	writeTestOrg(t, d.orgs, "org_renamed")
+	now := models.Now()
+	effectiveAt := now.Add(time.Second)
+	if _, err := changes.Schedule(ctx, models.ScheduledChangeOrganization, "org_renamed", "", json.RawMessage(`{"name":"Renamed"}`), effectiveAt, 0, "tester"); err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+
+	// Within the deletion target only the overdue scheduled change is breached
+	report, err := tracker.Evaluate(ctx, now.Add(10*time.Minute))
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	want := []SLACompliance{
+		{OrgID: "org_done", Operation: SLAOrgDeletion, Met: 1, Compliance: 100},
+		{OrgID: "org_renamed", Operation: SLAScheduledChange, Breached: 1, Compliance: 0},
+		{OrgID: "org_stuck", Operation: SLAOrgDeletion, Pending: 1, Compliance: 100},
+	}
+	if len(report.Compliance) != len(want) {
+		t.Fatalf("compliance = %+v, want %+v", report.Compliance, want)
+	}
+	for i := range want {
+		if report.Compliance[i] != want[i] {
+			t.Errorf("compliance[%d] = %+v, want %+v", i, report.Compliance[i], want[i])
+		}
+	}
+
+	// Past the deletion target the queued job breaches too; the change's
+	// breach is not raised a second time
+	if _, err := tracker.Evaluate(ctx, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if got := strings.Count(events.String(), "event=sla.breached"); got != 2 {
+		t.Errorf("emitted %d breach events, want 2:\n%s", got, events.String())
+	}
+	breaches := tracker.Breaches()
+	if len(breaches) != 2 || breaches[0].OrgID != "org_stuck" || breaches[1].OrgID != "org_renamed" {
+		t.Errorf("Breaches() = %+v, want org_stuck then org_renamed", breaches)
+	}
+
+	orgReport, err := tracker.Report(ctx, "org_stuck", now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if len(orgReport.Compliance) != 1 || orgReport.Compliance[0].Breached != 1 {
+		t.Errorf("org_stuck compliance = %+v, want one breach", orgReport.Compliance)
+	}
+}
+
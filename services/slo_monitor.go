@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/test-repo-golang-support/pkg/metrics"
+)
+
+// SLO defaults
+const (
+	DefaultSLOInterval = time.Minute
+	maxSLOAlerts       = 100
+)
+
+// SLI names
+const (
+	SLIAvailability = "availability"
+	SLILatency      = "latency"
+)
+
+// SLO severities, from best to worst
+const (
+	SLOSeverityOK       = "ok"
+	SLOSeverityWarning  = "warning"
+	SLOSeverityCritical = "critical"
+)
+
+// ErrSLONotFound is returned for an unknown objective name
+var ErrSLONotFound = errors.New("slo not found")
+
+// BurnRateRule raises an alert when the error budget burns at least
+// Threshold times faster than the SLO allows over both windows. The short
+// window lets the alert clear soon after the problem stops.
+type BurnRateRule struct {
+	Severity  string        `json:"severity"`
+	Long      time.Duration `json:"long_window"`
+	Short     time.Duration `json:"short_window"`
+	Threshold float64       `json:"threshold"`
+}
+
+// DefaultBurnRateRules page when 2% of a 30-day budget is spent in an hour
+// and warn when 5% is spent in six hours
+var DefaultBurnRateRules = []BurnRateRule{
+	{Severity: SLOSeverityCritical, Long: time.Hour, Short: 5 * time.Minute, Threshold: 14.4},
+	{Severity: SLOSeverityWarning, Long: 6 * time.Hour, Short: 30 * time.Minute, Threshold: 6},
+}
+
+// SLObjective is a latency and/or error objective for a group of routes.
+// Routes are mux path templates such as "/api/v1/users/{id}", or prefixes
+// ending in "*". Targets are percentages; a zero target disables that SLI.
+type SLObjective struct {
+	Name               string   `json:"name"`
+	Routes             []string `json:"routes"`
+	AvailabilityTarget float64  `json:"availability_target"`  // % of requests without a server error
+	LatencyThresholdMS int64    `json:"latency_threshold_ms"` // Slowest acceptable response
+	LatencyTarget      float64  `json:"latency_target"`       // % of requests within the threshold
+}
+
+// SLOConfig configures the SLO monitor
+type SLOConfig struct {
+	Objectives []SLObjective
+	Interval   time.Duration // How often burn rates are evaluated
+}
+
+// SLIStatus is the state of one SLI of an objective
+type SLIStatus struct {
+	SLI             string             `json:"sli"`
+	Target          float64            `json:"target"`
+	Requests        uint64             `json:"requests"`         // Over the longest window
+	Bad             uint64             `json:"bad"`              // Over the longest window
+	BudgetRemaining float64            `json:"budget_remaining"` // Share of the longest window's budget left
+	BurnRates       map[string]float64 `json:"burn_rates"`       // Window -> burn rate
+	Severity        string             `json:"severity"`
+}
+
+// SLOStatus is the state of an objective
+type SLOStatus struct {
+	SLObjective
+	Severity    string      `json:"severity"`
+	SLIs        []SLIStatus `json:"slis"`
+	EvaluatedAt time.Time   `json:"evaluated_at"`
+}
+
+// SLOAlert records a change in an SLI's severity
+type SLOAlert struct {
+	Objective string    `json:"objective"`
+	SLI       string    `json:"sli"`
+	Severity  string    `json:"severity"`
+	Previous  string    `json:"previous"`
+	BurnRate  float64   `json:"burn_rate"`
+	Window    string    `json:"window"`
+	At        time.Time `json:"at"`
+}
+
+// SLOMonitor evaluates objectives against the request metrics and emits
+// an event whenever an SLI's budget comes at risk or recovers. It runs as
+// a background worker between Initialize and Shutdown.
+type SLOMonitor struct {
+	metrics  *metrics.Registry
+	config   SLOConfig
+	rules    []BurnRateRule
+	logger   *log.Logger
+	severity map[string]string // objective/SLI -> last reported severity
+	alerts   []SLOAlert
+	stop     chan struct{}
+	done     chan struct{}
+	mu       sync.Mutex
+}
+
+// NewSLOMonitor creates a new SLOMonitor instance
+func NewSLOMonitor(registry *metrics.Registry, config SLOConfig, logger *log.Logger) *SLOMonitor {
+	if config.Interval <= 0 {
+		config.Interval = DefaultSLOInterval
+	}
+	return &SLOMonitor{
+		metrics:  registry,
+		config:   config,
+		rules:    DefaultBurnRateRules,
+		logger:   logger,
+		severity: make(map[string]string),
+	}
+}
+
+// =====================================
+// Lifecycle
+// =====================================
+
+// Initialize starts the background evaluation loop (pointer receiver)
+func (m *SLOMonitor) Initialize(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop != nil {
+		return errors.New("slo monitor already running")
+	}
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go m.run(m.stop, m.done)
+	return nil
+}
+
+// Shutdown stops the evaluation loop (pointer receiver)
+func (m *SLOMonitor) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run evaluates on every tick until stopped (pointer receiver)
+func (m *SLOMonitor) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			m.Evaluate(now.UTC())
+		}
+	}
+}
+
+// =====================================
+// Evaluation
+// =====================================
+
+// Evaluate computes every objective's status and emits an event for each
+// SLI whose severity changed since the last evaluation (pointer receiver)
+func (m *SLOMonitor) Evaluate(now time.Time) []SLOStatus {
+	statuses := m.Statuses(now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, status := range statuses {
+		for _, sli := range status.SLIs {
+			key := status.Name + "/" + sli.SLI
+			previous, seen := m.severity[key]
+			if !seen {
+				previous = SLOSeverityOK
+			}
+			if sli.Severity == previous {
+				continue
+			}
+			m.severity[key] = sli.Severity
+
+			alert := SLOAlert{
+				Objective: status.Name,
+				SLI:       sli.SLI,
+				Severity:  sli.Severity,
+				Previous:  previous,
+				At:        now,
+			}
+			alert.Window, alert.BurnRate = m.worstWindow(sli)
+			m.record(alert, status)
+		}
+	}
+	return statuses
+}
+
+// Statuses computes the status of every objective without emitting events (pointer receiver)
+func (m *SLOMonitor) Statuses(now time.Time) []SLOStatus {
+	statuses := make([]SLOStatus, 0, len(m.config.Objectives))
+	for _, objective := range m.config.Objectives {
+		statuses = append(statuses, m.status(objective, now))
+	}
+	return statuses
+}
+
+// Status computes the status of one objective (pointer receiver)
+func (m *SLOMonitor) Status(name string, now time.Time) (*SLOStatus, error) {
+	for _, objective := range m.config.Objectives {
+		if objective.Name == name {
+			status := m.status(objective, now)
+			return &status, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSLONotFound, name)
+}
+
+// Alerts returns the most recent severity changes, newest first (pointer receiver)
+func (m *SLOMonitor) Alerts() []SLOAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alerts := make([]SLOAlert, len(m.alerts))
+	for i, alert := range m.alerts {
+		alerts[len(m.alerts)-1-i] = alert
+	}
+	return alerts
+}
+
+// Rules returns the burn-rate alerting rules (pointer receiver)
+func (m *SLOMonitor) Rules() []BurnRateRule {
+	return append([]BurnRateRule(nil), m.rules...)
+}
+
+// status computes the SLIs of an objective over every alerting window (pointer receiver)
+func (m *SLOMonitor) status(objective SLObjective, now time.Time) SLOStatus {
+	status := SLOStatus{
+		SLObjective: objective,
+		Severity:    SLOSeverityOK,
+		EvaluatedAt: now,
+	}
+
+	match := objective.Matches
+	if objective.AvailabilityTarget > 0 {
+		status.SLIs = append(status.SLIs, m.sli(SLIAvailability, objective.AvailabilityTarget, now, func(window time.Duration) (uint64, uint64) {
+			counts := m.metrics.Window(match, window, now)
+			return counts.Requests, counts.Errors
+		}))
+	}
+	if objective.LatencyTarget > 0 && objective.LatencyThresholdMS > 0 {
+		bounds := m.metrics.Config().LatencyBounds
+		threshold := objective.LatencyThreshold()
+		status.SLIs = append(status.SLIs, m.sli(SLILatency, objective.LatencyTarget, now, func(window time.Duration) (uint64, uint64) {
+			counts := m.metrics.Window(match, window, now)
+			return counts.Requests, counts.Requests - counts.Within(bounds, threshold)
+		}))
+	}
+
+	for _, sli := range status.SLIs {
+		if severityRank(sli.Severity) > severityRank(status.Severity) {
+			status.Severity = sli.Severity
+		}
+	}
+	return status
+}
+
+// sli computes burn rates for one SLI. count returns the total and bad
+// requests over a window (pointer receiver).
+func (m *SLOMonitor) sli(name string, target float64, now time.Time, count func(window time.Duration) (uint64, uint64)) SLIStatus {
+	status := SLIStatus{
+		SLI:       name,
+		Target:    target,
+		BurnRates: make(map[string]float64),
+		Severity:  SLOSeverityOK,
+	}
+	budget := (100 - target) / 100
+
+	var longest time.Duration
+	for _, rule := range m.rules {
+		longTotal, longBad := count(rule.Long)
+		shortTotal, shortBad := count(rule.Short)
+		long := burnRate(longTotal, longBad, budget)
+		short := burnRate(shortTotal, shortBad, budget)
+		status.BurnRates[windowName(rule.Long)] = long
+		status.BurnRates[windowName(rule.Short)] = short
+
+		if long >= rule.Threshold && short >= rule.Threshold && severityRank(rule.Severity) > severityRank(status.Severity) {
+			status.Severity = rule.Severity
+		}
+		if rule.Long > longest {
+			longest = rule.Long
+			status.Requests, status.Bad = longTotal, longBad
+		}
+	}
+
+	status.BudgetRemaining = 1
+	if status.Requests > 0 && budget > 0 {
+		status.BudgetRemaining = 1 - float64(status.Bad)/float64(status.Requests)/budget
+	}
+	return status
+}
+
+// worstWindow returns the window with the highest burn rate (pointer receiver)
+func (m *SLOMonitor) worstWindow(sli SLIStatus) (string, float64) {
+	var window string
+	var worst float64
+	for _, rule := range m.rules {
+		name := windowName(rule.Long)
+		if rate := sli.BurnRates[name]; window == "" || rate > worst {
+			window, worst = name, rate
+		}
+	}
+	return window, worst
+}
+
+// record logs an alert event and keeps it in the recent history. Callers
+// hold m.mu (pointer receiver).
+func (m *SLOMonitor) record(alert SLOAlert, status SLOStatus) {
+	event := "slo.alert"
+	if alert.Severity == SLOSeverityOK {
+		event = "slo.resolved"
+	}
+	m.logger.Printf("event=%s objective=%s sli=%s severity=%s previous=%s burn_rate=%.2f window=%s routes=%s",
+		event, alert.Objective, alert.SLI, alert.Severity, alert.Previous, alert.BurnRate, alert.Window,
+		strings.Join(status.Routes, ","))
+
+	m.alerts = append(m.alerts, alert)
+	if len(m.alerts) > maxSLOAlerts {
+		m.alerts = m.alerts[len(m.alerts)-maxSLOAlerts:]
+	}
+}
+
+// =====================================
+// Value Receiver Methods on SLObjective
+// =====================================
+
+// Matches reports whether the objective covers a route template (value receiver)
+func (o SLObjective) Matches(route string) bool {
+	for _, pattern := range o.Routes {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(route, prefix) {
+				return true
+			}
+		} else if pattern == route {
+			return true
+		}
+	}
+	return false
+}
+
+// LatencyThreshold returns the latency threshold as a duration (value receiver)
+func (o SLObjective) LatencyThreshold() time.Duration {
+	return time.Duration(o.LatencyThresholdMS) * time.Millisecond
+}
+
+// Validate checks that the objective is well formed (value receiver)
+func (o SLObjective) Validate() error {
+	if o.Name == "" {
+		return errors.New("slo name is required")
+	}
+	if len(o.Routes) == 0 {
+		return fmt.Errorf("slo %s: at least one route is required", o.Name)
+	}
+	if o.AvailabilityTarget <= 0 && o.LatencyTarget <= 0 {
+		return fmt.Errorf("slo %s: an availability or latency target is required", o.Name)
+	}
+	for _, target := range []float64{o.AvailabilityTarget, o.LatencyTarget} {
+		if target < 0 || target >= 100 {
+			return fmt.Errorf("slo %s: targets must be percentages below 100", o.Name)
+		}
+	}
+	if o.LatencyTarget > 0 && o.LatencyThresholdMS <= 0 {
+		return fmt.Errorf("slo %s: a latency target needs latency_threshold_ms", o.Name)
+	}
+	return nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// DefaultSLObjectives covers the whole API when no objectives are configured (standalone function)
+func DefaultSLObjectives() []SLObjective {
+	return []SLObjective{{
+		Name:               "api",
+		Routes:             []string{"/api/v1/*"},
+		AvailabilityTarget: 99.9,
+		LatencyThresholdMS: 1000,
+		LatencyTarget:      99,
+	}}
+}
+
+// burnRate divides the bad-request ratio by the error budget; no traffic
+// burns nothing (standalone function)
+func burnRate(total, bad uint64, budget float64) float64 {
+	if total == 0 || budget <= 0 {
+		return 0
+	}
+	return float64(bad) / float64(total) / budget
+}
+
+// windowName formats a window as "5m", "1h" or "6h" (standalone function)
+func windowName(window time.Duration) string {
+	if window%time.Hour == 0 {
+		return fmt.Sprintf("%dh", window/time.Hour)
+	}
+	return fmt.Sprintf("%dm", window/time.Minute)
+}
+
+// severityRank orders severities from ok to critical (standalone function)
+func severityRank(severity string) int {
+	switch severity {
+	case SLOSeverityCritical:
+		return 2
+	case SLOSeverityWarning:
+		return 1
+	}
+	return 0
+}
+
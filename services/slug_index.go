@@ -0,0 +1,57 @@
+package services
+
+// slugIndex maps the slug of every organization to its ID so lookups and
+// uniqueness checks do not scan the store. Soft-deleted organizations keep
+// their slug, so it is not reused while they can still be restored. It has
+// no lock of its own; callers hold the owning store's lock.
+type slugIndex struct {
+	ids   map[string]string // Slug -> organization ID
+	slugs map[string]string // Organization ID -> indexed slug
+}
+
+// newSlugIndex creates an empty index (standalone function)
+func newSlugIndex() *slugIndex {
+	return &slugIndex{
+		ids:   make(map[string]string),
+		slugs: make(map[string]string),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on slugIndex
+// =====================================
+
+// lookup returns the ID of the organization with a slug (pointer receiver)
+func (x *slugIndex) lookup(slug string) (string, bool) {
+	id, exists := x.ids[slug]
+	return id, exists
+}
+
+// check returns ErrSlugTaken if another organization holds slug; an empty
+// slug is never taken (pointer receiver)
+func (x *slugIndex) check(id, slug string) error {
+	if owner, taken := x.ids[slug]; slug != "" && taken && owner != id {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// set indexes an organization under slug, replacing its previous entry; an
+// empty slug only removes it. Callers check first (pointer receiver).
+func (x *slugIndex) set(id, slug string) {
+	x.remove(id)
+	if slug == "" {
+		return
+	}
+	x.ids[slug] = id
+	x.slugs[id] = slug
+}
+
+// remove drops an organization's entry, if any (pointer receiver)
+func (x *slugIndex) remove(id string) {
+	if slug, exists := x.slugs[id]; exists {
+		delete(x.ids, slug)
+		delete(x.slugs, id)
+	}
+}
+
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot is an immutable point-in-time copy of a service's entities.
+// It is taken under the service's read lock and iterated without it, so
+// long-running exports and analytics never block writers.
+type Snapshot[T any] struct {
+	takenAt time.Time
+	items   []T
+}
+
+// snapshotCache holds the latest Snapshot of a service's entities so list
+// reads can copy out of it without the service lock. Writers drop it
+// while holding the write lock; the next reader rebuilds it once under
+// the read lock, so a cached snapshot is never older than the last write.
+type snapshotCache[T any] struct {
+	current atomic.Pointer[Snapshot[T]]
+}
+
+// newSnapshot wraps items that the caller has already deep-copied (standalone function)
+func newSnapshot[T any](takenAt time.Time, items []T) *Snapshot[T] {
+	return &Snapshot[T]{
+		takenAt: takenAt,
+		items:   items,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on Snapshot
+// =====================================
+
+// TakenAt returns when the snapshot was taken (pointer receiver)
+func (s *Snapshot[T]) TakenAt() time.Time {
+	return s.takenAt
+}
+
+// Len returns the number of entities in the snapshot (pointer receiver)
+func (s *Snapshot[T]) Len() int {
+	return len(s.items)
+}
+
+// Each calls fn with a copy of each entity in ID order, stopping at the
+// first error returned by fn or when ctx is done (pointer receiver)
+func (s *Snapshot[T]) Each(ctx context.Context, fn func(T) error) error {
+	for _, item := range s.items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// =====================================
+// Pointer Receiver Methods on snapshotCache
+// =====================================
+
+// load returns the cached snapshot, building it with build under mu's
+// read lock when there is none; build may assume the lock is held
+// (pointer receiver)
+func (c *snapshotCache[T]) load(mu *sync.RWMutex, build func() *Snapshot[T]) *Snapshot[T] {
+	if snapshot := c.current.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if snapshot := c.current.Load(); snapshot != nil {
+		return snapshot
+	}
+	snapshot := build()
+	c.current.Store(snapshot)
+	return snapshot
+}
+
+// invalidate drops the cached snapshot; callers hold the write lock (pointer receiver)
+func (c *snapshotCache[T]) invalidate() {
+	c.current.Store(nil)
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// cloneTime copies an optional timestamp so a snapshot shares no pointers
+// with the live store (standalone function)
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
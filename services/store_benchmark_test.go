@@ -0,0 +1,30 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/pkg/conformance"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Measure the stores under parallel load, e.g.
+//
+//	go test ./services -run '^$' -bench . -cpu 1,4,8
+
+func newUserService() interfaces.Repository { return services.NewUserService() }
+
+func newOrganizationService() interfaces.OrgRepository { return services.NewOrganizationService() }
+
+func BenchmarkUserServiceWrites(b *testing.B) {
+	conformance.BenchmarkRepositoryWrites(b, newUserService)
+}
+
+func BenchmarkUserServiceMixed(b *testing.B) {
+	conformance.BenchmarkRepositoryMixed(b, newUserService)
+}
+
+func BenchmarkOrganizationServiceWrites(b *testing.B) {
+	conformance.BenchmarkOrgRepositoryWrites(b, newOrganizationService)
+}
+
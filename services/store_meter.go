@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unsafe"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// ErrStoreFull is returned when a write would take a store past its limits
+var ErrStoreFull = errors.New("store is full")
+
+// mapEntryOverhead approximates the map bucket and pointer cost of one entity
+const mapEntryOverhead = 64
+
+// StoreLimitPolicy decides what happens when a write would exceed a limit
+type StoreLimitPolicy string
+
+// Store limit policies
+const (
+	// StoreLimitReject rejects the write with ErrStoreFull
+	StoreLimitReject StoreLimitPolicy = "reject"
+	// StoreLimitEvictDeleted hard-deletes soft-deleted entities, oldest
+	// deletion first, and rejects only if that does not free enough room
+	StoreLimitEvictDeleted StoreLimitPolicy = "evict_deleted"
+)
+
+// IsValid checks if the policy is known (value receiver)
+func (p StoreLimitPolicy) IsValid() bool {
+	return p == StoreLimitReject || p == StoreLimitEvictDeleted
+}
+
+// StoreLimits caps an in-memory store; zero values mean unlimited
+type StoreLimits struct {
+	MaxEntities int
+	MaxBytes    int64
+	Policy      StoreLimitPolicy // Defaults to StoreLimitReject
+}
+
+// StoreLimitsConfig holds the limits of every metered store
+type StoreLimitsConfig struct {
+	Users StoreLimits
+	Orgs  StoreLimits
+}
+
+// StoreStats reports the approximate memory use of one store
+type StoreStats struct {
+	Store       string           `json:"store"`
+	Entities    int              `json:"entities"`
+	Bytes       int64            `json:"approx_bytes"`
+	MaxEntities int              `json:"max_entities,omitempty"`
+	MaxBytes    int64            `json:"max_bytes,omitempty"`
+	Policy      StoreLimitPolicy `json:"policy"`
+	Rejected    uint64           `json:"rejected_writes"`
+	Evicted     uint64           `json:"evicted"`
+}
+
+// storeMeter tracks the approximate size of each entity in a store.
+// It has no lock of its own; callers hold the owning service's lock.
+type storeMeter struct {
+	name     string
+	limits   StoreLimits
+	sizes    map[string]int64
+	bytes    int64
+	rejected uint64
+	evicted  uint64
+}
+
+// evictionCandidate is a soft-deleted entity that may be evicted
+type evictionCandidate struct {
+	id        string
+	deletedAt time.Time
+}
+
+// newStoreMeter creates an unlimited meter (standalone function)
+func newStoreMeter(name string) *storeMeter {
+	return &storeMeter{
+		name:   name,
+		limits: StoreLimits{Policy: StoreLimitReject},
+		sizes:  make(map[string]int64),
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods on storeMeter
+// =====================================
+
+// setLimits replaces the limits, defaulting the policy (pointer receiver)
+func (m *storeMeter) setLimits(limits StoreLimits) {
+	if !limits.Policy.IsValid() {
+		limits.Policy = StoreLimitReject
+	}
+	m.limits = limits
+}
+
+// fits reports whether storing size bytes under id stays within the
+// limits, accounting for any entity it replaces (pointer receiver)
+func (m *storeMeter) fits(id string, size int64) bool {
+	entities := len(m.sizes)
+	bytes := m.bytes + size
+	if old, exists := m.sizes[id]; exists {
+		bytes -= old
+	} else {
+		entities++
+	}
+
+	if m.limits.MaxEntities > 0 && entities > m.limits.MaxEntities {
+		return false
+	}
+	return m.limits.MaxBytes <= 0 || bytes <= m.limits.MaxBytes
+}
+
+// admit makes room for an entity according to the policy, calling evict
+// for each soft-deleted candidate removed, and returns ErrStoreFull if the
+// entity still does not fit. Candidates are only listed when the store is
+// at its limit (pointer receiver).
+func (m *storeMeter) admit(id string, size int64, list func() []evictionCandidate, evict func(id string)) error {
+	if m.fits(id, size) {
+		return nil
+	}
+
+	if m.limits.Policy == StoreLimitEvictDeleted {
+		candidates := list()
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].deletedAt.Before(candidates[j].deletedAt)
+		})
+		for _, candidate := range candidates {
+			if candidate.id == id {
+				continue
+			}
+			evict(candidate.id)
+			m.forget(candidate.id)
+			m.evicted++
+			if m.fits(id, size) {
+				return nil
+			}
+		}
+	}
+
+	m.rejected++
+	return fmt.Errorf("%w: %s store is at its limit", ErrStoreFull, m.name)
+}
+
+// record stores the size of an entity after a successful write (pointer receiver)
+func (m *storeMeter) record(id string, size int64) {
+	m.bytes += size - m.sizes[id]
+	m.sizes[id] = size
+}
+
+// forget drops a deleted entity from the accounting (pointer receiver)
+func (m *storeMeter) forget(id string) {
+	m.bytes -= m.sizes[id]
+	delete(m.sizes, id)
+}
+
+// stats reports the current usage (pointer receiver)
+func (m *storeMeter) stats() StoreStats {
+	return StoreStats{
+		Store:       m.name,
+		Entities:    len(m.sizes),
+		Bytes:       m.bytes,
+		MaxEntities: m.limits.MaxEntities,
+		MaxBytes:    m.limits.MaxBytes,
+		Policy:      m.limits.Policy,
+		Rejected:    m.rejected,
+		Evicted:     m.evicted,
+	}
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// approxUserBytes estimates the memory held by a stored user (standalone function)
+func approxUserBytes(u *models.User) int64 {
+	size := int64(unsafe.Sizeof(*u)) + mapEntryOverhead
+	size += int64(len(u.ID) + len(u.FirstName) + len(u.LastName) + len(u.Email) +
+		len(u.Role) + len(u.Locale) + len(u.Timezone))
+	if u.DeletedAt != nil {
+		size += int64(unsafe.Sizeof(*u.DeletedAt))
+	}
+	return size
+}
+
+// approxOrgBytes estimates the memory held by a stored organization (standalone function)
+func approxOrgBytes(o *models.Organization) int64 {
+	size := int64(unsafe.Sizeof(*o)) + mapEntryOverhead
+	size += int64(len(o.ID) + len(o.Name) + len(o.Description) + len(o.Industry) +
+		len(o.Size) + len(o.OwnerID) + len(o.Slug))
+	size += int64(len(o.Street) + len(o.City) + len(o.State) + len(o.Country) + len(o.PostalCode))
+	size += int64(len(o.Phone) + len(o.ContactInfo.Email) + len(o.Website))
+	size += int64(len(o.Support.Tier) + len(o.Support.EscalationWebhook) + len(o.Support.EscalationContact))
+	if o.DeletedAt != nil {
+		size += int64(unsafe.Sizeof(*o.DeletedAt))
+	}
+	return size
+}
+
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/test-repo-golang-support/models"
+	"github.com/test-repo-golang-support/pkg/timing"
+)
+
+// ErrTaskNotFound is returned for an unknown task or one outside the given project
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrTaskInvalid is returned when a task fails validation
+var ErrTaskInvalid = errors.New("invalid task")
+
+// TaskFilter narrows ListTasks; empty fields match every task
+type TaskFilter struct {
+	Status     models.TaskStatus
+	AssigneeID string
+}
+
+// TaskService handles the tasks of projects
+type TaskService struct {
+	idSource // Hands out IDs for created entities
+	tasks    map[string]*models.Task
+	projects *ProjectService
+	mu       sync.RWMutex
+}
+
+// NewTaskService creates a new TaskService instance
+func NewTaskService(projects *ProjectService) *TaskService {
+	return &TaskService{
+		tasks:    make(map[string]*models.Task),
+		projects: projects,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// ReadTask retrieves a task of a project (pointer receiver)
+func (s *TaskService) ReadTask(ctx context.Context, projectID, id string) (*models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists || task.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+	copied := *task
+	return &copied, nil
+}
+
+// ListTasks lists a project's tasks matching the filter, oldest first
+// (pointer receiver)
+func (s *TaskService) ListTasks(ctx context.Context, projectID string, filter TaskFilter) ([]models.Task, error) {
+	defer timing.Track(ctx, timing.LayerService, "tasks.ListTasks")()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := make([]models.Task, 0)
+	for _, task := range s.tasks {
+		if task.ProjectID != projectID {
+			continue
+		}
+		if filter.Status != "" && task.Status != filter.Status {
+			continue
+		}
+		if filter.AssigneeID != "" && task.AssigneeID != filter.AssigneeID {
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+	return tasks, nil
+}
+
+// WriteTask creates or updates a task. The project must exist and an
+// assignee must be able to reach it, through membership of its
+// organization or a guest grant (pointer receiver).
+func (s *TaskService) WriteTask(ctx context.Context, task *models.Task) error {
+	defer timing.Track(ctx, timing.LayerService, "tasks.WriteTask")()
+	if task.ID == "" {
+		return errors.New("task ID is required")
+	}
+	if err := s.validate(ctx, task); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.tasks[task.ID]; exists {
+		if existing.ProjectID != task.ProjectID {
+			return fmt.Errorf("%w: %s", ErrTaskNotFound, task.ID)
+		}
+		if existing.Version != task.Version {
+			return ErrVersionConflict
+		}
+	}
+	task.IncrementVersion()
+	stored := *task
+	s.tasks[task.ID] = &stored
+	return nil
+}
+
+// DeleteTask removes a task of a project (pointer receiver)
+func (s *TaskService) DeleteTask(ctx context.Context, projectID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[id]
+	if !exists || task.ProjectID != projectID {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+	delete(s.tasks, id)
+	return nil
+}
+
+// RemoveProjectTasks removes every task of a project and returns how many
+// were removed (pointer receiver)
+func (s *TaskService) RemoveProjectTasks(ctx context.Context, projectID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, task := range s.tasks {
+		if task.ProjectID == projectID {
+			delete(s.tasks, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// UnassignUser clears a user as the assignee of every task and returns how
+// many tasks were changed (pointer receiver)
+func (s *TaskService) UnassignUser(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := 0
+	for id, task := range s.tasks {
+		if task.AssigneeID != userID {
+			continue
+		}
+		unassigned := *task
+		unassigned.AssigneeID = ""
+		unassigned.Touch()
+		unassigned.IncrementVersion()
+		s.tasks[id] = &unassigned
+		changed++
+	}
+	return changed, nil
+}
+
+// validate checks a task before it is stored (pointer receiver)
+func (s *TaskService) validate(ctx context.Context, task *models.Task) error {
+	if strings.TrimSpace(task.Title) == "" {
+		return fmt.Errorf("%w: title is required", ErrTaskInvalid)
+	}
+	if !task.Status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", ErrTaskInvalid, task.Status)
+	}
+	if task.AssigneeID == "" {
+		return nil
+	}
+
+	access, err := s.projects.CheckAccess(ctx, task.AssigneeID, task.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !access.Allowed {
+		return fmt.Errorf("%w: assignee %s cannot access project %s", ErrTaskInvalid, task.AssigneeID, task.ProjectID)
+	}
+	return nil
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+const (
+	// UserBackfillName identifies the User to UserRefactored backfill
+	UserBackfillName = "users_v2"
+	// UserBackfillDryRunName identifies the same backfill run as a dry run
+	UserBackfillDryRunName = "users_v2_dry_run"
+)
+
+// UserBackfill copies every stored User into the UserRefactored store of
+// the migration service, in user ID order so the last ID is the checkpoint.
+// A dry run only validates the field mapping and stores nothing.
+type UserBackfill struct {
+	users     *UserService
+	migration *UserMigrationService
+	dryRun    bool
+}
+
+// NewUserBackfill creates a new UserBackfill instance
+func NewUserBackfill(users *UserService, migration *UserMigrationService, dryRun bool) *UserBackfill {
+	return &UserBackfill{
+		users:     users,
+		migration: migration,
+		dryRun:    dryRun,
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods - Backfill Implementation
+// =====================================
+
+// Name returns the backfill name (pointer receiver)
+func (b *UserBackfill) Name() string {
+	if b.dryRun {
+		return UserBackfillDryRunName
+	}
+	return UserBackfillName
+}
+
+// Total counts users to migrate, soft-deleted ones included (pointer receiver)
+func (b *UserBackfill) Total(ctx context.Context) (int, error) {
+	users, err := b.users.ReadAllIncludingDeleted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(users), nil
+}
+
+// Batch migrates up to limit users with IDs after the cursor (pointer receiver)
+func (b *UserBackfill) Batch(ctx context.Context, cursor string, limit int) (BackfillBatch, error) {
+	users, err := b.users.ReadAllIncludingDeleted(ctx)
+	if err != nil {
+		return BackfillBatch{Cursor: cursor}, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	batch := BackfillBatch{Cursor: cursor}
+	pending := make([]*models.User, 0, limit)
+	for i := range users {
+		if users[i].ID <= cursor {
+			continue
+		}
+		if len(pending) == limit {
+			break
+		}
+		pending = append(pending, &users[i])
+	}
+	batch.Done = len(pending) < limit || pending[len(pending)-1].ID == users[len(users)-1].ID
+
+	for _, err := range b.migrate(ctx, pending) {
+		if err != nil {
+			batch.Failed++
+		} else {
+			batch.Processed++
+		}
+	}
+	if len(pending) > 0 {
+		batch.Cursor = pending[len(pending)-1].ID
+	}
+	return batch, nil
+}
+
+// migrate migrates a batch of users, or only checks them on a dry run (pointer receiver)
+func (b *UserBackfill) migrate(ctx context.Context, users []*models.User) []error {
+	if b.dryRun {
+		return b.migration.CheckUsers(ctx, users)
+	}
+	return b.migration.MigrateUsers(ctx, users)
+}
+
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUserDeletionNotFound is returned when the user to delete does not exist
+var ErrUserDeletionNotFound = errors.New("user to delete not found")
+
+// UserDeletionStores are the stores holding user-scoped data that a user
+// deletion clears
+type UserDeletionStores struct {
+	Orgs           *OrganizationService
+	Profiles       *ProfileService
+	Projects       *ProjectService
+	Tasks          *TaskService
+	JoinRequests   *JoinRequestService
+	Preferences    *PreferencesService
+	Impersonations *ImpersonationService
+}
+
+// UserDeletion reports how many records of each kind a user deletion removed
+type UserDeletion struct {
+	UserID  string         `json:"user_id"`
+	Removed map[string]int `json:"removed"` // Step name -> records removed or changed
+}
+
+// userDeletionStep removes one kind of user data and reports how many
+// records it removed
+type userDeletionStep struct {
+	name   string
+	remove func(ctx context.Context, userID string) (int, error)
+}
+
+// UserDeletionService removes users together with everything tied to
+// them: memberships, project assignments and guest grants, task
+// assignments, join requests, profile, settings and impersonations.
+// Steps are safe to repeat, so a failed deletion may be started again.
+type UserDeletionService struct {
+	users *UserService
+	steps []userDeletionStep
+}
+
+// NewUserDeletionService creates a new UserDeletionService instance
+func NewUserDeletionService(users *UserService, stores UserDeletionStores) *UserDeletionService {
+	return &UserDeletionService{
+		users: users,
+		steps: []userDeletionStep{
+			{name: "memberships", remove: stores.Orgs.RemoveUserMemberships},
+			{name: "project_assignments", remove: stores.Projects.RemoveUserAssignments},
+			{name: "guest_grants", remove: stores.Projects.RemoveUserGrants},
+			{name: "task_assignments", remove: stores.Tasks.UnassignUser},
+			{name: "join_requests", remove: stores.JoinRequests.RemoveUserJoinRequests},
+			{name: "profile", remove: stores.Profiles.RemoveUserProfile},
+			{name: "settings", remove: stores.Preferences.RemoveUserSettings},
+			{name: "impersonations", remove: func(ctx context.Context, userID string) (int, error) {
+				return stores.Impersonations.EndUserImpersonations(ctx, userID), nil
+			}},
+		},
+	}
+}
+
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// Delete removes a user, deleted or not, after clearing their data from
+// the other stores (pointer receiver)
+func (s *UserDeletionService) Delete(ctx context.Context, userID string) (*UserDeletion, error) {
+	if _, err := s.users.ReadIncludingDeleted(ctx, userID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserDeletionNotFound, userID)
+	}
+
+	deletion, err := s.RemoveUserData(ctx, userID)
+	if err != nil {
+		return deletion, err
+	}
+	if err := s.users.Delete(ctx, userID); err != nil {
+		return deletion, fmt.Errorf("user: %w", err)
+	}
+	return deletion, nil
+}
+
+// RemoveUserData clears a user's data from the other stores, leaving the
+// user record itself alone, and stops at the first failing step. It is
+// what the user store's eviction hook runs for users the limits evicted
+// (pointer receiver).
+func (s *UserDeletionService) RemoveUserData(ctx context.Context, userID string) (*UserDeletion, error) {
+	deletion := &UserDeletion{UserID: userID, Removed: make(map[string]int, len(s.steps))}
+	for _, step := range s.steps {
+		removed, err := step.remove(ctx, userID)
+		deletion.Removed[step.name] = removed
+		if err != nil {
+			return deletion, fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+	return deletion, nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/test-repo-golang-support/models"
+)
+
+// testUserDeletions is a UserDeletionService over fresh stores, kept
+// alongside them so tests can look inside
+type testUserDeletions struct {
+	*UserDeletionService
+	users          *UserService
+	orgs           *OrganizationService
+	profiles       *ProfileService
+	projects       *ProjectService
+	tasks          *TaskService
+	joinRequests   *JoinRequestService
+	notifier       *MultiChannelNotifier
+	preferences    *PreferencesService
+	impersonations *ImpersonationService
+}
+
+// newTestUserDeletions wires a UserDeletionService the way the application does
+func newTestUserDeletions(t *testing.T) *testUserDeletions {
+	t.Helper()
+	logger := log.New(io.Discard, "", 0)
+	users := NewUserService()
+	orgs := NewOrganizationService()
+	projects := NewProjectService(orgs)
+	notifier := NewMultiChannelNotifier(NewLogNotifier(logger), NewLogNotifier(logger), users)
+	f := &testUserDeletions{
+		users:          users,
+		orgs:           orgs,
+		profiles:       NewProfileService(users),
+		projects:       projects,
+		tasks:          NewTaskService(projects),
+		joinRequests:   NewJoinRequestService(orgs, notifier),
+		notifier:       notifier,
+		preferences:    NewPreferencesService(users, notifier),
+		impersonations: NewImpersonationService(users),
+	}
+	f.UserDeletionService = NewUserDeletionService(users, UserDeletionStores{
+		Orgs:           f.orgs,
+		Profiles:       f.profiles,
+		Projects:       f.projects,
+		Tasks:          f.tasks,
+		JoinRequests:   f.joinRequests,
+		Preferences:    f.preferences,
+		Impersonations: f.impersonations,
+	})
+	return f
+}
+
+// seedUser stores userID with a record in every store the deletion clears:
+// a membership of org_home, an assignment to proj_home and a task there, a
+// guest grant on proj_guest in org_guest, a join request to org_join, a
+// profile, a theme, notification preferences and an active impersonation
+func (f *testUserDeletions) seedUser(t *testing.T, userID string) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, id := range []string{userID, "user_admin"} {
+		if err := f.users.Write(ctx, CreateUser(id, "Test", id, id+"@example.com")); err != nil {
+			t.Fatalf("Write(%s): %v", id, err)
+		}
+	}
+	for _, id := range []string{"org_home", "org_guest", "org_join"} {
+		org := writeTestOrg(t, f.orgs, id)
+		if id == "org_join" {
+			org.Slug = "join"
+			org.SetPublicProfile(true)
+			if err := f.orgs.WriteOrg(ctx, org); err != nil {
+				t.Fatalf("WriteOrg(%s): %v", id, err)
+			}
+		}
+		if err := f.orgs.AddMember(ctx, CreateMembership("owner_"+id, id, models.MemberRoleAdmin)); err != nil {
+			t.Fatalf("AddMember(owner_%s): %v", id, err)
+		}
+	}
+	if err := f.orgs.AddMember(ctx, CreateMembership(userID, "org_home", models.MemberRoleMember)); err != nil {
+		t.Fatalf("AddMember(%s): %v", userID, err)
+	}
+
+	for _, p := range []struct{ id, org string }{{"proj_home", "org_home"}, {"proj_guest", "org_guest"}} {
+		if err := f.projects.WriteProject(ctx, CreateProject(p.id, p.id, "owner_"+p.org, p.org)); err != nil {
+			t.Fatalf("WriteProject(%s): %v", p.id, err)
+		}
+	}
+	if err := f.projects.AssignMember(ctx, models.NewProjectMember("pm_1", "proj_home", models.UserID(userID), models.ProjectMemberContributor, "owner_org_home")); err != nil {
+		t.Fatalf("AssignMember: %v", err)
+	}
+	if err := f.projects.GrantGuestAccess(ctx, models.NewProjectGrant("grant_1", "proj_guest", models.UserID(userID), "org_home", models.ProjectGrantEditor, "owner_org_guest")); err != nil {
+		t.Fatalf("GrantGuestAccess: %v", err)
+	}
+	task := models.NewTask("task_1", "proj_home", "Assigned")
+	task.AssigneeID = models.UserID(userID)
+	if err := f.tasks.WriteTask(ctx, task); err != nil {
+		t.Fatalf("WriteTask: %v", err)
+	}
+
+	if _, err := f.joinRequests.RequestToJoin(ctx, "org_join", userID, "let me in"); err != nil {
+		t.Fatalf("RequestToJoin: %v", err)
+	}
+	if err := f.profiles.SaveProfile(ctx, models.NewProfile("profile_1", models.UserID(userID))); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+	theme := models.ThemeDark
+	if _, err := f.preferences.UpdateSettings(ctx, userID, models.UserSettingsUpdate{Theme: &theme}); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	if _, err := f.notifier.SetPreferences(ctx, models.DefaultNotificationPreferences(models.UserID(userID))); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+	if _, err := f.impersonations.Start(ctx, "user_admin", userID, "support ticket", time.Hour); err != nil {
+		t.Fatalf("Start impersonation: %v", err)
+	}
+}
+
+// userRecords counts, per kind, the records still tied to userID
+func (f *testUserDeletions) userRecords(t *testing.T, userID string) map[string]int {
+	t.Helper()
+	ctx := context.Background()
+	records := make(map[string]int)
+
+	if orgs, err := f.orgs.GetUserOrganizations(ctx, userID); err == nil {
+		records["memberships"] = len(orgs)
+	}
+	f.projects.mu.RLock()
+	for _, member := range f.projects.members {
+		if string(member.UserID) == userID {
+			records["project_assignments"]++
+		}
+	}
+	for _, grant := range f.projects.grants {
+		if string(grant.UserID) == userID {
+			records["guest_grants"]++
+		}
+	}
+	f.projects.mu.RUnlock()
+
+	tasks, err := f.tasks.ListTasks(ctx, "proj_home", TaskFilter{AssigneeID: userID})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	records["task_assignments"] = len(tasks)
+
+	requests, err := f.joinRequests.ListJoinRequests(ctx, "org_join", "")
+	if err != nil {
+		t.Fatalf("ListJoinRequests: %v", err)
+	}
+	for _, request := range requests {
+		if request.UserID == userID {
+			records["join_requests"]++
+		}
+	}
+	if _, err := f.profiles.GetByUserID(ctx, models.UserID(userID)); err == nil {
+		records["profile"]++
+	}
+
+	f.preferences.mu.RLock()
+	if _, ok := f.preferences.themes[userID]; ok {
+		records["settings"]++
+	}
+	f.preferences.mu.RUnlock()
+	f.notifier.mu.RLock()
+	if _, ok := f.notifier.preferences[userID]; ok {
+		records["settings"]++
+	}
+	f.notifier.mu.RUnlock()
+
+	for _, impersonation := range f.impersonations.ListActive(ctx) {
+		if string(impersonation.UserID) == userID {
+			records["impersonations"]++
+		}
+	}
+
+	for kind, n := range records {
+		if n == 0 {
+			delete(records, kind)
+		}
+	}
+	return records
+}
+
+func TestUserDeletionLeavesNoRecords(t *testing.T) {
+	ctx := context.Background()
+	f := newTestUserDeletions(t)
+	f.seedUser(t, "user_gone")
+
+	before := f.userRecords(t, "user_gone")
+	for _, kind := range []string{"memberships", "project_assignments", "guest_grants", "task_assignments", "join_requests", "profile", "settings", "impersonations"} {
+		if before[kind] == 0 {
+			t.Fatalf("seeded user has no %s record: %v", kind, before)
+		}
+	}
+
+	deletion, err := f.Delete(ctx, "user_gone")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for kind, n := range before {
+		if deletion.Removed[kind] != n {
+			t.Errorf("Removed[%s] = %d, want %d", kind, deletion.Removed[kind], n)
+		}
+	}
+	if left := f.userRecords(t, "user_gone"); len(left) != 0 {
+		t.Errorf("records left after Delete: %v", left)
+	}
+	if _, err := f.users.ReadIncludingDeleted(ctx, "user_gone"); err == nil {
+		t.Error("user still stored after Delete")
+	}
+
+	// The other users keep their data
+	if _, err := f.orgs.GetMembership(ctx, "owner_org_home", "org_home"); err != nil {
+		t.Errorf("owner membership removed: %v", err)
+	}
+	if _, err := f.users.Read(ctx, "user_admin"); err != nil {
+		t.Errorf("admin removed: %v", err)
+	}
+}
+
+func TestUserDeletionUnknownUser(t *testing.T) {
+	f := newTestUserDeletions(t)
+	if _, err := f.Delete(context.Background(), "user_missing"); !errors.Is(err, ErrUserDeletionNotFound) {
+		t.Fatalf("Delete(user_missing): err = %v, want ErrUserDeletionNotFound", err)
+	}
+}
+
+func TestUserEvictionRunsDeletionCascade(t *testing.T) {
+	ctx := context.Background()
+	f := newTestUserDeletions(t)
+	f.seedUser(t, "user_gone")
+	f.users.SetEvictionHook(func(ctx context.Context, id string) {
+		if _, err := f.RemoveUserData(ctx, id); err != nil {
+			t.Errorf("RemoveUserData(%s): %v", id, err)
+		}
+	})
+
+	user, err := f.users.Read(ctx, "user_gone")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	user.Deactivate()
+	if err := f.users.Write(ctx, user); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+
+	// Two users are stored; a third write evicts the soft-deleted one
+	f.users.SetLimits(StoreLimits{MaxEntities: 2, Policy: StoreLimitEvictDeleted})
+	if err := f.users.Write(ctx, CreateUser("user_new", "New", "User", "new@example.com")); err != nil {
+		t.Fatalf("Write(user_new): %v", err)
+	}
+
+	if _, err := f.users.ReadIncludingDeleted(ctx, "user_gone"); err == nil {
+		t.Fatal("soft-deleted user was not evicted")
+	}
+	if left := f.userRecords(t, "user_gone"); len(left) != 0 {
+		t.Errorf("records left after eviction: %v", left)
+	}
+}
@@ -3,98 +3,278 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/test-repo-golang-support/models"
 )
 
-// UserMigrationService handles migration from old User to UserRefactored
-// This file demonstrates knowledge graph traversal:
-// - References models.User (old) and models.UserRefactored (new)
-// - Should detect that other files still use old User.Email
+// ErrInvalidMapping is returned when a user cannot be mapped onto UserRefactored
+var ErrInvalidMapping = errors.New("invalid field mapping")
+
+// UserMigrationService migrates users from models.User to models.UserRefactored.
+// The old UserService stays the source of truth until the migration completes;
+// migrated users are kept in a separate store.
 type UserMigrationService struct {
-	oldUsers map[string]*models.User
+	idSource // Hands out IDs for created entities
+	users    *UserService
 	newUsers map[string]*models.UserRefactored
+	failures map[string]MigrationFailure // key: user ID
+	mu       sync.RWMutex
+}
+
+// MigrationFailure records why a user could not be migrated
+type MigrationFailure struct {
+	UserID string `json:"user_id"`
+	Error  string `json:"error"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// MigrationStatus summarizes the migrated and failed users
+type MigrationStatus struct {
+	Migrated int                `json:"migrated"`
+	Failed   int                `json:"failed"`
+	Failures []MigrationFailure `json:"failures"`
 }
 
-// NewUserMigrationService creates a migration service
-func NewUserMigrationService() *UserMigrationService {
+// NewUserMigrationService creates a migration service reading from users
+func NewUserMigrationService(users *UserService) *UserMigrationService {
 	return &UserMigrationService{
-		oldUsers: make(map[string]*models.User),
+		users:    users,
 		newUsers: make(map[string]*models.UserRefactored),
+		failures: make(map[string]MigrationFailure),
 	}
 }
 
-// MigrateUser migrates an old user to the new format
-// BUG: This method accesses user.Email which was renamed to EmailAddress
-// Knowledge graph should detect this relationship and flag the issue
-func (s *UserMigrationService) MigrateUser(ctx context.Context, oldUser *models.User) (*models.UserRefactored, error) {
+// =====================================
+// Pointer Receiver Methods
+// =====================================
+
+// ConvertUser maps an old user onto the new shape and validates the
+// mapping without storing anything (pointer receiver)
+func (s *UserMigrationService) ConvertUser(oldUser *models.User) (*models.UserRefactored, error) {
 	if oldUser == nil {
 		return nil, errors.New("user is nil")
 	}
 
-	// BUG: Accessing oldUser.Email - field was renamed to EmailAddress in UserRefactored
-	// Knowledge graph should detect this cross-file relationship issue
 	newUser := &models.UserRefactored{
 		BaseEntity: models.BaseEntity{
 			ID:        oldUser.ID,
 			CreatedAt: oldUser.CreatedAt,
 			UpdatedAt: oldUser.UpdatedAt,
+			Version:   oldUser.Version,
 		},
 		Timestamps: models.Timestamps{
 			DeletedAt: oldUser.DeletedAt,
 		},
 		FirstName:    oldUser.FirstName,
-		LastName:      oldUser.LastName,
-		EmailAddress:  oldUser.Email, // BUG: Should detect that Email field exists but EmailAddress doesn't on old User
-		Role:          oldUser.Role,
-		Active:        oldUser.Active,
+		LastName:     oldUser.LastName,
+		EmailAddress: oldUser.Email, // Email was renamed to EmailAddress
+		Role:         oldUser.Role,
+		Active:       oldUser.Active,
 	}
 
-	s.newUsers[oldUser.ID] = newUser
+	if err := ValidateUserMapping(oldUser, newUser); err != nil {
+		return nil, err
+	}
 	return newUser, nil
 }
 
-// FindUserByEmailAddress finds a user by email address
-// BUG: This method calls FindByEmail which still uses old User.Email field
-// Knowledge graph should detect that FindByEmail in service.go uses user.Email
-// but we're now working with EmailAddress
-func (s *UserMigrationService) FindUserByEmailAddress(ctx context.Context, email string) (*models.UserRefactored, error) {
-	// BUG: FindByEmail uses old User.Email, but we need EmailAddress
-	// Knowledge graph should detect this relationship mismatch
-	oldUser, err := s.FindByEmail(ctx, email) // This method uses user.Email internally
+// MigrateUser converts an old user and stores the result. Failures are
+// recorded for the status report (pointer receiver).
+func (s *UserMigrationService) MigrateUser(ctx context.Context, oldUser *models.User) (*models.UserRefactored, error) {
+	newUser, err := s.ConvertUser(oldUser)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return newUser, s.store(oldUser, newUser, err)
+}
+
+// MigrateUsers converts a batch of users and stores the results under a
+// single lock acquisition. The returned slice holds the error for the user
+// at the same index, or nil (pointer receiver).
+func (s *UserMigrationService) MigrateUsers(ctx context.Context, oldUsers []*models.User) []error {
+	newUsers := make([]*models.UserRefactored, len(oldUsers))
+	errs := make([]error, len(oldUsers))
+	for i, oldUser := range oldUsers {
+		newUsers[i], errs[i] = s.ConvertUser(oldUser)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, oldUser := range oldUsers {
+		errs[i] = s.store(oldUser, newUsers[i], errs[i])
+	}
+	return errs
+}
+
+// CheckUser validates that a user would migrate cleanly without storing
+// it. Dry-run failures never replace a failure from a real run (pointer receiver).
+func (s *UserMigrationService) CheckUser(ctx context.Context, oldUser *models.User) error {
+	return s.CheckUsers(ctx, []*models.User{oldUser})[0]
+}
+
+// CheckUsers validates a batch of users like CheckUser, recording dry-run
+// failures under a single lock acquisition (pointer receiver)
+func (s *UserMigrationService) CheckUsers(ctx context.Context, oldUsers []*models.User) []error {
+	errs := make([]error, len(oldUsers))
+	for i, oldUser := range oldUsers {
+		_, errs[i] = s.ConvertUser(oldUser)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, oldUser := range oldUsers {
+		if oldUser == nil {
+			continue
+		}
+		existing, exists := s.failures[oldUser.ID]
+		if exists && !existing.DryRun {
+			continue
+		}
+		if errs[i] != nil {
+			s.failures[oldUser.ID] = MigrationFailure{UserID: oldUser.ID, Error: errs[i].Error(), DryRun: true}
+		} else {
+			delete(s.failures, oldUser.ID)
+		}
+	}
+	return errs
+}
+
+// MigrateUserByID migrates one user from the user service (pointer receiver)
+func (s *UserMigrationService) MigrateUserByID(ctx context.Context, userID string) (*models.UserRefactored, error) {
+	oldUser, err := s.users.ReadIncludingDeleted(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-
-	// Migrate to new format
 	return s.MigrateUser(ctx, oldUser)
 }
 
-// FindByEmail is a helper that would use the old UserService
-// This demonstrates that the knowledge graph should trace through
-// service relationships to find all affected code
-func (s *UserMigrationService) FindByEmail(ctx context.Context, email string) (*models.User, error) {
-	for _, user := range s.oldUsers {
-		if user.Email == email { // BUG: This field access should be flagged
+// ReadMigratedUser retrieves a migrated user by ID (pointer receiver)
+func (s *UserMigrationService) ReadMigratedUser(ctx context.Context, userID string) (*models.UserRefactored, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, exists := s.newUsers[userID]
+	if !exists {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// WriteMigratedUser stores a user created directly in the new shape (pointer receiver)
+func (s *UserMigrationService) WriteMigratedUser(ctx context.Context, user *models.UserRefactored) error {
+	if user.ID == "" {
+		return errors.New("user ID is required")
+	}
+	if !ValidateEmail(user.EmailAddress) {
+		return fmt.Errorf("%w: email_address %q is not a valid email", ErrInvalidMapping, user.EmailAddress)
+	}
+	user.EmailAddress = NormalizeEmail(user.EmailAddress)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.newUsers[user.ID] = user
+	return nil
+}
+
+// FindUserByEmailAddress finds a user by email address, migrating it on
+// first access (pointer receiver)
+func (s *UserMigrationService) FindUserByEmailAddress(ctx context.Context, email string) (*models.UserRefactored, error) {
+	email = NormalizeEmail(email)
+	s.mu.RLock()
+	for _, user := range s.newUsers {
+		if user.GetEmail() == email {
+			s.mu.RUnlock()
 			return user, nil
 		}
 	}
-	return nil, errors.New("user not found")
+	s.mu.RUnlock()
+
+	oldUser, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.MigrateUser(ctx, oldUser)
 }
 
-// UpdateUserEmail updates user email using new method signature
-// BUG: Calls UpdateEmailAddress with wrong number of arguments
-// Old UpdateEmail() took 1 arg, new UpdateEmailAddress() takes 2 args
-// Knowledge graph should detect this method signature change
+// UpdateUserEmail updates a migrated user's email address. Changed
+// addresses are unverified until confirmed (pointer receiver).
 func (s *UserMigrationService) UpdateUserEmail(ctx context.Context, userID, email string) error {
+	if !ValidateEmail(email) {
+		return errors.New("invalid email address")
+	}
+	email = NormalizeEmail(email)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	user, exists := s.newUsers[userID]
 	if !exists {
 		return errors.New("user not found")
 	}
+	user.UpdateEmailAddress(email, false)
+	return nil
+}
+
+// store records the outcome of converting one user; callers hold s.mu (pointer receiver)
+func (s *UserMigrationService) store(oldUser *models.User, newUser *models.UserRefactored, err error) error {
+	if err != nil {
+		if oldUser != nil {
+			s.failures[oldUser.ID] = MigrationFailure{UserID: oldUser.ID, Error: err.Error()}
+		}
+		return err
+	}
+	s.newUsers[newUser.ID] = newUser
+	delete(s.failures, newUser.ID)
+	return nil
+}
+
+// Status reports how many users were migrated and which failed (pointer receiver)
+func (s *UserMigrationService) Status(ctx context.Context) MigrationStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	failures := make([]MigrationFailure, 0, len(s.failures))
+	for _, failure := range s.failures {
+		failures = append(failures, failure)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].UserID < failures[j].UserID
+	})
 
-	// BUG: UpdateEmailAddress requires 2 args (email, verified) but only passing 1
-	// Knowledge graph should detect method signature mismatch
-	user.UpdateEmailAddress(email) // Should be: user.UpdateEmailAddress(email, false)
+	return MigrationStatus{
+		Migrated: len(s.newUsers),
+		Failed:   len(failures),
+		Failures: failures,
+	}
+}
+
+// =====================================
+// Standalone Functions
+// =====================================
+
+// ValidateUserMapping checks that every field survived the mapping from
+// User to UserRefactored, in particular Email to EmailAddress (standalone function)
+func ValidateUserMapping(oldUser *models.User, newUser *models.UserRefactored) error {
+	if newUser.ID != oldUser.ID {
+		return fmt.Errorf("%w: id %q became %q", ErrInvalidMapping, oldUser.ID, newUser.ID)
+	}
+	if newUser.EmailAddress != oldUser.Email {
+		return fmt.Errorf("%w: email %q became email_address %q", ErrInvalidMapping, oldUser.Email, newUser.EmailAddress)
+	}
+	if !ValidateEmail(newUser.EmailAddress) {
+		return fmt.Errorf("%w: email %q is not a valid email", ErrInvalidMapping, oldUser.Email)
+	}
+	if newUser.FirstName != oldUser.FirstName || newUser.LastName != oldUser.LastName {
+		return fmt.Errorf("%w: name was not preserved", ErrInvalidMapping)
+	}
+	if newUser.Role != oldUser.Role || newUser.Active != oldUser.Active {
+		return fmt.Errorf("%w: role or active flag was not preserved", ErrInvalidMapping)
+	}
 	return nil
 }
 
@@ -0,0 +1,1175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/handlers"
+	"github.com/test-repo-golang-support/interfaces"
+	"github.com/test-repo-golang-support/pkg/asynclog"
+	"github.com/test-repo-golang-support/pkg/container"
+	"github.com/test-repo-golang-support/pkg/logging"
+	"github.com/test-repo-golang-support/pkg/metrics"
+	"github.com/test-repo-golang-support/pkg/plugin"
+	"github.com/test-repo-golang-support/pkg/runtimeconfig"
+	"github.com/test-repo-golang-support/pkg/storage"
+	"github.com/test-repo-golang-support/services"
+)
+
+// Component names registered in the application container
+const (
+	componentUserService      = "services.user"
+	componentEnumService      = "services.enum"
+	componentOrgService       = "services.org"
+	componentProfileService   = "services.profile"
+	componentImportService    = "services.import"
+	componentSearchService    = "services.search"
+	componentMigrationService = "services.user_migration"
+	componentSettingsService  = "services.org_settings"
+	componentProjectService   = "services.project"
+	componentJoinService      = "services.join_request"
+	componentInvitations      = "services.invitation"
+	componentDrafts           = "services.draft"
+	componentSandboxes        = "services.sandbox"
+	componentTasks            = "services.task"
+	componentNotifier         = "services.notifier"
+	componentTemplates        = "services.notification_templates"
+	componentPreferences      = "services.preferences"
+	componentSitemapService   = "services.sitemap"
+	componentLocaleService    = "services.locale"
+	componentEscalations      = "services.escalation"
+	componentImpersonations   = "services.impersonation"
+	componentUserDeletions    = "services.user_deletion"
+	componentIDGenerator      = "services.ids"
+
+	componentAvatarStorage = "storage.avatars"
+	componentLogWriter     = "log.writer"
+	componentLogControl    = "log.controller"
+	componentMetrics       = "metrics.requests"
+
+	componentRetentionJanitor = "workers.retention"
+	componentBackfills        = "workers.backfill"
+	componentSLOMonitor       = "workers.slo"
+	componentScheduler        = "workers.scheduler"
+	componentProjectArchiver  = "workers.project_archive"
+	componentOrgDeletions     = "workers.org_deletion"
+	componentSLATracker       = "workers.sla"
+
+	componentHandler          = "handlers.user"
+	componentOrgHandler       = "handlers.org"
+	componentImportHandler    = "handlers.import"
+	componentExportHandler    = "handlers.export"
+	componentSearchHandler    = "handlers.search"
+	componentMigrationHandler = "handlers.user_migration"
+	componentRetentionHandler = "handlers.retention"
+	componentSettingsHandler  = "handlers.org_settings"
+	componentEnumHandler      = "handlers.enum"
+	componentProjectHandler   = "handlers.project"
+	componentPublicHandler    = "handlers.public"
+	componentEmbedHandler     = "handlers.embed"
+	componentSitemapHandler   = "handlers.sitemap"
+	componentBackfillHandler  = "handlers.backfill"
+	componentStoreHandler     = "handlers.store"
+	componentProfileHandler   = "handlers.profile"
+	componentDiagnostics      = "handlers.diagnostics"
+	componentJoinHandler      = "handlers.join_request"
+	componentInviteHandler    = "handlers.invitation"
+	componentDraftHandler     = "handlers.draft"
+	componentSandboxHandler   = "handlers.sandbox"
+	componentTaskHandler      = "handlers.task"
+	componentArchiveHandler   = "handlers.project_archive"
+	componentDeletionHandler  = "handlers.org_deletion"
+	componentNotifyHandler    = "handlers.notification"
+	componentLoggingHandler   = "handlers.logging"
+	componentTemplateHandler  = "handlers.notification_templates"
+	componentPrefsHandler     = "handlers.preferences"
+	componentSLOHandler       = "handlers.slo"
+	componentSLAHandler       = "handlers.sla"
+	componentScheduleHandler  = "handlers.scheduled_change"
+	componentAdminHandler     = "handlers.admin"
+
+	componentRouter = "http.router"
+	componentServer = "http.server"
+)
+
+// appConfig holds the settings read at startup and the dependencies chosen
+// from them; zero fields fall back to each component's defaults
+type appConfig struct {
+	Port           string
+	Retention      services.RetentionConfig
+	Sitemap        services.SitemapConfig
+	Backfill       services.BackfillConfig
+	Limits         services.StoreLimitsConfig
+	Avatars        interfaces.BlobStorage
+	Runtime        runtimeconfig.Settings
+	SlowRequests   handlers.SlowRequestConfig
+	Dedup          handlers.DedupConfig
+	SLOs           services.SLOConfig
+	Scheduler      services.SchedulerConfig
+	RateLimits     handlers.RateLimitConfig
+	Permissions    handlers.PermissionConfig
+	ProjectArchive services.ProjectArchiveConfig
+	Admin          handlers.AdminConfig
+	Email          services.EmailConfig
+	Bodies         handlers.RequestBodyConfig
+	Features       handlers.FeatureConfig
+}
+
+// newContainer registers every application component. Construction order
+// follows the dependencies each provider resolves, and the container shuts
+// components down in reverse, so the HTTP server always stops first and
+// the log writer, registered first, flushes last.
+func newContainer(logger *log.Logger, logs *asynclog.Writer, control *logging.Controller, config appConfig) *container.Container {
+	c := container.New()
+
+	// Logging
+	c.Provide(componentLogWriter, func(c *container.Container) (interface{}, error) {
+		return logs, nil
+	})
+	c.Provide(componentLogControl, func(c *container.Container) (interface{}, error) {
+		return control, nil
+	})
+
+	// Request metrics
+	c.Provide(componentMetrics, func(c *container.Container) (interface{}, error) {
+		return metrics.New(metrics.Config{}), nil
+	})
+
+	// Services
+	c.Provide(componentIDGenerator, func(c *container.Container) (interface{}, error) {
+		return services.DefaultIDGenerator(), nil
+	})
+	c.Provide(componentUserService, func(c *container.Container) (interface{}, error) {
+		userService := services.NewUserService()
+		userService.SetLimits(config.Limits.Users)
+		userService.SetEmailVerifier(services.NewEmailVerifier(config.Email))
+		return withIDGenerator(c, userService)
+	})
+	c.Provide(componentAvatarStorage, func(c *container.Container) (interface{}, error) {
+		return config.Avatars, nil
+	})
+	c.Provide(componentProfileService, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewProfileService(userService))
+	})
+	c.Provide(componentEnumService, func(c *container.Container) (interface{}, error) {
+		return services.NewEnumService(), nil
+	})
+	c.Provide(componentOrgService, func(c *container.Container) (interface{}, error) {
+		enumService, err := container.Get[*services.EnumService](c, componentEnumService)
+		if err != nil {
+			return nil, err
+		}
+		orgService := services.NewOrganizationService()
+		orgService.SetEnums(enumService)
+		orgService.SetLimits(config.Limits.Orgs)
+		return withIDGenerator(c, orgService)
+	})
+	c.Provide(componentImportService, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewImportService(userService, orgService))
+	})
+	c.Provide(componentSearchService, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		searchService := services.NewSearchService(userService, orgService, projectService)
+
+		// Keep the search index in sync with user, organization, and project writes
+		userService.SetIndexer(searchService)
+		orgService.SetIndexer(searchService)
+		projectService.SetIndexer(searchService)
+		return searchService, nil
+	})
+	c.Provide(componentMigrationService, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewUserMigrationService(userService))
+	})
+	c.Provide(componentProjectService, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewProjectService(orgService))
+	})
+	c.Provide(componentSettingsService, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewOrgSettingsService(orgService), nil
+	})
+
+	c.Provide(componentLocaleService, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		settingsService, err := container.Get[*services.OrgSettingsService](c, componentSettingsService)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewLocaleService(userService, settingsService), nil
+	})
+	c.Provide(componentEscalations, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewEscalationService(orgService, logger), nil
+	})
+	c.Provide(componentTemplates, func(c *container.Container) (interface{}, error) {
+		return services.NewNotificationTemplateService(), nil
+	})
+	c.Provide(componentNotifier, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		templates, err := container.Get[*services.NotificationTemplateService](c, componentTemplates)
+		if err != nil {
+			return nil, err
+		}
+		// No email or push provider is configured yet; both channels go to the log
+		channel := services.NewLogNotifier(logger)
+		notifier := services.NewMultiChannelNotifier(channel, channel, userService)
+		notifier.SetTemplates(templates)
+		return notifier, nil
+	})
+	c.Provide(componentPreferences, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		notifier, err := container.Get[*services.MultiChannelNotifier](c, componentNotifier)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewPreferencesService(userService, notifier), nil
+	})
+	c.Provide(componentJoinService, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		notifier, err := container.Get[*services.MultiChannelNotifier](c, componentNotifier)
+		if err != nil {
+			return nil, err
+		}
+		localeService, err := container.Get[*services.LocaleService](c, componentLocaleService)
+		if err != nil {
+			return nil, err
+		}
+		joinService := services.NewJoinRequestService(orgService, notifier)
+		joinService.SetLocales(localeService)
+		return withIDGenerator(c, joinService)
+	})
+	c.Provide(componentInvitations, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		notifier, err := container.Get[*services.MultiChannelNotifier](c, componentNotifier)
+		if err != nil {
+			return nil, err
+		}
+		templates, err := container.Get[*services.NotificationTemplateService](c, componentTemplates)
+		if err != nil {
+			return nil, err
+		}
+		// Invitees may not have an account yet, so email goes out directly
+		// rather than through per-user channel preferences
+		invitations := services.NewInvitationService(orgService, userService, notifier)
+		invitations.SetTemplates(templates)
+		return withIDGenerator(c, invitations)
+	})
+	c.Provide(componentDrafts, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewDraftService(userService, orgService, projectService))
+	})
+
+	c.Provide(componentTasks, func(c *container.Container) (interface{}, error) {
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		taskService := services.NewTaskService(projectService)
+		projectService.SetPurgeHook(func(ctx context.Context, id string) error {
+			_, err := taskService.RemoveProjectTasks(ctx, id)
+			return err
+		})
+		return withIDGenerator(c, taskService)
+	})
+	c.Provide(componentImpersonations, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewImpersonationService(userService))
+	})
+	c.Provide(componentUserDeletions, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		profileService, err := container.Get[*services.ProfileService](c, componentProfileService)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := container.Get[*services.TaskService](c, componentTasks)
+		if err != nil {
+			return nil, err
+		}
+		joinService, err := container.Get[*services.JoinRequestService](c, componentJoinService)
+		if err != nil {
+			return nil, err
+		}
+		preferences, err := container.Get[*services.PreferencesService](c, componentPreferences)
+		if err != nil {
+			return nil, err
+		}
+		impersonations, err := container.Get[*services.ImpersonationService](c, componentImpersonations)
+		if err != nil {
+			return nil, err
+		}
+		deletions := services.NewUserDeletionService(userService, services.UserDeletionStores{
+			Orgs:           orgService,
+			Profiles:       profileService,
+			Projects:       projectService,
+			Tasks:          tasks,
+			JoinRequests:   joinService,
+			Preferences:    preferences,
+			Impersonations: impersonations,
+		})
+
+		// Users evicted by the store limits take their data with them, as
+		// force-deleted users do
+		userService.SetEvictionHook(func(ctx context.Context, id string) {
+			if _, err := deletions.RemoveUserData(ctx, id); err != nil {
+				logger.Printf("event=user.eviction_cleanup_failed user=%s error=%q", id, err)
+			}
+		})
+		return deletions, nil
+	})
+	c.Provide(componentSandboxes, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		enumService, err := container.Get[*services.EnumService](c, componentEnumService)
+		if err != nil {
+			return nil, err
+		}
+		sandboxes := services.NewSandboxService(userService, orgService, projectService)
+		sandboxes.SetEnums(enumService)
+		return withIDGenerator(c, sandboxes)
+	})
+	c.Provide(componentSitemapService, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewSitemapService(orgService, config.Sitemap), nil
+	})
+
+	// Background workers
+	c.Provide(componentRetentionJanitor, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		localeService, err := container.Get[*services.LocaleService](c, componentLocaleService)
+		if err != nil {
+			return nil, err
+		}
+		deletions, err := container.Get[*services.OrgDeletionService](c, componentOrgDeletions)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		slas, err := container.Get[*services.SLATracker](c, componentSLATracker)
+		if err != nil {
+			return nil, err
+		}
+		janitor := services.NewRetentionJanitor(userService, orgService, projectService, deletions, config.Retention, logger)
+		janitor.SetLocales(localeService)
+		janitor.SetSLAs(slas)
+		return janitor, nil
+	})
+	c.Provide(componentSLATracker, func(c *container.Container) (interface{}, error) {
+		deletions, err := container.Get[*services.OrgDeletionService](c, componentOrgDeletions)
+		if err != nil {
+			return nil, err
+		}
+		changeScheduler, err := container.Get[*services.ChangeScheduler](c, componentScheduler)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewSLATracker(deletions, changeScheduler, config.Retention.SLAs, logger), nil
+	})
+
+	c.Provide(componentBackfills, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		migrationService, err := container.Get[*services.UserMigrationService](c, componentMigrationService)
+		if err != nil {
+			return nil, err
+		}
+		orchestrator := services.NewBackfillOrchestrator(config.Backfill, logger)
+		orchestrator.Register(services.NewUserBackfill(userService, migrationService, false))
+		orchestrator.Register(services.NewUserBackfill(userService, migrationService, true))
+		return orchestrator, nil
+	})
+	c.Provide(componentSLOMonitor, func(c *container.Container) (interface{}, error) {
+		registry, err := container.Get[*metrics.Registry](c, componentMetrics)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewSLOMonitor(registry, config.SLOs, logger), nil
+	})
+	c.Provide(componentOrgDeletions, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := container.Get[*services.TaskService](c, componentTasks)
+		if err != nil {
+			return nil, err
+		}
+		invitations, err := container.Get[*services.InvitationService](c, componentInvitations)
+		if err != nil {
+			return nil, err
+		}
+		joinService, err := container.Get[*services.JoinRequestService](c, componentJoinService)
+		if err != nil {
+			return nil, err
+		}
+		settingsService, err := container.Get[*services.OrgSettingsService](c, componentSettingsService)
+		if err != nil {
+			return nil, err
+		}
+		changeScheduler, err := container.Get[*services.ChangeScheduler](c, componentScheduler)
+		if err != nil {
+			return nil, err
+		}
+		sandboxes, err := container.Get[*services.SandboxService](c, componentSandboxes)
+		if err != nil {
+			return nil, err
+		}
+		deletions := services.NewOrgDeletionService(orgService, services.OrgDeletionStores{
+			Projects:     projectService,
+			Tasks:        tasks,
+			Invitations:  invitations,
+			JoinRequests: joinService,
+			Settings:     settingsService,
+			Changes:      changeScheduler,
+			Sandboxes:    sandboxes,
+		}, logger)
+		return withIDGenerator(c, deletions)
+	})
+	c.Provide(componentProjectArchiver, func(c *container.Container) (interface{}, error) {
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		settingsService, err := container.Get[*services.OrgSettingsService](c, componentSettingsService)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewProjectArchiver(projectService, orgService, settingsService, config.ProjectArchive, logger), nil
+	})
+	c.Provide(componentScheduler, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return withIDGenerator(c, services.NewChangeScheduler(userService, orgService, config.Scheduler, logger))
+	})
+
+	// Handlers
+	c.Provide(componentHandler, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewHandler(userService, logger), nil
+	})
+	c.Provide(componentOrgHandler, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		deletions, err := container.Get[*services.OrgDeletionService](c, componentOrgDeletions)
+		if err != nil {
+			return nil, err
+		}
+		orgHandler := handlers.NewOrgHandler(orgService, logger)
+		orgHandler.SetDeletions(deletions)
+		return orgHandler, nil
+	})
+	c.Provide(componentImportHandler, func(c *container.Container) (interface{}, error) {
+		importService, err := container.Get[*services.ImportService](c, componentImportService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewImportHandler(importService, logger), nil
+	})
+	c.Provide(componentExportHandler, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewExportHandler(userService, orgService, logger), nil
+	})
+	c.Provide(componentSearchHandler, func(c *container.Container) (interface{}, error) {
+		searchService, err := container.Get[*services.SearchService](c, componentSearchService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewSearchHandler(searchService, logger), nil
+	})
+	c.Provide(componentMigrationHandler, func(c *container.Container) (interface{}, error) {
+		migrationService, err := container.Get[*services.UserMigrationService](c, componentMigrationService)
+		if err != nil {
+			return nil, err
+		}
+		orchestrator, err := container.Get[*services.BackfillOrchestrator](c, componentBackfills)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewUserMigrationHandler(migrationService, orchestrator, logger), nil
+	})
+	c.Provide(componentRetentionHandler, func(c *container.Container) (interface{}, error) {
+		janitor, err := container.Get[*services.RetentionJanitor](c, componentRetentionJanitor)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewRetentionHandler(janitor, logger), nil
+	})
+	c.Provide(componentProfileHandler, func(c *container.Container) (interface{}, error) {
+		profileService, err := container.Get[*services.ProfileService](c, componentProfileService)
+		if err != nil {
+			return nil, err
+		}
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		avatars, err := container.Get[interfaces.BlobStorage](c, componentAvatarStorage)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewProfileHandler(profileService, userService, avatars, logger), nil
+	})
+	c.Provide(componentDeletionHandler, func(c *container.Container) (interface{}, error) {
+		deletions, err := container.Get[*services.OrgDeletionService](c, componentOrgDeletions)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewOrgDeletionHandler(deletions, logger), nil
+	})
+	c.Provide(componentArchiveHandler, func(c *container.Container) (interface{}, error) {
+		archiver, err := container.Get[*services.ProjectArchiver](c, componentProjectArchiver)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewProjectArchiveHandler(archiver, logger), nil
+	})
+	c.Provide(componentTaskHandler, func(c *container.Container) (interface{}, error) {
+		tasks, err := container.Get[*services.TaskService](c, componentTasks)
+		if err != nil {
+			return nil, err
+		}
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewTaskHandler(tasks, projectService, logger), nil
+	})
+	c.Provide(componentSandboxHandler, func(c *container.Container) (interface{}, error) {
+		sandboxes, err := container.Get[*services.SandboxService](c, componentSandboxes)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewSandboxHandler(sandboxes, logger, config.Permissions), nil
+	})
+	c.Provide(componentInviteHandler, func(c *container.Container) (interface{}, error) {
+		invitations, err := container.Get[*services.InvitationService](c, componentInvitations)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewInvitationHandler(invitations, orgService, logger), nil
+	})
+	c.Provide(componentDraftHandler, func(c *container.Container) (interface{}, error) {
+		drafts, err := container.Get[*services.DraftService](c, componentDrafts)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewDraftHandler(drafts, logger), nil
+	})
+	c.Provide(componentNotifyHandler, func(c *container.Container) (interface{}, error) {
+		notifier, err := container.Get[*services.MultiChannelNotifier](c, componentNotifier)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewNotificationHandler(notifier, logger), nil
+	})
+	c.Provide(componentPrefsHandler, func(c *container.Container) (interface{}, error) {
+		preferences, err := container.Get[*services.PreferencesService](c, componentPreferences)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewPreferencesHandler(preferences, logger), nil
+	})
+	c.Provide(componentTemplateHandler, func(c *container.Container) (interface{}, error) {
+		templates, err := container.Get[*services.NotificationTemplateService](c, componentTemplates)
+		if err != nil {
+			return nil, err
+		}
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewNotificationTemplateHandler(templates, userService, orgService, logger), nil
+	})
+	c.Provide(componentLoggingHandler, func(c *container.Container) (interface{}, error) {
+		control, err := container.Get[*logging.Controller](c, componentLogControl)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewLoggingHandler(control, logger), nil
+	})
+	c.Provide(componentAdminHandler, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		impersonations, err := container.Get[*services.ImpersonationService](c, componentImpersonations)
+		if err != nil {
+			return nil, err
+		}
+		deletions, err := container.Get[*services.UserDeletionService](c, componentUserDeletions)
+		if err != nil {
+			return nil, err
+		}
+		control, err := container.Get[*logging.Controller](c, componentLogControl)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewAdminHandler(userService, orgService, impersonations, deletions, control, logger, config.Admin), nil
+	})
+	c.Provide(componentSLOHandler, func(c *container.Container) (interface{}, error) {
+		monitor, err := container.Get[*services.SLOMonitor](c, componentSLOMonitor)
+		if err != nil {
+			return nil, err
+		}
+		registry, err := container.Get[*metrics.Registry](c, componentMetrics)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewSLOHandler(monitor, registry, logger), nil
+	})
+	c.Provide(componentSLAHandler, func(c *container.Container) (interface{}, error) {
+		tracker, err := container.Get[*services.SLATracker](c, componentSLATracker)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewSLAHandler(tracker, logger), nil
+	})
+	c.Provide(componentScheduleHandler, func(c *container.Container) (interface{}, error) {
+		changeScheduler, err := container.Get[*services.ChangeScheduler](c, componentScheduler)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewScheduledChangeHandler(changeScheduler, logger), nil
+	})
+	c.Provide(componentDiagnostics, func(c *container.Container) (interface{}, error) {
+		diagnosticsHandler := handlers.NewDiagnosticsHandler(config.Runtime, logger)
+		diagnosticsHandler.SetLogWriter(logs)
+		return diagnosticsHandler, nil
+	})
+	c.Provide(componentStoreHandler, func(c *container.Container) (interface{}, error) {
+		userService, err := container.Get[*services.UserService](c, componentUserService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		profileService, err := container.Get[*services.ProfileService](c, componentProfileService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewStoreHandler(userService, orgService, profileService, logger), nil
+	})
+	c.Provide(componentBackfillHandler, func(c *container.Container) (interface{}, error) {
+		orchestrator, err := container.Get[*services.BackfillOrchestrator](c, componentBackfills)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewBackfillHandler(orchestrator, logger), nil
+	})
+	c.Provide(componentSettingsHandler, func(c *container.Container) (interface{}, error) {
+		settingsService, err := container.Get[*services.OrgSettingsService](c, componentSettingsService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewOrgSettingsHandler(settingsService, logger), nil
+	})
+	c.Provide(componentProjectHandler, func(c *container.Container) (interface{}, error) {
+		projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewProjectHandler(projectService, logger), nil
+	})
+	c.Provide(componentPublicHandler, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewPublicHandler(orgService, logger), nil
+	})
+	c.Provide(componentEmbedHandler, func(c *container.Container) (interface{}, error) {
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewEmbedHandler(orgService, logger), nil
+	})
+	c.Provide(componentSitemapHandler, func(c *container.Container) (interface{}, error) {
+		sitemapService, err := container.Get[*services.SitemapService](c, componentSitemapService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewSitemapHandler(sitemapService, logger), nil
+	})
+	c.Provide(componentJoinHandler, func(c *container.Container) (interface{}, error) {
+		joinService, err := container.Get[*services.JoinRequestService](c, componentJoinService)
+		if err != nil {
+			return nil, err
+		}
+		orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewJoinRequestHandler(joinService, orgService, logger), nil
+	})
+	c.Provide(componentEnumHandler, func(c *container.Container) (interface{}, error) {
+		enumService, err := container.Get[*services.EnumService](c, componentEnumService)
+		if err != nil {
+			return nil, err
+		}
+		localeService, err := container.Get[*services.LocaleService](c, componentLocaleService)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewEnumHandler(enumService, localeService, logger), nil
+	})
+
+	// Components contributed by compiled-in plugins
+	plugin.ProvideAll(c)
+
+	// HTTP
+	c.Provide(componentRouter, func(c *container.Container) (interface{}, error) {
+		return newRouter(c, logger, config)
+	})
+	c.Provide(componentServer, func(c *container.Container) (interface{}, error) {
+		router, err := container.Get[*mux.Router](c, componentRouter)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Server{
+			Addr:         fmt.Sprintf(":%s", config.Port),
+			Handler:      router,
+			ReadTimeout:  defaultTimeout,
+			WriteTimeout: defaultTimeout,
+			IdleTimeout:  60 * time.Second,
+		}, nil
+	})
+
+	return c
+}
+
+// withIDGenerator hands a service that creates entities the shared
+// IDGenerator, so every ID comes from one monotonic source
+func withIDGenerator[T interface{ SetIDGenerator(services.IDGenerator) }](c *container.Container, service T) (interface{}, error) {
+	ids, err := container.Get[services.IDGenerator](c, componentIDGenerator)
+	if err != nil {
+		return nil, err
+	}
+	service.SetIDGenerator(ids)
+	return service, nil
+}
+
+// newRouter resolves every handler and mounts its routes
+func newRouter(c *container.Container, logger *log.Logger, config appConfig) (*mux.Router, error) {
+	handler, err := container.Get[*handlers.Handler](c, componentHandler)
+	if err != nil {
+		return nil, err
+	}
+	orgHandler, err := container.Get[*handlers.OrgHandler](c, componentOrgHandler)
+	if err != nil {
+		return nil, err
+	}
+	importHandler, err := container.Get[*handlers.ImportHandler](c, componentImportHandler)
+	if err != nil {
+		return nil, err
+	}
+	exportHandler, err := container.Get[*handlers.ExportHandler](c, componentExportHandler)
+	if err != nil {
+		return nil, err
+	}
+	searchHandler, err := container.Get[*handlers.SearchHandler](c, componentSearchHandler)
+	if err != nil {
+		return nil, err
+	}
+	migrationHandler, err := container.Get[*handlers.UserMigrationHandler](c, componentMigrationHandler)
+	if err != nil {
+		return nil, err
+	}
+	retentionHandler, err := container.Get[*handlers.RetentionHandler](c, componentRetentionHandler)
+	if err != nil {
+		return nil, err
+	}
+	backfillHandler, err := container.Get[*handlers.BackfillHandler](c, componentBackfillHandler)
+	if err != nil {
+		return nil, err
+	}
+	storeHandler, err := container.Get[*handlers.StoreHandler](c, componentStoreHandler)
+	if err != nil {
+		return nil, err
+	}
+	profileHandler, err := container.Get[*handlers.ProfileHandler](c, componentProfileHandler)
+	if err != nil {
+		return nil, err
+	}
+	notificationHandler, err := container.Get[*handlers.NotificationHandler](c, componentNotifyHandler)
+	if err != nil {
+		return nil, err
+	}
+	templateHandler, err := container.Get[*handlers.NotificationTemplateHandler](c, componentTemplateHandler)
+	if err != nil {
+		return nil, err
+	}
+	preferencesHandler, err := container.Get[*handlers.PreferencesHandler](c, componentPrefsHandler)
+	if err != nil {
+		return nil, err
+	}
+	diagnosticsHandler, err := container.Get[*handlers.DiagnosticsHandler](c, componentDiagnostics)
+	if err != nil {
+		return nil, err
+	}
+	loggingHandler, err := container.Get[*handlers.LoggingHandler](c, componentLoggingHandler)
+	if err != nil {
+		return nil, err
+	}
+	sloHandler, err := container.Get[*handlers.SLOHandler](c, componentSLOHandler)
+	if err != nil {
+		return nil, err
+	}
+	slaHandler, err := container.Get[*handlers.SLAHandler](c, componentSLAHandler)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := container.Get[*metrics.Registry](c, componentMetrics)
+	if err != nil {
+		return nil, err
+	}
+	settingsHandler, err := container.Get[*handlers.OrgSettingsHandler](c, componentSettingsHandler)
+	if err != nil {
+		return nil, err
+	}
+	enumHandler, err := container.Get[*handlers.EnumHandler](c, componentEnumHandler)
+	if err != nil {
+		return nil, err
+	}
+	projectHandler, err := container.Get[*handlers.ProjectHandler](c, componentProjectHandler)
+	if err != nil {
+		return nil, err
+	}
+	publicHandler, err := container.Get[*handlers.PublicHandler](c, componentPublicHandler)
+	if err != nil {
+		return nil, err
+	}
+	embedHandler, err := container.Get[*handlers.EmbedHandler](c, componentEmbedHandler)
+	if err != nil {
+		return nil, err
+	}
+	sitemapHandler, err := container.Get[*handlers.SitemapHandler](c, componentSitemapHandler)
+	if err != nil {
+		return nil, err
+	}
+	escalations, err := container.Get[*services.EscalationService](c, componentEscalations)
+	if err != nil {
+		return nil, err
+	}
+	orgService, err := container.Get[*services.OrganizationService](c, componentOrgService)
+	if err != nil {
+		return nil, err
+	}
+	projectService, err := container.Get[*services.ProjectService](c, componentProjectService)
+	if err != nil {
+		return nil, err
+	}
+	joinHandler, err := container.Get[*handlers.JoinRequestHandler](c, componentJoinHandler)
+	if err != nil {
+		return nil, err
+	}
+	invitationHandler, err := container.Get[*handlers.InvitationHandler](c, componentInviteHandler)
+	if err != nil {
+		return nil, err
+	}
+	draftHandler, err := container.Get[*handlers.DraftHandler](c, componentDraftHandler)
+	if err != nil {
+		return nil, err
+	}
+	scheduleHandler, err := container.Get[*handlers.ScheduledChangeHandler](c, componentScheduleHandler)
+	if err != nil {
+		return nil, err
+	}
+	taskHandler, err := container.Get[*handlers.TaskHandler](c, componentTaskHandler)
+	if err != nil {
+		return nil, err
+	}
+	archiveHandler, err := container.Get[*handlers.ProjectArchiveHandler](c, componentArchiveHandler)
+	if err != nil {
+		return nil, err
+	}
+	deletionHandler, err := container.Get[*handlers.OrgDeletionHandler](c, componentDeletionHandler)
+	if err != nil {
+		return nil, err
+	}
+	sandboxHandler, err := container.Get[*handlers.SandboxHandler](c, componentSandboxHandler)
+	if err != nil {
+		return nil, err
+	}
+	control, err := container.Get[*logging.Controller](c, componentLogControl)
+	if err != nil {
+		return nil, err
+	}
+	adminHandler, err := container.Get[*handlers.AdminHandler](c, componentAdminHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup routes
+	router := handlers.SetupRoutes(handler, logger, control)
+	router.Use(handlers.SlowRequestMiddleware(logger, config.SlowRequests))
+	router.Use(handlers.MetricsMiddleware(registry))
+	router.Use(handlers.FeatureMiddleware(logger, config.Features))
+	router.Use(handlers.LocaleMiddleware())
+	router.Use(handlers.RateLimitMiddleware(orgService, logger, config.RateLimits))
+	router.Use(handlers.RequestBodyMiddleware(logger, config.Bodies))
+	router.Use(handlers.ImpersonationMiddleware(adminHandler))
+	router.Use(handlers.SandboxMiddleware(sandboxHandler))
+	router.Use(handlers.DedupMiddleware(logger, config.Dedup))
+
+	// Setup public profile routes
+	handlers.SetupPublicRoutes(router, publicHandler)
+
+	// Setup sitemap and robots.txt routes
+	handlers.SetupSitemapRoutes(router, sitemapHandler)
+
+	// Setup embeddable widget routes
+	handlers.SetupEmbedRoutes(router, embedHandler)
+
+	// Setup the admin API, outside /api/v1. Every admin route is mounted on
+	// this group so none is reachable without the admin token.
+	admin := handlers.SetupAdminRoutes(router, adminHandler)
+
+	// Serve sandboxes addressed by subpath
+	handlers.SetupSandboxPathRoutes(router, sandboxHandler)
+
+	// Serve avatars from local disk when they are not stored in a bucket
+	avatars, err := container.Get[interfaces.BlobStorage](c, componentAvatarStorage)
+	if err != nil {
+		return nil, err
+	}
+	if local, ok := avatars.(*storage.LocalStorage); ok {
+		router.PathPrefix(local.URLPrefix() + "/").Handler(http.StripPrefix(local.URLPrefix(), local))
+	}
+
+	// Setup organization routes
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(handlers.EscalationMiddleware(escalations))
+	api.Use(handlers.PermissionMiddleware(orgService, projectService, logger, config.Permissions))
+	handlers.SetupOrgRoutes(api, orgHandler)
+	handlers.SetupOrgAdminRoutes(admin, orgHandler)
+	handlers.SetupOrgDeletionRoutes(api, deletionHandler)
+
+	// Setup profile routes
+	handlers.SetupProfileRoutes(api, profileHandler)
+
+	// Setup user settings routes
+	handlers.SetupPreferencesRoutes(api, preferencesHandler)
+
+	// Setup notification preference routes
+	handlers.SetupNotificationRoutes(api, notificationHandler)
+	handlers.SetupNotificationTemplateRoutes(admin, templateHandler)
+
+	// Setup organization settings routes
+	handlers.SetupOrgSettingsRoutes(api, settingsHandler)
+
+	// Setup join request routes
+	handlers.SetupJoinRequestRoutes(api, joinHandler)
+
+	// Setup invitation routes
+	handlers.SetupInvitationRoutes(api, invitationHandler)
+
+	// Setup project routes
+	handlers.SetupProjectRoutes(api, projectHandler)
+	handlers.SetupTaskRoutes(api, taskHandler)
+
+	// Setup draft routes
+	handlers.SetupDraftRoutes(api, draftHandler)
+
+	// Setup scheduled change routes
+	handlers.SetupScheduledChangeRoutes(api, scheduleHandler)
+
+	// Setup sandbox routes
+	handlers.SetupSandboxRoutes(api, sandboxHandler)
+	handlers.SetupSandboxAdminRoutes(admin, sandboxHandler)
+
+	// Setup import routes
+	handlers.SetupImportRoutes(api, importHandler)
+
+	// Setup export routes
+	handlers.SetupExportRoutes(api, exportHandler)
+
+	// Setup search routes
+	handlers.SetupSearchRoutes(api, searchHandler)
+	handlers.SetupSearchAdminRoutes(admin, searchHandler)
+
+	// Setup user migration routes
+	handlers.SetupUserMigrationRoutes(api, migrationHandler)
+
+	// Setup retention admin routes
+	handlers.SetupRetentionRoutes(admin, retentionHandler)
+
+	// Setup project auto-archive admin routes
+	handlers.SetupProjectArchiveRoutes(admin, archiveHandler)
+
+	// Setup backfill admin routes
+	handlers.SetupBackfillRoutes(admin, backfillHandler)
+
+	// Setup store usage routes
+	handlers.SetupStoreRoutes(admin, storeHandler)
+
+	// Setup runtime diagnostics routes
+	handlers.SetupDiagnosticsRoutes(admin, diagnosticsHandler)
+	handlers.SetupLoggingRoutes(admin, loggingHandler)
+
+	// Setup SLO and request metrics routes
+	handlers.SetupSLORoutes(admin, sloHandler)
+
+	// Setup SLA compliance routes
+	handlers.SetupSLARoutes(admin, slaHandler)
+
+	// Setup enum routes
+	handlers.SetupEnumRoutes(api, enumHandler)
+	handlers.SetupEnumAdminRoutes(admin, enumHandler)
+
+	// Setup plugin routes
+	if err := plugin.MountAll(api, c); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
+
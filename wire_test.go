@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/test-repo-golang-support/handlers"
+	"github.com/test-repo-golang-support/pkg/asynclog"
+	"github.com/test-repo-golang-support/pkg/container"
+	"github.com/test-repo-golang-support/pkg/logging"
+	"github.com/test-repo-golang-support/pkg/storage"
+)
+
+// testAdminToken is the admin token routers built by newTestRouter require
+const testAdminToken = "test-admin-token"
+
+// newTestRouter wires the application with default settings and returns
+// its router; nothing is started
+func newTestRouter(t *testing.T) *mux.Router {
+	t.Helper()
+	logger := log.New(io.Discard, "", 0)
+	logs := asynclog.New(io.Discard, asynclog.Config{})
+	t.Cleanup(func() { _ = logs.Shutdown(context.Background()) })
+	control := logging.NewController(logs, logging.Config{Level: logging.LevelInfo})
+
+	app := newContainer(logger, logs, control, appConfig{
+		Port:    "0",
+		Avatars: storage.NewLocalStorage(t.TempDir(), "/avatars"),
+		Admin:   handlers.AdminConfig{Token: testAdminToken},
+	})
+	router, err := container.Get[*mux.Router](app, componentRouter)
+	if err != nil {
+		t.Fatalf("building router: %v", err)
+	}
+	return router
+}
+
+// routeVariable matches a path variable such as {id} in a route template
+var routeVariable = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestAdminRoutesRequireAdminAuth(t *testing.T) {
+	router := newTestRouter(t)
+
+	type adminRoute struct{ method, path string }
+	routes := make([]adminRoute, 0)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.Contains(template, "/admin/") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil // Path prefixes of subrouters
+		}
+		if !strings.HasPrefix(template, "/admin/v1/") {
+			t.Errorf("%s is outside the guarded /admin/v1 group", template)
+		}
+		for _, method := range methods {
+			routes = append(routes, adminRoute{method, routeVariable.ReplaceAllString(template, "x")})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+	if len(routes) == 0 {
+		t.Fatal("no admin routes found")
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			// No admin token at all
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("without token: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+
+			// The admin token, but no acting administrator
+			rec = httptest.NewRecorder()
+			req = httptest.NewRequest(route.method, route.path, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testAdminToken)
+			router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("without acting admin: status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestOrgRoutesRequireActor(t *testing.T) {
+	router := newTestRouter(t)
+
+	// Asking to join is the one change a non-member makes to an organization
+	open := map[string]bool{"POST /api/v1/organizations/{id}/join-requests": true}
+
+	checked := 0
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !(strings.HasPrefix(template, "/api/v1/organizations/{id}") || strings.HasPrefix(template, "/api/v1/projects/{id}")) {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			if method == http.MethodGet || open[method+" "+template] {
+				continue
+			}
+			checked++
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, routeVariable.ReplaceAllString(template, "x"), strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s without %s: status = %d, want %d", method, template, handlers.ActingUserHeader, rec.Code, http.StatusUnauthorized)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+	if checked == 0 {
+		t.Fatal("no organization or project routes found")
+	}
+}
+